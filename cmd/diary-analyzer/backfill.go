@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/domain"
+	analyzerConfig "github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/config"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/gateway"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/usecase"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+)
+
+// runBackfill re-runs the analysis of past diaries and returns the exit code.
+//
+//	diary-analyzer backfill -from 2026-01-01 -to 2026-01-31 [-failed] [-interval 1s]
+func runBackfill(ctx context.Context, args []string) int {
+	log := slog.Default()
+
+	fs := flag.NewFlagSet("backfill", flag.ContinueOnError)
+	from := fs.String("from", "", "first date of the diaries to re-analyze (YYYY-MM-DD, required)")
+	to := fs.String("to", "", "last date of the diaries to re-analyze, inclusive (YYYY-MM-DD, default: from)")
+	failedOnly := fs.Bool("failed", false, "re-analyze only the diaries whose accuracy check or keyword extraction failed")
+	interval := fs.Duration("interval", time.Second, "wait between diaries to respect the NLP API rate limit")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	start, err := time.Parse("2006-01-02", *from)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "invalid -from, expected YYYY-MM-DD")
+		fs.Usage()
+		return 2
+	}
+	end := start
+	if *to != "" {
+		if end, err = time.Parse("2006-01-02", *to); err != nil {
+			fmt.Fprintln(os.Stderr, "invalid -to, expected YYYY-MM-DD")
+			fs.Usage()
+			return 2
+		}
+	}
+
+	config := analyzerConfig.Load()
+	if config.DB.DiaryDatabaseURL == "" {
+		log.Error("DIARY_DATABASE_URL is not set")
+		return 1
+	}
+
+	analyzerDB := db.NewDBManager(config.DB.DatabaseURL)
+	nlpGateway, err := newNLPGateway(config.ThirdParty, analyzerDB)
+	if err != nil {
+		log.Error("failed to create NLP gateway", "error", err.Error())
+		return 1
+	}
+	tokenizer, err := gateway.NewKagomeTokenizer()
+	if err != nil {
+		log.Error("failed to create tokenizer", "error", err.Error())
+		return 1
+	}
+
+	scoring, err := domain.NewAccuracyScoringStrategy(config.Scoring.AccuracyStrategy)
+	if err != nil {
+		log.Error("failed to create accuracy scoring strategy", "error", err.Error())
+		return 1
+	}
+
+	analysisRepository := repository.NewDiaryAnalysisRepository(analyzerDB)
+	diaryRepository := repository.NewDiaryRepository(db.NewDBManager(config.DB.DiaryDatabaseURL))
+	// re-analyses in bulk are not announced
+	analyzerUsecase := usecase.NewDiaryAnalysisUsecase(analysisRepository, repository.NewEntityRepository(analyzerDB), nlpGateway, tokenizer, scoring, nil)
+	backfillUsecase := usecase.NewBackfillUsecase(analysisRepository, diaryRepository, analyzerUsecase)
+
+	// Ctrl+C stops after the current diary
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Info("backfill started", "from", start.Format("2006-01-02"), "to", end.Format("2006-01-02"),
+		"failed_only", *failedOnly, "provider", config.ThirdParty.NLPProvider, "scoring", scoring.ID(), "interval", interval.String())
+
+	input := &usecase.BackfillInput{
+		From:       start,
+		To:         end.AddDate(0, 0, 1),
+		FailedOnly: *failedOnly,
+		Interval:   *interval,
+	}
+	result, err := backfillUsecase.Run(ctx, input, func(p usecase.BackfillProgress) {
+		log.Info("backfill progress", "processed", p.Processed, "total", p.Total,
+			"succeeded", p.Succeeded, "failed", p.Failed, "skipped", p.Skipped)
+	})
+	if result != nil {
+		log.Info("backfill finished", "processed", result.Processed, "total", result.Total,
+			"succeeded", result.Succeeded, "failed", result.Failed, "skipped", result.Skipped)
+	}
+	if err != nil {
+		log.Error("backfill stopped", "error", err.Error())
+		return 1
+	}
+	if result.Failed > 0 {
+		return 1
+	}
+	return 0
+}
@@ -2,19 +2,26 @@ package main
 
 import (
 	"context"
+	"expvar"
+	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/domain"
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/broker"
 	analyzerConfig "github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/config"
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/gateway"
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/handler"
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/scheduler"
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/usecase"
 	"github.com/furuya-3150/fam-diary-log/pkg/broker/consumer"
+	"github.com/furuya-3150/fam-diary-log/pkg/broker/publisher"
 	"github.com/furuya-3150/fam-diary-log/pkg/broker/rabbit"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
 	"github.com/furuya-3150/fam-diary-log/pkg/db"
 	"github.com/furuya-3150/fam-diary-log/pkg/logger"
 	"github.com/joho/godotenv"
@@ -45,6 +52,13 @@ func main() {
 	ctx := context.Background()
 	log := slog.Default()
 
+	if len(os.Args) > 1 && os.Args[1] == "backfill" {
+		os.Exit(runBackfill(ctx, os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "rebuild-daily" {
+		os.Exit(runRebuildDaily(ctx, os.Args[2:]))
+	}
+
 	// Get RabbitMQ URL from environment
 	rabbitmqURL := os.Getenv("RABBITMQ_URL")
 	if rabbitmqURL == "" {
@@ -65,14 +79,45 @@ func main() {
 	config := analyzerConfig.Load()
 	dbManager := db.NewDBManager(config.DB.DatabaseURL)
 	diaryAnalysisRepository := repository.NewDiaryAnalysisRepository(dbManager)
+	entityRepository := repository.NewEntityRepository(dbManager)
+
+	nlpGateway, err := newNLPGateway(config.ThirdParty, dbManager)
+	if err != nil {
+		log.Error("failed to create NLP gateway", "error", err.Error())
+		os.Exit(1)
+	}
+	log.Info("NLP gateway selected", "provider", config.ThirdParty.NLPProvider, "daily_quota", config.ThirdParty.NLPQuota.DailyQuota)
+
+	tokenizer, err := gateway.NewKagomeTokenizer()
+	if err != nil {
+		log.Error("failed to create tokenizer", "error", err.Error())
+		os.Exit(1)
+	}
 
-	gateway := gateway.NewYahooNLPGateway(config.ThirdParty.YahooNLPAppID)
+	scoring, err := domain.NewAccuracyScoringStrategy(config.Scoring.AccuracyStrategy)
+	if err != nil {
+		log.Error("failed to create accuracy scoring strategy", "error", err.Error())
+		os.Exit(1)
+	}
+	log.Info("accuracy scoring strategy selected", "strategy", scoring.ID())
 
-	analyzerUsecase := usecase.NewDiaryAnalysisUsecase(diaryAnalysisRepository, gateway)
+	// Announce analyses and reached goals on the diary exchange; the publisher shares the consumer's connection, closed by the consumer
+	pub, err := publisher.NewRabbitMQPublisher(conn, broker.DiaryPublisherConfig(), log)
+	if err != nil {
+		log.Error("failed to create publisher", "error", err.Error())
+		os.Exit(1)
+	}
 
-	eventHandler := handler.NewDiaryEventHandler(analyzerUsecase, log)
+	analyzerUsecase := usecase.NewDiaryAnalysisUsecase(diaryAnalysisRepository, entityRepository, nlpGateway, tokenizer, scoring, pub)
+
+	goalUsecase := usecase.NewGoalUsecase(repository.NewGoalRepository(dbManager), pub, &clock.Real{})
+
+	eventHandler := handler.NewDiaryEventHandler(analyzerUsecase, goalUsecase, log)
 
 	consumerConfig := broker.DiaryConsumerConfig()
+	consumerConfig.Concurrency = config.Consumer.Concurrency
+	consumerConfig.Prefetch = config.Consumer.Prefetch
+	consumerConfig.ShutdownTimeout = config.Consumer.ShutdownTimeout
 
 	// Create consumer
 	c, err := consumer.NewRabbitMQConsumer(conn, consumerConfig, eventHandler, log)
@@ -87,6 +132,45 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Keep the family members linked in diaries in sync with user-context; Stop closes the connection, so it has its own
+	familyConn, err := rabbit.NewConnection(rabbitConfig)
+	if err != nil {
+		log.Error("failed to connect to RabbitMQ", "error", err.Error())
+		os.Exit(1)
+	}
+	familyConsumer, err := consumer.NewRabbitMQConsumer(familyConn, broker.FamilyConsumerConfig(), handler.NewFamilyEventHandler(usecase.NewEntityUsecase(entityRepository), log), log)
+	if err != nil {
+		log.Error("failed to create family consumer", "error", err.Error())
+		os.Exit(1)
+	}
+	if err := familyConsumer.Start(ctx); err != nil {
+		log.Error("failed to start family consumer", "error", err.Error())
+		os.Exit(1)
+	}
+
+	// Retry failed goal checks, and failed enrichments (needs the diary content)
+	schedulerCtx, stopScheduler := context.WithCancel(ctx)
+	defer stopScheduler()
+	go scheduler.NewRetryScheduler("goal check", goalUsecase, config.Scheduler.RetryInterval).Start(schedulerCtx)
+	if config.DB.DiaryDatabaseURL != "" {
+		diaryRepository := repository.NewDiaryRepository(db.NewDBManager(config.DB.DiaryDatabaseURL))
+		retryUsecase := usecase.NewAnalysisRetryUsecase(diaryAnalysisRepository, diaryRepository, nlpGateway, tokenizer, scoring, &clock.Real{})
+		go scheduler.NewRetryScheduler("diary analysis", retryUsecase, config.Scheduler.RetryInterval).Start(schedulerCtx)
+	} else {
+		log.Warn("DIARY_DATABASE_URL is not set, failed analyses will not be retried")
+	}
+
+	// NLP cache and quota metrics
+	if config.Metrics.Addr != "" {
+		go func() {
+			mux := http.NewServeMux()
+			mux.Handle("/debug/vars", expvar.Handler())
+			if err := http.ListenAndServe(config.Metrics.Addr, mux); err != nil {
+				log.Error("metrics server stopped", "addr", config.Metrics.Addr, "error", err.Error())
+			}
+		}()
+	}
+
 	log.Info("diary-analyzer started", "version", "1.0.0")
 
 	// Wait for shutdown signal
@@ -97,8 +181,13 @@ func main() {
 	<-sigChan
 
 	log.Info("shutting down diary-analyzer")
+	stopScheduler()
+
+	if err := familyConsumer.Stop(); err != nil {
+		log.Error("failed to stop family consumer", "error", err.Error())
+	}
 
-	// Stop consumer
+	// Stop consumer, waiting for in-flight analyses
 	if err := c.Stop(); err != nil {
 		log.Error("failed to stop consumer", "error", err.Error())
 		os.Exit(1)
@@ -106,3 +195,35 @@ func main() {
 
 	log.Info("diary-analyzer stopped")
 }
+
+// newNLPGateway selects the NLPGateway by NLP_PROVIDER, limited to the daily quota,
+// caching the results in the analyzer database and splitting long texts for the Yahoo API
+func newNLPGateway(cfg analyzerConfig.ThirdPartyConfig, dbManager *db.DBManager) (gateway.NLPGateway, error) {
+	var nlpGateway gateway.NLPGateway
+	var rulesVersion string
+	switch cfg.NLPProvider {
+	case analyzerConfig.NLPProviderYahoo:
+		if cfg.YahooNLPAppID == "" {
+			return nil, fmt.Errorf("YAHOO_NLP_APP_ID is required for NLP_PROVIDER=%s", cfg.NLPProvider)
+		}
+		nlpGateway = gateway.NewYahooNLPGateway(cfg.YahooNLPAppID)
+		rulesVersion = gateway.KouseiRulesVersion
+	case analyzerConfig.NLPProviderLocal:
+		nlpGateway = gateway.NewLocalNLPGateway()
+		rulesVersion = gateway.LocalRulesVersion
+	default:
+		return nil, fmt.Errorf("unknown NLP_PROVIDER: %s", cfg.NLPProvider)
+	}
+
+	// cache hits do not use up the quota, which the consumer and the backfills share through the database
+	if cfg.NLPQuota.DailyQuota > 0 {
+		nlpGateway = gateway.NewRateLimitedNLPGateway(nlpGateway, repository.NewNLPQuotaRepository(dbManager), cfg.NLPProvider, cfg.NLPQuota.DailyQuota, cfg.NLPQuota.Burst, &clock.Real{})
+	}
+	nlpGateway = gateway.NewCachedNLPGateway(nlpGateway, repository.NewNLPCacheRepository(dbManager), cfg.NLPProvider, rulesVersion)
+
+	// long texts are split into requests the Kousei API accepts; each chunk is cached and counted against the quota
+	if cfg.NLPProvider == analyzerConfig.NLPProviderYahoo {
+		nlpGateway = gateway.NewChunkedNLPGateway(nlpGateway, gateway.KouseiMaxChunkBytes, gateway.KouseiChunkConcurrency)
+	}
+	return nlpGateway, nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	analyzerConfig "github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/config"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/usecase"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+)
+
+// runRebuildDaily recomputes the daily rollups of the analyses and returns the exit code.
+//
+//	diary-analyzer rebuild-daily -from 2026-01-01 -to 2026-01-31
+func runRebuildDaily(ctx context.Context, args []string) int {
+	log := slog.Default()
+
+	fs := flag.NewFlagSet("rebuild-daily", flag.ContinueOnError)
+	from := fs.String("from", "", "first date of the rollups to rebuild (YYYY-MM-DD, required)")
+	to := fs.String("to", "", "last date of the rollups to rebuild, inclusive (YYYY-MM-DD, default: from)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	start, err := time.Parse("2006-01-02", *from)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "invalid -from, expected YYYY-MM-DD")
+		fs.Usage()
+		return 2
+	}
+	end := start
+	if *to != "" {
+		if end, err = time.Parse("2006-01-02", *to); err != nil {
+			fmt.Fprintln(os.Stderr, "invalid -to, expected YYYY-MM-DD")
+			fs.Usage()
+			return 2
+		}
+	}
+
+	config := analyzerConfig.Load()
+	analysisRepository := repository.NewDiaryAnalysisRepository(db.NewDBManager(config.DB.DatabaseURL))
+	rollupUsecase := usecase.NewRollupUsecase(analysisRepository)
+
+	// Ctrl+C stops after the current day
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Info("rebuild of daily rollups started", "from", start.Format("2006-01-02"), "to", end.Format("2006-01-02"))
+
+	err = rollupUsecase.RebuildDaily(ctx, start, end.AddDate(0, 0, 1), func(p usecase.RollupProgress) {
+		log.Info("rebuild progress", "day", p.Day.Format("2006-01-02"), "rollups", p.Rollups,
+			"processed", p.Processed, "total", p.Total)
+	})
+	if err != nil {
+		log.Error("rebuild of daily rollups stopped", "error", err.Error())
+		return 1
+	}
+	log.Info("rebuild of daily rollups finished")
+	return 0
+}
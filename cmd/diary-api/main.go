@@ -1,12 +1,21 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"time"
 
+	"github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/broker"
 	"github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/config"
+	"github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/handler"
 	"github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/http"
+	"github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/scheduler"
+	"github.com/furuya-3150/fam-diary-log/internal/diary/usecase"
+	"github.com/furuya-3150/fam-diary-log/pkg/broker/consumer"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
 	"github.com/joho/godotenv"
 )
 
@@ -14,9 +23,55 @@ func main() {
 	slog.Info("Starting diary API server... 123")
 	e := http.NewRouter()
 
+	// タイムカプセル日記の解禁イベントを定期的に発行する
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go newUnlockScheduler().Start(ctx)
+
+	// @メンション用の家族メンバー情報を user-context のイベントから同期する
+	startFamilyConsumer(ctx)
+
 	e.Logger.Fatal(e.Start(":8080"))
 }
 
+func newUnlockScheduler() *scheduler.UnlockScheduler {
+	dbManager := db.NewDBManager(config.Cfg.DB.DatabaseURL)
+	txManager := db.NewTransaction(dbManager)
+	pub := broker.NewDiaryPublisher(slog.Default())
+
+	diaryRepo := repository.NewDiaryRepository(dbManager)
+	unlockUsecase := usecase.NewDiaryUnlockUsecase(txManager, diaryRepo, pub, &clock.Real{}, newMentionUsecase(dbManager))
+	return scheduler.NewUnlockScheduler(unlockUsecase, config.Cfg.Scheduler.UnlockInterval)
+}
+
+func newMentionUsecase(dbManager *db.DBManager) usecase.MentionUsecase {
+	pub := broker.NewDiaryPublisher(slog.Default())
+	return usecase.NewMentionUsecase(repository.NewFamilyMemberRepository(dbManager), repository.NewMentionRepository(dbManager), pub, &clock.Real{})
+}
+
+func startFamilyConsumer(ctx context.Context) {
+	log := slog.Default()
+
+	conn, err := broker.GetRabbitMQConnection()
+	if err != nil {
+		log.Error("failed to get RabbitMQ connection", "error", err.Error())
+		os.Exit(1)
+	}
+
+	dbManager := db.NewDBManager(config.Cfg.DB.DatabaseURL)
+	eventHandler := handler.NewFamilyEventHandler(newMentionUsecase(dbManager), log)
+
+	c, err := consumer.NewRabbitMQConsumer(conn, broker.FamilyConsumerConfig(), eventHandler, log)
+	if err != nil {
+		log.Error("failed to create consumer", "error", err.Error())
+		os.Exit(1)
+	}
+	if err := c.Start(ctx); err != nil {
+		log.Error("failed to start consumer", "error", err.Error())
+		os.Exit(1)
+	}
+}
+
 func init() {
 	// 日本時間（JST）を設定
 	jst, err := time.LoadLocation("Asia/Tokyo")
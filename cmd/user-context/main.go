@@ -1,21 +1,39 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"time"
 
+	"github.com/furuya-3150/fam-diary-log/internal/user-context/infrastructure/broker"
 	"github.com/furuya-3150/fam-diary-log/internal/user-context/infrastructure/config"
 	"github.com/furuya-3150/fam-diary-log/internal/user-context/infrastructure/http"
+	"github.com/furuya-3150/fam-diary-log/internal/user-context/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/internal/user-context/infrastructure/scheduler"
+	"github.com/furuya-3150/fam-diary-log/internal/user-context/usecase"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
 	"github.com/joho/godotenv"
 )
 
 func main() {
 	e := http.NewRouter()
 
+	// outbox に保存した家族イベントを定期的に発行する
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go newOutboxRelayScheduler().Start(ctx)
+
 	e.Logger.Fatal(e.Start(":8082"))
 }
 
+func newOutboxRelayScheduler() *scheduler.OutboxRelayScheduler {
+	dbManager := db.NewDBManager(config.Cfg.DB.DatabaseURL)
+	outboxUsecase := usecase.NewOutboxUsecase(repository.NewOutboxRepository(dbManager), broker.NewFamilyEventPublisher(slog.Default()), &clock.Real{})
+	return scheduler.NewOutboxRelayScheduler(outboxUsecase, config.Cfg.Scheduler.OutboxRelayInterval)
+}
+
 func init() {
 	// 日本時間（JST）を設定
 	jst, err := time.LoadLocation("Asia/Tokyo")
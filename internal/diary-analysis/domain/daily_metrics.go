@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Readability metrics of the daily rollup, read by the readability trend
+const (
+	MetricKanjiRatio            = "kanji_ratio"
+	MetricHiraganaRatio         = "hiragana_ratio"
+	MetricKatakanaRatio         = "katakana_ratio"
+	MetricAverageSentenceLength = "average_sentence_length"
+	MetricLongestSentenceLength = "longest_sentence_length"
+	MetricTypeTokenRatio        = "type_token_ratio"
+)
+
+// RollupMetrics are the metrics of the daily rollup (diary_analysis_daily) maintained by diary-analyzer
+var RollupMetrics = []string{
+	MetricCharCount, MetricSentenceCount, MetricWritingTimeSeconds, MetricAccuracyScore, MetricSentimentScore,
+	MetricKanjiRatio, MetricHiraganaRatio, MetricKatakanaRatio, MetricAverageSentenceLength, MetricLongestSentenceLength, MetricTypeTokenRatio,
+}
+
+// MetricAggregate aggregates the values of a metric over the analyses that have it.
+// Min and Max are nil when Count is 0.
+type MetricAggregate struct {
+	Count int
+	Sum   float64
+	Min   *float64
+	Max   *float64
+}
+
+// DailyAnalysisMetrics is the rollup of the analyses of a member on a local date.
+// Metrics has an aggregate for each of RollupMetrics.
+type DailyAnalysisMetrics struct {
+	UserID     uuid.UUID
+	LocalDate  time.Time
+	DiaryCount int
+	Metrics    map[string]*MetricAggregate
+}
+
+// Metric returns the aggregate of the metric, empty when the rollup has none
+func (d *DailyAnalysisMetrics) Metric(name string) *MetricAggregate {
+	if agg, ok := d.Metrics[name]; ok && agg != nil {
+		return agg
+	}
+	return &MetricAggregate{}
+}
@@ -13,10 +13,30 @@ type DiaryAnalysis struct {
 	FamilyID           uuid.UUID `gorm:"type:uuid;not null" json:"family_id"`
 	CharCount          int       `gorm:"not null;default:0" json:"char_count"`
 	SentenceCount      int       `gorm:"not null;default:0" json:"sentence_count"`
-	AccuracyScore      int       `gorm:"default:0" json:"accuracy_score"`
 	WritingTimeSeconds int       `gorm:"default:0" json:"writing_time_seconds"`
+	SentimentScore     *float64  `json:"sentiment_score"`
 	CreatedAt          time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt          time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	// AccuracyScore is null unless AccuracyStatus is complete
+	AccuracyScore  *int    `json:"accuracy_score"`
+	AccuracyStatus string  `json:"accuracy_status"`
+	AccuracyError  *string `json:"accuracy_error,omitempty"`
+	KeywordsStatus string  `json:"keywords_status"`
+	KeywordsError  *string `json:"keywords_error,omitempty"`
+	// Status is the overall status of the analysis, derived from the statuses of the enrichments
+	Status   string     `gorm:"-" json:"status"`
+	UnlockAt *time.Time `json:"-"`
+	// Language is the detected language of the diary: ja, en, mixed or und
+	Language string `json:"language"`
+	// AccuracyStrategy is the ID of the scoring strategy of AccuracyScore (flat_v1, per_100_chars_v1, severity_v1)
+	AccuracyStrategy *string `json:"accuracy_strategy"`
+	// Readability metrics are null for analyses made before they were introduced
+	KanjiRatio            *float64 `json:"kanji_ratio"`
+	HiraganaRatio         *float64 `json:"hiragana_ratio"`
+	KatakanaRatio         *float64 `json:"katakana_ratio"`
+	AverageSentenceLength *float64 `json:"average_sentence_length"`
+	LongestSentenceLength *int     `json:"longest_sentence_length"`
+	TypeTokenRatio        *float64 `json:"type_token_ratio"`
 }
 
 // TableName specifies the table name
@@ -24,8 +44,53 @@ func (DiaryAnalysis) TableName() string {
 	return "diary_analyses"
 }
 
+// Statuses of an analysis and its enrichments (accuracy check, keyword extraction)
+const (
+	AnalysisStatusPending  = "pending"
+	AnalysisStatusComplete = "complete"
+	AnalysisStatusFailed   = "failed"
+	// AnalysisStatusSkipped is an enrichment that does not apply, e.g. the accuracy check of an English diary
+	AnalysisStatusSkipped = "skipped"
+	// AnalysisStatusDeferred is an accuracy check postponed until the NLP quota allows
+	AnalysisStatusDeferred = "deferred"
+)
+
+// OverallStatus is failed if an enrichment failed, pending if one is not done yet, otherwise complete.
+// A skipped enrichment counts as done, a deferred one as pending.
+func (a *DiaryAnalysis) OverallStatus() string {
+	statuses := []string{a.AccuracyStatus, a.KeywordsStatus}
+	for _, s := range statuses {
+		if s == AnalysisStatusFailed {
+			return AnalysisStatusFailed
+		}
+	}
+	for _, s := range statuses {
+		if s != AnalysisStatusComplete && s != AnalysisStatusSkipped {
+			return AnalysisStatusPending
+		}
+	}
+	return AnalysisStatusComplete
+}
+
 // GetWeekCharCountRequest represents a request to get character count for a week
 type GetWeekCharCountRequest struct {
 	Date   string    `json:"date"` // YYYY-MM-DD format
 	UserID uuid.UUID `json:"user_id"`
 }
+
+// Sentiment trend periods
+const (
+	SentimentPeriodWeek  = "week"
+	SentimentPeriodMonth = "month"
+)
+
+// SentimentTrend is the daily average sentiment of a user in a week or a month.
+// Days without a scored diary are null.
+type SentimentTrend struct {
+	UserID    uuid.UUID           `json:"user_id"`
+	Period    string              `json:"period"`
+	StartDate string              `json:"start_date"`
+	EndDate   string              `json:"end_date"`
+	Average   *float64            `json:"average"`
+	Daily     map[string]*float64 `json:"daily"`
+}
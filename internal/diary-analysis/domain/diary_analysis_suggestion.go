@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DiaryAnalysisSuggestion is a proofreading issue stored by diary-analyzer.
+// Offset and Length are in Unicode code points of the diary content.
+type DiaryAnalysisSuggestion struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:uuid" json:"id"`
+	AnalysisID uuid.UUID `gorm:"type:uuid;not null" json:"-"`
+	DiaryID    uuid.UUID `gorm:"type:uuid;not null" json:"diary_id"`
+	Rule       string    `json:"rule"`
+	Offset     int       `gorm:"column:offset_chars" json:"offset"`
+	Length     int       `gorm:"column:length_chars" json:"length"`
+	Message    string    `json:"message"`
+	Suggestion string    `json:"suggestion"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name
+func (DiaryAnalysisSuggestion) TableName() string {
+	return "diary_analysis_suggestions"
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Entity kinds. Members are synced from user-context; places and pets are maintained by the family.
+const (
+	EntityKindMember = "member"
+	EntityKindPlace  = "place"
+	EntityKindPet    = "pet"
+)
+
+// Family entity limits
+const (
+	MaxEntityNameLength     = 100
+	MaxEntityAliases        = 10
+	DefaultEntityDiaryLimit = 20
+	MaxEntityDiaryLimit     = 100
+	// MaxEntityMentionDays is the longest range of the mention counts, a school year
+	MaxEntityMentionDays = 366
+)
+
+// FamilyEntity is a place or a pet of the family. diary-analyzer links its name and aliases in new diaries.
+type FamilyEntity struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	FamilyID  uuid.UUID `gorm:"type:uuid;not null" json:"-"`
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	Aliases   []string  `gorm:"serializer:json;type:jsonb;not null" json:"aliases"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name
+func (FamilyEntity) TableName() string {
+	return "family_entities"
+}
+
+// EntityDiary is a diary mentioning a member, a place or a pet
+type EntityDiary struct {
+	DiaryID      uuid.UUID `json:"diary_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	MentionCount int       `json:"mention_count"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// EntityDiaryCriteria represents the criteria for listing the diaries mentioning an entity
+type EntityDiaryCriteria struct {
+	FamilyID uuid.UUID
+	// ViewerID hides locked time capsules of other members
+	ViewerID uuid.UUID
+	Kind     string
+	EntityID uuid.UUID
+	Limit    int
+}
+
+// EntityMentionCount is how often a member wrote about a member, a place or a pet.
+// Name is the current name of the entity, empty when it has been deleted.
+type EntityMentionCount struct {
+	UserID       uuid.UUID `json:"user_id"`
+	EntityKind   string    `json:"entity_kind"`
+	EntityID     uuid.UUID `json:"entity_id"`
+	Name         string    `json:"name"`
+	DiaryCount   int       `json:"diary_count"`
+	MentionCount int       `json:"mention_count"`
+}
+
+// EntityMentionCriteria represents the criteria for counting the mentions in the diaries of a family
+type EntityMentionCriteria struct {
+	FamilyID  uuid.UUID
+	ViewerID  uuid.UUID
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// EntityMentions are the mention counts of a family between two dates, inclusive
+type EntityMentions struct {
+	StartDate string                `json:"start_date"`
+	EndDate   string                `json:"end_date"`
+	Counts    []*EntityMentionCount `json:"counts"`
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnalysisSharingSetting is whether a member shares their analyses with the family comparison.
+// A member without a setting shares them.
+type AnalysisSharingSetting struct {
+	UserID       uuid.UUID `gorm:"primaryKey;type:uuid" json:"-"`
+	FamilyID     uuid.UUID `gorm:"primaryKey;type:uuid" json:"-"`
+	ShareEnabled bool      `gorm:"not null" json:"share_enabled"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"-"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name
+func (AnalysisSharingSetting) TableName() string {
+	return "analysis_sharing_settings"
+}
+
+// FamilyMember is a member of the family synced from user-context
+type FamilyMember struct {
+	UserID uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+}
+
+// Rankings of the family comparison
+const (
+	// RankingMostCharacters ranks the members by the characters written in the period.
+	// Japanese has no spaces between words, so the characters stand in for the words written.
+	RankingMostCharacters = "most_characters"
+	// RankingBestAccuracy ranks the members by the average accuracy score in the period
+	RankingBestAccuracy = "best_accuracy"
+)
+
+// MemberMetricSeries is the series of a member in the family comparison.
+// Name is empty for a member not synced from user-context yet.
+type MemberMetricSeries struct {
+	UserID uuid.UUID      `json:"user_id"`
+	Name   string         `json:"name"`
+	Series []*MetricStats `json:"series"`
+	Total  *MetricStats   `json:"total"`
+}
+
+// RankingEntry is a member in a ranking. Members with the same value share the rank.
+type RankingEntry struct {
+	Rank   int       `json:"rank"`
+	UserID uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+	Value  float64   `json:"value"`
+}
+
+// FamilyMetricSeries compares a metric between the members sharing their analyses.
+// Each series has one MetricStats per bucket, in the order of Buckets; Family aggregates all members.
+// Rankings only contain members with a value in the period.
+type FamilyMetricSeries struct {
+	Metric      string                     `json:"metric"`
+	Granularity string                     `json:"granularity"`
+	StartDate   string                     `json:"start_date"`
+	EndDate     string                     `json:"end_date"`
+	Buckets     []*MetricBucket            `json:"buckets"`
+	Members     []*MemberMetricSeries      `json:"members"`
+	Family      []*MetricStats             `json:"family"`
+	FamilyTotal *MetricStats               `json:"family_total"`
+	Rankings    map[string][]*RankingEntry `json:"rankings"`
+}
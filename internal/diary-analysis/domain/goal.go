@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Metrics of a writing goal
+const (
+	GoalMetricPosts          = "posts"
+	GoalMetricCharacters     = "characters"
+	GoalMetricWritingMinutes = "writing_minutes"
+)
+
+// Periods of a writing goal. A week starts on Monday.
+const (
+	GoalPeriodWeekly  = "weekly"
+	GoalPeriodMonthly = "monthly"
+)
+
+// MaxGoalTarget is the largest target of a goal, far beyond a month of writing
+const MaxGoalTarget = 1000000
+
+// DefaultTimezone is the time zone of members not synced from user-context yet, as in diary-analyzer
+const DefaultTimezone = "Asia/Tokyo"
+
+// Goal is a personal writing goal: a target of a metric per week or month.
+// A member has at most one goal per metric and period. diary-analyzer publishes goal.achieved when it is reached.
+type Goal struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null" json:"-"`
+	FamilyID  uuid.UUID `gorm:"type:uuid;not null" json:"-"`
+	Metric    string    `json:"metric"`
+	Period    string    `json:"period"`
+	Target    int       `json:"target"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name
+func (Goal) TableName() string {
+	return "writing_goals"
+}
+
+// GoalProgress is the progress of a goal in the current week or month.
+// Current counts the member's own diaries on the local dates from StartDate to EndDate, including locked time capsules.
+type GoalProgress struct {
+	GoalID    uuid.UUID `json:"goal_id"`
+	Metric    string    `json:"metric"`
+	Period    string    `json:"period"`
+	Target    int       `json:"target"`
+	Current   int       `json:"current"`
+	Achieved  bool      `json:"achieved"`
+	StartDate string    `json:"start_date"`
+	EndDate   string    `json:"end_date"`
+}
+
+// GoalsProgress is the progress of the goals of a member on a local date
+type GoalsProgress struct {
+	Date  string          `json:"date"`
+	Goals []*GoalProgress `json:"goals"`
+}
@@ -0,0 +1,53 @@
+package domain
+
+import "github.com/google/uuid"
+
+// Metrics that can be aggregated into a series
+const (
+	MetricCharCount          = "char_count"
+	MetricSentenceCount      = "sentence_count"
+	MetricAccuracyScore      = "accuracy_score"
+	MetricWritingTimeSeconds = "writing_time_seconds"
+	MetricSentimentScore     = "sentiment_score"
+)
+
+// Metrics lists the metrics in the order of their columns
+var Metrics = []string{MetricCharCount, MetricSentenceCount, MetricAccuracyScore, MetricWritingTimeSeconds, MetricSentimentScore}
+
+// Granularities of the buckets of a metric series. A week starts on Monday.
+const (
+	MetricGranularityDay   = "day"
+	MetricGranularityWeek  = "week"
+	MetricGranularityMonth = "month"
+)
+
+// MaxMetricSeriesDays is the longest range of a metric series
+const MaxMetricSeriesDays = 366
+
+// MetricBucket is a day, a week or a month of a metric series
+type MetricBucket struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+// MetricStats aggregates the values of a metric in a bucket.
+// Count is the number of diaries with the value; the others are null when it is 0.
+type MetricStats struct {
+	Count int      `json:"count"`
+	Sum   *float64 `json:"sum"`
+	Avg   *float64 `json:"avg"`
+	Min   *float64 `json:"min"`
+	Max   *float64 `json:"max"`
+}
+
+// MetricSeries is the metrics of a user's diaries per bucket.
+// Each series has one MetricStats per bucket, in the order of Buckets.
+// StartDate and EndDate cover whole weeks or months.
+type MetricSeries struct {
+	UserID      uuid.UUID                 `json:"user_id"`
+	Granularity string                    `json:"granularity"`
+	StartDate   string                    `json:"start_date"`
+	EndDate     string                    `json:"end_date"`
+	Buckets     []*MetricBucket           `json:"buckets"`
+	Series      map[string][]*MetricStats `json:"series"`
+}
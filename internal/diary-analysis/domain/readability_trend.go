@@ -0,0 +1,31 @@
+package domain
+
+import "github.com/google/uuid"
+
+// MaxReadabilityTrendDays is the longest range of a readability trend, a school year
+const MaxReadabilityTrendDays = 366
+
+// ReadabilityBucket is the average readability of the diaries written in a week or a month.
+// DiaryCount is the number of diaries with readability metrics; the metrics are null when it is 0.
+// LongestSentenceLength is the longest sentence of the period, not an average.
+type ReadabilityBucket struct {
+	StartDate             string   `json:"start_date"`
+	EndDate               string   `json:"end_date"`
+	DiaryCount            int      `json:"diary_count"`
+	KanjiRatio            *float64 `json:"kanji_ratio"`
+	HiraganaRatio         *float64 `json:"hiragana_ratio"`
+	KatakanaRatio         *float64 `json:"katakana_ratio"`
+	AverageSentenceLength *float64 `json:"average_sentence_length"`
+	LongestSentenceLength *int     `json:"longest_sentence_length"`
+	TypeTokenRatio        *float64 `json:"type_token_ratio"`
+}
+
+// ReadabilityTrend is the readability of a user's diaries per week or month.
+// StartDate and EndDate cover whole weeks or months.
+type ReadabilityTrend struct {
+	UserID    uuid.UUID            `json:"user_id"`
+	Period    string               `json:"period"`
+	StartDate string               `json:"start_date"`
+	EndDate   string               `json:"end_date"`
+	Buckets   []*ReadabilityBucket `json:"buckets"`
+}
@@ -6,10 +6,17 @@ import (
 	"github.com/google/uuid"
 )
 
-// DiaryAnalysisSearchCriteria represents the criteria for searching diary analyses
+// DiaryAnalysisSearchCriteria represents the criteria for searching the daily rollups of diary analyses.
+// WeekStart and WeekEnd are the first and the last local date.
 type DiaryAnalysisSearchCriteria struct {
-	UserID    uuid.UUID
-	WeekStart time.Time
-	WeekEnd   time.Time
-	Columns   []string // columns to select in the query
+	// UserID restricts the analyses to the user when set; the whole family is searched otherwise
+	UserID uuid.UUID
+	// FamilyID restricts the analyses to the family when set
+	FamilyID uuid.UUID
+	// ViewerID hides locked time capsules of other members when set
+	ViewerID uuid.UUID
+	// SharedOnly excludes the members who opted out of sharing their analyses
+	SharedOnly bool
+	WeekStart  time.Time
+	WeekEnd    time.Time
 }
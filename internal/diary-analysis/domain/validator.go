@@ -2,7 +2,10 @@ package domain
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // ValidateYYYYMMDDFormat validates date in YYYY-MM-DD format
@@ -18,3 +21,129 @@ func ValidateYYYYMMDDFormat(dateStr string) (time.Time, error) {
 
 	return parsedDate, nil
 }
+
+// ValidateSentimentPeriod validates the period of a sentiment trend or a word cloud
+func ValidateSentimentPeriod(period string) error {
+	if period != SentimentPeriodWeek && period != SentimentPeriodMonth {
+		return fmt.Errorf("period must be %s or %s", SentimentPeriodWeek, SentimentPeriodMonth)
+	}
+	return nil
+}
+
+// ValidateWordFrequencyLimit validates the number of words of a word cloud
+func ValidateWordFrequencyLimit(limit int) error {
+	if limit < 1 || limit > MaxWordFrequencyLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxWordFrequencyLimit)
+	}
+	return nil
+}
+
+// ValidateReadabilityRange validates the range of a readability trend
+func ValidateReadabilityRange(from, to time.Time) error {
+	if to.Before(from) {
+		return fmt.Errorf("to must not be before from")
+	}
+	if to.Sub(from) >= MaxReadabilityTrendDays*24*time.Hour {
+		return fmt.Errorf("range must be at most %d days", MaxReadabilityTrendDays)
+	}
+	return nil
+}
+
+// ValidateFamilyEntity validates a place or a pet of the family
+func ValidateFamilyEntity(kind, name string, aliases []string) error {
+	if kind != EntityKindPlace && kind != EntityKindPet {
+		return fmt.Errorf("kind must be %s or %s", EntityKindPlace, EntityKindPet)
+	}
+	if err := validateEntityName("name", name); err != nil {
+		return err
+	}
+	if len(aliases) > MaxEntityAliases {
+		return fmt.Errorf("aliases must be at most %d", MaxEntityAliases)
+	}
+	for _, a := range aliases {
+		if err := validateEntityName("alias", a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateEntityName(field, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%s is required", field)
+	}
+	if utf8.RuneCountInString(name) > MaxEntityNameLength {
+		return fmt.Errorf("%s must be at most %d characters", field, MaxEntityNameLength)
+	}
+	return nil
+}
+
+// ValidateEntityKind validates the kind of a linked entity
+func ValidateEntityKind(kind string) error {
+	if kind != EntityKindMember && kind != EntityKindPlace && kind != EntityKindPet {
+		return fmt.Errorf("kind must be %s, %s or %s", EntityKindMember, EntityKindPlace, EntityKindPet)
+	}
+	return nil
+}
+
+// ValidateEntityMentionRange validates the range of the mention counts
+func ValidateEntityMentionRange(from, to time.Time) error {
+	if to.Before(from) {
+		return fmt.Errorf("to must not be before from")
+	}
+	if to.Sub(from) >= MaxEntityMentionDays*24*time.Hour {
+		return fmt.Errorf("range must be at most %d days", MaxEntityMentionDays)
+	}
+	return nil
+}
+
+// ValidateMetrics validates the metrics of a metric series
+func ValidateMetrics(metrics []string) error {
+	if len(metrics) == 0 {
+		return fmt.Errorf("metrics is required")
+	}
+	for _, m := range metrics {
+		if !slices.Contains(Metrics, m) {
+			return fmt.Errorf("metric must be one of %s", strings.Join(Metrics, ", "))
+		}
+	}
+	return nil
+}
+
+// ValidateMetricGranularity validates the granularity of a metric series
+func ValidateMetricGranularity(granularity string) error {
+	if granularity != MetricGranularityDay && granularity != MetricGranularityWeek && granularity != MetricGranularityMonth {
+		return fmt.Errorf("granularity must be %s, %s or %s", MetricGranularityDay, MetricGranularityWeek, MetricGranularityMonth)
+	}
+	return nil
+}
+
+// ValidateMetricSeriesRange validates the range of a metric series
+func ValidateMetricSeriesRange(from, to time.Time) error {
+	if to.Before(from) {
+		return fmt.Errorf("to must not be before from")
+	}
+	if to.Sub(from) >= MaxMetricSeriesDays*24*time.Hour {
+		return fmt.Errorf("range must be at most %d days", MaxMetricSeriesDays)
+	}
+	return nil
+}
+
+// ValidateGoal validates the metric, the period and the target of a writing goal
+func ValidateGoal(metric, period string, target int) error {
+	if metric != GoalMetricPosts && metric != GoalMetricCharacters && metric != GoalMetricWritingMinutes {
+		return fmt.Errorf("metric must be %s, %s or %s", GoalMetricPosts, GoalMetricCharacters, GoalMetricWritingMinutes)
+	}
+	if period != GoalPeriodWeekly && period != GoalPeriodMonthly {
+		return fmt.Errorf("period must be %s or %s", GoalPeriodWeekly, GoalPeriodMonthly)
+	}
+	return ValidateGoalTarget(target)
+}
+
+// ValidateGoalTarget validates the target of a writing goal
+func ValidateGoalTarget(target int) error {
+	if target < 1 || target > MaxGoalTarget {
+		return fmt.Errorf("target must be between 1 and %d", MaxGoalTarget)
+	}
+	return nil
+}
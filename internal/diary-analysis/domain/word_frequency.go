@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Word frequency limits
+const (
+	DefaultWordFrequencyLimit = 50
+	MaxWordFrequencyLimit     = 200
+)
+
+// WordFrequency is the number of times a keyword appeared in the diaries of a period
+type WordFrequency struct {
+	Word       string `json:"word"`
+	Count      int    `json:"count"`
+	ProperNoun bool   `json:"proper_noun"`
+}
+
+// WordFrequencyCriteria represents the criteria for aggregating diary keywords
+type WordFrequencyCriteria struct {
+	FamilyID uuid.UUID
+	// UserID restricts the keywords to a member when set, otherwise the whole family
+	UserID    uuid.UUID
+	ViewerID  uuid.UUID
+	StartDate time.Time
+	EndDate   time.Time
+	Limit     int
+}
+
+// WordCloud is the word frequency of a family (or a member) in a week or a month
+type WordCloud struct {
+	UserID    *uuid.UUID       `json:"user_id,omitempty"`
+	Period    string           `json:"period"`
+	StartDate string           `json:"start_date"`
+	EndDate   string           `json:"end_date"`
+	Words     []*WordFrequency `json:"words"`
+}
@@ -20,6 +20,9 @@ func SetupTestDB(t *testing.T) (*db.DBManager) {
 	if err := dbManager.GetGorm().Exec("DELETE FROM diary_analyses").Error; err != nil {
 		t.Fatalf("failed to clean up test database: %v", err)
 	}
+	if err := dbManager.GetGorm().Exec("DELETE FROM diary_analysis_daily").Error; err != nil {
+		t.Fatalf("failed to clean up test database: %v", err)
+	}
 
 	return dbManager
 }
@@ -29,4 +32,7 @@ func TeardownTestDB(t *testing.T, gormDB *gorm.DB) {
 	if err := gormDB.Exec("DELETE FROM diary_analyses").Error; err != nil {
 		t.Logf("warning: failed to cleanup test database: %v", err)
 	}
+	if err := gormDB.Exec("DELETE FROM diary_analysis_daily").Error; err != nil {
+		t.Logf("warning: failed to cleanup test database: %v", err)
+	}
 }
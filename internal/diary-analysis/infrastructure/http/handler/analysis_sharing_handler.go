@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/usecase"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+	"github.com/furuya-3150/fam-diary-log/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// sharingSettingRequest is the body of PUT /settings/analysis-sharing
+type sharingSettingRequest struct {
+	ShareEnabled *bool `json:"share_enabled"`
+}
+
+type AnalysisSharingHandler struct {
+	asu usecase.AnalysisSharingUsecase
+}
+
+func NewAnalysisSharingHandler(asu usecase.AnalysisSharingUsecase) *AnalysisSharingHandler {
+	return &AnalysisSharingHandler{
+		asu: asu,
+	}
+}
+
+// GetSharingSetting handles GET /settings/analysis-sharing
+func (ash *AnalysisSharingHandler) GetSharingSetting(c echo.Context) error {
+	familyID, userID, err := familyContext(c)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	setting, err := ash.asu.GetSharingSetting(c.Request().Context(), familyID, userID)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusOK, setting)
+}
+
+// UpdateSharingSetting handles PUT /settings/analysis-sharing
+func (ash *AnalysisSharingHandler) UpdateSharingSetting(c echo.Context) error {
+	familyID, userID, err := familyContext(c)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+	var req sharingSettingRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.RespondWithError(c, &errors.BadRequestError{Message: "invalid request body: " + err.Error()})
+	}
+	if req.ShareEnabled == nil {
+		return errors.RespondWithError(c, &errors.ValidationError{Message: "share_enabled is required"})
+	}
+
+	setting, err := ash.asu.UpdateSharingSetting(c.Request().Context(), familyID, userID, *req.ShareEnabled)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusOK, setting)
+}
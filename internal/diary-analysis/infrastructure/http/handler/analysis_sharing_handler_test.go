@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAnalysisSharingUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAnalysisSharingUsecase) GetSharingSetting(ctx context.Context, familyID, userID uuid.UUID) (*domain.AnalysisSharingSetting, error) {
+	args := m.Called(ctx, familyID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AnalysisSharingSetting), args.Error(1)
+}
+
+func (m *MockAnalysisSharingUsecase) UpdateSharingSetting(ctx context.Context, familyID, userID uuid.UUID, shareEnabled bool) (*domain.AnalysisSharingSetting, error) {
+	args := m.Called(ctx, familyID, userID, shareEnabled)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AnalysisSharingSetting), args.Error(1)
+}
+
+// UpdateSharingSetting opts the requesting member out
+func TestAnalysisSharingHandler_UpdateSharingSetting_OptOut(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockAnalysisSharingUsecase)
+	handler := NewAnalysisSharingHandler(mockUsecase)
+
+	familyID, userID := uuid.New(), uuid.New()
+	mockUsecase.On("UpdateSharingSetting", mock.Anything, familyID, userID, false).
+		Return(&domain.AnalysisSharingSetting{ShareEnabled: false}, nil)
+
+	c, rec := newFamilyContext(http.MethodPut, "/families/me/settings/analysis-sharing", `{"share_enabled":false}`, familyID, userID)
+
+	err := handler.UpdateSharingSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"share_enabled":false`)
+	mockUsecase.AssertExpectations(t)
+}
+
+// UpdateSharingSetting without share_enabled - bad request
+func TestAnalysisSharingHandler_UpdateSharingSetting_Missing(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockAnalysisSharingUsecase)
+	handler := NewAnalysisSharingHandler(mockUsecase)
+
+	c, rec := newFamilyContext(http.MethodPut, "/families/me/settings/analysis-sharing", `{}`, uuid.New(), uuid.New())
+
+	err := handler.UpdateSharingSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUsecase.AssertNotCalled(t, "UpdateSharingSetting", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
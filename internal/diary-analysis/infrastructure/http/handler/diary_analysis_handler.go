@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/usecase"
 	"github.com/furuya-3150/fam-diary-log/pkg/errors"
 	"github.com/furuya-3150/fam-diary-log/pkg/middleware/auth"
@@ -75,3 +79,271 @@ func (dah *DiaryAnalysisHandler) GetWeekWritingTime(c echo.Context) error {
 		return dah.dau.GetWritingTimeByDate(ctx.Request().Context(), userID, date)
 	})
 }
+
+// GetAnalysis handles GET /:diary_id
+func (dah *DiaryAnalysisHandler) GetAnalysis(c echo.Context) error {
+	return dah.handleDiary(c, func(ctx context.Context, familyID, viewerID, diaryID uuid.UUID) (interface{}, error) {
+		return dah.dau.GetAnalysis(ctx, familyID, viewerID, diaryID)
+	})
+}
+
+// GetSuggestions handles GET /:diary_id/suggestions
+func (dah *DiaryAnalysisHandler) GetSuggestions(c echo.Context) error {
+	return dah.handleDiary(c, func(ctx context.Context, familyID, viewerID, diaryID uuid.UUID) (interface{}, error) {
+		return dah.dau.GetSuggestions(ctx, familyID, viewerID, diaryID)
+	})
+}
+
+// handleDiary is a common handler for the analysis of a diary
+func (dah *DiaryAnalysisHandler) handleDiary(c echo.Context, usecaseFunc func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) (interface{}, error)) error {
+	ctx := c.Request().Context()
+	viewerID, ok := ctx.Value(auth.ContextKeyUserID).(uuid.UUID)
+	if !ok || viewerID == uuid.Nil {
+		return errors.RespondWithError(c, &errors.LogicError{Message: "userIDを指定してください"})
+	}
+	familyID, ok := ctx.Value(auth.ContextKeyFamilyID).(uuid.UUID)
+	if !ok || familyID == uuid.Nil {
+		return errors.RespondWithError(c, &errors.LogicError{Message: "familyIDを指定してください"})
+	}
+
+	diaryID, err := uuid.Parse(c.Param("diary_id"))
+	if err != nil {
+		return errors.RespondWithError(c, &errors.ValidationError{Message: "invalid diary ID"})
+	}
+
+	result, err := usecaseFunc(ctx, familyID, viewerID, diaryID)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusOK, result)
+}
+
+// GetSentimentTrend handles GET /sentiment-trend
+// user_id defaults to the requesting user; admins (parents) can pass another family member's ID.
+func (dah *DiaryAnalysisHandler) GetSentimentTrend(c echo.Context) error {
+	ctx := c.Request().Context()
+	viewerID, ok := ctx.Value(auth.ContextKeyUserID).(uuid.UUID)
+	if !ok || viewerID == uuid.Nil {
+		return errors.RespondWithError(c, &errors.LogicError{Message: "userIDを指定してください"})
+	}
+	familyID, ok := ctx.Value(auth.ContextKeyFamilyID).(uuid.UUID)
+	if !ok || familyID == uuid.Nil {
+		return errors.RespondWithError(c, &errors.LogicError{Message: "familyIDを指定してください"})
+	}
+	role, _ := auth.GetRoleFromContext(ctx)
+
+	input := &usecase.SentimentTrendInput{
+		FamilyID: familyID,
+		ViewerID: viewerID,
+		IsAdmin:  role == auth.RoleAdmin,
+		UserID:   viewerID,
+		Date:     c.QueryParam("date"),
+		Period:   c.QueryParam("period"),
+	}
+	if raw := c.QueryParam("user_id"); raw != "" {
+		target, err := uuid.Parse(raw)
+		if err != nil {
+			return errors.RespondWithError(c, &errors.ValidationError{Message: "invalid user_id"})
+		}
+		input.UserID = target
+	}
+	if input.Date == "" {
+		return errors.RespondWithError(c, &errors.ValidationError{Message: "date query parameter is required"})
+	}
+	if input.Period == "" {
+		input.Period = domain.SentimentPeriodWeek
+	}
+
+	trend, err := dah.dau.GetSentimentTrend(ctx, input)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusOK, trend)
+}
+
+// GetWordFrequency handles GET /word-frequency
+// user_id is optional; the keywords of the whole family are aggregated when it is omitted.
+func (dah *DiaryAnalysisHandler) GetWordFrequency(c echo.Context) error {
+	ctx := c.Request().Context()
+	viewerID, ok := ctx.Value(auth.ContextKeyUserID).(uuid.UUID)
+	if !ok || viewerID == uuid.Nil {
+		return errors.RespondWithError(c, &errors.LogicError{Message: "userIDを指定してください"})
+	}
+	familyID, ok := ctx.Value(auth.ContextKeyFamilyID).(uuid.UUID)
+	if !ok || familyID == uuid.Nil {
+		return errors.RespondWithError(c, &errors.LogicError{Message: "familyIDを指定してください"})
+	}
+
+	input := &usecase.WordFrequencyInput{
+		FamilyID: familyID,
+		ViewerID: viewerID,
+		Date:     c.QueryParam("date"),
+		Period:   c.QueryParam("period"),
+		Limit:    domain.DefaultWordFrequencyLimit,
+	}
+	if raw := c.QueryParam("user_id"); raw != "" {
+		target, err := uuid.Parse(raw)
+		if err != nil {
+			return errors.RespondWithError(c, &errors.ValidationError{Message: "invalid user_id"})
+		}
+		input.UserID = target
+	}
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			return errors.RespondWithError(c, &errors.ValidationError{Message: "invalid limit"})
+		}
+		input.Limit = limit
+	}
+	if input.Date == "" {
+		return errors.RespondWithError(c, &errors.ValidationError{Message: "date query parameter is required"})
+	}
+	if input.Period == "" {
+		input.Period = domain.SentimentPeriodWeek
+	}
+
+	cloud, err := dah.dau.GetWordFrequency(ctx, input)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusOK, cloud)
+}
+
+// GetReadabilityTrend handles GET /readability-trend
+// user_id defaults to the requesting user; admins (parents) can pass another family member's ID.
+func (dah *DiaryAnalysisHandler) GetReadabilityTrend(c echo.Context) error {
+	ctx := c.Request().Context()
+	viewerID, ok := ctx.Value(auth.ContextKeyUserID).(uuid.UUID)
+	if !ok || viewerID == uuid.Nil {
+		return errors.RespondWithError(c, &errors.LogicError{Message: "userIDを指定してください"})
+	}
+	familyID, ok := ctx.Value(auth.ContextKeyFamilyID).(uuid.UUID)
+	if !ok || familyID == uuid.Nil {
+		return errors.RespondWithError(c, &errors.LogicError{Message: "familyIDを指定してください"})
+	}
+	role, _ := auth.GetRoleFromContext(ctx)
+
+	input := &usecase.ReadabilityTrendInput{
+		FamilyID: familyID,
+		ViewerID: viewerID,
+		IsAdmin:  role == auth.RoleAdmin,
+		UserID:   viewerID,
+		From:     c.QueryParam("from"),
+		To:       c.QueryParam("to"),
+		Period:   c.QueryParam("period"),
+	}
+	if raw := c.QueryParam("user_id"); raw != "" {
+		target, err := uuid.Parse(raw)
+		if err != nil {
+			return errors.RespondWithError(c, &errors.ValidationError{Message: "invalid user_id"})
+		}
+		input.UserID = target
+	}
+	if input.From == "" || input.To == "" {
+		return errors.RespondWithError(c, &errors.ValidationError{Message: "from and to query parameters are required"})
+	}
+	if input.Period == "" {
+		input.Period = domain.SentimentPeriodWeek
+	}
+
+	trend, err := dah.dau.GetReadabilityTrend(ctx, input)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusOK, trend)
+}
+
+// GetMetricSeries handles GET /metrics
+// metrics is a comma-separated list; granularity defaults to day.
+// user_id defaults to the requesting user; admins (parents) can pass another family member's ID.
+func (dah *DiaryAnalysisHandler) GetMetricSeries(c echo.Context) error {
+	ctx := c.Request().Context()
+	viewerID, ok := ctx.Value(auth.ContextKeyUserID).(uuid.UUID)
+	if !ok || viewerID == uuid.Nil {
+		return errors.RespondWithError(c, &errors.LogicError{Message: "userIDを指定してください"})
+	}
+	familyID, ok := ctx.Value(auth.ContextKeyFamilyID).(uuid.UUID)
+	if !ok || familyID == uuid.Nil {
+		return errors.RespondWithError(c, &errors.LogicError{Message: "familyIDを指定してください"})
+	}
+	role, _ := auth.GetRoleFromContext(ctx)
+
+	input := &usecase.MetricSeriesInput{
+		FamilyID:    familyID,
+		ViewerID:    viewerID,
+		IsAdmin:     role == auth.RoleAdmin,
+		UserID:      viewerID,
+		From:        c.QueryParam("from"),
+		To:          c.QueryParam("to"),
+		Granularity: c.QueryParam("granularity"),
+	}
+	if raw := c.QueryParam("metrics"); raw != "" {
+		for _, m := range strings.Split(raw, ",") {
+			input.Metrics = append(input.Metrics, strings.TrimSpace(m))
+		}
+	}
+	if raw := c.QueryParam("user_id"); raw != "" {
+		target, err := uuid.Parse(raw)
+		if err != nil {
+			return errors.RespondWithError(c, &errors.ValidationError{Message: "invalid user_id"})
+		}
+		input.UserID = target
+	}
+	if len(input.Metrics) == 0 {
+		return errors.RespondWithError(c, &errors.ValidationError{Message: "metrics query parameter is required"})
+	}
+	if input.From == "" || input.To == "" {
+		return errors.RespondWithError(c, &errors.ValidationError{Message: "from and to query parameters are required"})
+	}
+	if input.Granularity == "" {
+		input.Granularity = domain.MetricGranularityDay
+	}
+
+	series, err := dah.dau.GetMetricSeries(ctx, input)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusOK, series)
+}
+
+// GetFamilyMetrics handles GET /family-metrics
+// It compares a metric between the members sharing their analyses; granularity defaults to week.
+// Only admins (parents) can compare sentiment_score.
+func (dah *DiaryAnalysisHandler) GetFamilyMetrics(c echo.Context) error {
+	familyID, viewerID, err := familyContext(c)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+	role, _ := auth.GetRoleFromContext(c.Request().Context())
+
+	input := &usecase.FamilyMetricsInput{
+		FamilyID:    familyID,
+		ViewerID:    viewerID,
+		IsAdmin:     role == auth.RoleAdmin,
+		Metric:      c.QueryParam("metric"),
+		From:        c.QueryParam("from"),
+		To:          c.QueryParam("to"),
+		Granularity: c.QueryParam("granularity"),
+	}
+	if input.Metric == "" {
+		return errors.RespondWithError(c, &errors.ValidationError{Message: "metric query parameter is required"})
+	}
+	if input.From == "" || input.To == "" {
+		return errors.RespondWithError(c, &errors.ValidationError{Message: "from and to query parameters are required"})
+	}
+	if input.Granularity == "" {
+		input.Granularity = domain.MetricGranularityWeek
+	}
+
+	metrics, err := dah.dau.GetFamilyMetrics(c.Request().Context(), input)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusOK, metrics)
+}
@@ -7,6 +7,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/usecase"
 	"github.com/furuya-3150/fam-diary-log/pkg/errors"
 	"github.com/furuya-3150/fam-diary-log/pkg/middleware/auth"
 	"github.com/google/uuid"
@@ -39,6 +41,62 @@ func (m *MockDiaryAnalysisUsecase) GetWritingTimeByDate(ctx context.Context, use
 	return args.Get(0).(map[string]interface{}), args.Error(1)
 }
 
+func (m *MockDiaryAnalysisUsecase) GetSentimentTrend(ctx context.Context, input *usecase.SentimentTrendInput) (*domain.SentimentTrend, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SentimentTrend), args.Error(1)
+}
+
+func (m *MockDiaryAnalysisUsecase) GetWordFrequency(ctx context.Context, input *usecase.WordFrequencyInput) (*domain.WordCloud, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WordCloud), args.Error(1)
+}
+
+func (m *MockDiaryAnalysisUsecase) GetReadabilityTrend(ctx context.Context, input *usecase.ReadabilityTrendInput) (*domain.ReadabilityTrend, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReadabilityTrend), args.Error(1)
+}
+
+func (m *MockDiaryAnalysisUsecase) GetMetricSeries(ctx context.Context, input *usecase.MetricSeriesInput) (*domain.MetricSeries, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MetricSeries), args.Error(1)
+}
+
+func (m *MockDiaryAnalysisUsecase) GetFamilyMetrics(ctx context.Context, input *usecase.FamilyMetricsInput) (*domain.FamilyMetricSeries, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FamilyMetricSeries), args.Error(1)
+}
+
+func (m *MockDiaryAnalysisUsecase) GetAnalysis(ctx context.Context, familyID, viewerID, diaryID uuid.UUID) (*domain.DiaryAnalysis, error) {
+	args := m.Called(ctx, familyID, viewerID, diaryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DiaryAnalysis), args.Error(1)
+}
+
+func (m *MockDiaryAnalysisUsecase) GetSuggestions(ctx context.Context, familyID, viewerID, diaryID uuid.UUID) ([]*domain.DiaryAnalysisSuggestion, error) {
+	args := m.Called(ctx, familyID, viewerID, diaryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DiaryAnalysisSuggestion), args.Error(1)
+}
+
 // GetWeekCharCount with valid user ID and date - success
 func TestDiaryAnalysisHandler_GetWeekCharCount_Success(t *testing.T) {
 	t.Parallel()
@@ -373,3 +431,347 @@ func TestDiaryAnalysisHandler_GetWeekWritingTime_Success(t *testing.T) {
 	assert.NoError(t, err, "failed to unmarshal response")
 	assert.NotNil(t, response["data"], "expected data in response")
 }
+
+// GetSuggestions with valid diary ID - success
+func TestDiaryAnalysisHandler_GetSuggestions_Success(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+	handler := NewDiaryAnalysisHandler(mockUsecase)
+
+	familyID := uuid.New()
+	diaryID := uuid.New()
+	suggestions := []*domain.DiaryAnalysisSuggestion{
+		{ID: uuid.New(), DiaryID: diaryID, Offset: 3, Length: 2, Message: "助詞が重複しています", Suggestion: "を"},
+	}
+	viewerID := uuid.New()
+	mockUsecase.On("GetSuggestions", mock.Anything, familyID, viewerID, diaryID).Return(suggestions, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/families/me/analyzed-diaries/"+diaryID.String()+"/suggestions", nil)
+	ctx := context.WithValue(req.Context(), auth.ContextKeyFamilyID, familyID)
+	req = req.WithContext(context.WithValue(ctx, auth.ContextKeyUserID, viewerID))
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("diary_id")
+	c.SetParamValues(diaryID.String())
+
+	err := handler.GetSuggestions(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"offset":3`)
+	assert.Contains(t, rec.Body.String(), `"suggestion":"を"`)
+	mockUsecase.AssertExpectations(t)
+}
+
+// GetSuggestions with invalid diary ID - bad request
+func TestDiaryAnalysisHandler_GetSuggestions_InvalidDiaryID(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+	handler := NewDiaryAnalysisHandler(mockUsecase)
+
+	req := httptest.NewRequest(http.MethodGet, "/families/me/analyzed-diaries/invalid/suggestions", nil)
+	ctx := context.WithValue(req.Context(), auth.ContextKeyFamilyID, uuid.New())
+	req = req.WithContext(context.WithValue(ctx, auth.ContextKeyUserID, uuid.New()))
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("diary_id")
+	c.SetParamValues("invalid")
+
+	err := handler.GetSuggestions(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUsecase.AssertNotCalled(t, "GetSuggestions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// GetSentimentTrend of another member passes the target user and the viewer's role
+func TestDiaryAnalysisHandler_GetSentimentTrend_OtherMember(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+	handler := NewDiaryAnalysisHandler(mockUsecase)
+
+	viewerID := uuid.New()
+	childID := uuid.New()
+	familyID := uuid.New()
+	trend := &domain.SentimentTrend{UserID: childID, Period: domain.SentimentPeriodMonth}
+
+	mockUsecase.On("GetSentimentTrend", mock.Anything, &usecase.SentimentTrendInput{
+		FamilyID: familyID,
+		ViewerID: viewerID,
+		IsAdmin:  true,
+		UserID:   childID,
+		Date:     "2026-01-20",
+		Period:   domain.SentimentPeriodMonth,
+	}).Return(trend, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/families/me/analyzed-diaries/sentiment-trend?date=2026-01-20&period=month&user_id="+childID.String(), nil)
+	ctx := context.WithValue(req.Context(), auth.ContextKeyUserID, viewerID)
+	ctx = context.WithValue(ctx, auth.ContextKeyFamilyID, familyID)
+	ctx = context.WithValue(ctx, auth.ContextKeyRole, auth.RoleAdmin)
+	req = req.WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	err := handler.GetSentimentTrend(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// GetWordFrequency defaults to the whole family, a week and the default limit
+func TestDiaryAnalysisHandler_GetWordFrequency_Defaults(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+	handler := NewDiaryAnalysisHandler(mockUsecase)
+
+	viewerID := uuid.New()
+	familyID := uuid.New()
+	cloud := &domain.WordCloud{Period: domain.SentimentPeriodWeek, Words: []*domain.WordFrequency{{Word: "公園", Count: 3}}}
+
+	mockUsecase.On("GetWordFrequency", mock.Anything, &usecase.WordFrequencyInput{
+		FamilyID: familyID,
+		ViewerID: viewerID,
+		Date:     "2026-01-20",
+		Period:   domain.SentimentPeriodWeek,
+		Limit:    domain.DefaultWordFrequencyLimit,
+	}).Return(cloud, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/families/me/analyzed-diaries/word-frequency?date=2026-01-20", nil)
+	ctx := context.WithValue(req.Context(), auth.ContextKeyUserID, viewerID)
+	ctx = context.WithValue(ctx, auth.ContextKeyFamilyID, familyID)
+	req = req.WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	err := handler.GetWordFrequency(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// GetWordFrequency with a non-numeric limit - bad request
+func TestDiaryAnalysisHandler_GetWordFrequency_InvalidLimit(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+	handler := NewDiaryAnalysisHandler(mockUsecase)
+
+	req := httptest.NewRequest(http.MethodGet, "/families/me/analyzed-diaries/word-frequency?date=2026-01-20&limit=many", nil)
+	ctx := context.WithValue(req.Context(), auth.ContextKeyUserID, uuid.New())
+	ctx = context.WithValue(ctx, auth.ContextKeyFamilyID, uuid.New())
+	req = req.WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	err := handler.GetWordFrequency(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUsecase.AssertNotCalled(t, "GetWordFrequency", mock.Anything, mock.Anything)
+}
+
+// GetAnalysis of a failed accuracy check returns a null score with the status
+func TestDiaryAnalysisHandler_GetAnalysis_Failed(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+	handler := NewDiaryAnalysisHandler(mockUsecase)
+
+	familyID, viewerID, diaryID := uuid.New(), uuid.New(), uuid.New()
+	reason := "yahoo api: 503"
+	mockUsecase.On("GetAnalysis", mock.Anything, familyID, viewerID, diaryID).Return(&domain.DiaryAnalysis{
+		DiaryID:        diaryID,
+		AccuracyStatus: domain.AnalysisStatusFailed,
+		AccuracyError:  &reason,
+		KeywordsStatus: domain.AnalysisStatusComplete,
+		Status:         domain.AnalysisStatusFailed,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/families/me/analyzed-diaries/"+diaryID.String(), nil)
+	ctx := context.WithValue(req.Context(), auth.ContextKeyFamilyID, familyID)
+	req = req.WithContext(context.WithValue(ctx, auth.ContextKeyUserID, viewerID))
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("diary_id")
+	c.SetParamValues(diaryID.String())
+
+	err := handler.GetAnalysis(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"accuracy_score":null`)
+	assert.Contains(t, rec.Body.String(), `"accuracy_status":"failed"`)
+	assert.Contains(t, rec.Body.String(), `"status":"failed"`)
+	mockUsecase.AssertExpectations(t)
+}
+
+// GetReadabilityTrend defaults to the requesting user and weekly buckets
+func TestDiaryAnalysisHandler_GetReadabilityTrend_Defaults(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+	handler := NewDiaryAnalysisHandler(mockUsecase)
+
+	viewerID := uuid.New()
+	familyID := uuid.New()
+	trend := &domain.ReadabilityTrend{UserID: viewerID, Period: domain.SentimentPeriodWeek}
+
+	mockUsecase.On("GetReadabilityTrend", mock.Anything, &usecase.ReadabilityTrendInput{
+		FamilyID: familyID,
+		ViewerID: viewerID,
+		UserID:   viewerID,
+		From:     "2026-04-01",
+		To:       "2027-03-31",
+		Period:   domain.SentimentPeriodWeek,
+	}).Return(trend, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/families/me/analyzed-diaries/readability-trend?from=2026-04-01&to=2027-03-31", nil)
+	ctx := context.WithValue(req.Context(), auth.ContextKeyUserID, viewerID)
+	ctx = context.WithValue(ctx, auth.ContextKeyFamilyID, familyID)
+	req = req.WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	err := handler.GetReadabilityTrend(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// GetReadabilityTrend without to - bad request
+func TestDiaryAnalysisHandler_GetReadabilityTrend_MissingTo(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+	handler := NewDiaryAnalysisHandler(mockUsecase)
+
+	req := httptest.NewRequest(http.MethodGet, "/families/me/analyzed-diaries/readability-trend?from=2026-04-01", nil)
+	ctx := context.WithValue(req.Context(), auth.ContextKeyUserID, uuid.New())
+	ctx = context.WithValue(ctx, auth.ContextKeyFamilyID, uuid.New())
+	req = req.WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	err := handler.GetReadabilityTrend(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUsecase.AssertNotCalled(t, "GetReadabilityTrend", mock.Anything, mock.Anything)
+}
+
+// GetMetricSeries splits the metrics and defaults to daily buckets of the requesting user
+func TestDiaryAnalysisHandler_GetMetricSeries_Defaults(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+	handler := NewDiaryAnalysisHandler(mockUsecase)
+
+	viewerID := uuid.New()
+	familyID := uuid.New()
+	series := &domain.MetricSeries{UserID: viewerID, Granularity: domain.MetricGranularityDay}
+
+	mockUsecase.On("GetMetricSeries", mock.Anything, &usecase.MetricSeriesInput{
+		FamilyID:    familyID,
+		ViewerID:    viewerID,
+		UserID:      viewerID,
+		Metrics:     []string{domain.MetricCharCount, domain.MetricAccuracyScore},
+		From:        "2026-04-01",
+		To:          "2026-04-30",
+		Granularity: domain.MetricGranularityDay,
+	}).Return(series, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/families/me/analyzed-diaries/metrics?metrics=char_count,accuracy_score&from=2026-04-01&to=2026-04-30", nil)
+	ctx := context.WithValue(req.Context(), auth.ContextKeyUserID, viewerID)
+	ctx = context.WithValue(ctx, auth.ContextKeyFamilyID, familyID)
+	req = req.WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	err := handler.GetMetricSeries(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// GetMetricSeries without metrics - bad request
+func TestDiaryAnalysisHandler_GetMetricSeries_MissingMetrics(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+	handler := NewDiaryAnalysisHandler(mockUsecase)
+
+	req := httptest.NewRequest(http.MethodGet, "/families/me/analyzed-diaries/metrics?from=2026-04-01&to=2026-04-30", nil)
+	ctx := context.WithValue(req.Context(), auth.ContextKeyUserID, uuid.New())
+	ctx = context.WithValue(ctx, auth.ContextKeyFamilyID, uuid.New())
+	req = req.WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	err := handler.GetMetricSeries(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUsecase.AssertNotCalled(t, "GetMetricSeries", mock.Anything, mock.Anything)
+}
+
+// GetFamilyMetrics defaults to weekly buckets
+func TestDiaryAnalysisHandler_GetFamilyMetrics_Defaults(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+	handler := NewDiaryAnalysisHandler(mockUsecase)
+
+	viewerID := uuid.New()
+	familyID := uuid.New()
+
+	mockUsecase.On("GetFamilyMetrics", mock.Anything, &usecase.FamilyMetricsInput{
+		FamilyID:    familyID,
+		ViewerID:    viewerID,
+		Metric:      domain.MetricAccuracyScore,
+		From:        "2026-04-01",
+		To:          "2026-04-30",
+		Granularity: domain.MetricGranularityWeek,
+	}).Return(&domain.FamilyMetricSeries{Metric: domain.MetricAccuracyScore}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/families/me/analyzed-diaries/family-metrics?metric=accuracy_score&from=2026-04-01&to=2026-04-30", nil)
+	ctx := context.WithValue(req.Context(), auth.ContextKeyUserID, viewerID)
+	ctx = context.WithValue(ctx, auth.ContextKeyFamilyID, familyID)
+	req = req.WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	err := handler.GetFamilyMetrics(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// GetFamilyMetrics without metric - bad request
+func TestDiaryAnalysisHandler_GetFamilyMetrics_MissingMetric(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+	handler := NewDiaryAnalysisHandler(mockUsecase)
+
+	req := httptest.NewRequest(http.MethodGet, "/families/me/analyzed-diaries/family-metrics?from=2026-04-01&to=2026-04-30", nil)
+	ctx := context.WithValue(req.Context(), auth.ContextKeyUserID, uuid.New())
+	ctx = context.WithValue(ctx, auth.ContextKeyFamilyID, uuid.New())
+	req = req.WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	err := handler.GetFamilyMetrics(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUsecase.AssertNotCalled(t, "GetFamilyMetrics", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/usecase"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+	"github.com/furuya-3150/fam-diary-log/pkg/middleware/auth"
+	"github.com/furuya-3150/fam-diary-log/pkg/response"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// entityRequest is the body of POST /entities and PUT /entities/:entity_id
+type entityRequest struct {
+	Kind    string   `json:"kind"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
+
+type EntityHandler struct {
+	eu usecase.EntityUsecase
+}
+
+func NewEntityHandler(eu usecase.EntityUsecase) *EntityHandler {
+	return &EntityHandler{
+		eu: eu,
+	}
+}
+
+// ListEntities handles GET /entities
+func (eh *EntityHandler) ListEntities(c echo.Context) error {
+	familyID, _, err := familyContext(c)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	entities, err := eh.eu.ListEntities(c.Request().Context(), familyID)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusOK, entities)
+}
+
+// CreateEntity handles POST /entities
+func (eh *EntityHandler) CreateEntity(c echo.Context) error {
+	familyID, _, err := familyContext(c)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+	var req entityRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.RespondWithError(c, &errors.BadRequestError{Message: "invalid request body: " + err.Error()})
+	}
+
+	entity, err := eh.eu.CreateEntity(c.Request().Context(), &usecase.EntityInput{
+		FamilyID: familyID,
+		Kind:     req.Kind,
+		Name:     req.Name,
+		Aliases:  req.Aliases,
+	})
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusCreated, entity)
+}
+
+// UpdateEntity handles PUT /entities/:entity_id
+func (eh *EntityHandler) UpdateEntity(c echo.Context) error {
+	familyID, _, err := familyContext(c)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+	entityID, err := uuid.Parse(c.Param("entity_id"))
+	if err != nil {
+		return errors.RespondWithError(c, &errors.ValidationError{Message: "invalid entity ID"})
+	}
+	var req entityRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.RespondWithError(c, &errors.BadRequestError{Message: "invalid request body: " + err.Error()})
+	}
+
+	entity, err := eh.eu.UpdateEntity(c.Request().Context(), &usecase.EntityInput{
+		ID:       entityID,
+		FamilyID: familyID,
+		Kind:     req.Kind,
+		Name:     req.Name,
+		Aliases:  req.Aliases,
+	})
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusOK, entity)
+}
+
+// DeleteEntity handles DELETE /entities/:entity_id
+func (eh *EntityHandler) DeleteEntity(c echo.Context) error {
+	familyID, _, err := familyContext(c)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+	entityID, err := uuid.Parse(c.Param("entity_id"))
+	if err != nil {
+		return errors.RespondWithError(c, &errors.ValidationError{Message: "invalid entity ID"})
+	}
+
+	if err := eh.eu.DeleteEntity(c.Request().Context(), familyID, entityID); err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusNoContent, nil)
+}
+
+// ListEntityDiaries handles GET /entities/:kind/:entity_id/diaries
+// entity_id is the user ID of a member and the ID of a place or a pet.
+func (eh *EntityHandler) ListEntityDiaries(c echo.Context) error {
+	familyID, viewerID, err := familyContext(c)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+	entityID, err := uuid.Parse(c.Param("entity_id"))
+	if err != nil {
+		return errors.RespondWithError(c, &errors.ValidationError{Message: "invalid entity ID"})
+	}
+
+	input := &usecase.EntityDiariesInput{
+		FamilyID: familyID,
+		ViewerID: viewerID,
+		Kind:     c.Param("kind"),
+		EntityID: entityID,
+		Limit:    domain.DefaultEntityDiaryLimit,
+	}
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			return errors.RespondWithError(c, &errors.ValidationError{Message: "invalid limit"})
+		}
+		input.Limit = limit
+	}
+
+	diaries, err := eh.eu.ListEntityDiaries(c.Request().Context(), input)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusOK, diaries)
+}
+
+// GetEntityMentions handles GET /entity-mentions
+// It counts the members, places and pets each member wrote about between from and to.
+func (eh *EntityHandler) GetEntityMentions(c echo.Context) error {
+	familyID, viewerID, err := familyContext(c)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	input := &usecase.EntityMentionsInput{
+		FamilyID: familyID,
+		ViewerID: viewerID,
+		From:     c.QueryParam("from"),
+		To:       c.QueryParam("to"),
+	}
+	if input.From == "" || input.To == "" {
+		return errors.RespondWithError(c, &errors.ValidationError{Message: "from and to query parameters are required"})
+	}
+
+	mentions, err := eh.eu.GetEntityMentions(c.Request().Context(), input)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusOK, mentions)
+}
+
+// familyContext returns the family and the user of the request
+func familyContext(c echo.Context) (familyID, viewerID uuid.UUID, err error) {
+	ctx := c.Request().Context()
+	viewerID, ok := ctx.Value(auth.ContextKeyUserID).(uuid.UUID)
+	if !ok || viewerID == uuid.Nil {
+		return uuid.Nil, uuid.Nil, &errors.LogicError{Message: "userIDを指定してください"}
+	}
+	familyID, ok = ctx.Value(auth.ContextKeyFamilyID).(uuid.UUID)
+	if !ok || familyID == uuid.Nil {
+		return uuid.Nil, uuid.Nil, &errors.LogicError{Message: "familyIDを指定してください"}
+	}
+	return familyID, viewerID, nil
+}
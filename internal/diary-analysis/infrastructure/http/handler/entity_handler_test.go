@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/usecase"
+	"github.com/furuya-3150/fam-diary-log/pkg/middleware/auth"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEntityUsecase struct {
+	mock.Mock
+}
+
+func (m *MockEntityUsecase) ListEntities(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyEntity, error) {
+	args := m.Called(ctx, familyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.FamilyEntity), args.Error(1)
+}
+
+func (m *MockEntityUsecase) CreateEntity(ctx context.Context, input *usecase.EntityInput) (*domain.FamilyEntity, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FamilyEntity), args.Error(1)
+}
+
+func (m *MockEntityUsecase) UpdateEntity(ctx context.Context, input *usecase.EntityInput) (*domain.FamilyEntity, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FamilyEntity), args.Error(1)
+}
+
+func (m *MockEntityUsecase) DeleteEntity(ctx context.Context, familyID, entityID uuid.UUID) error {
+	args := m.Called(ctx, familyID, entityID)
+	return args.Error(0)
+}
+
+func (m *MockEntityUsecase) ListEntityDiaries(ctx context.Context, input *usecase.EntityDiariesInput) ([]*domain.EntityDiary, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EntityDiary), args.Error(1)
+}
+
+func (m *MockEntityUsecase) GetEntityMentions(ctx context.Context, input *usecase.EntityMentionsInput) (*domain.EntityMentions, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EntityMentions), args.Error(1)
+}
+
+// newFamilyContext creates an Echo context of a request by the user of the family
+func newFamilyContext(method, target, body string, familyID, viewerID uuid.UUID) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	ctx := context.WithValue(req.Context(), auth.ContextKeyUserID, viewerID)
+	ctx = context.WithValue(ctx, auth.ContextKeyFamilyID, familyID)
+	rec := httptest.NewRecorder()
+	return echo.New().NewContext(req.WithContext(ctx), rec), rec
+}
+
+// CreateEntity adds a place of the family
+func TestEntityHandler_CreateEntity_Success(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockEntityUsecase)
+	handler := NewEntityHandler(mockUsecase)
+
+	familyID := uuid.New()
+	mockUsecase.On("CreateEntity", mock.Anything, &usecase.EntityInput{
+		FamilyID: familyID,
+		Kind:     domain.EntityKindPlace,
+		Name:     "中央公園",
+		Aliases:  []string{"公園"},
+	}).Return(&domain.FamilyEntity{ID: uuid.New(), Kind: domain.EntityKindPlace, Name: "中央公園"}, nil)
+
+	c, rec := newFamilyContext(http.MethodPost, "/families/me/entities",
+		`{"kind":"place","name":"中央公園","aliases":["公園"]}`, familyID, uuid.New())
+
+	err := handler.CreateEntity(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+// DeleteEntity with an invalid ID - bad request
+func TestEntityHandler_DeleteEntity_InvalidID(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockEntityUsecase)
+	handler := NewEntityHandler(mockUsecase)
+
+	c, rec := newFamilyContext(http.MethodDelete, "/families/me/entities/pochi", "", uuid.New(), uuid.New())
+	c.SetParamNames("entity_id")
+	c.SetParamValues("pochi")
+
+	err := handler.DeleteEntity(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUsecase.AssertNotCalled(t, "DeleteEntity", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ListEntityDiaries lists the diaries about a member with the default limit
+func TestEntityHandler_ListEntityDiaries_Defaults(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockEntityUsecase)
+	handler := NewEntityHandler(mockUsecase)
+
+	familyID, viewerID, grandpa := uuid.New(), uuid.New(), uuid.New()
+	mockUsecase.On("ListEntityDiaries", mock.Anything, &usecase.EntityDiariesInput{
+		FamilyID: familyID,
+		ViewerID: viewerID,
+		Kind:     domain.EntityKindMember,
+		EntityID: grandpa,
+		Limit:    domain.DefaultEntityDiaryLimit,
+	}).Return([]*domain.EntityDiary{{DiaryID: uuid.New(), MentionCount: 2}}, nil)
+
+	c, rec := newFamilyContext(http.MethodGet, "/families/me/entities/member/"+grandpa.String()+"/diaries", "", familyID, viewerID)
+	c.SetParamNames("kind", "entity_id")
+	c.SetParamValues(domain.EntityKindMember, grandpa.String())
+
+	err := handler.ListEntityDiaries(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"mention_count":2`)
+	mockUsecase.AssertExpectations(t)
+}
+
+// GetEntityMentions without to - bad request
+func TestEntityHandler_GetEntityMentions_MissingTo(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockEntityUsecase)
+	handler := NewEntityHandler(mockUsecase)
+
+	c, rec := newFamilyContext(http.MethodGet, "/families/me/entity-mentions?from=2026-04-01", "", uuid.New(), uuid.New())
+
+	err := handler.GetEntityMentions(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUsecase.AssertNotCalled(t, "GetEntityMentions", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/usecase"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+	"github.com/furuya-3150/fam-diary-log/pkg/response"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// goalRequest is the body of POST /goals and PUT /goals/:goal_id; PUT changes the target only
+type goalRequest struct {
+	Metric string `json:"metric"`
+	Period string `json:"period"`
+	Target int    `json:"target"`
+}
+
+type GoalHandler struct {
+	gu usecase.GoalUsecase
+}
+
+func NewGoalHandler(gu usecase.GoalUsecase) *GoalHandler {
+	return &GoalHandler{
+		gu: gu,
+	}
+}
+
+// ListGoals handles GET /goals
+func (gh *GoalHandler) ListGoals(c echo.Context) error {
+	familyID, userID, err := familyContext(c)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	goals, err := gh.gu.ListGoals(c.Request().Context(), familyID, userID)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusOK, goals)
+}
+
+// CreateGoal handles POST /goals
+func (gh *GoalHandler) CreateGoal(c echo.Context) error {
+	familyID, userID, err := familyContext(c)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+	var req goalRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.RespondWithError(c, &errors.BadRequestError{Message: "invalid request body: " + err.Error()})
+	}
+
+	goal, err := gh.gu.CreateGoal(c.Request().Context(), &usecase.GoalInput{
+		FamilyID: familyID,
+		UserID:   userID,
+		Metric:   req.Metric,
+		Period:   req.Period,
+		Target:   req.Target,
+	})
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusCreated, goal)
+}
+
+// UpdateGoal handles PUT /goals/:goal_id
+func (gh *GoalHandler) UpdateGoal(c echo.Context) error {
+	familyID, userID, err := familyContext(c)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+	goalID, err := uuid.Parse(c.Param("goal_id"))
+	if err != nil {
+		return errors.RespondWithError(c, &errors.ValidationError{Message: "invalid goal ID"})
+	}
+	var req goalRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.RespondWithError(c, &errors.BadRequestError{Message: "invalid request body: " + err.Error()})
+	}
+
+	goal, err := gh.gu.UpdateGoal(c.Request().Context(), &usecase.GoalInput{
+		ID:       goalID,
+		FamilyID: familyID,
+		UserID:   userID,
+		Target:   req.Target,
+	})
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusOK, goal)
+}
+
+// DeleteGoal handles DELETE /goals/:goal_id
+func (gh *GoalHandler) DeleteGoal(c echo.Context) error {
+	familyID, userID, err := familyContext(c)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+	goalID, err := uuid.Parse(c.Param("goal_id"))
+	if err != nil {
+		return errors.RespondWithError(c, &errors.ValidationError{Message: "invalid goal ID"})
+	}
+
+	if err := gh.gu.DeleteGoal(c.Request().Context(), familyID, userID, goalID); err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusNoContent, nil)
+}
+
+// GetProgress handles GET /goals/progress?date=YYYY-MM-DD
+// date is optional and defaults to today in the member's time zone.
+func (gh *GoalHandler) GetProgress(c echo.Context) error {
+	familyID, userID, err := familyContext(c)
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	progress, err := gh.gu.GetProgress(c.Request().Context(), &usecase.GoalProgressInput{
+		FamilyID: familyID,
+		UserID:   userID,
+		Date:     c.QueryParam("date"),
+	})
+	if err != nil {
+		return errors.RespondWithError(c, err)
+	}
+
+	return response.RespondSuccess(c, http.StatusOK, progress)
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGoalUsecase struct {
+	mock.Mock
+}
+
+func (m *MockGoalUsecase) ListGoals(ctx context.Context, familyID, userID uuid.UUID) ([]*domain.Goal, error) {
+	args := m.Called(ctx, familyID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Goal), args.Error(1)
+}
+
+func (m *MockGoalUsecase) CreateGoal(ctx context.Context, input *usecase.GoalInput) (*domain.Goal, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Goal), args.Error(1)
+}
+
+func (m *MockGoalUsecase) UpdateGoal(ctx context.Context, input *usecase.GoalInput) (*domain.Goal, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Goal), args.Error(1)
+}
+
+func (m *MockGoalUsecase) DeleteGoal(ctx context.Context, familyID, userID, goalID uuid.UUID) error {
+	args := m.Called(ctx, familyID, userID, goalID)
+	return args.Error(0)
+}
+
+func (m *MockGoalUsecase) GetProgress(ctx context.Context, input *usecase.GoalProgressInput) (*domain.GoalsProgress, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GoalsProgress), args.Error(1)
+}
+
+// CreateGoal adds a goal of the requesting member
+func TestGoalHandler_CreateGoal_Success(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockGoalUsecase)
+	handler := NewGoalHandler(mockUsecase)
+
+	familyID, userID := uuid.New(), uuid.New()
+	mockUsecase.On("CreateGoal", mock.Anything, &usecase.GoalInput{
+		FamilyID: familyID,
+		UserID:   userID,
+		Metric:   domain.GoalMetricPosts,
+		Period:   domain.GoalPeriodWeekly,
+		Target:   3,
+	}).Return(&domain.Goal{ID: uuid.New(), Metric: domain.GoalMetricPosts, Period: domain.GoalPeriodWeekly, Target: 3}, nil)
+
+	c, rec := newFamilyContext(http.MethodPost, "/families/me/goals", `{"metric":"posts","period":"weekly","target":3}`, familyID, userID)
+
+	err := handler.CreateGoal(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"target":3`)
+	mockUsecase.AssertExpectations(t)
+}
+
+// UpdateGoal with an invalid goal ID - bad request
+func TestGoalHandler_UpdateGoal_InvalidID(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockGoalUsecase)
+	handler := NewGoalHandler(mockUsecase)
+
+	c, rec := newFamilyContext(http.MethodPut, "/families/me/goals/weekly", `{"target":5}`, uuid.New(), uuid.New())
+	c.SetParamNames("goal_id")
+	c.SetParamValues("weekly")
+
+	err := handler.UpdateGoal(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUsecase.AssertNotCalled(t, "UpdateGoal", mock.Anything, mock.Anything)
+}
+
+// GetProgress passes the date, which is optional
+func TestGoalHandler_GetProgress(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := new(MockGoalUsecase)
+	handler := NewGoalHandler(mockUsecase)
+
+	familyID, userID := uuid.New(), uuid.New()
+	mockUsecase.On("GetProgress", mock.Anything, &usecase.GoalProgressInput{
+		FamilyID: familyID,
+		UserID:   userID,
+		Date:     "2026-01-30",
+	}).Return(&domain.GoalsProgress{
+		Date: "2026-01-30",
+		Goals: []*domain.GoalProgress{
+			{GoalID: uuid.New(), Metric: domain.GoalMetricPosts, Period: domain.GoalPeriodWeekly, Target: 3, Current: 3, Achieved: true},
+		},
+	}, nil)
+
+	c, rec := newFamilyContext(http.MethodGet, "/families/me/goals/progress?date=2026-01-30", "", familyID, userID)
+
+	err := handler.GetProgress(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"achieved":true`)
+	mockUsecase.AssertExpectations(t)
+}
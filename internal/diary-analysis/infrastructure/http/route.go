@@ -7,6 +7,7 @@ import (
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/infrastructure/http/handler"
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/infrastructure/repository"
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/usecase"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
 	"github.com/furuya-3150/fam-diary-log/pkg/db"
 	"github.com/furuya-3150/fam-diary-log/pkg/middleware/auth"
 	"github.com/labstack/echo/v4"
@@ -22,6 +23,15 @@ func NewRouter() *echo.Echo {
 	diaryAnalysisUsecase := usecase.NewDiaryAnalysisUsecase(diaryAnalysisRepo)
 	diaryAnalysisHandler := handler.NewDiaryAnalysisHandler(diaryAnalysisUsecase)
 
+	entityRepo := repository.NewEntityRepository(dbManager)
+	entityHandler := handler.NewEntityHandler(usecase.NewEntityUsecase(entityRepo))
+
+	sharingRepo := repository.NewAnalysisSharingRepository(dbManager)
+	sharingHandler := handler.NewAnalysisSharingHandler(usecase.NewAnalysisSharingUsecase(sharingRepo))
+
+	goalRepo := repository.NewGoalRepository(dbManager)
+	goalHandler := handler.NewGoalHandler(usecase.NewGoalUsecase(goalRepo, diaryAnalysisRepo, &clock.Real{}))
+
 	e := echo.New()
 
 	// CORS middleware
@@ -43,6 +53,34 @@ func NewRouter() *echo.Echo {
 	analyses.GET("/weekly-sentence-count", diaryAnalysisHandler.GetWeekSentenceCount)
 	analyses.GET("/weekly-accuracy-score", diaryAnalysisHandler.GetWeekAccuracyScore)
 	analyses.GET("/weekly-writing-time", diaryAnalysisHandler.GetWeekWritingTime)
+	analyses.GET("/sentiment-trend", diaryAnalysisHandler.GetSentimentTrend)
+	analyses.GET("/word-frequency", diaryAnalysisHandler.GetWordFrequency)
+	analyses.GET("/readability-trend", diaryAnalysisHandler.GetReadabilityTrend)
+	analyses.GET("/metrics", diaryAnalysisHandler.GetMetricSeries)
+	analyses.GET("/family-metrics", diaryAnalysisHandler.GetFamilyMetrics)
+	analyses.GET("/:diary_id", diaryAnalysisHandler.GetAnalysis)
+	analyses.GET("/:diary_id/suggestions", diaryAnalysisHandler.GetSuggestions)
+
+	// members, places and pets linked in diaries
+	families := e.Group("/families/me")
+	families.Use(auth.JWTAuthMiddleware(cfg.JWT.Secret), auth.RequireFamily())
+	families.GET("/entities", entityHandler.ListEntities)
+	families.POST("/entities", entityHandler.CreateEntity)
+	families.PUT("/entities/:entity_id", entityHandler.UpdateEntity)
+	families.DELETE("/entities/:entity_id", entityHandler.DeleteEntity)
+	families.GET("/entities/:kind/:entity_id/diaries", entityHandler.ListEntityDiaries)
+	families.GET("/entity-mentions", entityHandler.GetEntityMentions)
+
+	// whether the member's analyses are compared with the family
+	families.GET("/settings/analysis-sharing", sharingHandler.GetSharingSetting)
+	families.PUT("/settings/analysis-sharing", sharingHandler.UpdateSharingSetting)
+
+	// personal writing goals; diary-analyzer publishes goal.achieved when one is reached
+	families.GET("/goals", goalHandler.ListGoals)
+	families.POST("/goals", goalHandler.CreateGoal)
+	families.GET("/goals/progress", goalHandler.GetProgress)
+	families.PUT("/goals/:goal_id", goalHandler.UpdateGoal)
+	families.DELETE("/goals/:goal_id", goalHandler.DeleteGoal)
 
 	return e
 }
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+type AnalysisSharingRepository interface {
+	// FindSetting returns the sharing setting of the member, or nil if they never changed it
+	FindSetting(ctx context.Context, familyID, userID uuid.UUID) (*domain.AnalysisSharingSetting, error)
+	UpsertSetting(ctx context.Context, setting *domain.AnalysisSharingSetting) error
+}
+
+type analysisSharingRepository struct {
+	dm *db.DBManager
+}
+
+func NewAnalysisSharingRepository(dm *db.DBManager) AnalysisSharingRepository {
+	return &analysisSharingRepository{
+		dm: dm,
+	}
+}
+
+func (asr *analysisSharingRepository) FindSetting(ctx context.Context, familyID, userID uuid.UUID) (*domain.AnalysisSharingSetting, error) {
+	db := asr.dm.DB(ctx)
+
+	var settings []*domain.AnalysisSharingSetting
+	err := db.Where("family_id = ? AND user_id = ?", familyID, userID).
+		Limit(1).
+		Find(&settings).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(settings) == 0 {
+		return nil, nil
+	}
+	return settings[0], nil
+}
+
+func (asr *analysisSharingRepository) UpsertSetting(ctx context.Context, setting *domain.AnalysisSharingSetting) error {
+	setting.UpdatedAt = time.Now()
+	return asr.dm.DB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "family_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"share_enabled", "updated_at"}),
+	}).Create(setting).Error
+}
@@ -1,13 +1,25 @@
 package repository
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
 	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"github.com/google/uuid"
 )
 
 type DiaryAnalysisRepository interface {
-	List(ctx context.Context, criteria *domain.DiaryAnalysisSearchCriteria) ([]*domain.DiaryAnalysis, error)
+	// ListDaily returns the daily rollups of the analyses per member and local date, in date order
+	ListDaily(ctx context.Context, criteria *domain.DiaryAnalysisSearchCriteria) ([]*domain.DailyAnalysisMetrics, error)
+	// FindLatestByDiaryID returns the latest analysis of the diary in the family visible to the viewer, or nil if there is none
+	FindLatestByDiaryID(ctx context.Context, familyID, viewerID, diaryID uuid.UUID) (*domain.DiaryAnalysis, error)
+	ListSuggestions(ctx context.Context, analysisID uuid.UUID) ([]*domain.DiaryAnalysisSuggestion, error)
+	// ListWordFrequencies returns the most frequent keywords, excluding locked time capsules of other members
+	ListWordFrequencies(ctx context.Context, criteria *domain.WordFrequencyCriteria) ([]*domain.WordFrequency, error)
+	// ListSharedMembers returns the members of the family synced from user-context, excluding those who opted out of sharing their analyses
+	ListSharedMembers(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyMember, error)
 }
 
 type diaryAnalysisRepository struct {
@@ -20,35 +32,153 @@ func NewDiaryAnalysisRepository(dm *db.DBManager) DiaryAnalysisRepository {
 	}
 }
 
-// List retrieves diary analyses based on the search criteria
-func (dar *diaryAnalysisRepository) List(ctx context.Context, criteria *domain.DiaryAnalysisSearchCriteria) ([]*domain.DiaryAnalysis, error) {
+// dailySelect merges the rollups of a member on a local date, which has one row per unlock_at
+func dailySelect() string {
+	columns := []string{"user_id", "local_date", "SUM(diary_count)"}
+	for _, m := range domain.RollupMetrics {
+		columns = append(columns, fmt.Sprintf("SUM(%[1]s_count), SUM(%[1]s_sum), MIN(%[1]s_min), MAX(%[1]s_max)", m))
+	}
+	return strings.Join(columns, ", ")
+}
+
+// ListDaily retrieves the daily rollups of diary analyses based on the search criteria
+func (dar *diaryAnalysisRepository) ListDaily(ctx context.Context, criteria *domain.DiaryAnalysisSearchCriteria) ([]*domain.DailyAnalysisMetrics, error) {
 	db := dar.dm.DB(ctx)
 
-	var diaryAnalysis []*domain.DiaryAnalysis
+	q := db.Table("diary_analysis_daily").Select(dailySelect())
+
+	if criteria.UserID != uuid.Nil {
+		q = q.Where("user_id = ?", criteria.UserID)
+	}
+
+	if criteria.FamilyID != uuid.Nil {
+		q = q.Where("family_id = ?", criteria.FamilyID)
+	}
+
+	if criteria.ViewerID != uuid.Nil {
+		q = q.Where("(unlock_at IS NULL OR unlock_at <= NOW() OR user_id = ?)", criteria.ViewerID)
+	}
 
-	q := db.Model(&domain.DiaryAnalysis{}).
-		Where("user_id = ?", criteria.UserID)
+	if criteria.SharedOnly {
+		q = q.Where("NOT EXISTS (SELECT 1 FROM analysis_sharing_settings AS s " +
+			"WHERE s.user_id = diary_analysis_daily.user_id AND s.family_id = diary_analysis_daily.family_id AND NOT s.share_enabled)")
+	}
 
 	if !criteria.WeekStart.IsZero() {
-		q = q.Where("DATE(created_at) >= ?", criteria.WeekStart)
+		q = q.Where("local_date >= ?", criteria.WeekStart.Format(time.DateOnly))
 	}
 
 	if !criteria.WeekEnd.IsZero() {
-		q = q.Where("DATE(created_at) <= ?", criteria.WeekEnd)
+		q = q.Where("local_date <= ?", criteria.WeekEnd.Format(time.DateOnly))
+	}
+
+	rows, err := q.
+		Group("user_id, local_date").
+		Order("local_date ASC, user_id ASC").
+		Rows()
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	// Apply columns selection if specified
-	if len(criteria.Columns) > 0 {
-		q = q.Select(criteria.Columns)
+	var dailies []*domain.DailyAnalysisMetrics
+	for rows.Next() {
+		daily := &domain.DailyAnalysisMetrics{Metrics: make(map[string]*domain.MetricAggregate, len(domain.RollupMetrics))}
+		dest := []interface{}{&daily.UserID, &daily.LocalDate, &daily.DiaryCount}
+		for _, m := range domain.RollupMetrics {
+			agg := &domain.MetricAggregate{}
+			daily.Metrics[m] = agg
+			dest = append(dest, &agg.Count, &agg.Sum, &agg.Min, &agg.Max)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		dailies = append(dailies, daily)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
+	return dailies, nil
+}
 
-	err := q.
-		Order("created_at ASC").
-		Find(&diaryAnalysis).Error
+func (dar *diaryAnalysisRepository) FindLatestByDiaryID(ctx context.Context, familyID, viewerID, diaryID uuid.UUID) (*domain.DiaryAnalysis, error) {
+	db := dar.dm.DB(ctx)
+
+	var analyses []*domain.DiaryAnalysis
+	err := db.Where("family_id = ? AND diary_id = ?", familyID, diaryID).
+		Where("(unlock_at IS NULL OR unlock_at <= NOW() OR user_id = ?)", viewerID).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&analyses).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(analyses) == 0 {
+		return nil, nil
+	}
+	return analyses[0], nil
+}
+
+// ListSuggestions returns the suggestions of the analysis in the order they appear in the content
+func (dar *diaryAnalysisRepository) ListSuggestions(ctx context.Context, analysisID uuid.UUID) ([]*domain.DiaryAnalysisSuggestion, error) {
+	db := dar.dm.DB(ctx)
+
+	var suggestions []*domain.DiaryAnalysisSuggestion
+	err := db.Where("analysis_id = ?", analysisID).
+		Order("offset_chars ASC, length_chars ASC").
+		Find(&suggestions).Error
+	if err != nil {
+		return nil, err
+	}
+	return suggestions, nil
+}
+
+func (dar *diaryAnalysisRepository) ListWordFrequencies(ctx context.Context, criteria *domain.WordFrequencyCriteria) ([]*domain.WordFrequency, error) {
+	db := dar.dm.DB(ctx)
+
+	q := db.Table("diary_keywords AS k").
+		Select("k.word AS word, SUM(k.count) AS count, BOOL_OR(k.proper_noun) AS proper_noun").
+		Joins("JOIN diary_analyses AS a ON a.id = k.analysis_id").
+		Where("k.family_id = ?", criteria.FamilyID).
+		Where("(a.unlock_at IS NULL OR a.unlock_at <= NOW() OR a.user_id = ?)", criteria.ViewerID)
+
+	if criteria.UserID != uuid.Nil {
+		q = q.Where("k.user_id = ?", criteria.UserID)
+	}
+
+	if !criteria.StartDate.IsZero() {
+		q = q.Where("a.local_date >= ?", criteria.StartDate.Format(time.DateOnly))
+	}
 
+	if !criteria.EndDate.IsZero() {
+		q = q.Where("a.local_date <= ?", criteria.EndDate.Format(time.DateOnly))
+	}
+
+	var frequencies []*domain.WordFrequency
+	err := q.
+		Group("k.word").
+		Order("count DESC, k.word ASC").
+		Limit(criteria.Limit).
+		Scan(&frequencies).Error
 	if err != nil {
 		return nil, err
 	}
+	return frequencies, nil
+}
+
+func (dar *diaryAnalysisRepository) ListSharedMembers(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyMember, error) {
+	db := dar.dm.DB(ctx)
 
-	return diaryAnalysis, nil
+	var members []*domain.FamilyMember
+	err := db.Table("family_members AS m").
+		Select("m.user_id AS user_id, m.name AS name").
+		Joins("LEFT JOIN analysis_sharing_settings AS s ON s.user_id = m.user_id AND s.family_id = m.family_id").
+		Where("m.family_id = ?", familyID).
+		Where("(s.share_enabled IS NULL OR s.share_enabled)").
+		Order("m.name ASC, m.user_id ASC").
+		Scan(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
 }
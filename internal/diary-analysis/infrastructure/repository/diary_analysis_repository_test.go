@@ -10,10 +10,40 @@ import (
 
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/infrastructure/helper"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
 )
 
-// List diary analyses with cancelled context
-func TestDiaryAnalysisRepository_List_ContextCancelled(t *testing.T) {
+// createDaily stores a rollup of the char counts of a member on a date, as diary-analyzer maintains it
+func createDaily(t *testing.T, dbManager *db.DBManager, familyID, userID uuid.UUID, date time.Time, unlockAt *time.Time, charCounts ...float64) {
+	t.Helper()
+
+	row := map[string]interface{}{
+		"family_id":        familyID,
+		"user_id":          userID,
+		"local_date":       date.Format(time.DateOnly),
+		"unlock_at":        unlockAt,
+		"diary_count":      len(charCounts),
+		"char_count_count": len(charCounts),
+	}
+	sum := 0.0
+	for i, c := range charCounts {
+		sum += c
+		if i == 0 || c < row["char_count_min"].(float64) {
+			row["char_count_min"] = c
+		}
+		if i == 0 || c > row["char_count_max"].(float64) {
+			row["char_count_max"] = c
+		}
+	}
+	row["char_count_sum"] = sum
+
+	if err := dbManager.GetGorm().Table("diary_analysis_daily").Create(row).Error; err != nil {
+		t.Fatalf("failed to create test daily rollup: %v", err)
+	}
+}
+
+// ListDaily with cancelled context
+func TestDiaryAnalysisRepository_ListDaily_ContextCancelled(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -28,20 +58,18 @@ func TestDiaryAnalysisRepository_List_ContextCancelled(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 
-	userID := uuid.New()
 	criteria := &domain.DiaryAnalysisSearchCriteria{
-		UserID:  userID,
-		Columns: []string{"*"},
+		UserID: uuid.New(),
 	}
 
-	_, err := repo.List(ctx, criteria)
+	_, err := repo.ListDaily(ctx, criteria)
 	if err == nil {
 		t.Fatal("expected error for cancelled context")
 	}
 }
 
-// List diary analyses - success test
-func TestDiaryAnalysisRepository_List_Success(t *testing.T) {
+// ListDaily - success test
+func TestDiaryAnalysisRepository_ListDaily_Success(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -56,76 +84,51 @@ func TestDiaryAnalysisRepository_List_Success(t *testing.T) {
 	userID := uuid.New()
 	familyID := uuid.New()
 	baseDate := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+	unlocked := baseDate.AddDate(0, 0, -1)
 
-	// Create test data
-	analysis1 := &domain.DiaryAnalysis{
-		ID:            uuid.New(),
-		DiaryID:       uuid.New(),
-		UserID:        userID,
-		FamilyID:      familyID,
-		CharCount:     100,
-		SentenceCount: 10,
-		AccuracyScore: 50,
-		CreatedAt:     baseDate,
-	}
-
-	analysis2 := &domain.DiaryAnalysis{
-		ID:            uuid.New(),
-		DiaryID:       uuid.New(),
-		UserID:        userID,
-		FamilyID:      familyID,
-		CharCount:     150,
-		SentenceCount: 15,
-		AccuracyScore: 60,
-		CreatedAt:     baseDate.AddDate(0, 0, 1),
-	}
+	// The rollups of a time capsule are kept apart per unlock_at and merged on read
+	createDaily(t, dbManager, familyID, userID, baseDate.AddDate(0, 0, 1), nil, 150)
+	createDaily(t, dbManager, familyID, userID, baseDate, nil, 100, 40)
+	createDaily(t, dbManager, familyID, userID, baseDate, &unlocked, 60)
 
-	// Create records in database
-	if err := dbManager.GetGorm().Create(analysis1).Error; err != nil {
-		t.Fatalf("failed to create test diary analysis: %v", err)
-	}
-	if err := dbManager.GetGorm().Create(analysis2).Error; err != nil {
-		t.Fatalf("failed to create test diary analysis: %v", err)
-	}
-
-	// Create search criteria
 	criteria := &domain.DiaryAnalysisSearchCriteria{
 		UserID:    userID,
+		ViewerID:  uuid.New(),
 		WeekStart: baseDate.AddDate(0, 0, -1),
 		WeekEnd:   baseDate.AddDate(0, 0, 6),
-		Columns:   []string{"*"},
 	}
 
-	// Call List
-	results, err := repo.List(context.Background(), criteria)
+	results, err := repo.ListDaily(context.Background(), criteria)
 	if err != nil {
-		t.Fatalf("List failed: %v", err)
+		t.Fatalf("ListDaily failed: %v", err)
 	}
 
-	// Verify results
 	if len(results) != 2 {
-		t.Errorf("expected 2 results, got %d", len(results))
+		t.Fatalf("expected 2 results, got %d", len(results))
 	}
 
-	// Verify data is sorted by created_at ascending
-	if results[0].ID != analysis1.ID {
-		t.Errorf("first result ID mismatch: got %v, want %v", results[0].ID, analysis1.ID)
-	}
-	if results[1].ID != analysis2.ID {
-		t.Errorf("second result ID mismatch: got %v, want %v", results[1].ID, analysis2.ID)
+	// Verify data is sorted by local date ascending
+	if !results[0].LocalDate.Equal(baseDate) {
+		t.Errorf("first result date mismatch: got %v, want %v", results[0].LocalDate, baseDate)
 	}
 
-	// Verify char counts
-	if results[0].CharCount != 100 {
-		t.Errorf("first result CharCount mismatch: got %d, want 100", results[0].CharCount)
+	chars := results[0].Metric(domain.MetricCharCount)
+	if results[0].DiaryCount != 3 || chars.Count != 3 || chars.Sum != 200 {
+		t.Errorf("first result mismatch: got %d diaries, %d char counts summing to %v", results[0].DiaryCount, chars.Count, chars.Sum)
 	}
-	if results[1].CharCount != 150 {
-		t.Errorf("second result CharCount mismatch: got %d, want 150", results[1].CharCount)
+	if chars.Min == nil || *chars.Min != 40 || chars.Max == nil || *chars.Max != 100 {
+		t.Errorf("first result min/max mismatch: got %v/%v", chars.Min, chars.Max)
+	}
+	if accuracy := results[0].Metric(domain.MetricAccuracyScore); accuracy.Count != 0 || accuracy.Min != nil {
+		t.Errorf("expected no accuracy score, got %+v", accuracy)
+	}
+	if results[1].Metric(domain.MetricCharCount).Sum != 150 {
+		t.Errorf("second result CharCount mismatch: got %v, want 150", results[1].Metric(domain.MetricCharCount).Sum)
 	}
 }
 
-// List diary analyses with no matching records
-func TestDiaryAnalysisRepository_List_NoRecords(t *testing.T) {
+// ListDaily hides the locked time capsules of other members
+func TestDiaryAnalysisRepository_ListDaily_LockedTimeCapsule(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -138,27 +141,64 @@ func TestDiaryAnalysisRepository_List_NoRecords(t *testing.T) {
 	repo := NewDiaryAnalysisRepository(dbManager)
 
 	userID := uuid.New()
+	familyID := uuid.New()
+	baseDate := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+	locked := time.Now().AddDate(1, 0, 0)
+
+	createDaily(t, dbManager, familyID, userID, baseDate, nil, 100)
+	createDaily(t, dbManager, familyID, userID, baseDate, &locked, 60)
+
+	for _, tc := range []struct {
+		name     string
+		viewerID uuid.UUID
+		want     float64
+	}{
+		{"other member", uuid.New(), 100},
+		{"author", userID, 160},
+	} {
+		results, err := repo.ListDaily(context.Background(), &domain.DiaryAnalysisSearchCriteria{
+			FamilyID: familyID,
+			ViewerID: tc.viewerID,
+		})
+		if err != nil {
+			t.Fatalf("%s: ListDaily failed: %v", tc.name, err)
+		}
+		if len(results) != 1 || results[0].Metric(domain.MetricCharCount).Sum != tc.want {
+			t.Errorf("%s: expected %v characters, got %+v", tc.name, tc.want, results)
+		}
+	}
+}
+
+// ListDaily with no matching records
+func TestDiaryAnalysisRepository_ListDaily_NoRecords(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	godotenv.Load("../../../../cmd/diary-analysis/.env")
+
+	dbManager := helper.SetupTestDB(t)
+	defer helper.TeardownTestDB(t, dbManager.GetGorm())
+
+	repo := NewDiaryAnalysisRepository(dbManager)
 
 	// Create search criteria for non-existent user
 	criteria := &domain.DiaryAnalysisSearchCriteria{
-		UserID:  userID,
-		Columns: []string{"*"},
+		UserID: uuid.New(),
 	}
 
-	// Call List
-	results, err := repo.List(context.Background(), criteria)
+	results, err := repo.ListDaily(context.Background(), criteria)
 	if err != nil {
-		t.Fatalf("List failed: %v", err)
+		t.Fatalf("ListDaily failed: %v", err)
 	}
 
-	// Verify empty results
 	if len(results) != 0 {
 		t.Errorf("expected 0 results, got %d", len(results))
 	}
 }
 
-// List diary analyses with date range filter
-func TestDiaryAnalysisRepository_List_WithDateRangeFilter(t *testing.T) {
+// ListDaily with date range filter
+func TestDiaryAnalysisRepository_ListDaily_WithDateRangeFilter(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -174,62 +214,27 @@ func TestDiaryAnalysisRepository_List_WithDateRangeFilter(t *testing.T) {
 	familyID := uuid.New()
 	baseDate := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
 
-	// Create test data with different dates
-	analysis1 := &domain.DiaryAnalysis{
-		ID:        uuid.New(),
-		DiaryID:   uuid.New(),
-		UserID:    userID,
-		FamilyID:  familyID,
-		CharCount: 100,
-		CreatedAt: baseDate,
-	}
-
-	analysis2 := &domain.DiaryAnalysis{
-		ID:        uuid.New(),
-		DiaryID:   uuid.New(),
-		UserID:    userID,
-		FamilyID:  familyID,
-		CharCount: 150,
-		CreatedAt: baseDate.AddDate(0, 0, 3),
-	}
+	createDaily(t, dbManager, familyID, userID, baseDate, nil, 100)
+	createDaily(t, dbManager, familyID, userID, baseDate.AddDate(0, 0, 3), nil, 150)
+	createDaily(t, dbManager, familyID, userID, baseDate.AddDate(0, 0, 10), nil, 200)
 
-	analysis3 := &domain.DiaryAnalysis{
-		ID:        uuid.New(),
-		DiaryID:   uuid.New(),
-		UserID:    userID,
-		FamilyID:  familyID,
-		CharCount: 200,
-		CreatedAt: baseDate.AddDate(0, 0, 10),
-	}
-
-	// Create all records
-	for _, analysis := range []*domain.DiaryAnalysis{analysis1, analysis2, analysis3} {
-		if err := dbManager.GetGorm().Create(analysis).Error; err != nil {
-			t.Fatalf("failed to create test diary analysis: %v", err)
-		}
-	}
-
-	// Create search criteria with narrow date range
+	// The end of the range is the last second of the week, like datetime.GetWeekRange
 	criteria := &domain.DiaryAnalysisSearchCriteria{
 		UserID:    userID,
 		WeekStart: baseDate,
-		WeekEnd:   baseDate.AddDate(0, 0, 5),
-		Columns:   []string{"*"},
+		WeekEnd:   baseDate.AddDate(0, 0, 4).Add(-time.Second),
 	}
 
-	// Call List
-	results, err := repo.List(context.Background(), criteria)
+	results, err := repo.ListDaily(context.Background(), criteria)
 	if err != nil {
-		t.Fatalf("List failed: %v", err)
+		t.Fatalf("ListDaily failed: %v", err)
 	}
 
 	// Verify only records within date range are returned
 	if len(results) != 2 {
-		t.Errorf("expected 2 results within date range, got %d", len(results))
+		t.Fatalf("expected 2 results within date range, got %d", len(results))
 	}
-
-	// Verify the records are the correct ones
-	if results[0].CharCount != 100 || results[1].CharCount != 150 {
+	if results[0].Metric(domain.MetricCharCount).Sum != 100 || results[1].Metric(domain.MetricCharCount).Sum != 150 {
 		t.Errorf("unexpected char counts in filtered results")
 	}
 }
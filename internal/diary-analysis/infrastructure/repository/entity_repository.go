@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type EntityRepository interface {
+	ListEntities(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyEntity, error)
+	// FindEntity returns the place or pet of the family, or nil if there is none
+	FindEntity(ctx context.Context, familyID, entityID uuid.UUID) (*domain.FamilyEntity, error)
+	CreateEntity(ctx context.Context, entity *domain.FamilyEntity) error
+	UpdateEntity(ctx context.Context, entity *domain.FamilyEntity) error
+	// DeleteEntity deletes the place or pet of the family together with its references in diaries
+	DeleteEntity(ctx context.Context, familyID, entityID uuid.UUID) error
+	// ListEntityDiaries returns the diaries mentioning the entity, newest first, excluding locked time capsules of other members
+	ListEntityDiaries(ctx context.Context, criteria *domain.EntityDiaryCriteria) ([]*domain.EntityDiary, error)
+	// CountEntityMentions counts the mentions per author and entity, excluding locked time capsules of other members
+	CountEntityMentions(ctx context.Context, criteria *domain.EntityMentionCriteria) ([]*domain.EntityMentionCount, error)
+}
+
+type entityRepository struct {
+	dm *db.DBManager
+}
+
+func NewEntityRepository(dm *db.DBManager) EntityRepository {
+	return &entityRepository{
+		dm: dm,
+	}
+}
+
+func (er *entityRepository) ListEntities(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyEntity, error) {
+	db := er.dm.DB(ctx)
+
+	var entities []*domain.FamilyEntity
+	err := db.Where("family_id = ?", familyID).
+		Order("kind ASC, name ASC").
+		Find(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (er *entityRepository) FindEntity(ctx context.Context, familyID, entityID uuid.UUID) (*domain.FamilyEntity, error) {
+	db := er.dm.DB(ctx)
+
+	var entities []*domain.FamilyEntity
+	err := db.Where("family_id = ? AND id = ?", familyID, entityID).
+		Limit(1).
+		Find(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(entities) == 0 {
+		return nil, nil
+	}
+	return entities[0], nil
+}
+
+func (er *entityRepository) CreateEntity(ctx context.Context, entity *domain.FamilyEntity) error {
+	return er.dm.DB(ctx).Create(entity).Error
+}
+
+func (er *entityRepository) UpdateEntity(ctx context.Context, entity *domain.FamilyEntity) error {
+	return er.dm.DB(ctx).
+		Model(entity).
+		Select("kind", "name", "aliases", "updated_at").
+		Updates(entity).Error
+}
+
+func (er *entityRepository) DeleteEntity(ctx context.Context, familyID, entityID uuid.UUID) error {
+	return er.dm.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("DELETE FROM diary_entity_references WHERE family_id = ? AND entity_kind <> ? AND entity_id = ?",
+			familyID, domain.EntityKindMember, entityID).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("family_id = ? AND id = ?", familyID, entityID).Delete(&domain.FamilyEntity{}).Error
+	})
+}
+
+func (er *entityRepository) ListEntityDiaries(ctx context.Context, criteria *domain.EntityDiaryCriteria) ([]*domain.EntityDiary, error) {
+	db := er.dm.DB(ctx)
+
+	var diaries []*domain.EntityDiary
+	err := db.Table("diary_entity_references AS r").
+		Select("a.diary_id AS diary_id, a.user_id AS user_id, COUNT(*) AS mention_count, a.created_at AS created_at").
+		Joins("JOIN diary_analyses AS a ON a.id = r.analysis_id").
+		Where("r.family_id = ? AND r.entity_kind = ? AND r.entity_id = ?", criteria.FamilyID, criteria.Kind, criteria.EntityID).
+		Where("(a.unlock_at IS NULL OR a.unlock_at <= NOW() OR a.user_id = ?)", criteria.ViewerID).
+		Group("a.diary_id, a.user_id, a.created_at").
+		Order("a.created_at DESC").
+		Limit(criteria.Limit).
+		Scan(&diaries).Error
+	if err != nil {
+		return nil, err
+	}
+	return diaries, nil
+}
+
+func (er *entityRepository) CountEntityMentions(ctx context.Context, criteria *domain.EntityMentionCriteria) ([]*domain.EntityMentionCount, error) {
+	db := er.dm.DB(ctx)
+
+	var counts []*domain.EntityMentionCount
+	err := db.Table("diary_entity_references AS r").
+		Select("r.user_id AS user_id, r.entity_kind AS entity_kind, r.entity_id AS entity_id, "+
+			"COALESCE(MAX(m.name), MAX(e.name), '') AS name, "+
+			"COUNT(DISTINCT r.diary_id) AS diary_count, COUNT(*) AS mention_count").
+		Joins("JOIN diary_analyses AS a ON a.id = r.analysis_id").
+		Joins("LEFT JOIN family_members AS m ON r.entity_kind = ? AND m.family_id = r.family_id AND m.user_id = r.entity_id", domain.EntityKindMember).
+		Joins("LEFT JOIN family_entities AS e ON r.entity_kind <> ? AND e.id = r.entity_id", domain.EntityKindMember).
+		Where("r.family_id = ?", criteria.FamilyID).
+		Where("(a.unlock_at IS NULL OR a.unlock_at <= NOW() OR a.user_id = ?)", criteria.ViewerID).
+		Where("a.local_date >= ? AND a.local_date <= ?", criteria.StartDate.Format(time.DateOnly), criteria.EndDate.Format(time.DateOnly)).
+		Group("r.user_id, r.entity_kind, r.entity_id").
+		Order("r.user_id ASC, mention_count DESC, r.entity_id ASC").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
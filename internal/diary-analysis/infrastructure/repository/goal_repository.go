@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"github.com/google/uuid"
+)
+
+type GoalRepository interface {
+	ListGoals(ctx context.Context, familyID, userID uuid.UUID) ([]*domain.Goal, error)
+	// FindGoal returns the goal of the member, or nil if there is none
+	FindGoal(ctx context.Context, familyID, userID, goalID uuid.UUID) (*domain.Goal, error)
+	CreateGoal(ctx context.Context, goal *domain.Goal) error
+	UpdateGoal(ctx context.Context, goal *domain.Goal) error
+	// DeleteGoal deletes the goal together with the record of the periods it was reached in
+	DeleteGoal(ctx context.Context, familyID, userID, goalID uuid.UUID) error
+	// FindTimezone returns the time zone of the member synced from user-context, or "" if the member is not synced yet
+	FindTimezone(ctx context.Context, familyID, userID uuid.UUID) (string, error)
+}
+
+type goalRepository struct {
+	dm *db.DBManager
+}
+
+func NewGoalRepository(dm *db.DBManager) GoalRepository {
+	return &goalRepository{
+		dm: dm,
+	}
+}
+
+func (gr *goalRepository) ListGoals(ctx context.Context, familyID, userID uuid.UUID) ([]*domain.Goal, error) {
+	db := gr.dm.DB(ctx)
+
+	var goals []*domain.Goal
+	err := db.Where("family_id = ? AND user_id = ?", familyID, userID).
+		Order("period DESC, metric ASC").
+		Find(&goals).Error
+	if err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+func (gr *goalRepository) FindGoal(ctx context.Context, familyID, userID, goalID uuid.UUID) (*domain.Goal, error) {
+	db := gr.dm.DB(ctx)
+
+	var goals []*domain.Goal
+	err := db.Where("family_id = ? AND user_id = ? AND id = ?", familyID, userID, goalID).
+		Limit(1).
+		Find(&goals).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(goals) == 0 {
+		return nil, nil
+	}
+	return goals[0], nil
+}
+
+func (gr *goalRepository) CreateGoal(ctx context.Context, goal *domain.Goal) error {
+	return gr.dm.DB(ctx).Create(goal).Error
+}
+
+func (gr *goalRepository) UpdateGoal(ctx context.Context, goal *domain.Goal) error {
+	return gr.dm.DB(ctx).
+		Model(goal).
+		Select("target", "updated_at").
+		Updates(goal).Error
+}
+
+func (gr *goalRepository) DeleteGoal(ctx context.Context, familyID, userID, goalID uuid.UUID) error {
+	// writing_goal_achievements は ON DELETE CASCADE で消える
+	return gr.dm.DB(ctx).
+		Where("family_id = ? AND user_id = ? AND id = ?", familyID, userID, goalID).
+		Delete(&domain.Goal{}).Error
+}
+
+func (gr *goalRepository) FindTimezone(ctx context.Context, familyID, userID uuid.UUID) (string, error) {
+	db := gr.dm.DB(ctx)
+
+	var timezones []string
+	err := db.Table("family_members").
+		Where("family_id = ? AND user_id = ?", familyID, userID).
+		Limit(1).
+		Pluck("timezone", &timezones).Error
+	if err != nil {
+		return "", err
+	}
+	if len(timezones) == 0 {
+		return "", nil
+	}
+	return timezones[0], nil
+}
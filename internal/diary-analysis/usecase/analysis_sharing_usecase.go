@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/infrastructure/repository"
+	"github.com/google/uuid"
+)
+
+// AnalysisSharingUsecase maintains whether a member shares their analyses with the family comparison
+type AnalysisSharingUsecase interface {
+	GetSharingSetting(ctx context.Context, familyID, userID uuid.UUID) (*domain.AnalysisSharingSetting, error)
+	UpdateSharingSetting(ctx context.Context, familyID, userID uuid.UUID, shareEnabled bool) (*domain.AnalysisSharingSetting, error)
+}
+
+type analysisSharingUsecase struct {
+	asr repository.AnalysisSharingRepository
+}
+
+// NewAnalysisSharingUsecase creates a new AnalysisSharingUsecase instance
+func NewAnalysisSharingUsecase(asr repository.AnalysisSharingRepository) AnalysisSharingUsecase {
+	return &analysisSharingUsecase{
+		asr: asr,
+	}
+}
+
+// GetSharingSetting returns the setting of the member; a member who never changed it shares their analyses
+func (asu *analysisSharingUsecase) GetSharingSetting(ctx context.Context, familyID, userID uuid.UUID) (*domain.AnalysisSharingSetting, error) {
+	setting, err := asu.asr.FindSetting(ctx, familyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if setting == nil {
+		return &domain.AnalysisSharingSetting{UserID: userID, FamilyID: familyID, ShareEnabled: true}, nil
+	}
+	return setting, nil
+}
+
+// UpdateSharingSetting opts the member in or out of the family comparison
+func (asu *analysisSharingUsecase) UpdateSharingSetting(ctx context.Context, familyID, userID uuid.UUID, shareEnabled bool) (*domain.AnalysisSharingSetting, error) {
+	setting := &domain.AnalysisSharingSetting{
+		UserID:       userID,
+		FamilyID:     familyID,
+		ShareEnabled: shareEnabled,
+	}
+	if err := asu.asr.UpsertSetting(ctx, setting); err != nil {
+		return nil, err
+	}
+	return setting, nil
+}
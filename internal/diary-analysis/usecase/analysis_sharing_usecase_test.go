@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAnalysisSharingRepository struct {
+	mock.Mock
+}
+
+func (m *MockAnalysisSharingRepository) FindSetting(ctx context.Context, familyID, userID uuid.UUID) (*domain.AnalysisSharingSetting, error) {
+	args := m.Called(ctx, familyID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AnalysisSharingSetting), args.Error(1)
+}
+
+func (m *MockAnalysisSharingRepository) UpsertSetting(ctx context.Context, setting *domain.AnalysisSharingSetting) error {
+	args := m.Called(ctx, setting)
+	return args.Error(0)
+}
+
+// GetSharingSetting of a member who never changed it - shared
+func TestAnalysisSharingUsecase_GetSharingSetting_Default(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockAnalysisSharingRepository)
+	uc := NewAnalysisSharingUsecase(mockRepository)
+
+	familyID, userID := uuid.New(), uuid.New()
+	mockRepository.On("FindSetting", mock.Anything, familyID, userID).Return(nil, nil)
+
+	setting, err := uc.GetSharingSetting(context.Background(), familyID, userID)
+
+	assert.NoError(t, err)
+	assert.True(t, setting.ShareEnabled)
+}
+
+// UpdateSharingSetting opts the member out
+func TestAnalysisSharingUsecase_UpdateSharingSetting_OptOut(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockAnalysisSharingRepository)
+	uc := NewAnalysisSharingUsecase(mockRepository)
+
+	familyID, userID := uuid.New(), uuid.New()
+	mockRepository.On("UpsertSetting", mock.Anything, &domain.AnalysisSharingSetting{
+		UserID:       userID,
+		FamilyID:     familyID,
+		ShareEnabled: false,
+	}).Return(nil)
+
+	setting, err := uc.UpdateSharingSetting(context.Background(), familyID, userID, false)
+
+	assert.NoError(t, err)
+	assert.False(t, setting.ShareEnabled)
+	mockRepository.AssertExpectations(t)
+}
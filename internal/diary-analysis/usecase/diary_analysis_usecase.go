@@ -1,7 +1,11 @@
 package usecase
 
 import (
+	"cmp"
 	"context"
+	"math"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
@@ -17,6 +21,73 @@ type DiaryAnalysisUsecase interface {
 	GetSentenceCountByDate(ctx context.Context, userID uuid.UUID, dateStr string) (map[string]interface{}, error)
 	GetAccuracyScoreByDate(ctx context.Context, userID uuid.UUID, dateStr string) (map[string]interface{}, error)
 	GetWritingTimeByDate(ctx context.Context, userID uuid.UUID, dateStr string) (map[string]interface{}, error)
+	GetAnalysis(ctx context.Context, familyID, viewerID, diaryID uuid.UUID) (*domain.DiaryAnalysis, error)
+	GetSuggestions(ctx context.Context, familyID, viewerID, diaryID uuid.UUID) ([]*domain.DiaryAnalysisSuggestion, error)
+	GetSentimentTrend(ctx context.Context, input *SentimentTrendInput) (*domain.SentimentTrend, error)
+	GetWordFrequency(ctx context.Context, input *WordFrequencyInput) (*domain.WordCloud, error)
+	GetReadabilityTrend(ctx context.Context, input *ReadabilityTrendInput) (*domain.ReadabilityTrend, error)
+	GetMetricSeries(ctx context.Context, input *MetricSeriesInput) (*domain.MetricSeries, error)
+	GetFamilyMetrics(ctx context.Context, input *FamilyMetricsInput) (*domain.FamilyMetricSeries, error)
+}
+
+// SentimentTrendInput is the input for GetSentimentTrend.
+// Only family admins (parents) can see the trend of another member.
+type SentimentTrendInput struct {
+	FamilyID uuid.UUID
+	ViewerID uuid.UUID
+	IsAdmin  bool
+	UserID   uuid.UUID
+	Date     string
+	Period   string
+}
+
+// WordFrequencyInput is the input for GetWordFrequency.
+// UserID is optional; the whole family is aggregated when it is uuid.Nil.
+type WordFrequencyInput struct {
+	FamilyID uuid.UUID
+	ViewerID uuid.UUID
+	UserID   uuid.UUID
+	Date     string
+	Period   string
+	Limit    int
+}
+
+// ReadabilityTrendInput is the input for GetReadabilityTrend.
+// Only family admins (parents) can see the trend of another member.
+type ReadabilityTrendInput struct {
+	FamilyID uuid.UUID
+	ViewerID uuid.UUID
+	IsAdmin  bool
+	UserID   uuid.UUID
+	From     string
+	To       string
+	Period   string
+}
+
+// MetricSeriesInput is the input for GetMetricSeries.
+// Only family admins (parents) can see the metrics of another member.
+type MetricSeriesInput struct {
+	FamilyID    uuid.UUID
+	ViewerID    uuid.UUID
+	IsAdmin     bool
+	UserID      uuid.UUID
+	Metrics     []string
+	From        string
+	To          string
+	Granularity string
+}
+
+// FamilyMetricsInput is the input for GetFamilyMetrics.
+// Members who opted out of sharing their analyses are excluded.
+// Only family admins (parents) can compare the sentiment of the members.
+type FamilyMetricsInput struct {
+	FamilyID    uuid.UUID
+	ViewerID    uuid.UUID
+	IsAdmin     bool
+	Metric      string
+	From        string
+	To          string
+	Granularity string
 }
 
 type diaryAnalysisUsecase struct {
@@ -30,8 +101,10 @@ func NewDiaryAnalysisUsecase(dar repository.DiaryAnalysisRepository) DiaryAnalys
 	}
 }
 
-// getValueByDateCommon is a helper method for retrieving values for each day of the week
-func (dau *diaryAnalysisUsecase) getValueByDateCommon(ctx context.Context, userID uuid.UUID, dateStr string, columnName string, getValue func(*domain.DiaryAnalysis) int) (map[string]interface{}, error) {
+// getValueByDateCommon is a helper method for retrieving values for each day of the week.
+// Days are the local dates of the author (see diary-analyzer's daily rollup), and getValue aggregates all analyses of a day;
+// it is only called for the days with a known value of the metric (e.g. not a failed accuracy check).
+func (dau *diaryAnalysisUsecase) getValueByDateCommon(ctx context.Context, userID uuid.UUID, dateStr string, metric string, getValue func(*domain.MetricAggregate) interface{}) (map[string]interface{}, error) {
 	// Validate and parse date
 	date, err := domain.ValidateYYYYMMDDFormat(dateStr)
 	if err != nil {
@@ -46,17 +119,16 @@ func (dau *diaryAnalysisUsecase) getValueByDateCommon(ctx context.Context, userI
 	// Get week range
 	weekStart, weekEnd := datetime.GetWeekRange(date)
 
-	// slog.Debug("Fetching diary analysis data", "userID", userID, "weekStart", weekStart, "weekEnd", weekEnd, "column", columnName)
+	// slog.Debug("Fetching diary analysis data", "userID", userID, "weekStart", weekStart, "weekEnd", weekEnd, "metric", metric)
 
 	// Create search criteria
 	criteria := &domain.DiaryAnalysisSearchCriteria{
 		UserID:    userID,
 		WeekStart: weekStart,
 		WeekEnd:   weekEnd,
-		Columns:   []string{"DATE(created_at) as created_at", columnName},
 	}
 
-	analysis, err := dau.dar.List(ctx, criteria)
+	dailies, err := dau.dar.ListDaily(ctx, criteria)
 	if err != nil {
 		return nil, err
 	}
@@ -64,41 +136,637 @@ func (dau *diaryAnalysisUsecase) getValueByDateCommon(ctx context.Context, userI
 	resultMap := initializeWeekResultMap(weekStart)
 
 	// Fill in actual values from repository results
-	for _, result := range analysis {
-		resultMap[result.CreatedAt.Format("2006-01-02")] = getValue(result)
+	for _, daily := range dailies {
+		if agg := daily.Metric(metric); agg.Count > 0 {
+			resultMap[daily.LocalDate.Format("2006-01-02")] = getValue(agg)
+		}
 	}
 
 	return resultMap, nil
 }
 
-// GetCharCountByDate retrieves character count for each day of the week containing the specified date
+// GetCharCountByDate retrieves the total character count for each day of the week containing the specified date
 func (dau *diaryAnalysisUsecase) GetCharCountByDate(ctx context.Context, userID uuid.UUID, dateStr string) (map[string]interface{}, error) {
-	return dau.getValueByDateCommon(ctx, userID, dateStr, "char_count", func(a *domain.DiaryAnalysis) int {
-		return a.CharCount
+	return dau.getValueByDateCommon(ctx, userID, dateStr, domain.MetricCharCount, func(agg *domain.MetricAggregate) interface{} {
+		return int(agg.Sum)
 	})
 }
 
-// GetSentenceCountByDate retrieves sentence count for each day of the week containing the specified date
+// GetSentenceCountByDate retrieves the total sentence count for each day of the week containing the specified date
 func (dau *diaryAnalysisUsecase) GetSentenceCountByDate(ctx context.Context, userID uuid.UUID, dateStr string) (map[string]interface{}, error) {
-	return dau.getValueByDateCommon(ctx, userID, dateStr, "sentence_count", func(a *domain.DiaryAnalysis) int {
-		return a.SentenceCount
+	return dau.getValueByDateCommon(ctx, userID, dateStr, domain.MetricSentenceCount, func(agg *domain.MetricAggregate) interface{} {
+		return int(agg.Sum)
 	})
 }
 
-// GetAccuracyScoreByDate retrieves accuracy score for each day of the week containing the specified date
+// GetAccuracyScoreByDate retrieves the average accuracy score for each day of the week containing the specified date.
+// Days whose accuracy checks have not completed are null.
 func (dau *diaryAnalysisUsecase) GetAccuracyScoreByDate(ctx context.Context, userID uuid.UUID, dateStr string) (map[string]interface{}, error) {
-	return dau.getValueByDateCommon(ctx, userID, dateStr, "accuracy_score", func(a *domain.DiaryAnalysis) int {
-		return a.AccuracyScore
+	return dau.getValueByDateCommon(ctx, userID, dateStr, domain.MetricAccuracyScore, func(agg *domain.MetricAggregate) interface{} {
+		return int(math.Round(agg.Sum / float64(agg.Count)))
 	})
 }
 
-// GetWritingTimeByDate retrieves writing time for each day of the week containing the specified date
+// GetWritingTimeByDate retrieves the total writing time for each day of the week containing the specified date
 func (dau *diaryAnalysisUsecase) GetWritingTimeByDate(ctx context.Context, userID uuid.UUID, dateStr string) (map[string]interface{}, error) {
-	return dau.getValueByDateCommon(ctx, userID, dateStr, "writing_time_seconds", func(a *domain.DiaryAnalysis) int {
-		return a.WritingTimeSeconds
+	return dau.getValueByDateCommon(ctx, userID, dateStr, domain.MetricWritingTimeSeconds, func(agg *domain.MetricAggregate) interface{} {
+		return int(agg.Sum)
 	})
 }
 
+// GetAnalysis retrieves the latest analysis of the diary with the status of each enrichment
+func (dau *diaryAnalysisUsecase) GetAnalysis(ctx context.Context, familyID, viewerID, diaryID uuid.UUID) (*domain.DiaryAnalysis, error) {
+	if diaryID == uuid.Nil {
+		return nil, &errors.ValidationError{Message: "invalid diary ID"}
+	}
+
+	analysis, err := dau.dar.FindLatestByDiaryID(ctx, familyID, viewerID, diaryID)
+	if err != nil {
+		return nil, err
+	}
+	if analysis == nil {
+		return nil, &errors.NotFoundError{Message: "diary analysis not found"}
+	}
+
+	analysis.Status = analysis.OverallStatus()
+	return analysis, nil
+}
+
+// GetSuggestions retrieves the proofreading suggestions of the latest analysis of the diary
+func (dau *diaryAnalysisUsecase) GetSuggestions(ctx context.Context, familyID, viewerID, diaryID uuid.UUID) ([]*domain.DiaryAnalysisSuggestion, error) {
+	if diaryID == uuid.Nil {
+		return nil, &errors.ValidationError{Message: "invalid diary ID"}
+	}
+
+	analysis, err := dau.dar.FindLatestByDiaryID(ctx, familyID, viewerID, diaryID)
+	if err != nil {
+		return nil, err
+	}
+	if analysis == nil {
+		return nil, &errors.NotFoundError{Message: "diary analysis not found"}
+	}
+	// 校正が完了していない場合、空のリストは「指摘なし」と区別できないため返さない
+	if analysis.AccuracyStatus != domain.AnalysisStatusComplete {
+		return nil, &errors.NotFoundError{Message: "suggestions are not available, the accuracy check is " + analysis.AccuracyStatus}
+	}
+
+	return dau.dar.ListSuggestions(ctx, analysis.ID)
+}
+
+// GetSentimentTrend retrieves the daily average sentiment of a family member in the week or month containing the specified date
+func (dau *diaryAnalysisUsecase) GetSentimentTrend(ctx context.Context, input *SentimentTrendInput) (*domain.SentimentTrend, error) {
+	date, err := domain.ValidateYYYYMMDDFormat(input.Date)
+	if err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+	period := input.Period
+	if err := domain.ValidateSentimentPeriod(period); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+	userID, familyID := input.UserID, input.FamilyID
+	if userID == uuid.Nil || familyID == uuid.Nil {
+		return nil, &errors.ValidationError{Message: "invalid user ID"}
+	}
+	if userID != input.ViewerID && !input.IsAdmin {
+		return nil, &errors.ForbiddenError{Message: "only an admin can view the sentiment of other members"}
+	}
+
+	start, end := datetime.GetWeekRange(date)
+	if period == domain.SentimentPeriodMonth {
+		start, end = datetime.GetMonthRange(date)
+	}
+
+	criteria := &domain.DiaryAnalysisSearchCriteria{
+		UserID:    userID,
+		FamilyID:  familyID,
+		ViewerID:  input.ViewerID,
+		WeekStart: start,
+		WeekEnd:   end,
+	}
+	dailies, err := dau.dar.ListDaily(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1日に複数の分析がある場合は平均する
+	sums := make(map[string]float64)
+	counts := make(map[string]int)
+	var total float64
+	scored := 0
+	for _, d := range dailies {
+		agg := d.Metric(domain.MetricSentimentScore)
+		if agg.Count == 0 {
+			continue
+		}
+		day := d.LocalDate.Format("2006-01-02")
+		sums[day] += agg.Sum
+		counts[day] += agg.Count
+		total += agg.Sum
+		scored += agg.Count
+	}
+
+	trend := &domain.SentimentTrend{
+		UserID:    userID,
+		Period:    period,
+		StartDate: start.Format("2006-01-02"),
+		EndDate:   end.Format("2006-01-02"),
+		Daily:     make(map[string]*float64),
+	}
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		day := d.Format("2006-01-02")
+		if counts[day] == 0 {
+			trend.Daily[day] = nil
+			continue
+		}
+		avg := sums[day] / float64(counts[day])
+		trend.Daily[day] = &avg
+	}
+	if scored > 0 {
+		avg := total / float64(scored)
+		trend.Average = &avg
+	}
+
+	return trend, nil
+}
+
+// GetWordFrequency retrieves the most frequent keywords of the family in the week or month containing the specified date
+func (dau *diaryAnalysisUsecase) GetWordFrequency(ctx context.Context, input *WordFrequencyInput) (*domain.WordCloud, error) {
+	date, err := domain.ValidateYYYYMMDDFormat(input.Date)
+	if err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+	if err := domain.ValidateSentimentPeriod(input.Period); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+	if err := domain.ValidateWordFrequencyLimit(input.Limit); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+	if input.FamilyID == uuid.Nil || input.ViewerID == uuid.Nil {
+		return nil, &errors.ValidationError{Message: "invalid family ID"}
+	}
+
+	start, end := datetime.GetWeekRange(date)
+	if input.Period == domain.SentimentPeriodMonth {
+		start, end = datetime.GetMonthRange(date)
+	}
+
+	words, err := dau.dar.ListWordFrequencies(ctx, &domain.WordFrequencyCriteria{
+		FamilyID:  input.FamilyID,
+		UserID:    input.UserID,
+		ViewerID:  input.ViewerID,
+		StartDate: start,
+		EndDate:   end,
+		Limit:     input.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if words == nil {
+		words = []*domain.WordFrequency{}
+	}
+
+	cloud := &domain.WordCloud{
+		Period:    input.Period,
+		StartDate: start.Format("2006-01-02"),
+		EndDate:   end.Format("2006-01-02"),
+		Words:     words,
+	}
+	if input.UserID != uuid.Nil {
+		userID := input.UserID
+		cloud.UserID = &userID
+	}
+	return cloud, nil
+}
+
+// GetReadabilityTrend retrieves the average readability of a family member per week or month between the specified dates
+func (dau *diaryAnalysisUsecase) GetReadabilityTrend(ctx context.Context, input *ReadabilityTrendInput) (*domain.ReadabilityTrend, error) {
+	from, err := domain.ValidateYYYYMMDDFormat(input.From)
+	if err != nil {
+		return nil, &errors.ValidationError{Message: "from: " + err.Error()}
+	}
+	to, err := domain.ValidateYYYYMMDDFormat(input.To)
+	if err != nil {
+		return nil, &errors.ValidationError{Message: "to: " + err.Error()}
+	}
+	if err := domain.ValidateReadabilityRange(from, to); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+	period := input.Period
+	if err := domain.ValidateSentimentPeriod(period); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+	userID, familyID := input.UserID, input.FamilyID
+	if userID == uuid.Nil || familyID == uuid.Nil {
+		return nil, &errors.ValidationError{Message: "invalid user ID"}
+	}
+	if userID != input.ViewerID && !input.IsAdmin {
+		return nil, &errors.ForbiddenError{Message: "only an admin can view the readability of other members"}
+	}
+
+	periodRange := datetime.GetWeekRange
+	if period == domain.SentimentPeriodMonth {
+		periodRange = datetime.GetMonthRange
+	}
+
+	var buckets []*readabilityAccumulator
+	for start, end := periodRange(from); !start.After(to); start, end = periodRange(end.AddDate(0, 0, 1)) {
+		buckets = append(buckets, &readabilityAccumulator{start: start, end: end})
+	}
+	first, last := buckets[0], buckets[len(buckets)-1]
+
+	criteria := &domain.DiaryAnalysisSearchCriteria{
+		UserID:    userID,
+		FamilyID:  familyID,
+		ViewerID:  input.ViewerID,
+		WeekStart: first.start,
+		WeekEnd:   last.end,
+	}
+	dailies, err := dau.dar.ListDaily(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range dailies {
+		for _, b := range buckets {
+			if !d.LocalDate.Before(b.start) && !d.LocalDate.After(b.end) {
+				b.add(d)
+				break
+			}
+		}
+	}
+
+	trend := &domain.ReadabilityTrend{
+		UserID:    userID,
+		Period:    period,
+		StartDate: first.start.Format("2006-01-02"),
+		EndDate:   last.end.Format("2006-01-02"),
+		Buckets:   make([]*domain.ReadabilityBucket, len(buckets)),
+	}
+	for i, b := range buckets {
+		trend.Buckets[i] = b.bucket()
+	}
+
+	return trend, nil
+}
+
+// readabilityMetrics are the averaged readability metrics, in the order of readabilityAccumulator.sums
+var readabilityMetrics = [5]string{
+	domain.MetricKanjiRatio, domain.MetricHiraganaRatio, domain.MetricKatakanaRatio,
+	domain.MetricAverageSentenceLength, domain.MetricTypeTokenRatio,
+}
+
+// readabilityAccumulator averages the readability metrics of the analyses in a week or month.
+// Each metric is averaged over the analyses that have it.
+type readabilityAccumulator struct {
+	start, end time.Time
+	count      int
+	sums       [5]float64
+	counts     [5]int
+	longest    *int
+}
+
+func (acc *readabilityAccumulator) add(d *domain.DailyAnalysisMetrics) {
+	acc.count += d.Metric(domain.MetricKanjiRatio).Count
+	for i, m := range readabilityMetrics {
+		agg := d.Metric(m)
+		acc.sums[i] += agg.Sum
+		acc.counts[i] += agg.Count
+	}
+	if v := d.Metric(domain.MetricLongestSentenceLength).Max; v != nil && (acc.longest == nil || int(*v) > *acc.longest) {
+		longest := int(*v)
+		acc.longest = &longest
+	}
+}
+
+func (acc *readabilityAccumulator) bucket() *domain.ReadabilityBucket {
+	avg := func(i int) *float64 {
+		if acc.counts[i] == 0 {
+			return nil
+		}
+		v := math.Round(acc.sums[i]/float64(acc.counts[i])*1000) / 1000
+		return &v
+	}
+	return &domain.ReadabilityBucket{
+		StartDate:             acc.start.Format("2006-01-02"),
+		EndDate:               acc.end.Format("2006-01-02"),
+		DiaryCount:            acc.count,
+		KanjiRatio:            avg(0),
+		HiraganaRatio:         avg(1),
+		KatakanaRatio:         avg(2),
+		AverageSentenceLength: avg(3),
+		LongestSentenceLength: acc.longest,
+		TypeTokenRatio:        avg(4),
+	}
+}
+
+// GetMetricSeries aggregates the metrics of a family member per day, week or month between the specified dates.
+// All series share the same buckets, including the buckets without diaries.
+func (dau *diaryAnalysisUsecase) GetMetricSeries(ctx context.Context, input *MetricSeriesInput) (*domain.MetricSeries, error) {
+	var metrics []string
+	for _, m := range input.Metrics {
+		if !slices.Contains(metrics, m) {
+			metrics = append(metrics, m)
+		}
+	}
+	if err := domain.ValidateMetrics(metrics); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+	buckets, err := newMetricBuckets(input.From, input.To, input.Granularity)
+	if err != nil {
+		return nil, err
+	}
+	userID, familyID := input.UserID, input.FamilyID
+	if userID == uuid.Nil || familyID == uuid.Nil {
+		return nil, &errors.ValidationError{Message: "invalid user ID"}
+	}
+	if userID != input.ViewerID && !input.IsAdmin {
+		return nil, &errors.ForbiddenError{Message: "only an admin can view the metrics of other members"}
+	}
+
+	first, last := buckets[0], buckets[len(buckets)-1]
+	dailies, err := dau.dar.ListDaily(ctx, &domain.DiaryAnalysisSearchCriteria{
+		UserID:    userID,
+		FamilyID:  familyID,
+		ViewerID:  input.ViewerID,
+		WeekStart: first.start,
+		WeekEnd:   last.end,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accs := make([][]metricAccumulator, len(metrics))
+	for j := range metrics {
+		accs[j] = make([]metricAccumulator, len(buckets))
+	}
+	for _, d := range dailies {
+		i := findMetricBucket(buckets, d.LocalDate)
+		if i < 0 {
+			continue
+		}
+		for j, m := range metrics {
+			accs[j][i].merge(d.Metric(m))
+		}
+	}
+
+	series := &domain.MetricSeries{
+		UserID:      userID,
+		Granularity: input.Granularity,
+		StartDate:   first.start.Format("2006-01-02"),
+		EndDate:     last.end.Format("2006-01-02"),
+		Buckets:     metricBucketsOf(buckets),
+		Series:      make(map[string][]*domain.MetricStats, len(metrics)),
+	}
+	for j, m := range metrics {
+		series.Series[m] = metricStatsOf(accs[j])
+	}
+
+	return series, nil
+}
+
+// GetFamilyMetrics compares a metric between the members sharing their analyses per day, week or month
+// between the specified dates, with the family totals and the rankings of the period.
+func (dau *diaryAnalysisUsecase) GetFamilyMetrics(ctx context.Context, input *FamilyMetricsInput) (*domain.FamilyMetricSeries, error) {
+	if err := domain.ValidateMetrics([]string{input.Metric}); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+	buckets, err := newMetricBuckets(input.From, input.To, input.Granularity)
+	if err != nil {
+		return nil, err
+	}
+	if input.FamilyID == uuid.Nil || input.ViewerID == uuid.Nil {
+		return nil, &errors.ValidationError{Message: "invalid family ID"}
+	}
+	if input.Metric == domain.MetricSentimentScore && !input.IsAdmin {
+		return nil, &errors.ForbiddenError{Message: "only an admin can compare the sentiment of other members"}
+	}
+
+	members, err := dau.dar.ListSharedMembers(ctx, input.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	first, last := buckets[0], buckets[len(buckets)-1]
+	dailies, err := dau.dar.ListDaily(ctx, &domain.DiaryAnalysisSearchCriteria{
+		FamilyID:   input.FamilyID,
+		ViewerID:   input.ViewerID,
+		SharedOnly: true,
+		WeekStart:  first.start,
+		WeekEnd:    last.end,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 同期前のメンバーも日記があれば比較に含める
+	accs := make(map[uuid.UUID]*memberMetricAccumulator, len(members))
+	for _, m := range members {
+		accs[m.UserID] = &memberMetricAccumulator{member: m, buckets: make([]metricAccumulator, len(buckets))}
+	}
+	var unsynced []*domain.FamilyMember
+	family := make([]metricAccumulator, len(buckets))
+	var familyTotal metricAccumulator
+	for _, d := range dailies {
+		i := findMetricBucket(buckets, d.LocalDate)
+		if i < 0 {
+			continue
+		}
+		acc, ok := accs[d.UserID]
+		if !ok {
+			member := &domain.FamilyMember{UserID: d.UserID}
+			unsynced = append(unsynced, member)
+			acc = &memberMetricAccumulator{member: member, buckets: make([]metricAccumulator, len(buckets))}
+			accs[d.UserID] = acc
+		}
+		agg := d.Metric(input.Metric)
+		acc.buckets[i].merge(agg)
+		acc.total.merge(agg)
+		acc.chars.merge(d.Metric(domain.MetricCharCount))
+		acc.accuracy.merge(d.Metric(domain.MetricAccuracyScore))
+		family[i].merge(agg)
+		familyTotal.merge(agg)
+	}
+	slices.SortFunc(unsynced, func(a, b *domain.FamilyMember) int {
+		return strings.Compare(a.UserID.String(), b.UserID.String())
+	})
+	members = append(members, unsynced...)
+
+	result := &domain.FamilyMetricSeries{
+		Metric:      input.Metric,
+		Granularity: input.Granularity,
+		StartDate:   first.start.Format("2006-01-02"),
+		EndDate:     last.end.Format("2006-01-02"),
+		Buckets:     metricBucketsOf(buckets),
+		Members:     make([]*domain.MemberMetricSeries, len(members)),
+		Family:      metricStatsOf(family),
+		FamilyTotal: familyTotal.stats(),
+	}
+	ordered := make([]*memberMetricAccumulator, len(members))
+	for i, m := range members {
+		acc := accs[m.UserID]
+		ordered[i] = acc
+		result.Members[i] = &domain.MemberMetricSeries{
+			UserID: m.UserID,
+			Name:   m.Name,
+			Series: metricStatsOf(acc.buckets),
+			Total:  acc.total.stats(),
+		}
+	}
+	result.Rankings = map[string][]*domain.RankingEntry{
+		domain.RankingMostCharacters: rankMembers(ordered, func(acc *memberMetricAccumulator) *float64 {
+			return acc.chars.stats().Sum
+		}),
+		domain.RankingBestAccuracy: rankMembers(ordered, func(acc *memberMetricAccumulator) *float64 {
+			return acc.accuracy.stats().Avg
+		}),
+	}
+
+	return result, nil
+}
+
+// metricBucket is a day, a week or a month of a metric series
+type metricBucket struct {
+	start, end time.Time
+}
+
+// newMetricBuckets validates the range and returns the days, weeks or months covering it
+func newMetricBuckets(fromStr, toStr, granularity string) ([]metricBucket, error) {
+	from, err := domain.ValidateYYYYMMDDFormat(fromStr)
+	if err != nil {
+		return nil, &errors.ValidationError{Message: "from: " + err.Error()}
+	}
+	to, err := domain.ValidateYYYYMMDDFormat(toStr)
+	if err != nil {
+		return nil, &errors.ValidationError{Message: "to: " + err.Error()}
+	}
+	if err := domain.ValidateMetricSeriesRange(from, to); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+	if err := domain.ValidateMetricGranularity(granularity); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+
+	bucketRange := getDayRange
+	switch granularity {
+	case domain.MetricGranularityWeek:
+		bucketRange = datetime.GetWeekRange
+	case domain.MetricGranularityMonth:
+		bucketRange = datetime.GetMonthRange
+	}
+
+	var buckets []metricBucket
+	for start, end := bucketRange(from); !start.After(to); start, end = bucketRange(end.AddDate(0, 0, 1)) {
+		buckets = append(buckets, metricBucket{start: start, end: end})
+	}
+	return buckets, nil
+}
+
+// findMetricBucket returns the index of the bucket containing t, or -1
+func findMetricBucket(buckets []metricBucket, t time.Time) int {
+	i, _ := slices.BinarySearchFunc(buckets, t, func(b metricBucket, t time.Time) int {
+		if b.end.Before(t) {
+			return -1
+		}
+		return 1
+	})
+	if i == len(buckets) || t.Before(buckets[i].start) {
+		return -1
+	}
+	return i
+}
+
+func metricBucketsOf(buckets []metricBucket) []*domain.MetricBucket {
+	result := make([]*domain.MetricBucket, len(buckets))
+	for i, b := range buckets {
+		result[i] = &domain.MetricBucket{
+			StartDate: b.start.Format("2006-01-02"),
+			EndDate:   b.end.Format("2006-01-02"),
+		}
+	}
+	return result
+}
+
+// getDayRange returns the start and the end of the day, like datetime.GetWeekRange for a week
+func getDayRange(date time.Time) (time.Time, time.Time) {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	end := time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 999999999, date.Location())
+	return start, end
+}
+
+// metricAccumulator aggregates the values of a metric over the daily rollups
+type metricAccumulator struct {
+	count            int
+	sum              float64
+	minimum, maximum float64
+}
+
+func (acc *metricAccumulator) merge(agg *domain.MetricAggregate) {
+	if agg.Count == 0 || agg.Min == nil || agg.Max == nil {
+		return
+	}
+	if acc.count == 0 || *agg.Min < acc.minimum {
+		acc.minimum = *agg.Min
+	}
+	if acc.count == 0 || *agg.Max > acc.maximum {
+		acc.maximum = *agg.Max
+	}
+	acc.count += agg.Count
+	acc.sum += agg.Sum
+}
+
+func (acc *metricAccumulator) stats() *domain.MetricStats {
+	if acc.count == 0 {
+		return &domain.MetricStats{}
+	}
+	sum, minimum, maximum := acc.sum, acc.minimum, acc.maximum
+	avg := math.Round(sum/float64(acc.count)*1000) / 1000
+	return &domain.MetricStats{
+		Count: acc.count,
+		Sum:   &sum,
+		Avg:   &avg,
+		Min:   &minimum,
+		Max:   &maximum,
+	}
+}
+
+func metricStatsOf(accs []metricAccumulator) []*domain.MetricStats {
+	stats := make([]*domain.MetricStats, len(accs))
+	for i := range accs {
+		stats[i] = accs[i].stats()
+	}
+	return stats
+}
+
+// memberMetricAccumulator aggregates the rollups of a member in the family comparison.
+// chars and accuracy are aggregated over the whole period for the rankings.
+type memberMetricAccumulator struct {
+	member          *domain.FamilyMember
+	buckets         []metricAccumulator
+	total           metricAccumulator
+	chars, accuracy metricAccumulator
+}
+
+// rankMembers ranks the members with a value, highest first. Members with the same value share the rank.
+func rankMembers(accs []*memberMetricAccumulator, value func(*memberMetricAccumulator) *float64) []*domain.RankingEntry {
+	entries := []*domain.RankingEntry{}
+	for _, acc := range accs {
+		if v := value(acc); v != nil {
+			entries = append(entries, &domain.RankingEntry{UserID: acc.member.UserID, Name: acc.member.Name, Value: *v})
+		}
+	}
+	// 同じ値の場合は並び順を安定させるため members の順を保つ
+	slices.SortStableFunc(entries, func(a, b *domain.RankingEntry) int {
+		return cmp.Compare(b.Value, a.Value)
+	})
+	for i, e := range entries {
+		e.Rank = i + 1
+		if i > 0 && e.Value == entries[i-1].Value {
+			e.Rank = entries[i-1].Rank
+		}
+	}
+	return entries
+}
+
 // Build map with all dates of the week, initializing with nil
 func initializeWeekResultMap(weekStart time.Time) map[string]interface{} {
 	resultMap := make(map[string]interface{})
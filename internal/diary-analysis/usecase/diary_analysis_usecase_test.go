@@ -16,12 +16,106 @@ type MockDiaryAnalysisRepository struct {
 	mock.Mock
 }
 
-func (m *MockDiaryAnalysisRepository) List(ctx context.Context, criteria *domain.DiaryAnalysisSearchCriteria) ([]*domain.DiaryAnalysis, error) {
+func (m *MockDiaryAnalysisRepository) ListDaily(ctx context.Context, criteria *domain.DiaryAnalysisSearchCriteria) ([]*domain.DailyAnalysisMetrics, error) {
 	args := m.Called(ctx, criteria)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*domain.DiaryAnalysis), args.Error(1)
+	return args.Get(0).([]*domain.DailyAnalysisMetrics), args.Error(1)
+}
+
+func (m *MockDiaryAnalysisRepository) FindLatestByDiaryID(ctx context.Context, familyID, viewerID, diaryID uuid.UUID) (*domain.DiaryAnalysis, error) {
+	args := m.Called(ctx, familyID, viewerID, diaryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DiaryAnalysis), args.Error(1)
+}
+
+func (m *MockDiaryAnalysisRepository) ListSuggestions(ctx context.Context, analysisID uuid.UUID) ([]*domain.DiaryAnalysisSuggestion, error) {
+	args := m.Called(ctx, analysisID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DiaryAnalysisSuggestion), args.Error(1)
+}
+
+func (m *MockDiaryAnalysisRepository) ListWordFrequencies(ctx context.Context, criteria *domain.WordFrequencyCriteria) ([]*domain.WordFrequency, error) {
+	args := m.Called(ctx, criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.WordFrequency), args.Error(1)
+}
+
+func (m *MockDiaryAnalysisRepository) ListSharedMembers(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyMember, error) {
+	args := m.Called(ctx, familyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.FamilyMember), args.Error(1)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+// rollUp aggregates the analyses per member and date like the diary_analysis_daily rollup of diary-analyzer
+func rollUp(analyses []*domain.DiaryAnalysis) []*domain.DailyAnalysisMetrics {
+	var dailies []*domain.DailyAnalysisMetrics
+	for _, a := range analyses {
+		var daily *domain.DailyAnalysisMetrics
+		for _, d := range dailies {
+			if d.UserID == a.UserID && d.LocalDate.Equal(a.CreatedAt) {
+				daily = d
+			}
+		}
+		if daily == nil {
+			daily = &domain.DailyAnalysisMetrics{UserID: a.UserID, LocalDate: a.CreatedAt, Metrics: map[string]*domain.MetricAggregate{}}
+			for _, m := range domain.RollupMetrics {
+				daily.Metrics[m] = &domain.MetricAggregate{}
+			}
+			dailies = append(dailies, daily)
+		}
+		daily.DiaryCount++
+
+		values := map[string]*float64{
+			domain.MetricCharCount:             floatPtr(float64(a.CharCount)),
+			domain.MetricSentenceCount:         floatPtr(float64(a.SentenceCount)),
+			domain.MetricWritingTimeSeconds:    floatPtr(float64(a.WritingTimeSeconds)),
+			domain.MetricSentimentScore:        a.SentimentScore,
+			domain.MetricKanjiRatio:            a.KanjiRatio,
+			domain.MetricHiraganaRatio:         a.HiraganaRatio,
+			domain.MetricKatakanaRatio:         a.KatakanaRatio,
+			domain.MetricAverageSentenceLength: a.AverageSentenceLength,
+			domain.MetricTypeTokenRatio:        a.TypeTokenRatio,
+		}
+		if a.AccuracyScore != nil {
+			values[domain.MetricAccuracyScore] = floatPtr(float64(*a.AccuracyScore))
+		}
+		if a.LongestSentenceLength != nil {
+			values[domain.MetricLongestSentenceLength] = floatPtr(float64(*a.LongestSentenceLength))
+		}
+		for m, v := range values {
+			if v == nil {
+				continue
+			}
+			agg := daily.Metrics[m]
+			if agg.Min == nil || *v < *agg.Min {
+				agg.Min = floatPtr(*v)
+			}
+			if agg.Max == nil || *v > *agg.Max {
+				agg.Max = floatPtr(*v)
+			}
+			agg.Count++
+			agg.Sum += *v
+		}
+	}
+	return dailies
 }
 
 // GetCharCountByDate with valid date - success
@@ -88,9 +182,9 @@ func TestDiaryAnalysisUsecase_GetCharCountByDate_Success(t *testing.T) {
 		},
 	}
 
-	mockRepository.On("List", mock.Anything, mock.MatchedBy(func(criteria *domain.DiaryAnalysisSearchCriteria) bool {
+	mockRepository.On("ListDaily", mock.Anything, mock.MatchedBy(func(criteria *domain.DiaryAnalysisSearchCriteria) bool {
 		return criteria.UserID == userID
-	})).Return(mockResults, nil)
+	})).Return(rollUp(mockResults), nil)
 
 	// Call GetCharCountByDate
 	actual, err := usecase.GetCharCountByDate(context.Background(), userID, dateStr)
@@ -111,7 +205,7 @@ func TestDiaryAnalysisUsecase_GetCharCountByDate_Success(t *testing.T) {
 	assert.Equal(t, expected, actual)
 
 	// Verify mock was called
-	mockRepository.AssertCalled(t, "List", mock.Anything, mock.Anything)
+	mockRepository.AssertCalled(t, "ListDaily", mock.Anything, mock.Anything)
 }
 
 // GetCharCountByDate with invalid date format
@@ -166,7 +260,7 @@ func TestDiaryAnalysisUsecase_GetCharCountByDate_NoResults(t *testing.T) {
 	dateStr := "2026-01-20"
 
 	// Mock empty repository results
-	mockRepository.On("List", mock.Anything, mock.Anything).Return([]*domain.DiaryAnalysis{}, nil)
+	mockRepository.On("ListDaily", mock.Anything, mock.Anything).Return([]*domain.DailyAnalysisMetrics{}, nil)
 
 	// Call GetCharCountByDate
 	actual, err := usecase.GetCharCountByDate(context.Background(), userID, dateStr)
@@ -198,7 +292,7 @@ func TestDiaryAnalysisUsecase_GetCharCountByDate_RepositoryError(t *testing.T) {
 	dateStr := "2026-01-20"
 
 	// Mock repository error
-	mockRepository.On("List", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+	mockRepository.On("ListDaily", mock.Anything, mock.Anything).Return(nil, assert.AnError)
 
 	// Call GetCharCountByDate
 	_, err := usecase.GetCharCountByDate(context.Background(), userID, dateStr)
@@ -234,13 +328,13 @@ func TestDiaryAnalysisUsecase_GetCharCountByDate_DateRange(t *testing.T) {
 		},
 	}
 
-	mockRepository.On("List", mock.Anything, mock.MatchedBy(func(criteria *domain.DiaryAnalysisSearchCriteria) bool {
+	mockRepository.On("ListDaily", mock.Anything, mock.MatchedBy(func(criteria *domain.DiaryAnalysisSearchCriteria) bool {
 		// Verify the week range is correct (Monday to Sunday)
 		// For 2026-01-20 (Monday), should query from 2026-01-19 to 2026-01-25
 		return criteria.UserID == userID &&
 			!criteria.WeekStart.IsZero() &&
 			!criteria.WeekEnd.IsZero()
-	})).Return(mockResults, nil)
+	})).Return(rollUp(mockResults), nil)
 
 	// Call GetCharCountByDate
 	actual, err := usecase.GetCharCountByDate(context.Background(), userID, dateStr)
@@ -261,7 +355,7 @@ func TestDiaryAnalysisUsecase_GetCharCountByDate_DateRange(t *testing.T) {
 	assert.Equal(t, expected, actual)
 
 	// Verify mock was called with correct criteria
-	mockRepository.AssertCalled(t, "List", mock.Anything, mock.Anything)
+	mockRepository.AssertCalled(t, "ListDaily", mock.Anything, mock.Anything)
 }
 
 // GetAccuracyScoreByDate with valid date - success
@@ -283,7 +377,7 @@ func TestDiaryAnalysisUsecase_GetAccuracyScoreByDate_Success(t *testing.T) {
 			DiaryID:       uuid.New(),
 			UserID:        userID,
 			FamilyID:      uuid.New(),
-			AccuracyScore: 50,
+			AccuracyScore: intPtr(50),
 			CreatedAt:     createdAt,
 		},
 		{
@@ -291,12 +385,12 @@ func TestDiaryAnalysisUsecase_GetAccuracyScoreByDate_Success(t *testing.T) {
 			DiaryID:       uuid.New(),
 			UserID:        userID,
 			FamilyID:      uuid.New(),
-			AccuracyScore: 60,
+			AccuracyScore: intPtr(60),
 			CreatedAt:     createdAt.AddDate(0, 0, 1),
 		},
 	}
 
-	mockRepository.On("List", mock.Anything, mock.Anything).Return(mockResults, nil)
+	mockRepository.On("ListDaily", mock.Anything, mock.Anything).Return(rollUp(mockResults), nil)
 
 	// Call GetAccuracyScoreByDate
 	actual, err := usecase.GetAccuracyScoreByDate(context.Background(), userID, dateStr)
@@ -349,7 +443,7 @@ func TestDiaryAnalysisUsecase_GetWritingTimeByDate_Success(t *testing.T) {
 		},
 	}
 
-	mockRepository.On("List", mock.Anything, mock.Anything).Return(mockResults, nil)
+	mockRepository.On("ListDaily", mock.Anything, mock.Anything).Return(rollUp(mockResults), nil)
 
 	// Call GetWritingTimeByDate
 	actual, err := usecase.GetWritingTimeByDate(context.Background(), userID, dateStr)
@@ -369,3 +463,664 @@ func TestDiaryAnalysisUsecase_GetWritingTimeByDate_Success(t *testing.T) {
 	}
 	assert.Equal(t, expected, actual)
 }
+
+// GetSuggestions returns the suggestions of the latest analysis
+func TestDiaryAnalysisUsecase_GetSuggestions_Success(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockDiaryAnalysisRepository)
+	usecase := NewDiaryAnalysisUsecase(mockRepository)
+
+	familyID := uuid.New()
+	viewerID := uuid.New()
+	diaryID := uuid.New()
+	analysis := &domain.DiaryAnalysis{ID: uuid.New(), DiaryID: diaryID, FamilyID: familyID, AccuracyStatus: domain.AnalysisStatusComplete}
+	suggestions := []*domain.DiaryAnalysisSuggestion{
+		{ID: uuid.New(), DiaryID: diaryID, Offset: 3, Length: 2, Message: "助詞が重複しています", Suggestion: "を"},
+	}
+
+	mockRepository.On("FindLatestByDiaryID", mock.Anything, familyID, viewerID, diaryID).Return(analysis, nil)
+	mockRepository.On("ListSuggestions", mock.Anything, analysis.ID).Return(suggestions, nil)
+
+	result, err := usecase.GetSuggestions(context.Background(), familyID, viewerID, diaryID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, suggestions, result)
+	mockRepository.AssertExpectations(t)
+}
+
+// GetSuggestions for a diary without analysis (or of another family) - not found
+func TestDiaryAnalysisUsecase_GetSuggestions_NotFound(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockDiaryAnalysisRepository)
+	usecase := NewDiaryAnalysisUsecase(mockRepository)
+
+	familyID := uuid.New()
+	viewerID := uuid.New()
+	diaryID := uuid.New()
+	mockRepository.On("FindLatestByDiaryID", mock.Anything, familyID, viewerID, diaryID).Return(nil, nil)
+
+	result, err := usecase.GetSuggestions(context.Background(), familyID, viewerID, diaryID)
+
+	assert.Nil(t, result)
+	assert.IsType(t, &errors.NotFoundError{}, err)
+	mockRepository.AssertNotCalled(t, "ListSuggestions", mock.Anything, mock.Anything)
+}
+
+// GetSentimentTrend averages the scores per day and ignores unscored analyses
+func TestDiaryAnalysisUsecase_GetSentimentTrend_Week(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockDiaryAnalysisRepository)
+	usecase := NewDiaryAnalysisUsecase(mockRepository)
+
+	userID := uuid.New()
+	familyID := uuid.New()
+	score := func(v float64) *float64 { return &v }
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+
+	mockRepository.On("ListDaily", mock.Anything, mock.MatchedBy(func(c *domain.DiaryAnalysisSearchCriteria) bool {
+		return c.UserID == userID && c.FamilyID == familyID &&
+			c.WeekStart.Format("2006-01-02") == "2026-01-19" && c.WeekEnd.Format("2006-01-02") == "2026-01-25"
+	})).Return(rollUp([]*domain.DiaryAnalysis{
+		{CreatedAt: day("2026-01-19"), SentimentScore: score(0.5)},
+		{CreatedAt: day("2026-01-20"), SentimentScore: score(-1)},
+		{CreatedAt: day("2026-01-20"), SentimentScore: score(0)},
+		{CreatedAt: day("2026-01-21"), SentimentScore: nil},
+	}), nil)
+
+	trend, err := usecase.GetSentimentTrend(context.Background(), &SentimentTrendInput{
+		FamilyID: familyID,
+		ViewerID: userID,
+		UserID:   userID,
+		Date:     "2026-01-20",
+		Period:   domain.SentimentPeriodWeek,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, trend.Daily, 7)
+	assert.Equal(t, 0.5, *trend.Daily["2026-01-19"])
+	assert.Equal(t, -0.5, *trend.Daily["2026-01-20"])
+	assert.Nil(t, trend.Daily["2026-01-21"])
+	assert.InDelta(t, -0.1666, *trend.Average, 0.001)
+}
+
+// GetSentimentTrend for a month covers every day of the month
+func TestDiaryAnalysisUsecase_GetSentimentTrend_Month(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockDiaryAnalysisRepository)
+	usecase := NewDiaryAnalysisUsecase(mockRepository)
+
+	userID := uuid.New()
+	mockRepository.On("ListDaily", mock.Anything, mock.Anything).Return([]*domain.DailyAnalysisMetrics{}, nil)
+
+	trend, err := usecase.GetSentimentTrend(context.Background(), &SentimentTrendInput{
+		FamilyID: uuid.New(),
+		ViewerID: userID,
+		UserID:   userID,
+		Date:     "2026-02-14",
+		Period:   domain.SentimentPeriodMonth,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-02-01", trend.StartDate)
+	assert.Equal(t, "2026-02-28", trend.EndDate)
+	assert.Len(t, trend.Daily, 28)
+	assert.Nil(t, trend.Average)
+}
+
+// GetSentimentTrend of another member requires admin
+func TestDiaryAnalysisUsecase_GetSentimentTrend_OtherMemberForbidden(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockDiaryAnalysisRepository)
+	usecase := NewDiaryAnalysisUsecase(mockRepository)
+
+	_, err := usecase.GetSentimentTrend(context.Background(), &SentimentTrendInput{
+		FamilyID: uuid.New(),
+		ViewerID: uuid.New(),
+		UserID:   uuid.New(),
+		Date:     "2026-01-20",
+		Period:   domain.SentimentPeriodWeek,
+	})
+
+	assert.IsType(t, &errors.ForbiddenError{}, err)
+	mockRepository.AssertNotCalled(t, "ListDaily", mock.Anything, mock.Anything)
+}
+
+// GetSentimentTrend with invalid period - validation error
+func TestDiaryAnalysisUsecase_GetSentimentTrend_InvalidPeriod(t *testing.T) {
+	t.Parallel()
+
+	usecase := NewDiaryAnalysisUsecase(new(MockDiaryAnalysisRepository))
+	userID := uuid.New()
+
+	_, err := usecase.GetSentimentTrend(context.Background(), &SentimentTrendInput{
+		FamilyID: uuid.New(),
+		ViewerID: userID,
+		UserID:   userID,
+		Date:     "2026-01-20",
+		Period:   "year",
+	})
+
+	assert.IsType(t, &errors.ValidationError{}, err)
+}
+
+// GetWordFrequency for a month aggregates the whole family with the viewer's visibility
+func TestDiaryAnalysisUsecase_GetWordFrequency_FamilyMonth(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockDiaryAnalysisRepository)
+	usecase := NewDiaryAnalysisUsecase(mockRepository)
+
+	familyID := uuid.New()
+	viewerID := uuid.New()
+	words := []*domain.WordFrequency{{Word: "公園", Count: 5}, {Word: "京都", Count: 2, ProperNoun: true}}
+
+	mockRepository.On("ListWordFrequencies", mock.Anything, &domain.WordFrequencyCriteria{
+		FamilyID:  familyID,
+		ViewerID:  viewerID,
+		StartDate: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 2, 28, 23, 59, 59, 999999999, time.UTC),
+		Limit:     10,
+	}).Return(words, nil)
+
+	cloud, err := usecase.GetWordFrequency(context.Background(), &WordFrequencyInput{
+		FamilyID: familyID,
+		ViewerID: viewerID,
+		Date:     "2026-02-14",
+		Period:   domain.SentimentPeriodMonth,
+		Limit:    10,
+	})
+
+	assert.NoError(t, err)
+	assert.Nil(t, cloud.UserID)
+	assert.Equal(t, "2026-02-01", cloud.StartDate)
+	assert.Equal(t, "2026-02-28", cloud.EndDate)
+	assert.Equal(t, words, cloud.Words)
+	mockRepository.AssertExpectations(t)
+}
+
+// GetWordFrequency with a limit over the maximum - validation error
+func TestDiaryAnalysisUsecase_GetWordFrequency_LimitTooLarge(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockDiaryAnalysisRepository)
+	usecase := NewDiaryAnalysisUsecase(mockRepository)
+
+	_, err := usecase.GetWordFrequency(context.Background(), &WordFrequencyInput{
+		FamilyID: uuid.New(),
+		ViewerID: uuid.New(),
+		Date:     "2026-01-20",
+		Period:   domain.SentimentPeriodWeek,
+		Limit:    domain.MaxWordFrequencyLimit + 1,
+	})
+
+	assert.IsType(t, &errors.ValidationError{}, err)
+	mockRepository.AssertNotCalled(t, "ListWordFrequencies", mock.Anything, mock.Anything)
+}
+
+// GetAccuracyScoreByDate leaves days whose accuracy check failed null
+func TestDiaryAnalysisUsecase_GetAccuracyScoreByDate_Failed(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockDiaryAnalysisRepository)
+	usecase := NewDiaryAnalysisUsecase(mockRepository)
+
+	userID := uuid.New()
+	createdAt := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+	mockRepository.On("ListDaily", mock.Anything, mock.Anything).Return(rollUp([]*domain.DiaryAnalysis{
+		{UserID: userID, AccuracyScore: nil, AccuracyStatus: domain.AnalysisStatusFailed, CreatedAt: createdAt},
+		{UserID: userID, AccuracyScore: intPtr(70), AccuracyStatus: domain.AnalysisStatusComplete, CreatedAt: createdAt.AddDate(0, 0, 1)},
+	}), nil)
+
+	actual, err := usecase.GetAccuracyScoreByDate(context.Background(), userID, "2026-01-20")
+
+	assert.NoError(t, err)
+	assert.Nil(t, actual["2026-01-20"])
+	assert.Equal(t, 70, actual["2026-01-21"])
+}
+
+// GetCharCountByDate and GetAccuracyScoreByDate with several diaries on a day - the total characters and the average score
+func TestDiaryAnalysisUsecase_GetByDate_SeveralDiariesOnADay(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockDiaryAnalysisRepository)
+	usecase := NewDiaryAnalysisUsecase(mockRepository)
+
+	userID := uuid.New()
+	day := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+	mockRepository.On("ListDaily", mock.Anything, mock.Anything).Return(rollUp([]*domain.DiaryAnalysis{
+		{UserID: userID, CharCount: 100, AccuracyScore: intPtr(70), CreatedAt: day},
+		{UserID: userID, CharCount: 50, AccuracyScore: intPtr(75), CreatedAt: day},
+		{UserID: userID, CharCount: 30, AccuracyScore: nil, CreatedAt: day},
+	}), nil)
+
+	chars, err := usecase.GetCharCountByDate(context.Background(), userID, "2026-01-20")
+	assert.NoError(t, err)
+	assert.Equal(t, 180, chars["2026-01-20"])
+
+	scores, err := usecase.GetAccuracyScoreByDate(context.Background(), userID, "2026-01-20")
+	assert.NoError(t, err)
+	assert.Equal(t, 73, scores["2026-01-20"])
+}
+
+// GetSuggestions of a failed accuracy check - not found rather than an empty list
+func TestDiaryAnalysisUsecase_GetSuggestions_AccuracyFailed(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockDiaryAnalysisRepository)
+	usecase := NewDiaryAnalysisUsecase(mockRepository)
+
+	familyID, viewerID, diaryID := uuid.New(), uuid.New(), uuid.New()
+	analysis := &domain.DiaryAnalysis{ID: uuid.New(), DiaryID: diaryID, AccuracyStatus: domain.AnalysisStatusFailed}
+	mockRepository.On("FindLatestByDiaryID", mock.Anything, familyID, viewerID, diaryID).Return(analysis, nil)
+
+	_, err := usecase.GetSuggestions(context.Background(), familyID, viewerID, diaryID)
+
+	assert.IsType(t, &errors.NotFoundError{}, err)
+	mockRepository.AssertNotCalled(t, "ListSuggestions", mock.Anything, mock.Anything)
+}
+
+// GetAnalysis derives the overall status from the enrichments
+func TestDiaryAnalysisUsecase_GetAnalysis_Status(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		accuracy string
+		keywords string
+		want     string
+	}{
+		{"complete", domain.AnalysisStatusComplete, domain.AnalysisStatusComplete, domain.AnalysisStatusComplete},
+		{"keywords pending", domain.AnalysisStatusComplete, domain.AnalysisStatusPending, domain.AnalysisStatusPending},
+		{"accuracy failed", domain.AnalysisStatusFailed, domain.AnalysisStatusPending, domain.AnalysisStatusFailed},
+		{"english diary", domain.AnalysisStatusSkipped, domain.AnalysisStatusComplete, domain.AnalysisStatusComplete},
+		{"accuracy over quota", domain.AnalysisStatusDeferred, domain.AnalysisStatusComplete, domain.AnalysisStatusPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepository := new(MockDiaryAnalysisRepository)
+			usecase := NewDiaryAnalysisUsecase(mockRepository)
+
+			familyID, viewerID, diaryID := uuid.New(), uuid.New(), uuid.New()
+			mockRepository.On("FindLatestByDiaryID", mock.Anything, familyID, viewerID, diaryID).Return(&domain.DiaryAnalysis{
+				DiaryID:        diaryID,
+				AccuracyStatus: tt.accuracy,
+				KeywordsStatus: tt.keywords,
+			}, nil)
+
+			analysis, err := usecase.GetAnalysis(context.Background(), familyID, viewerID, diaryID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, analysis.Status)
+		})
+	}
+}
+
+// GetReadabilityTrend averages the metrics per week and keeps the longest sentence
+func TestDiaryAnalysisUsecase_GetReadabilityTrend_Week(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockDiaryAnalysisRepository)
+	usecase := NewDiaryAnalysisUsecase(mockRepository)
+
+	userID := uuid.New()
+	familyID := uuid.New()
+	ratio := func(v float64) *float64 { return &v }
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+
+	// 2026-01-14 (Wed) - 2026-01-27 (Tue) covers three weeks starting on 01-12
+	mockRepository.On("ListDaily", mock.Anything, mock.MatchedBy(func(c *domain.DiaryAnalysisSearchCriteria) bool {
+		return c.UserID == userID && c.FamilyID == familyID &&
+			c.WeekStart.Format("2006-01-02") == "2026-01-12" && c.WeekEnd.Format("2006-01-02") == "2026-02-01"
+	})).Return(rollUp([]*domain.DiaryAnalysis{
+		{CreatedAt: day("2026-01-12"), KanjiRatio: ratio(0.2), HiraganaRatio: ratio(0.8), KatakanaRatio: ratio(0),
+			AverageSentenceLength: ratio(10), LongestSentenceLength: intPtr(12), TypeTokenRatio: ratio(0.5)},
+		{CreatedAt: day("2026-01-18"), KanjiRatio: ratio(0.3), HiraganaRatio: ratio(0.6), KatakanaRatio: ratio(0.1),
+			AverageSentenceLength: ratio(20), LongestSentenceLength: intPtr(30), TypeTokenRatio: nil},
+		// made before readability metrics
+		{CreatedAt: day("2026-01-19")},
+		{CreatedAt: day("2026-01-27"), KanjiRatio: ratio(0.4), HiraganaRatio: ratio(0.5), KatakanaRatio: ratio(0.1),
+			AverageSentenceLength: ratio(15), LongestSentenceLength: intPtr(18), TypeTokenRatio: ratio(0.7)},
+	}), nil)
+
+	trend, err := usecase.GetReadabilityTrend(context.Background(), &ReadabilityTrendInput{
+		FamilyID: familyID,
+		ViewerID: userID,
+		UserID:   userID,
+		From:     "2026-01-14",
+		To:       "2026-01-27",
+		Period:   domain.SentimentPeriodWeek,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-01-12", trend.StartDate)
+	assert.Equal(t, "2026-02-01", trend.EndDate)
+	assert.Len(t, trend.Buckets, 3)
+
+	first := trend.Buckets[0]
+	assert.Equal(t, "2026-01-12", first.StartDate)
+	assert.Equal(t, "2026-01-18", first.EndDate)
+	assert.Equal(t, 2, first.DiaryCount)
+	assert.Equal(t, 0.25, *first.KanjiRatio)
+	assert.Equal(t, 15.0, *first.AverageSentenceLength)
+	assert.Equal(t, 30, *first.LongestSentenceLength)
+	assert.Equal(t, 0.5, *first.TypeTokenRatio)
+
+	second := trend.Buckets[1]
+	assert.Equal(t, 0, second.DiaryCount)
+	assert.Nil(t, second.KanjiRatio)
+	assert.Nil(t, second.LongestSentenceLength)
+
+	assert.Equal(t, 1, trend.Buckets[2].DiaryCount)
+	assert.Equal(t, 0.7, *trend.Buckets[2].TypeTokenRatio)
+}
+
+// GetReadabilityTrend for a school year has a bucket per month
+func TestDiaryAnalysisUsecase_GetReadabilityTrend_SchoolYear(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockDiaryAnalysisRepository)
+	usecase := NewDiaryAnalysisUsecase(mockRepository)
+
+	userID := uuid.New()
+	mockRepository.On("ListDaily", mock.Anything, mock.Anything).Return([]*domain.DailyAnalysisMetrics{}, nil)
+
+	trend, err := usecase.GetReadabilityTrend(context.Background(), &ReadabilityTrendInput{
+		FamilyID: uuid.New(),
+		ViewerID: userID,
+		UserID:   userID,
+		From:     "2026-04-01",
+		To:       "2027-03-31",
+		Period:   domain.SentimentPeriodMonth,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, trend.Buckets, 12)
+	assert.Equal(t, "2026-04-01", trend.Buckets[0].StartDate)
+	assert.Equal(t, "2027-03-31", trend.Buckets[11].EndDate)
+}
+
+// GetReadabilityTrend validates the range and the viewer
+func TestDiaryAnalysisUsecase_GetReadabilityTrend_Errors(t *testing.T) {
+	t.Parallel()
+
+	viewerID := uuid.New()
+	tests := []struct {
+		name    string
+		input   *ReadabilityTrendInput
+		wantErr interface{}
+	}{
+		{"to before from", &ReadabilityTrendInput{UserID: viewerID, From: "2026-02-01", To: "2026-01-01", Period: domain.SentimentPeriodWeek}, &errors.ValidationError{}},
+		{"longer than a year", &ReadabilityTrendInput{UserID: viewerID, From: "2026-01-01", To: "2027-01-02", Period: domain.SentimentPeriodMonth}, &errors.ValidationError{}},
+		{"invalid period", &ReadabilityTrendInput{UserID: viewerID, From: "2026-01-01", To: "2026-01-31", Period: "day"}, &errors.ValidationError{}},
+		{"other member", &ReadabilityTrendInput{UserID: uuid.New(), From: "2026-01-01", To: "2026-01-31", Period: domain.SentimentPeriodWeek}, &errors.ForbiddenError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepository := new(MockDiaryAnalysisRepository)
+			usecase := NewDiaryAnalysisUsecase(mockRepository)
+			tt.input.FamilyID = uuid.New()
+			tt.input.ViewerID = viewerID
+
+			_, err := usecase.GetReadabilityTrend(context.Background(), tt.input)
+
+			assert.IsType(t, tt.wantErr, err)
+			mockRepository.AssertNotCalled(t, "ListDaily", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// GetMetricSeries aggregates several metrics into daily buckets aligned across the series
+func TestDiaryAnalysisUsecase_GetMetricSeries_Day(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockDiaryAnalysisRepository)
+	usecase := NewDiaryAnalysisUsecase(mockRepository)
+
+	userID := uuid.New()
+	familyID := uuid.New()
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+
+	mockRepository.On("ListDaily", mock.Anything, mock.MatchedBy(func(c *domain.DiaryAnalysisSearchCriteria) bool {
+		return c.UserID == userID && c.FamilyID == familyID &&
+			c.WeekStart.Format("2006-01-02") == "2026-01-12" && c.WeekEnd.Format("2006-01-02") == "2026-01-14"
+	})).Return(rollUp([]*domain.DiaryAnalysis{
+		{CreatedAt: day("2026-01-12"), CharCount: 100, AccuracyScore: intPtr(80)},
+		{CreatedAt: day("2026-01-12"), CharCount: 300, AccuracyScore: nil},
+		{CreatedAt: day("2026-01-14"), CharCount: 50, AccuracyScore: intPtr(90)},
+	}), nil)
+
+	series, err := usecase.GetMetricSeries(context.Background(), &MetricSeriesInput{
+		FamilyID:    familyID,
+		ViewerID:    userID,
+		UserID:      userID,
+		Metrics:     []string{domain.MetricAccuracyScore, domain.MetricCharCount, domain.MetricAccuracyScore},
+		From:        "2026-01-12",
+		To:          "2026-01-14",
+		Granularity: domain.MetricGranularityDay,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-01-12", series.StartDate)
+	assert.Equal(t, "2026-01-14", series.EndDate)
+	assert.Len(t, series.Buckets, 3)
+	assert.Equal(t, "2026-01-13", series.Buckets[1].StartDate)
+	assert.Len(t, series.Series, 2)
+
+	chars := series.Series[domain.MetricCharCount]
+	assert.Len(t, chars, 3)
+	assert.Equal(t, 2, chars[0].Count)
+	assert.Equal(t, 400.0, *chars[0].Sum)
+	assert.Equal(t, 200.0, *chars[0].Avg)
+	assert.Equal(t, 100.0, *chars[0].Min)
+	assert.Equal(t, 300.0, *chars[0].Max)
+	assert.Equal(t, 0, chars[1].Count)
+	assert.Nil(t, chars[1].Avg)
+	assert.Equal(t, 50.0, *chars[2].Sum)
+
+	// a failed accuracy check does not count
+	scores := series.Series[domain.MetricAccuracyScore]
+	assert.Len(t, scores, 3)
+	assert.Equal(t, 1, scores[0].Count)
+	assert.Equal(t, 80.0, *scores[0].Avg)
+	assert.Equal(t, 90.0, *scores[2].Max)
+}
+
+// GetMetricSeries by month covers whole months
+func TestDiaryAnalysisUsecase_GetMetricSeries_Month(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockDiaryAnalysisRepository)
+	usecase := NewDiaryAnalysisUsecase(mockRepository)
+
+	userID := uuid.New()
+	mockRepository.On("ListDaily", mock.Anything, mock.Anything).Return([]*domain.DailyAnalysisMetrics{}, nil)
+
+	series, err := usecase.GetMetricSeries(context.Background(), &MetricSeriesInput{
+		FamilyID:    uuid.New(),
+		ViewerID:    userID,
+		UserID:      userID,
+		Metrics:     []string{domain.MetricWritingTimeSeconds},
+		From:        "2026-04-15",
+		To:          "2026-06-10",
+		Granularity: domain.MetricGranularityMonth,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-04-01", series.StartDate)
+	assert.Equal(t, "2026-06-30", series.EndDate)
+	assert.Len(t, series.Buckets, 3)
+	assert.Len(t, series.Series[domain.MetricWritingTimeSeconds], 3)
+}
+
+// GetMetricSeries validates the metrics, the range, the granularity and the viewer
+func TestDiaryAnalysisUsecase_GetMetricSeries_Errors(t *testing.T) {
+	t.Parallel()
+
+	viewerID := uuid.New()
+	chars := []string{domain.MetricCharCount}
+	tests := []struct {
+		name    string
+		input   *MetricSeriesInput
+		wantErr interface{}
+	}{
+		{"no metrics", &MetricSeriesInput{UserID: viewerID, From: "2026-01-01", To: "2026-01-31", Granularity: domain.MetricGranularityDay}, &errors.ValidationError{}},
+		{"unknown metric", &MetricSeriesInput{UserID: viewerID, Metrics: []string{"title"}, From: "2026-01-01", To: "2026-01-31", Granularity: domain.MetricGranularityDay}, &errors.ValidationError{}},
+		{"to before from", &MetricSeriesInput{UserID: viewerID, Metrics: chars, From: "2026-02-01", To: "2026-01-01", Granularity: domain.MetricGranularityDay}, &errors.ValidationError{}},
+		{"longer than a year", &MetricSeriesInput{UserID: viewerID, Metrics: chars, From: "2026-01-01", To: "2027-01-02", Granularity: domain.MetricGranularityMonth}, &errors.ValidationError{}},
+		{"invalid granularity", &MetricSeriesInput{UserID: viewerID, Metrics: chars, From: "2026-01-01", To: "2026-01-31", Granularity: "year"}, &errors.ValidationError{}},
+		{"other member", &MetricSeriesInput{UserID: uuid.New(), Metrics: chars, From: "2026-01-01", To: "2026-01-31", Granularity: domain.MetricGranularityWeek}, &errors.ForbiddenError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepository := new(MockDiaryAnalysisRepository)
+			usecase := NewDiaryAnalysisUsecase(mockRepository)
+			tt.input.FamilyID = uuid.New()
+			tt.input.ViewerID = viewerID
+
+			_, err := usecase.GetMetricSeries(context.Background(), tt.input)
+
+			assert.IsType(t, tt.wantErr, err)
+			mockRepository.AssertNotCalled(t, "ListDaily", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// GetFamilyMetrics compares the members sharing their analyses with the family totals and the rankings
+func TestDiaryAnalysisUsecase_GetFamilyMetrics_Week(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockDiaryAnalysisRepository)
+	usecase := NewDiaryAnalysisUsecase(mockRepository)
+
+	familyID := uuid.New()
+	mother, son, daughter, unsynced := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+
+	mockRepository.On("ListSharedMembers", mock.Anything, familyID).Return([]*domain.FamilyMember{
+		{UserID: mother, Name: "母"},
+		{UserID: son, Name: "太郎"},
+		{UserID: daughter, Name: "花子"},
+	}, nil)
+	mockRepository.On("ListDaily", mock.Anything, mock.MatchedBy(func(c *domain.DiaryAnalysisSearchCriteria) bool {
+		return c.UserID == uuid.Nil && c.FamilyID == familyID && c.SharedOnly &&
+			c.WeekStart.Format("2006-01-02") == "2026-01-12" && c.WeekEnd.Format("2006-01-02") == "2026-01-25"
+	})).Return(rollUp([]*domain.DiaryAnalysis{
+		{UserID: son, CreatedAt: day("2026-01-12"), CharCount: 200, AccuracyScore: intPtr(70)},
+		{UserID: mother, CreatedAt: day("2026-01-13"), CharCount: 500, AccuracyScore: intPtr(95)},
+		{UserID: son, CreatedAt: day("2026-01-20"), CharCount: 300, AccuracyScore: nil},
+		{UserID: unsynced, CreatedAt: day("2026-01-21"), CharCount: 500, AccuracyScore: intPtr(95)},
+	}), nil)
+
+	result, err := usecase.GetFamilyMetrics(context.Background(), &FamilyMetricsInput{
+		FamilyID:    familyID,
+		ViewerID:    son,
+		Metric:      domain.MetricCharCount,
+		From:        "2026-01-14",
+		To:          "2026-01-21",
+		Granularity: domain.MetricGranularityWeek,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-01-12", result.StartDate)
+	assert.Equal(t, "2026-01-25", result.EndDate)
+	assert.Len(t, result.Buckets, 2)
+
+	// members without diaries are listed, members not synced yet come last
+	assert.Len(t, result.Members, 4)
+	assert.Equal(t, son, result.Members[1].UserID)
+	assert.Equal(t, []*domain.MetricStats{
+		{Count: 1, Sum: floatPtr(200), Avg: floatPtr(200), Min: floatPtr(200), Max: floatPtr(200)},
+		{Count: 1, Sum: floatPtr(300), Avg: floatPtr(300), Min: floatPtr(300), Max: floatPtr(300)},
+	}, result.Members[1].Series)
+	assert.Equal(t, 500.0, *result.Members[1].Total.Sum)
+	assert.Equal(t, 0, result.Members[2].Total.Count)
+	assert.Equal(t, unsynced, result.Members[3].UserID)
+	assert.Equal(t, "", result.Members[3].Name)
+
+	assert.Equal(t, 700.0, *result.Family[0].Sum)
+	assert.Equal(t, 800.0, *result.Family[1].Sum)
+	assert.Equal(t, 4, result.FamilyTotal.Count)
+
+	// ties share the rank
+	chars := result.Rankings[domain.RankingMostCharacters]
+	assert.Len(t, chars, 3)
+	assert.Equal(t, []int{1, 1, 1}, []int{chars[0].Rank, chars[1].Rank, chars[2].Rank})
+	assert.Equal(t, "母", chars[0].Name)
+
+	accuracy := result.Rankings[domain.RankingBestAccuracy]
+	assert.Len(t, accuracy, 3)
+	assert.Equal(t, mother, accuracy[0].UserID)
+	assert.Equal(t, 1, accuracy[1].Rank)
+	assert.Equal(t, son, accuracy[2].UserID)
+	assert.Equal(t, 3, accuracy[2].Rank)
+	assert.Equal(t, 70.0, accuracy[2].Value)
+}
+
+// GetFamilyMetrics compares the sentiment of the members only for admins
+func TestDiaryAnalysisUsecase_GetFamilyMetrics_SentimentNotAdmin(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockDiaryAnalysisRepository)
+	usecase := NewDiaryAnalysisUsecase(mockRepository)
+
+	_, err := usecase.GetFamilyMetrics(context.Background(), &FamilyMetricsInput{
+		FamilyID:    uuid.New(),
+		ViewerID:    uuid.New(),
+		Metric:      domain.MetricSentimentScore,
+		From:        "2026-01-01",
+		To:          "2026-01-31",
+		Granularity: domain.MetricGranularityWeek,
+	})
+
+	assert.IsType(t, &errors.ForbiddenError{}, err)
+	mockRepository.AssertNotCalled(t, "ListSharedMembers", mock.Anything, mock.Anything)
+	mockRepository.AssertNotCalled(t, "ListDaily", mock.Anything, mock.Anything)
+}
+
+// GetFamilyMetrics validates the metric and the range before reading the analyses
+func TestDiaryAnalysisUsecase_GetFamilyMetrics_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input *FamilyMetricsInput
+	}{
+		{"unknown metric", &FamilyMetricsInput{Metric: "title", From: "2026-01-01", To: "2026-01-31", Granularity: domain.MetricGranularityWeek}},
+		{"longer than a year", &FamilyMetricsInput{Metric: domain.MetricCharCount, From: "2026-01-01", To: "2027-01-02", Granularity: domain.MetricGranularityMonth}},
+		{"invalid granularity", &FamilyMetricsInput{Metric: domain.MetricCharCount, From: "2026-01-01", To: "2026-01-31", Granularity: "year"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepository := new(MockDiaryAnalysisRepository)
+			usecase := NewDiaryAnalysisUsecase(mockRepository)
+			tt.input.FamilyID = uuid.New()
+			tt.input.ViewerID = uuid.New()
+
+			_, err := usecase.GetFamilyMetrics(context.Background(), tt.input)
+
+			assert.IsType(t, &errors.ValidationError{}, err)
+			mockRepository.AssertNotCalled(t, "ListDaily", mock.Anything, mock.Anything)
+		})
+	}
+}
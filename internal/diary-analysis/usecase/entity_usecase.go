@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+	"github.com/google/uuid"
+)
+
+// EntityUsecase maintains the places and pets of a family and reads the members, places and pets
+// diary-analyzer linked in the diaries
+type EntityUsecase interface {
+	ListEntities(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyEntity, error)
+	CreateEntity(ctx context.Context, input *EntityInput) (*domain.FamilyEntity, error)
+	UpdateEntity(ctx context.Context, input *EntityInput) (*domain.FamilyEntity, error)
+	DeleteEntity(ctx context.Context, familyID, entityID uuid.UUID) error
+	ListEntityDiaries(ctx context.Context, input *EntityDiariesInput) ([]*domain.EntityDiary, error)
+	GetEntityMentions(ctx context.Context, input *EntityMentionsInput) (*domain.EntityMentions, error)
+}
+
+// EntityInput is the input for CreateEntity and UpdateEntity. ID is ignored by CreateEntity.
+type EntityInput struct {
+	ID       uuid.UUID
+	FamilyID uuid.UUID
+	Kind     string
+	Name     string
+	Aliases  []string
+}
+
+// EntityDiariesInput is the input for ListEntityDiaries. EntityID is the user ID of a member.
+type EntityDiariesInput struct {
+	FamilyID uuid.UUID
+	ViewerID uuid.UUID
+	Kind     string
+	EntityID uuid.UUID
+	Limit    int
+}
+
+// EntityMentionsInput is the input for GetEntityMentions
+type EntityMentionsInput struct {
+	FamilyID uuid.UUID
+	ViewerID uuid.UUID
+	From     string
+	To       string
+}
+
+type entityUsecase struct {
+	er repository.EntityRepository
+}
+
+// NewEntityUsecase creates a new EntityUsecase instance
+func NewEntityUsecase(er repository.EntityRepository) EntityUsecase {
+	return &entityUsecase{
+		er: er,
+	}
+}
+
+func (eu *entityUsecase) ListEntities(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyEntity, error) {
+	return eu.er.ListEntities(ctx, familyID)
+}
+
+// CreateEntity adds a place or a pet. It is linked in the diaries analyzed from now on.
+func (eu *entityUsecase) CreateEntity(ctx context.Context, input *EntityInput) (*domain.FamilyEntity, error) {
+	entity, err := newFamilyEntity(input)
+	if err != nil {
+		return nil, err
+	}
+	entity.ID = uuid.New()
+
+	if err := eu.er.CreateEntity(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// UpdateEntity renames a place or a pet. References already found keep pointing at it.
+func (eu *entityUsecase) UpdateEntity(ctx context.Context, input *EntityInput) (*domain.FamilyEntity, error) {
+	entity, err := newFamilyEntity(input)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := eu.er.FindEntity(ctx, input.FamilyID, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, &errors.NotFoundError{Message: "entity not found"}
+	}
+
+	entity.ID = existing.ID
+	entity.CreatedAt = existing.CreatedAt
+	if err := eu.er.UpdateEntity(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (eu *entityUsecase) DeleteEntity(ctx context.Context, familyID, entityID uuid.UUID) error {
+	existing, err := eu.er.FindEntity(ctx, familyID, entityID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return &errors.NotFoundError{Message: "entity not found"}
+	}
+
+	return eu.er.DeleteEntity(ctx, familyID, entityID)
+}
+
+// ListEntityDiaries lists the diaries mentioning a member, a place or a pet, newest first
+func (eu *entityUsecase) ListEntityDiaries(ctx context.Context, input *EntityDiariesInput) ([]*domain.EntityDiary, error) {
+	if err := domain.ValidateEntityKind(input.Kind); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+	if input.Limit < 1 || input.Limit > domain.MaxEntityDiaryLimit {
+		return nil, &errors.ValidationError{Message: fmt.Sprintf("limit must be between 1 and %d", domain.MaxEntityDiaryLimit)}
+	}
+	if input.EntityID == uuid.Nil {
+		return nil, &errors.ValidationError{Message: "invalid entity ID"}
+	}
+
+	criteria := &domain.EntityDiaryCriteria{
+		FamilyID: input.FamilyID,
+		ViewerID: input.ViewerID,
+		Kind:     input.Kind,
+		EntityID: input.EntityID,
+		Limit:    input.Limit,
+	}
+	return eu.er.ListEntityDiaries(ctx, criteria)
+}
+
+// GetEntityMentions counts who each member wrote about between the specified dates
+func (eu *entityUsecase) GetEntityMentions(ctx context.Context, input *EntityMentionsInput) (*domain.EntityMentions, error) {
+	from, err := domain.ValidateYYYYMMDDFormat(input.From)
+	if err != nil {
+		return nil, &errors.ValidationError{Message: "from: " + err.Error()}
+	}
+	to, err := domain.ValidateYYYYMMDDFormat(input.To)
+	if err != nil {
+		return nil, &errors.ValidationError{Message: "to: " + err.Error()}
+	}
+	if err := domain.ValidateEntityMentionRange(from, to); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+
+	criteria := &domain.EntityMentionCriteria{
+		FamilyID:  input.FamilyID,
+		ViewerID:  input.ViewerID,
+		StartDate: from,
+		EndDate:   to,
+	}
+	counts, err := eu.er.CountEntityMentions(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+	if counts == nil {
+		counts = []*domain.EntityMentionCount{}
+	}
+
+	return &domain.EntityMentions{
+		StartDate: from.Format("2006-01-02"),
+		EndDate:   to.Format("2006-01-02"),
+		Counts:    counts,
+	}, nil
+}
+
+// newFamilyEntity validates the input and trims the names, dropping duplicate aliases
+func newFamilyEntity(input *EntityInput) (*domain.FamilyEntity, error) {
+	if err := domain.ValidateFamilyEntity(input.Kind, input.Name, input.Aliases); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+
+	name := strings.TrimSpace(input.Name)
+	aliases := make([]string, 0, len(input.Aliases))
+	seen := map[string]bool{name: true}
+	for _, a := range input.Aliases {
+		a = strings.TrimSpace(a)
+		if seen[a] {
+			continue
+		}
+		seen[a] = true
+		aliases = append(aliases, a)
+	}
+
+	return &domain.FamilyEntity{
+		ID:       input.ID,
+		FamilyID: input.FamilyID,
+		Kind:     input.Kind,
+		Name:     name,
+		Aliases:  aliases,
+	}, nil
+}
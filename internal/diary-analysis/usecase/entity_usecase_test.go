@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEntityRepository struct {
+	mock.Mock
+}
+
+func (m *MockEntityRepository) ListEntities(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyEntity, error) {
+	args := m.Called(ctx, familyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.FamilyEntity), args.Error(1)
+}
+
+func (m *MockEntityRepository) FindEntity(ctx context.Context, familyID, entityID uuid.UUID) (*domain.FamilyEntity, error) {
+	args := m.Called(ctx, familyID, entityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FamilyEntity), args.Error(1)
+}
+
+func (m *MockEntityRepository) CreateEntity(ctx context.Context, entity *domain.FamilyEntity) error {
+	args := m.Called(ctx, entity)
+	return args.Error(0)
+}
+
+func (m *MockEntityRepository) UpdateEntity(ctx context.Context, entity *domain.FamilyEntity) error {
+	args := m.Called(ctx, entity)
+	return args.Error(0)
+}
+
+func (m *MockEntityRepository) DeleteEntity(ctx context.Context, familyID, entityID uuid.UUID) error {
+	args := m.Called(ctx, familyID, entityID)
+	return args.Error(0)
+}
+
+func (m *MockEntityRepository) ListEntityDiaries(ctx context.Context, criteria *domain.EntityDiaryCriteria) ([]*domain.EntityDiary, error) {
+	args := m.Called(ctx, criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EntityDiary), args.Error(1)
+}
+
+func (m *MockEntityRepository) CountEntityMentions(ctx context.Context, criteria *domain.EntityMentionCriteria) ([]*domain.EntityMentionCount, error) {
+	args := m.Called(ctx, criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EntityMentionCount), args.Error(1)
+}
+
+// CreateEntity trims the names and drops duplicate aliases
+func TestEntityUsecase_CreateEntity_NormalizesNames(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockEntityRepository)
+	uc := NewEntityUsecase(mockRepository)
+
+	familyID := uuid.New()
+	mockRepository.On("CreateEntity", mock.Anything, mock.MatchedBy(func(e *domain.FamilyEntity) bool {
+		return e.ID != uuid.Nil && e.FamilyID == familyID && e.Kind == domain.EntityKindPet &&
+			e.Name == "ポチ" && assert.ObjectsAreEqual([]string{"ぽち"}, e.Aliases)
+	})).Return(nil)
+
+	entity, err := uc.CreateEntity(context.Background(), &EntityInput{
+		FamilyID: familyID,
+		Kind:     domain.EntityKindPet,
+		Name:     " ポチ ",
+		Aliases:  []string{"ぽち", "ポチ", " ぽち"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ポチ", entity.Name)
+	mockRepository.AssertExpectations(t)
+}
+
+// CreateEntity of a member or a blank alias - validation error
+func TestEntityUsecase_CreateEntity_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input *EntityInput
+	}{
+		{"member kind", &EntityInput{Kind: domain.EntityKindMember, Name: "おじいちゃん"}},
+		{"blank name", &EntityInput{Kind: domain.EntityKindPlace, Name: " "}},
+		{"blank alias", &EntityInput{Kind: domain.EntityKindPlace, Name: "公園", Aliases: []string{""}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepository := new(MockEntityRepository)
+			uc := NewEntityUsecase(mockRepository)
+
+			_, err := uc.CreateEntity(context.Background(), tt.input)
+
+			assert.IsType(t, &errors.ValidationError{}, err)
+			mockRepository.AssertNotCalled(t, "CreateEntity", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// UpdateEntity of another family's entity - not found
+func TestEntityUsecase_UpdateEntity_NotFound(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockEntityRepository)
+	uc := NewEntityUsecase(mockRepository)
+
+	familyID, entityID := uuid.New(), uuid.New()
+	mockRepository.On("FindEntity", mock.Anything, familyID, entityID).Return(nil, nil)
+
+	_, err := uc.UpdateEntity(context.Background(), &EntityInput{
+		ID:       entityID,
+		FamilyID: familyID,
+		Kind:     domain.EntityKindPlace,
+		Name:     "公園",
+	})
+
+	assert.IsType(t, &errors.NotFoundError{}, err)
+	mockRepository.AssertNotCalled(t, "UpdateEntity", mock.Anything, mock.Anything)
+}
+
+// ListEntityDiaries with an unknown kind - validation error
+func TestEntityUsecase_ListEntityDiaries_InvalidKind(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockEntityRepository)
+	uc := NewEntityUsecase(mockRepository)
+
+	_, err := uc.ListEntityDiaries(context.Background(), &EntityDiariesInput{
+		Kind:     "toy",
+		EntityID: uuid.New(),
+		Limit:    domain.DefaultEntityDiaryLimit,
+	})
+
+	assert.IsType(t, &errors.ValidationError{}, err)
+}
+
+// GetEntityMentions counts the mentions between the dates and returns an empty list without mentions
+func TestEntityUsecase_GetEntityMentions_Empty(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockEntityRepository)
+	uc := NewEntityUsecase(mockRepository)
+
+	familyID, viewerID := uuid.New(), uuid.New()
+	mockRepository.On("CountEntityMentions", mock.Anything, &domain.EntityMentionCriteria{
+		FamilyID:  familyID,
+		ViewerID:  viewerID,
+		StartDate: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC),
+	}).Return(nil, nil)
+
+	mentions, err := uc.GetEntityMentions(context.Background(), &EntityMentionsInput{
+		FamilyID: familyID,
+		ViewerID: viewerID,
+		From:     "2026-04-01",
+		To:       "2026-04-30",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-04-01", mentions.StartDate)
+	assert.Equal(t, "2026-04-30", mentions.EndDate)
+	assert.NotNil(t, mentions.Counts)
+	assert.Empty(t, mentions.Counts)
+}
+
+// GetEntityMentions with to before from - validation error
+func TestEntityUsecase_GetEntityMentions_InvalidRange(t *testing.T) {
+	t.Parallel()
+
+	mockRepository := new(MockEntityRepository)
+	uc := NewEntityUsecase(mockRepository)
+
+	_, err := uc.GetEntityMentions(context.Background(), &EntityMentionsInput{From: "2026-04-30", To: "2026-04-01"})
+
+	assert.IsType(t, &errors.ValidationError{}, err)
+}
@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+	"github.com/furuya-3150/fam-diary-log/pkg/datetime"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+	"github.com/google/uuid"
+)
+
+// GoalUsecase maintains the personal writing goals of a member and their progress
+type GoalUsecase interface {
+	ListGoals(ctx context.Context, familyID, userID uuid.UUID) ([]*domain.Goal, error)
+	CreateGoal(ctx context.Context, input *GoalInput) (*domain.Goal, error)
+	UpdateGoal(ctx context.Context, input *GoalInput) (*domain.Goal, error)
+	DeleteGoal(ctx context.Context, familyID, userID, goalID uuid.UUID) error
+	GetProgress(ctx context.Context, input *GoalProgressInput) (*domain.GoalsProgress, error)
+}
+
+// GoalInput is the input for CreateGoal and UpdateGoal.
+// ID is ignored by CreateGoal, and UpdateGoal changes the target only.
+type GoalInput struct {
+	ID       uuid.UUID
+	FamilyID uuid.UUID
+	UserID   uuid.UUID
+	Metric   string
+	Period   string
+	Target   int
+}
+
+// GoalProgressInput is the input for GetProgress.
+// Date is optional; it defaults to today in the member's time zone.
+type GoalProgressInput struct {
+	FamilyID uuid.UUID
+	UserID   uuid.UUID
+	Date     string
+}
+
+type goalUsecase struct {
+	gr  repository.GoalRepository
+	dar repository.DiaryAnalysisRepository
+	clk clock.Clock
+}
+
+// NewGoalUsecase creates a new GoalUsecase instance
+func NewGoalUsecase(gr repository.GoalRepository, dar repository.DiaryAnalysisRepository, clk clock.Clock) GoalUsecase {
+	return &goalUsecase{
+		gr:  gr,
+		dar: dar,
+		clk: clk,
+	}
+}
+
+func (gu *goalUsecase) ListGoals(ctx context.Context, familyID, userID uuid.UUID) ([]*domain.Goal, error) {
+	return gu.gr.ListGoals(ctx, familyID, userID)
+}
+
+// CreateGoal adds a goal. Diaries written earlier in the period count towards it.
+func (gu *goalUsecase) CreateGoal(ctx context.Context, input *GoalInput) (*domain.Goal, error) {
+	if err := domain.ValidateGoal(input.Metric, input.Period, input.Target); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+
+	goals, err := gu.gr.ListGoals(ctx, input.FamilyID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if slices.ContainsFunc(goals, func(g *domain.Goal) bool {
+		return g.Metric == input.Metric && g.Period == input.Period
+	}) {
+		return nil, &errors.ConflictError{Message: "a goal of the metric and period already exists"}
+	}
+
+	goal := &domain.Goal{
+		ID:       uuid.New(),
+		UserID:   input.UserID,
+		FamilyID: input.FamilyID,
+		Metric:   input.Metric,
+		Period:   input.Period,
+		Target:   input.Target,
+	}
+	if err := gu.gr.CreateGoal(ctx, goal); err != nil {
+		return nil, err
+	}
+	return goal, nil
+}
+
+// UpdateGoal changes the target of a goal. A goal reached in the period is celebrated again when the new target is reached.
+func (gu *goalUsecase) UpdateGoal(ctx context.Context, input *GoalInput) (*domain.Goal, error) {
+	if err := domain.ValidateGoalTarget(input.Target); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+
+	goal, err := gu.gr.FindGoal(ctx, input.FamilyID, input.UserID, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if goal == nil {
+		return nil, &errors.NotFoundError{Message: "goal not found"}
+	}
+
+	goal.Target = input.Target
+	if err := gu.gr.UpdateGoal(ctx, goal); err != nil {
+		return nil, err
+	}
+	return goal, nil
+}
+
+func (gu *goalUsecase) DeleteGoal(ctx context.Context, familyID, userID, goalID uuid.UUID) error {
+	goal, err := gu.gr.FindGoal(ctx, familyID, userID, goalID)
+	if err != nil {
+		return err
+	}
+	if goal == nil {
+		return &errors.NotFoundError{Message: "goal not found"}
+	}
+
+	return gu.gr.DeleteGoal(ctx, familyID, userID, goalID)
+}
+
+// GetProgress computes the progress of each goal in the week or the month containing the date
+// from the daily rollups of the member's own diaries
+func (gu *goalUsecase) GetProgress(ctx context.Context, input *GoalProgressInput) (*domain.GoalsProgress, error) {
+	if input.FamilyID == uuid.Nil || input.UserID == uuid.Nil {
+		return nil, &errors.ValidationError{Message: "invalid user ID"}
+	}
+	date, err := gu.progressDate(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	goals, err := gu.gr.ListGoals(ctx, input.FamilyID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	progress := &domain.GoalsProgress{
+		Date:  date.Format("2006-01-02"),
+		Goals: make([]*domain.GoalProgress, 0, len(goals)),
+	}
+	if len(goals) == 0 {
+		return progress, nil
+	}
+
+	// 週は月をまたぐことがあるため、週と月の両方を含む範囲を読む
+	weekStart, weekEnd := datetime.GetWeekRange(date)
+	monthStart, monthEnd := datetime.GetMonthRange(date)
+	dailies, err := gu.dar.ListDaily(ctx, &domain.DiaryAnalysisSearchCriteria{
+		UserID:    input.UserID,
+		FamilyID:  input.FamilyID,
+		ViewerID:  input.UserID,
+		WeekStart: earliest(weekStart, monthStart),
+		WeekEnd:   latest(weekEnd, monthEnd),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, goal := range goals {
+		start, end := weekStart, weekEnd
+		if goal.Period == domain.GoalPeriodMonthly {
+			start, end = monthStart, monthEnd
+		}
+
+		var acc goalAccumulator
+		for _, d := range dailies {
+			if !d.LocalDate.Before(start) && !d.LocalDate.After(end) {
+				acc.add(d)
+			}
+		}
+		current := acc.value(goal.Metric)
+
+		progress.Goals = append(progress.Goals, &domain.GoalProgress{
+			GoalID:    goal.ID,
+			Metric:    goal.Metric,
+			Period:    goal.Period,
+			Target:    goal.Target,
+			Current:   current,
+			Achieved:  current >= goal.Target,
+			StartDate: start.Format("2006-01-02"),
+			EndDate:   end.Format("2006-01-02"),
+		})
+	}
+
+	return progress, nil
+}
+
+// progressDate returns the date of the input, or today in the time zone of the member
+func (gu *goalUsecase) progressDate(ctx context.Context, input *GoalProgressInput) (time.Time, error) {
+	if input.Date != "" {
+		date, err := domain.ValidateYYYYMMDDFormat(input.Date)
+		if err != nil {
+			return time.Time{}, &errors.ValidationError{Message: err.Error()}
+		}
+		return date, nil
+	}
+
+	timezone, err := gu.gr.FindTimezone(ctx, input.FamilyID, input.UserID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if timezone == "" {
+		timezone = domain.DefaultTimezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		slog.Warn("unknown time zone, using the default", "timezone", timezone, "default", domain.DefaultTimezone)
+		loc, err = time.LoadLocation(domain.DefaultTimezone)
+		if err != nil {
+			loc = time.FixedZone(domain.DefaultTimezone, 9*60*60)
+		}
+	}
+	year, month, day := gu.clk.Now().In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil
+}
+
+// goalAccumulator totals the daily rollups of a goal period
+type goalAccumulator struct {
+	posts   int
+	chars   float64
+	seconds float64
+}
+
+func (acc *goalAccumulator) add(d *domain.DailyAnalysisMetrics) {
+	acc.posts += d.DiaryCount
+	acc.chars += d.Metric(domain.MetricCharCount).Sum
+	acc.seconds += d.Metric(domain.MetricWritingTimeSeconds).Sum
+}
+
+// value returns the progress of the goal metric; writing minutes are rounded down like in diary-analyzer
+func (acc *goalAccumulator) value(metric string) int {
+	switch metric {
+	case domain.GoalMetricPosts:
+		return acc.posts
+	case domain.GoalMetricCharacters:
+		return int(acc.chars)
+	case domain.GoalMetricWritingMinutes:
+		return int(acc.seconds) / 60
+	default:
+		return 0
+	}
+}
+
+func earliest(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
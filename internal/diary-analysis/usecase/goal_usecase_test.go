@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analysis/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGoalRepository struct {
+	mock.Mock
+}
+
+func (m *MockGoalRepository) ListGoals(ctx context.Context, familyID, userID uuid.UUID) ([]*domain.Goal, error) {
+	args := m.Called(ctx, familyID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Goal), args.Error(1)
+}
+
+func (m *MockGoalRepository) FindGoal(ctx context.Context, familyID, userID, goalID uuid.UUID) (*domain.Goal, error) {
+	args := m.Called(ctx, familyID, userID, goalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Goal), args.Error(1)
+}
+
+func (m *MockGoalRepository) CreateGoal(ctx context.Context, goal *domain.Goal) error {
+	args := m.Called(ctx, goal)
+	return args.Error(0)
+}
+
+func (m *MockGoalRepository) UpdateGoal(ctx context.Context, goal *domain.Goal) error {
+	args := m.Called(ctx, goal)
+	return args.Error(0)
+}
+
+func (m *MockGoalRepository) DeleteGoal(ctx context.Context, familyID, userID, goalID uuid.UUID) error {
+	args := m.Called(ctx, familyID, userID, goalID)
+	return args.Error(0)
+}
+
+func (m *MockGoalRepository) FindTimezone(ctx context.Context, familyID, userID uuid.UUID) (string, error) {
+	args := m.Called(ctx, familyID, userID)
+	return args.String(0), args.Error(1)
+}
+
+// CreateGoal stores a goal of the member
+func TestGoalUsecase_CreateGoal(t *testing.T) {
+	t.Parallel()
+
+	mockGoals := new(MockGoalRepository)
+	usecase := NewGoalUsecase(mockGoals, new(MockDiaryAnalysisRepository), &clock.Real{})
+
+	familyID, userID := uuid.New(), uuid.New()
+	mockGoals.On("ListGoals", mock.Anything, familyID, userID).Return([]*domain.Goal{
+		{Metric: domain.GoalMetricPosts, Period: domain.GoalPeriodMonthly},
+	}, nil)
+	mockGoals.On("CreateGoal", mock.Anything, mock.MatchedBy(func(g *domain.Goal) bool {
+		return g.ID != uuid.Nil && g.FamilyID == familyID && g.UserID == userID &&
+			g.Metric == domain.GoalMetricPosts && g.Period == domain.GoalPeriodWeekly && g.Target == 3
+	})).Return(nil)
+
+	goal, err := usecase.CreateGoal(context.Background(), &GoalInput{
+		FamilyID: familyID, UserID: userID, Metric: domain.GoalMetricPosts, Period: domain.GoalPeriodWeekly, Target: 3,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, goal.Target)
+	mockGoals.AssertExpectations(t)
+}
+
+// CreateGoal with a goal of the same metric and period - conflict
+func TestGoalUsecase_CreateGoal_Duplicate(t *testing.T) {
+	t.Parallel()
+
+	mockGoals := new(MockGoalRepository)
+	usecase := NewGoalUsecase(mockGoals, new(MockDiaryAnalysisRepository), &clock.Real{})
+
+	familyID, userID := uuid.New(), uuid.New()
+	mockGoals.On("ListGoals", mock.Anything, familyID, userID).Return([]*domain.Goal{
+		{Metric: domain.GoalMetricCharacters, Period: domain.GoalPeriodWeekly},
+	}, nil)
+
+	_, err := usecase.CreateGoal(context.Background(), &GoalInput{
+		FamilyID: familyID, UserID: userID, Metric: domain.GoalMetricCharacters, Period: domain.GoalPeriodWeekly, Target: 1000,
+	})
+
+	assert.IsType(t, &errors.ConflictError{}, err)
+	mockGoals.AssertNotCalled(t, "CreateGoal", mock.Anything, mock.Anything)
+}
+
+// CreateGoal with an unknown metric, an unknown period or a target out of range
+func TestGoalUsecase_CreateGoal_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		metric string
+		period string
+		target int
+	}{
+		{"unknown metric", "accuracy", domain.GoalPeriodWeekly, 1},
+		{"unknown period", domain.GoalMetricPosts, "daily", 1},
+		{"zero target", domain.GoalMetricPosts, domain.GoalPeriodWeekly, 0},
+		{"target too large", domain.GoalMetricCharacters, domain.GoalPeriodMonthly, domain.MaxGoalTarget + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGoals := new(MockGoalRepository)
+			usecase := NewGoalUsecase(mockGoals, new(MockDiaryAnalysisRepository), &clock.Real{})
+
+			_, err := usecase.CreateGoal(context.Background(), &GoalInput{
+				FamilyID: uuid.New(), UserID: uuid.New(), Metric: tt.metric, Period: tt.period, Target: tt.target,
+			})
+
+			assert.IsType(t, &errors.ValidationError{}, err)
+			mockGoals.AssertNotCalled(t, "CreateGoal", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// UpdateGoal of another member's goal - not found
+func TestGoalUsecase_UpdateGoal_NotFound(t *testing.T) {
+	t.Parallel()
+
+	mockGoals := new(MockGoalRepository)
+	usecase := NewGoalUsecase(mockGoals, new(MockDiaryAnalysisRepository), &clock.Real{})
+
+	familyID, userID, goalID := uuid.New(), uuid.New(), uuid.New()
+	mockGoals.On("FindGoal", mock.Anything, familyID, userID, goalID).Return(nil, nil)
+
+	_, err := usecase.UpdateGoal(context.Background(), &GoalInput{ID: goalID, FamilyID: familyID, UserID: userID, Target: 5})
+
+	assert.IsType(t, &errors.NotFoundError{}, err)
+	mockGoals.AssertNotCalled(t, "UpdateGoal", mock.Anything, mock.Anything)
+}
+
+// GetProgress totals the member's own diaries in the week and the month of the date
+func TestGoalUsecase_GetProgress(t *testing.T) {
+	t.Parallel()
+
+	mockGoals := new(MockGoalRepository)
+	mockAnalyses := new(MockDiaryAnalysisRepository)
+	usecase := NewGoalUsecase(mockGoals, mockAnalyses, &clock.Real{})
+
+	familyID, userID := uuid.New(), uuid.New()
+	weeklyPosts := &domain.Goal{ID: uuid.New(), Metric: domain.GoalMetricPosts, Period: domain.GoalPeriodWeekly, Target: 3}
+	weeklyMinutes := &domain.Goal{ID: uuid.New(), Metric: domain.GoalMetricWritingMinutes, Period: domain.GoalPeriodWeekly, Target: 30}
+	monthlyChars := &domain.Goal{ID: uuid.New(), Metric: domain.GoalMetricCharacters, Period: domain.GoalPeriodMonthly, Target: 1000}
+	mockGoals.On("ListGoals", mock.Anything, familyID, userID).Return([]*domain.Goal{weeklyPosts, weeklyMinutes, monthlyChars}, nil)
+
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+	// the week of Friday 2026-01-30 runs from 01-26 to 02-01
+	mockAnalyses.On("ListDaily", mock.Anything, mock.MatchedBy(func(c *domain.DiaryAnalysisSearchCriteria) bool {
+		return c.UserID == userID && c.FamilyID == familyID && c.ViewerID == userID &&
+			c.WeekStart.Equal(day(1)) && c.WeekEnd.Format("2006-01-02") == "2026-02-01"
+	})).Return(rollUp([]*domain.DiaryAnalysis{
+		{UserID: userID, CharCount: 400, WritingTimeSeconds: 600, CreatedAt: day(5)},
+		{UserID: userID, CharCount: 300, WritingTimeSeconds: 900, CreatedAt: day(26)},
+		{UserID: userID, CharCount: 200, WritingTimeSeconds: 899, CreatedAt: day(30)},
+		{UserID: userID, CharCount: 150, WritingTimeSeconds: 0, CreatedAt: day(30)},
+	}), nil)
+
+	progress, err := usecase.GetProgress(context.Background(), &GoalProgressInput{FamilyID: familyID, UserID: userID, Date: "2026-01-30"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-01-30", progress.Date)
+	assert.Len(t, progress.Goals, 3)
+
+	assert.Equal(t, weeklyPosts.ID, progress.Goals[0].GoalID)
+	assert.Equal(t, 3, progress.Goals[0].Current)
+	assert.True(t, progress.Goals[0].Achieved)
+	assert.Equal(t, "2026-01-26", progress.Goals[0].StartDate)
+	assert.Equal(t, "2026-02-01", progress.Goals[0].EndDate)
+
+	// 1799 seconds
+	assert.Equal(t, 29, progress.Goals[1].Current)
+	assert.False(t, progress.Goals[1].Achieved)
+
+	assert.Equal(t, 1050, progress.Goals[2].Current)
+	assert.True(t, progress.Goals[2].Achieved)
+	assert.Equal(t, "2026-01-01", progress.Goals[2].StartDate)
+	assert.Equal(t, "2026-01-31", progress.Goals[2].EndDate)
+}
+
+// GetProgress without a date - today in the member's time zone
+func TestGoalUsecase_GetProgress_TodayInTimezone(t *testing.T) {
+	t.Parallel()
+
+	mockGoals := new(MockGoalRepository)
+	// 2026-02-01 03:00 in Tokyo, still 2026-01-31 in New York
+	usecase := NewGoalUsecase(mockGoals, new(MockDiaryAnalysisRepository), &clock.Fixed{Time: time.Date(2026, 1, 31, 18, 0, 0, 0, time.UTC)})
+
+	tests := []struct {
+		timezone string
+		want     string
+	}{
+		{"America/New_York", "2026-01-31"},
+		{"Asia/Tokyo", "2026-02-01"},
+		// not synced yet
+		{"", "2026-02-01"},
+	}
+
+	for _, tt := range tests {
+		familyID, userID := uuid.New(), uuid.New()
+		mockGoals.On("FindTimezone", mock.Anything, familyID, userID).Return(tt.timezone, nil)
+		mockGoals.On("ListGoals", mock.Anything, familyID, userID).Return([]*domain.Goal{}, nil)
+
+		progress, err := usecase.GetProgress(context.Background(), &GoalProgressInput{FamilyID: familyID, UserID: userID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, progress.Date, tt.timezone)
+		assert.Empty(t, progress.Goals)
+	}
+}
+
+// GetProgress with an invalid date
+func TestGoalUsecase_GetProgress_InvalidDate(t *testing.T) {
+	t.Parallel()
+
+	mockGoals := new(MockGoalRepository)
+	usecase := NewGoalUsecase(mockGoals, new(MockDiaryAnalysisRepository), &clock.Real{})
+
+	_, err := usecase.GetProgress(context.Background(), &GoalProgressInput{FamilyID: uuid.New(), UserID: uuid.New(), Date: "2026/01/30"})
+
+	assert.IsType(t, &errors.ValidationError{}, err)
+	mockGoals.AssertNotCalled(t, "ListGoals", mock.Anything, mock.Anything, mock.Anything)
+}
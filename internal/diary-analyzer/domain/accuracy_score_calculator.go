@@ -1,19 +1,43 @@
 package domain
 
-// AccuracyScoreCalculator calculates accuracy score based on suggestions
-type AccuracyScoreCalculator struct{}
+// AccuracyScoreCalculator calculates accuracy score based on suggestions with a scoring strategy
+type AccuracyScoreCalculator struct {
+	strategy AccuracyScoringStrategy
+}
 
-// NewAccuracyScoreCalculator creates a new AccuracyScoreCalculator
+// NewAccuracyScoreCalculator creates a new AccuracyScoreCalculator with the legacy flat penalty
 func NewAccuracyScoreCalculator() *AccuracyScoreCalculator {
-	return &AccuracyScoreCalculator{}
+	return NewAccuracyScoreCalculatorWithStrategy(FlatPenaltyStrategy{})
+}
+
+// NewAccuracyScoreCalculatorWithStrategy creates a new AccuracyScoreCalculator with the strategy
+func NewAccuracyScoreCalculatorWithStrategy(strategy AccuracyScoringStrategy) *AccuracyScoreCalculator {
+	return &AccuracyScoreCalculator{strategy: strategy}
+}
+
+// Calculate calculates accuracy score of a diary of charCount characters from its issues, in [0, 100]
+func (c *AccuracyScoreCalculator) Calculate(issues []AccuracyIssue, charCount int) int {
+	return c.strategy.Score(issues, charCount)
+}
+
+// StrategyID is the ID of the scoring strategy, stored with the score
+func (c *AccuracyScoreCalculator) StrategyID() string {
+	return c.strategy.ID()
+}
+
+// Supports reports whether a diary in the language can be scored.
+// The proofreaders check Japanese only, so English diaries are not scored.
+func (c *AccuracyScoreCalculator) Supports(language string) bool {
+	return language != LanguageEnglish
 }
 
-// Calculate calculates accuracy score from suggestion count
-// Each suggestion reduces score by 10 points, minimum score is 0
-func (c *AccuracyScoreCalculator) Calculate(suggestionCount int) int {
-	score := 100 - (suggestionCount * 10)
-	if score < 0 {
-		score = 0
+// Applies reports whether a suggestion at the offset counts against the score.
+// Suggestions in English sentences of a mixed diary are false positives of the Japanese proofreaders.
+func (c *AccuracyScoreCalculator) Applies(sentences []Sentence, offset int) bool {
+	for _, s := range sentences {
+		if offset >= s.Offset && offset < s.Offset+s.Length {
+			return s.Language != LanguageEnglish
+		}
 	}
-	return score
+	return true
 }
@@ -38,8 +38,27 @@ func TestAccuracyScoreCalculatorCalculate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := calculator.Calculate(tt.suggestionCount)
+			result := calculator.Calculate(make([]AccuracyIssue, tt.suggestionCount), 0)
 			assert.Equal(t, tt.expectedScore, result)
 		})
 	}
 }
+
+func TestAccuracyScoreCalculatorSupports(t *testing.T) {
+	calculator := NewAccuracyScoreCalculator()
+
+	assert.True(t, calculator.Supports(LanguageJapanese))
+	assert.True(t, calculator.Supports(LanguageMixed))
+	assert.True(t, calculator.Supports(LanguageUnknown))
+	assert.False(t, calculator.Supports(LanguageEnglish))
+}
+
+func TestAccuracyScoreCalculatorApplies(t *testing.T) {
+	calculator := NewAccuracyScoreCalculator()
+	// 「晴れ。」0-2, 「Good day.」4-12
+	sentences := NewSentenceSegmenter().Segment("晴れ。 Good day.")
+
+	assert.True(t, calculator.Applies(sentences, 1))
+	assert.False(t, calculator.Applies(sentences, 5))
+	assert.True(t, calculator.Applies(sentences, 3))
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// Accuracy scoring strategies selectable by ACCURACY_SCORING_STRATEGY
+const (
+	AccuracyScoringFlat        = "flat"
+	AccuracyScoringPer100Chars = "per_100_chars"
+	AccuracyScoringSeverity    = "severity"
+)
+
+// IDs of the accuracy scoring strategies, stored with each score.
+// Bump the version when the formula of a strategy changes so that past scores stay interpretable.
+const (
+	AccuracyStrategyFlatV1        = "flat_v1"
+	AccuracyStrategyPer100CharsV1 = "per_100_chars_v1"
+	AccuracyStrategySeverityV1    = "severity_v1"
+)
+
+const (
+	// accuracyPenalty is the points deducted per issue, or per issue per 100 characters
+	accuracyPenalty = 10
+	// accuracyNormalizationChars is the length the issues are normalized to.
+	// Shorter diaries are scored as if they had this length, so that a single issue in a short diary is not overly penalized.
+	accuracyNormalizationChars = 100
+)
+
+// AccuracyIssue is a proofreading issue counted against the accuracy score.
+// Rule is the type of the issue; it is empty when the proofreader does not report it.
+type AccuracyIssue struct {
+	Rule string
+}
+
+// AccuracyScoringStrategy scores the accuracy of a diary from its proofreading issues
+type AccuracyScoringStrategy interface {
+	// ID identifies the strategy and its version
+	ID() string
+	// Score returns the accuracy in [0, 100] of a diary of charCount characters with the issues
+	Score(issues []AccuracyIssue, charCount int) int
+}
+
+// NewAccuracyScoringStrategy returns the current version of the strategy
+func NewAccuracyScoringStrategy(name string) (AccuracyScoringStrategy, error) {
+	switch name {
+	case AccuracyScoringFlat:
+		return FlatPenaltyStrategy{}, nil
+	case AccuracyScoringPer100Chars:
+		return Per100CharsStrategy{}, nil
+	case AccuracyScoringSeverity:
+		return SeverityWeightedStrategy{}, nil
+	default:
+		return nil, fmt.Errorf("unknown accuracy scoring strategy: %s", name)
+	}
+}
+
+// FlatPenaltyStrategy deducts 10 points per issue regardless of the length of the diary
+type FlatPenaltyStrategy struct{}
+
+func (FlatPenaltyStrategy) ID() string {
+	return AccuracyStrategyFlatV1
+}
+
+func (FlatPenaltyStrategy) Score(issues []AccuracyIssue, charCount int) int {
+	return clampScore(100 - float64(len(issues)*accuracyPenalty))
+}
+
+// Per100CharsStrategy deducts 10 points per issue per 100 characters,
+// so 3 issues in 900 characters score 97 while 3 issues in 100 characters score 70
+type Per100CharsStrategy struct{}
+
+func (Per100CharsStrategy) ID() string {
+	return AccuracyStrategyPer100CharsV1
+}
+
+func (Per100CharsStrategy) Score(issues []AccuracyIssue, charCount int) int {
+	return clampScore(100 - float64(len(issues))*per100Chars(charCount)*accuracyPenalty)
+}
+
+// 指摘の種類ごとの重み（未知の種類は 1）。
+// ローカル校正器のルールと Yahoo! 校正支援の指摘区分の両方を含む。
+var accuracySeverities = map[string]float64{
+	"doubled_particle":    1,
+	"ra_nuki":             0.5,
+	"mixed_style":         0.5,
+	"repeated_word":       0.5,
+	"width_inconsistency": 0.3,
+	"誤変換":                 1,
+	"誤用":                  1,
+	"ら抜き":                 0.5,
+	"二重否定":                0.5,
+	"助詞不足の可能性あり":          0.5,
+	"使用注意":                0.5,
+	"当て字":                 0.5,
+	"冗長表現":                0.3,
+	"用字":                  0.3,
+	"表外漢字あり":              0.3,
+	"機種依存または拡張文字":         0.3,
+}
+
+// SeverityWeightedStrategy is Per100CharsStrategy with each issue weighted by the severity of its type.
+// Issues of unknown type weigh 1, so it scores like Per100CharsStrategy when the proofreader does not report types.
+type SeverityWeightedStrategy struct{}
+
+func (SeverityWeightedStrategy) ID() string {
+	return AccuracyStrategySeverityV1
+}
+
+func (SeverityWeightedStrategy) Score(issues []AccuracyIssue, charCount int) int {
+	weighted := 0.0
+	for _, issue := range issues {
+		weight, ok := accuracySeverities[issue.Rule]
+		if !ok {
+			weight = 1
+		}
+		weighted += weight
+	}
+	return clampScore(100 - weighted*per100Chars(charCount)*accuracyPenalty)
+}
+
+// per100Chars converts a count per diary to a count per 100 characters
+func per100Chars(charCount int) float64 {
+	if charCount < accuracyNormalizationChars {
+		charCount = accuracyNormalizationChars
+	}
+	return accuracyNormalizationChars / float64(charCount)
+}
+
+func clampScore(score float64) int {
+	return int(math.Max(0, math.Min(100, math.Round(score))))
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccuracyScoringStrategies(t *testing.T) {
+	issues := func(rules ...string) []AccuracyIssue {
+		result := make([]AccuracyIssue, len(rules))
+		for i, r := range rules {
+			result[i] = AccuracyIssue{Rule: r}
+		}
+		return result
+	}
+	three := issues("", "", "")
+
+	tests := []struct {
+		name      string
+		strategy  AccuracyScoringStrategy
+		issues    []AccuracyIssue
+		charCount int
+		expected  int
+	}{
+		{"flat ignores length (short)", FlatPenaltyStrategy{}, three, 20, 70},
+		{"flat ignores length (long)", FlatPenaltyStrategy{}, three, 900, 70},
+		{"flat clamps to zero", FlatPenaltyStrategy{}, make([]AccuracyIssue, 12), 900, 0},
+		{"per 100 chars of a long diary", Per100CharsStrategy{}, three, 900, 97},
+		{"per 100 chars of 100 chars", Per100CharsStrategy{}, three, 100, 70},
+		{"per 100 chars of a short diary", Per100CharsStrategy{}, three, 20, 70},
+		{"per 100 chars without issues", Per100CharsStrategy{}, nil, 300, 100},
+		{"severity of minor issues", SeverityWeightedStrategy{}, issues("width_inconsistency", "mixed_style"), 100, 92},
+		{"severity of unknown types", SeverityWeightedStrategy{}, three, 100, 70},
+		{"severity of a long diary", SeverityWeightedStrategy{}, issues("誤変換", "ra_nuki"), 500, 97},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.strategy.Score(tt.issues, tt.charCount))
+		})
+	}
+}
+
+func TestNewAccuracyScoringStrategy(t *testing.T) {
+	tests := map[string]string{
+		AccuracyScoringFlat:        AccuracyStrategyFlatV1,
+		AccuracyScoringPer100Chars: AccuracyStrategyPer100CharsV1,
+		AccuracyScoringSeverity:    AccuracyStrategySeverityV1,
+	}
+	for name, id := range tests {
+		strategy, err := NewAccuracyScoringStrategy(name)
+		require.NoError(t, err)
+		assert.Equal(t, id, strategy.ID())
+	}
+
+	_, err := NewAccuracyScoringStrategy("strict")
+	assert.Error(t, err)
+}
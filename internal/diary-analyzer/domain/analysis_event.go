@@ -13,8 +13,58 @@ type DiaryAnalysis struct {
 	FamilyID           uuid.UUID `gorm:"column:family_id;type:uuid;not null"`
 	CharCount          int       `gorm:"column:char_count;type:integer"`
 	SentenceCount      int       `gorm:"column:sentence_count;type:integer"`
-	AccuracyScore      int       `gorm:"column:accuracy_score;type:integer"`
 	WritingTimeSeconds int       `gorm:"column:writing_time_seconds;type:integer"`
-	CreatedAt          time.Time `gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt          time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	// AccuracyScore is NULL unless AccuracyStatus is complete
+	AccuracyScore  *int   `gorm:"column:accuracy_score;type:integer"`
+	AccuracyStatus string `gorm:"column:accuracy_status;type:varchar(20)"`
+	// AccuracyStrategy is the ID of the scoring strategy of AccuracyScore, e.g. flat_v1
+	AccuracyStrategy *string `gorm:"column:accuracy_strategy;type:varchar(30)"`
+	// AccuracyError is the reason the accuracy check failed. NULL when it succeeded.
+	AccuracyError  *string `gorm:"column:accuracy_error;type:text"`
+	KeywordsStatus string  `gorm:"column:keywords_status;type:varchar(20)"`
+	KeywordsError  *string `gorm:"column:keywords_error;type:text"`
+	// RetryCount is the number of retries of failed enrichments; NextRetryAt is NULL when no retry is scheduled
+	RetryCount  int        `gorm:"column:retry_count;type:integer"`
+	NextRetryAt *time.Time `gorm:"column:next_retry_at"`
+	// DeferredUntil is when the NLP quota allows the deferred accuracy check again. Not stored; it sets NextRetryAt.
+	DeferredUntil *time.Time `gorm:"-"`
+	// SentimentScore is the emotional tone in [-1, 1]. NULL for analyses made before sentiment scoring and for English diaries.
+	SentimentScore         *float64 `gorm:"column:sentiment_score;type:double precision"`
+	SentimentPositiveCount int      `gorm:"column:sentiment_positive_count;type:integer"`
+	SentimentNegativeCount int      `gorm:"column:sentiment_negative_count;type:integer"`
+	// Language is the detected language of the diary: ja, en, mixed or und (undetermined, also for analyses made before detection)
+	Language string `gorm:"column:language;type:varchar(10)"`
+	// Readability metrics are NULL for analyses made before they were introduced, and the script ratios for English diaries.
+	// TypeTokenRatio is computed from the morphemes, so it is NULL until the keyword extraction completes.
+	KanjiRatio            *float64 `gorm:"column:kanji_ratio;type:double precision"`
+	HiraganaRatio         *float64 `gorm:"column:hiragana_ratio;type:double precision"`
+	KatakanaRatio         *float64 `gorm:"column:katakana_ratio;type:double precision"`
+	AverageSentenceLength *float64 `gorm:"column:average_sentence_length;type:double precision"`
+	LongestSentenceLength *int     `gorm:"column:longest_sentence_length;type:integer"`
+	TypeTokenRatio        *float64 `gorm:"column:type_token_ratio;type:double precision"`
+	// UnlockAt hides the analysis of a time capsule from other members until that time
+	UnlockAt  *time.Time `gorm:"column:unlock_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;autoCreateTime"`
+	// LocalDate is the date of CreatedAt in the time zone of the author, which the daily rollup buckets on (see LocalDate)
+	LocalDate time.Time `gorm:"column:local_date;type:date;not null"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	// Suggestions are stored together with the analysis
+	Suggestions []*DiaryAnalysisSuggestion `gorm:"foreignKey:AnalysisID"`
+	Keywords    []*DiaryKeyword            `gorm:"foreignKey:AnalysisID"`
+	// EntityReferences are the members, places and pets mentioned in the diary
+	EntityReferences []*DiaryEntityReference `gorm:"foreignKey:AnalysisID"`
+}
+
+// DiaryAnalysisSuggestion is a proofreading issue of the diary content.
+// Offset and Length are in Unicode code points.
+type DiaryAnalysisSuggestion struct {
+	ID         uuid.UUID `gorm:"column:id;type:uuid;primaryKey;default:gen_random_uuid()"`
+	AnalysisID uuid.UUID `gorm:"column:analysis_id;type:uuid;not null"`
+	DiaryID    uuid.UUID `gorm:"column:diary_id;type:uuid;not null"`
+	Rule       string    `gorm:"column:rule;type:varchar(50)"`
+	Offset     int       `gorm:"column:offset_chars;type:integer"`
+	Length     int       `gorm:"column:length_chars;type:integer"`
+	Message    string    `gorm:"column:message;type:text"`
+	Suggestion string    `gorm:"column:suggestion;type:text"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
 }
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// Statuses of an enrichment (accuracy check, keyword extraction) of an analysis
+const (
+	AnalysisStatusPending  = "pending"
+	AnalysisStatusComplete = "complete"
+	AnalysisStatusFailed   = "failed"
+	// AnalysisStatusSkipped is an enrichment that does not apply, e.g. the accuracy check of an English diary
+	AnalysisStatusSkipped = "skipped"
+	// AnalysisStatusDeferred is an enrichment postponed because the NLP quota is used up.
+	// It is retried when the quota allows and does not count against MaxAnalysisRetries.
+	AnalysisStatusDeferred = "deferred"
+)
+
+const (
+	// MaxAnalysisRetries is the number of times a failed enrichment is retried
+	MaxAnalysisRetries = 5
+	// AnalysisRetryBaseDelay is the wait before the first retry; it doubles on every retry
+	AnalysisRetryBaseDelay = time.Minute
+	// AnalysisRetryBatchSize is the number of analyses retried per scheduler run
+	AnalysisRetryBatchSize = 20
+)
+
+// HasFailedEnrichment reports whether an enrichment of the analysis failed
+func (a *DiaryAnalysis) HasFailedEnrichment() bool {
+	return a.AccuracyStatus == AnalysisStatusFailed || a.KeywordsStatus == AnalysisStatusFailed
+}
+
+// ScheduleRetry sets when the failed enrichments are retried after retryCount retries,
+// with exponential backoff, and a deferred accuracy check at DeferredUntil, whichever comes first.
+// NextRetryAt is cleared when nothing failed or was deferred, or retries are exhausted.
+func (a *DiaryAnalysis) ScheduleRetry(now time.Time) {
+	a.NextRetryAt = nil
+	if a.HasFailedEnrichment() && a.RetryCount < MaxAnalysisRetries {
+		next := now.Add(AnalysisRetryBaseDelay << a.RetryCount)
+		a.NextRetryAt = &next
+	}
+
+	if a.AccuracyStatus == AnalysisStatusDeferred {
+		next := now
+		if a.DeferredUntil != nil && a.DeferredUntil.After(now) {
+			next = *a.DeferredUntil
+		}
+		if a.NextRetryAt == nil || next.Before(*a.NextRetryAt) {
+			a.NextRetryAt = &next
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiaryAnalysisScheduleRetry(t *testing.T) {
+	now := time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		analysis DiaryAnalysis
+		want     *time.Time
+	}{
+		{
+			name:     "complete",
+			analysis: DiaryAnalysis{AccuracyStatus: AnalysisStatusComplete, KeywordsStatus: AnalysisStatusComplete},
+			want:     nil,
+		},
+		{
+			name:     "first failure",
+			analysis: DiaryAnalysis{AccuracyStatus: AnalysisStatusFailed, KeywordsStatus: AnalysisStatusComplete},
+			want:     ptrTime(now.Add(time.Minute)),
+		},
+		{
+			name:     "third retry backs off",
+			analysis: DiaryAnalysis{AccuracyStatus: AnalysisStatusComplete, KeywordsStatus: AnalysisStatusFailed, RetryCount: 3},
+			want:     ptrTime(now.Add(8 * time.Minute)),
+		},
+		{
+			name:     "retries exhausted",
+			analysis: DiaryAnalysis{AccuracyStatus: AnalysisStatusFailed, RetryCount: MaxAnalysisRetries},
+			want:     nil,
+		},
+		{
+			name:     "deferred until the quota allows",
+			analysis: DiaryAnalysis{AccuracyStatus: AnalysisStatusDeferred, KeywordsStatus: AnalysisStatusComplete, DeferredUntil: ptrTime(now.Add(30 * time.Minute))},
+			want:     ptrTime(now.Add(30 * time.Minute)),
+		},
+		{
+			name:     "deferred regardless of retries",
+			analysis: DiaryAnalysis{AccuracyStatus: AnalysisStatusDeferred, RetryCount: MaxAnalysisRetries, DeferredUntil: ptrTime(now.Add(time.Hour))},
+			want:     ptrTime(now.Add(time.Hour)),
+		},
+		{
+			name:     "earlier failure retry wins over deferral",
+			analysis: DiaryAnalysis{AccuracyStatus: AnalysisStatusDeferred, KeywordsStatus: AnalysisStatusFailed, DeferredUntil: ptrTime(now.Add(time.Hour))},
+			want:     ptrTime(now.Add(time.Minute)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.analysis
+			a.ScheduleRetry(now)
+			assert.Equal(t, tt.want, a.NextRetryAt)
+		})
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Diary is the diary to (re-)analyze. It is read from the diary database by the backfill,
+// or built from diary.updated events.
+type Diary struct {
+	ID                 uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	UserID             uuid.UUID  `gorm:"column:user_id;type:uuid"`
+	FamilyID           uuid.UUID  `gorm:"column:family_id;type:uuid"`
+	Content            string     `gorm:"column:content;type:text"`
+	WritingTimeSeconds int        `gorm:"column:writing_time_seconds;type:integer"`
+	UnlockAt           *time.Time `gorm:"column:unlock_at"`
+	// CreatedAt is kept as the analysis date so that charts do not move on re-analysis
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+// TableName specifies the table name in the diary database
+func (Diary) TableName() string {
+	return "diaries"
+}
@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// EntityKind is the kind of a family entity mentioned in diaries
+type EntityKind string
+
+const (
+	EntityKindMember EntityKind = "member"
+	EntityKindPlace  EntityKind = "place"
+	EntityKindPet    EntityKind = "pet"
+)
+
+// Valid reports whether the kind is a known kind
+func (k EntityKind) Valid() bool {
+	switch k {
+	case EntityKindMember, EntityKindPlace, EntityKindPet:
+		return true
+	default:
+		return false
+	}
+}
+
+// FamilyMember is the analyzer-side projection of a family member,
+// kept in sync by family.member_upserted and family.member_removed events
+type FamilyMember struct {
+	FamilyID  uuid.UUID `gorm:"column:family_id;type:uuid;primaryKey"`
+	UserID    uuid.UUID `gorm:"column:user_id;type:uuid;primaryKey"`
+	Name      string    `gorm:"column:name;type:varchar(255);not null"`
+	Nicknames []string  `gorm:"column:nicknames;serializer:json;type:jsonb;not null"`
+	// Timezone is the IANA time zone of the member, which dates their analyses
+	Timezone string    `gorm:"column:timezone;type:varchar(64);not null"`
+	SyncedAt time.Time `gorm:"column:synced_at;not null"`
+	// RemovedAt is set when the member left the family
+	RemovedAt *time.Time `gorm:"column:removed_at"`
+}
+
+// TableName specifies the table name
+func (FamilyMember) TableName() string {
+	return "family_members"
+}
+
+// FamilyEntity is a place or a pet maintained by the family, matched by its name and aliases
+type FamilyEntity struct {
+	ID        uuid.UUID  `gorm:"column:id;type:uuid;primaryKey;default:gen_random_uuid()"`
+	FamilyID  uuid.UUID  `gorm:"column:family_id;type:uuid;not null"`
+	Kind      EntityKind `gorm:"column:kind;type:varchar(20);not null"`
+	Name      string     `gorm:"column:name;type:varchar(100);not null"`
+	Aliases   []string   `gorm:"column:aliases;serializer:json;type:jsonb;not null"`
+	CreatedAt time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name
+func (FamilyEntity) TableName() string {
+	return "family_entities"
+}
+
+// DiaryEntityReference is a mention of a family member, place or pet in the diary content.
+// EntityID is the user ID of a member and the family entity ID of a place or a pet.
+// Offset and Length are in Unicode code points; Surface is the matched text.
+type DiaryEntityReference struct {
+	ID         uuid.UUID  `gorm:"column:id;type:uuid;primaryKey;default:gen_random_uuid()"`
+	AnalysisID uuid.UUID  `gorm:"column:analysis_id;type:uuid;not null"`
+	DiaryID    uuid.UUID  `gorm:"column:diary_id;type:uuid;not null"`
+	UserID     uuid.UUID  `gorm:"column:user_id;type:uuid;not null"`
+	FamilyID   uuid.UUID  `gorm:"column:family_id;type:uuid;not null"`
+	EntityKind EntityKind `gorm:"column:entity_kind;type:varchar(20);not null"`
+	EntityID   uuid.UUID  `gorm:"column:entity_id;type:uuid;not null"`
+	Surface    string     `gorm:"column:surface;type:varchar(100);not null"`
+	Offset     int        `gorm:"column:offset_chars;not null"`
+	Length     int        `gorm:"column:length_chars;not null"`
+	CreatedAt  time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name
+func (DiaryEntityReference) TableName() string {
+	return "diary_entity_references"
+}
+
+// EntityMention is an occurrence of a family entity in a text
+type EntityMention struct {
+	Kind     EntityKind
+	EntityID uuid.UUID
+	Surface  string
+	Offset   int
+	Length   int
+}
+
+// entityName is a name or a nickname of an entity
+type entityName struct {
+	kind     EntityKind
+	entityID uuid.UUID
+	name     []rune
+}
+
+// EntityLinker finds the members, places and pets of a family in diary texts
+type EntityLinker struct {
+	names []entityName
+}
+
+// NewEntityLinker creates an EntityLinker matching the names and nicknames of the members
+// and the names and aliases of the places and pets
+func NewEntityLinker(members []*FamilyMember, entities []*FamilyEntity) *EntityLinker {
+	l := &EntityLinker{}
+	for _, m := range members {
+		l.add(EntityKindMember, m.UserID, m.Name)
+		for _, n := range m.Nicknames {
+			l.add(EntityKindMember, m.UserID, n)
+		}
+	}
+	for _, e := range entities {
+		l.add(e.Kind, e.ID, e.Name)
+		for _, a := range e.Aliases {
+			l.add(e.Kind, e.ID, a)
+		}
+	}
+
+	// 長い名前を優先し、「ケン」が「ケンタ」を隠さないようにする
+	sort.SliceStable(l.names, func(i, j int) bool {
+		return len(l.names[i].name) > len(l.names[j].name)
+	})
+	return l
+}
+
+func (l *EntityLinker) add(kind EntityKind, entityID uuid.UUID, name string) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return
+	}
+	l.names = append(l.names, entityName{kind: kind, entityID: entityID, name: []rune(name)})
+}
+
+// Link returns the mentions in text, in order of appearance and without overlaps.
+// Names are matched case-insensitively, preferring the longest name at each position.
+// Japanese text continues right before and after a name ("昨日おばあちゃんと"), so only the ASCII edges
+// of a name require a word boundary, and "Ken" is not found in "Kenta".
+func (l *EntityLinker) Link(text string) []EntityMention {
+	if len(l.names) == 0 {
+		return nil
+	}
+
+	runes := []rune(text)
+	var mentions []EntityMention
+	for i := 0; i < len(runes); i++ {
+		for _, n := range l.names {
+			end := i + len(n.name)
+			if end > len(runes) || !strings.EqualFold(string(runes[i:end]), string(n.name)) {
+				continue
+			}
+			if i > 0 && isASCIIWordRune(n.name[0]) && isASCIIWordRune(runes[i-1]) {
+				continue
+			}
+			if end < len(runes) && isASCIIWordRune(n.name[len(n.name)-1]) && isASCIIWordRune(runes[end]) {
+				continue
+			}
+
+			mentions = append(mentions, EntityMention{
+				Kind:     n.kind,
+				EntityID: n.entityID,
+				Surface:  string(runes[i:end]),
+				Offset:   i,
+				Length:   len(n.name),
+			})
+			i = end - 1
+			break
+		}
+	}
+	return mentions
+}
+
+func isASCIIWordRune(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_')
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntityLinker_Link(t *testing.T) {
+	grandpa := uuid.New()
+	ken := uuid.New()
+	kenta := uuid.New()
+	park := uuid.New()
+	pochi := uuid.New()
+
+	members := []*FamilyMember{
+		{UserID: grandpa, Name: "山田一郎", Nicknames: []string{"おじいちゃん", "じいじ"}},
+		{UserID: ken, Name: "Ken"},
+		{UserID: kenta, Name: "Kenta", Nicknames: []string{" ", ""}},
+	}
+	entities := []*FamilyEntity{
+		{ID: park, Kind: EntityKindPlace, Name: "中央公園", Aliases: []string{"公園"}},
+		{ID: pochi, Kind: EntityKindPet, Name: "ポチ"},
+	}
+	linker := NewEntityLinker(members, entities)
+
+	tests := []struct {
+		name string
+		text string
+		want []EntityMention
+	}{
+		{
+			name: "nickname inside Japanese text",
+			text: "今日はおじいちゃんとポチの散歩に行った。",
+			want: []EntityMention{
+				{Kind: EntityKindMember, EntityID: grandpa, Surface: "おじいちゃん", Offset: 3, Length: 6},
+				{Kind: EntityKindPet, EntityID: pochi, Surface: "ポチ", Offset: 10, Length: 2},
+			},
+		},
+		{
+			name: "longest name wins",
+			text: "中央公園で遊んだ。公園は広い。",
+			want: []EntityMention{
+				{Kind: EntityKindPlace, EntityID: park, Surface: "中央公園", Offset: 0, Length: 4},
+				{Kind: EntityKindPlace, EntityID: park, Surface: "公園", Offset: 9, Length: 2},
+			},
+		},
+		{
+			name: "ASCII names need word boundaries and ignore case",
+			text: "kenta and KEN played. Kendo is not a name.",
+			want: []EntityMention{
+				{Kind: EntityKindMember, EntityID: kenta, Surface: "kenta", Offset: 0, Length: 5},
+				{Kind: EntityKindMember, EntityID: ken, Surface: "KEN", Offset: 10, Length: 3},
+			},
+		},
+		{
+			name: "ASCII name next to Japanese",
+			text: "Kenと遊んだ",
+			want: []EntityMention{
+				{Kind: EntityKindMember, EntityID: ken, Surface: "Ken", Offset: 0, Length: 3},
+			},
+		},
+		{
+			name: "no mention",
+			text: "今日は雨だった。",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, linker.Link(tt.text))
+		})
+	}
+}
+
+func TestEntityLinker_LinkWithoutNames(t *testing.T) {
+	assert.Nil(t, NewEntityLinker(nil, nil).Link("おじいちゃんと公園に行った。"))
+}
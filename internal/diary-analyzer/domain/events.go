@@ -15,9 +15,134 @@ type DiaryCreatedEvent struct {
 	Title              string    `json:"title"`
 	Content            string    `json:"content"`
 	WritingTimeSeconds int       `json:"writing_time_seconds"`
-	Timestamp          time.Time `json:"timestamp"`
+	// UnlockAt is set for a time capsule hidden from other members until that time
+	UnlockAt  *time.Time `json:"unlock_at,omitempty"`
+	Timestamp time.Time  `json:"timestamp"`
 }
 
 func (e *DiaryCreatedEvent) EventType() string {
 	return "diary.created"
 }
+
+// DiaryUpdatedEvent represents an event when a diary is edited
+type DiaryUpdatedEvent struct {
+	ID       string    `json:"id"`
+	DiaryID  uuid.UUID `json:"diary_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	FamilyID uuid.UUID `json:"family_id"`
+	EditedBy uuid.UUID `json:"edited_by"`
+	Title    string    `json:"title"`
+	Content  string    `json:"content"`
+	// UnlockAt is set for a time capsule hidden from other members until that time
+	UnlockAt  *time.Time `json:"unlock_at,omitempty"`
+	Timestamp time.Time  `json:"timestamp"`
+}
+
+func (e *DiaryUpdatedEvent) EventType() string {
+	return "diary.updated"
+}
+
+// DiaryAnalyzedEvent is published when the analysis of a new or updated diary is stored, with its metrics.
+// The analysis of an updated diary keeps its analysis_id. Re-analyses by the backfill are not announced.
+// Enrichments may still be pending or failed; their status tells whether the score and keywords are ready.
+type DiaryAnalyzedEvent struct {
+	ID                 string    `json:"id"`
+	AnalysisID         uuid.UUID `json:"analysis_id"`
+	DiaryID            uuid.UUID `json:"diary_id"`
+	UserID             uuid.UUID `json:"user_id"`
+	FamilyID           uuid.UUID `json:"family_id"`
+	CharCount          int       `json:"char_count"`
+	SentenceCount      int       `json:"sentence_count"`
+	WritingTimeSeconds int       `json:"writing_time_seconds"`
+	Language           string    `json:"language"`
+	AccuracyScore      *int      `json:"accuracy_score"`
+	AccuracyStatus     string    `json:"accuracy_status"`
+	KeywordsStatus     string    `json:"keywords_status"`
+	SentimentScore     *float64  `json:"sentiment_score"`
+	// UnlockAt is set for a time capsule; consumers must not show the metrics to other members before it
+	UnlockAt  *time.Time `json:"unlock_at,omitempty"`
+	Timestamp time.Time  `json:"timestamp"`
+}
+
+func (e *DiaryAnalyzedEvent) EventType() string {
+	return "diary.analyzed"
+}
+
+// NewDiaryAnalyzedEvent creates a new DiaryAnalyzedEvent from the stored analysis
+func NewDiaryAnalyzedEvent(analysis *DiaryAnalysis) *DiaryAnalyzedEvent {
+	return &DiaryAnalyzedEvent{
+		ID:                 uuid.New().String(),
+		AnalysisID:         analysis.ID,
+		DiaryID:            analysis.DiaryID,
+		UserID:             analysis.UserID,
+		FamilyID:           analysis.FamilyID,
+		CharCount:          analysis.CharCount,
+		SentenceCount:      analysis.SentenceCount,
+		WritingTimeSeconds: analysis.WritingTimeSeconds,
+		Language:           analysis.Language,
+		AccuracyScore:      analysis.AccuracyScore,
+		AccuracyStatus:     analysis.AccuracyStatus,
+		KeywordsStatus:     analysis.KeywordsStatus,
+		SentimentScore:     analysis.SentimentScore,
+		UnlockAt:           analysis.UnlockAt,
+		Timestamp:          time.Now(),
+	}
+}
+
+// FamilyMemberUpsertedEvent is published by user-context when a user joins a family or changes the profile
+type FamilyMemberUpsertedEvent struct {
+	ID        string    `json:"id"`
+	FamilyID  uuid.UUID `json:"family_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name"`
+	Nicknames []string  `json:"nicknames"`
+	Role      string    `json:"role"`
+	// Timezone is the IANA time zone of the user; empty in events published before time zones were introduced
+	Timezone  string    `json:"timezone"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// FamilyMemberRemovedEvent is published by user-context when a member is removed from a family
+type FamilyMemberRemovedEvent struct {
+	ID        string    `json:"id"`
+	FamilyID  uuid.UUID `json:"family_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// GoalAchievedEvent is published when a member reaches a writing goal in a week or a month, once per goal, period and target.
+// PeriodStart and PeriodEnd are the first and the last local date (YYYY-MM-DD) of the period.
+type GoalAchievedEvent struct {
+	ID          string    `json:"id"`
+	GoalID      uuid.UUID `json:"goal_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	FamilyID    uuid.UUID `json:"family_id"`
+	Metric      string    `json:"metric"`
+	Period      string    `json:"period"`
+	Target      int       `json:"target"`
+	Value       int       `json:"value"`
+	PeriodStart string    `json:"period_start"`
+	PeriodEnd   string    `json:"period_end"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+func (e *GoalAchievedEvent) EventType() string {
+	return "goal.achieved"
+}
+
+// NewGoalAchievedEvent creates a new GoalAchievedEvent for the goal reached with the value in the period
+func NewGoalAchievedEvent(goal *Goal, value int, periodStart, periodEnd time.Time) *GoalAchievedEvent {
+	return &GoalAchievedEvent{
+		ID:          uuid.New().String(),
+		GoalID:      goal.ID,
+		UserID:      goal.UserID,
+		FamilyID:    goal.FamilyID,
+		Metric:      goal.Metric,
+		Period:      goal.Period,
+		Target:      goal.Target,
+		Value:       value,
+		PeriodStart: periodStart.Format("2006-01-02"),
+		PeriodEnd:   periodEnd.Format("2006-01-02"),
+		Timestamp:   time.Now(),
+	}
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/pkg/datetime"
+	"github.com/google/uuid"
+)
+
+// Goal metrics and periods, maintained by diary-analysis
+const (
+	GoalMetricPosts          = "posts"
+	GoalMetricCharacters     = "characters"
+	GoalMetricWritingMinutes = "writing_minutes"
+
+	GoalPeriodWeekly  = "weekly"
+	GoalPeriodMonthly = "monthly"
+)
+
+// Goal is a personal writing goal of a member: a target of a metric per week or month
+type Goal struct {
+	ID        uuid.UUID `gorm:"column:id;type:uuid;primaryKey"`
+	UserID    uuid.UUID `gorm:"column:user_id;type:uuid"`
+	FamilyID  uuid.UUID `gorm:"column:family_id;type:uuid"`
+	Metric    string    `gorm:"column:metric"`
+	Period    string    `gorm:"column:period"`
+	Target    int       `gorm:"column:target"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+// TableName specifies the table name
+func (Goal) TableName() string {
+	return "writing_goals"
+}
+
+// GoalPeriodRange returns the first and the last local date of the week (Monday to Sunday) or the month containing the date
+func GoalPeriodRange(period string, localDate time.Time) (time.Time, time.Time) {
+	start, end := datetime.GetWeekRange(localDate)
+	if period == GoalPeriodMonthly {
+		start, end = datetime.GetMonthRange(localDate)
+	}
+	return start, time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+}
+
+// GoalTotals are the totals of a member over the local dates of a goal period
+type GoalTotals struct {
+	Posts              int
+	CharCount          int
+	WritingTimeSeconds int
+}
+
+// Value returns the progress of the goal metric; writing minutes are rounded down
+func (t *GoalTotals) Value(metric string) int {
+	switch metric {
+	case GoalMetricPosts:
+		return t.Posts
+	case GoalMetricCharacters:
+		return t.CharCount
+	case GoalMetricWritingMinutes:
+		return t.WritingTimeSeconds / 60
+	default:
+		return 0
+	}
+}
+
+// GoalCheck is a check of the goals of a member for a local date that failed, retried by the scheduler
+// apart from the diary event that triggered it
+type GoalCheck struct {
+	FamilyID    uuid.UUID `gorm:"column:family_id;type:uuid;primaryKey"`
+	UserID      uuid.UUID `gorm:"column:user_id;type:uuid;primaryKey"`
+	LocalDate   time.Time `gorm:"column:local_date;type:date;primaryKey"`
+	RetryCount  int       `gorm:"column:retry_count"`
+	NextRetryAt time.Time `gorm:"column:next_retry_at"`
+}
+
+// TableName specifies the table name
+func (GoalCheck) TableName() string {
+	return "writing_goal_checks"
+}
+
+// ScheduleRetry sets when the check is retried after RetryCount retries, with the backoff of the failed enrichments.
+// It returns false when the retries are exhausted.
+func (c *GoalCheck) ScheduleRetry(now time.Time) bool {
+	if c.RetryCount >= MaxAnalysisRetries {
+		return false
+	}
+	c.NextRetryAt = now.Add(AnalysisRetryBaseDelay << c.RetryCount)
+	return true
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoalPeriodRange(t *testing.T) {
+	day := func(s string) time.Time {
+		v, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name      string
+		period    string
+		localDate string
+		wantStart string
+		wantEnd   string
+	}{
+		{"weekly on a Wednesday", GoalPeriodWeekly, "2026-01-14", "2026-01-12", "2026-01-18"},
+		{"weekly on a Sunday", GoalPeriodWeekly, "2026-01-18", "2026-01-12", "2026-01-18"},
+		{"weekly across months", GoalPeriodWeekly, "2026-02-01", "2026-01-26", "2026-02-01"},
+		{"monthly", GoalPeriodMonthly, "2026-02-14", "2026-02-01", "2026-02-28"},
+		{"monthly on the last day", GoalPeriodMonthly, "2026-12-31", "2026-12-01", "2026-12-31"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := GoalPeriodRange(tt.period, day(tt.localDate))
+			assert.Equal(t, day(tt.wantStart), start)
+			assert.Equal(t, day(tt.wantEnd), end)
+		})
+	}
+}
+
+func TestGoalTotalsValue(t *testing.T) {
+	totals := &GoalTotals{Posts: 3, CharCount: 1200, WritingTimeSeconds: 1799}
+
+	assert.Equal(t, 3, totals.Value(GoalMetricPosts))
+	assert.Equal(t, 1200, totals.Value(GoalMetricCharacters))
+	// 29 minutes 59 seconds
+	assert.Equal(t, 29, totals.Value(GoalMetricWritingMinutes))
+	assert.Equal(t, 0, totals.Value("unknown"))
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"sort"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// MaxKeywordsPerDiary is the number of keywords stored per diary
+const MaxKeywordsPerDiary = 10
+
+// DiaryKeyword is a noun or proper noun frequently used in a diary
+type DiaryKeyword struct {
+	ID         uuid.UUID `gorm:"column:id;type:uuid;primaryKey;default:gen_random_uuid()"`
+	AnalysisID uuid.UUID `gorm:"column:analysis_id;type:uuid;not null"`
+	DiaryID    uuid.UUID `gorm:"column:diary_id;type:uuid;not null"`
+	UserID     uuid.UUID `gorm:"column:user_id;type:uuid;not null"`
+	FamilyID   uuid.UUID `gorm:"column:family_id;type:uuid;not null"`
+	Word       string    `gorm:"column:word;type:varchar(100);not null"`
+	ProperNoun bool      `gorm:"column:proper_noun;not null"`
+	Count      int       `gorm:"column:count;type:integer;not null"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+// Morpheme is a token of morphological analysis. POS is the IPA part of speech hierarchy.
+type Morpheme struct {
+	Surface string
+	POS     []string
+}
+
+// 日記に頻出するが話題を表さない語
+var keywordStopWords = map[string]bool{
+	"今日": true, "昨日": true, "明日": true, "今朝": true, "今夜": true, "午前": true, "午後": true,
+	"時間": true, "自分": true, "みんな": true, "感じ": true, "気持ち": true, "一日": true,
+}
+
+// KeywordExtractor extracts keywords from morphemes
+type KeywordExtractor struct{}
+
+// NewKeywordExtractor creates a new KeywordExtractor
+func NewKeywordExtractor() *KeywordExtractor {
+	return &KeywordExtractor{}
+}
+
+// Extract returns up to limit nouns ordered by frequency, then by first appearance.
+// Consecutive nouns are joined into a compound (運動+会 → 運動会).
+func (e *KeywordExtractor) Extract(morphemes []Morpheme, limit int) []*DiaryKeyword {
+	var keywords []*DiaryKeyword
+	byWord := make(map[string]*DiaryKeyword)
+
+	add := func(word string, proper bool) {
+		if !isKeyword(word) {
+			return
+		}
+		if k, ok := byWord[word]; ok {
+			k.Count++
+			return
+		}
+		k := &DiaryKeyword{Word: word, ProperNoun: proper, Count: 1}
+		byWord[word] = k
+		keywords = append(keywords, k)
+	}
+
+	compound, proper := "", false
+	for _, m := range morphemes {
+		if isCompoundPart(m, compound != "") {
+			compound += m.Surface
+			proper = proper || isProperNoun(m)
+			continue
+		}
+		add(compound, proper)
+		compound, proper = "", false
+		if isCompoundPart(m, false) {
+			compound, proper = m.Surface, isProperNoun(m)
+		}
+	}
+	add(compound, proper)
+
+	sort.SliceStable(keywords, func(i, j int) bool {
+		return keywords[i].Count > keywords[j].Count
+	})
+	if len(keywords) > limit {
+		keywords = keywords[:limit]
+	}
+	return keywords
+}
+
+func isCompoundPart(m Morpheme, continuing bool) bool {
+	if len(m.POS) < 2 || m.POS[0] != "名詞" {
+		return false
+	}
+	switch m.POS[1] {
+	case "一般", "固有名詞", "サ変接続":
+		return true
+	case "接尾":
+		return continuing
+	default:
+		return false
+	}
+}
+
+func isProperNoun(m Morpheme) bool {
+	return len(m.POS) >= 2 && m.POS[1] == "固有名詞"
+}
+
+func isKeyword(word string) bool {
+	if word == "" || keywordStopWords[word] || len([]rune(word)) > 100 {
+		return false
+	}
+	runes := []rune(word)
+	// 1文字の語は漢字のみ（猫、海）
+	if len(runes) == 1 {
+		return unicode.Is(unicode.Han, runes[0])
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeywordExtractorExtract(t *testing.T) {
+	noun := func(surface string, sub string) Morpheme {
+		return Morpheme{Surface: surface, POS: []string{"名詞", sub}}
+	}
+	particle := func(surface string) Morpheme {
+		return Morpheme{Surface: surface, POS: []string{"助詞", "格助詞"}}
+	}
+
+	morphemes := []Morpheme{
+		noun("今日", "副詞可能"), particle("は"),
+		noun("運動", "サ変接続"), noun("会", "接尾"), particle("で"),
+		noun("花子", "固有名詞"), particle("と"), noun("猫", "一般"), particle("を"),
+		noun("見", "一般"), particle("た"),
+		noun("運動", "サ変接続"), noun("会", "接尾"), particle("の"),
+		noun("あと", "非自立"), noun("花子", "固有名詞"), particle("と"), noun("時間", "一般"),
+		particle("ね"), noun("ね", "一般"),
+	}
+
+	keywords := NewKeywordExtractor().Extract(morphemes, 10)
+
+	words := make([]string, len(keywords))
+	for i, k := range keywords {
+		words[i] = k.Word
+	}
+	assert.Equal(t, []string{"運動会", "花子", "猫", "見"}, words)
+	assert.Equal(t, 2, keywords[0].Count)
+	assert.False(t, keywords[0].ProperNoun)
+	assert.True(t, keywords[1].ProperNoun)
+}
+
+func TestKeywordExtractorExtractLimit(t *testing.T) {
+	morphemes := []Morpheme{
+		{Surface: "公園", POS: []string{"名詞", "一般"}},
+		{Surface: "と", POS: []string{"助詞", "並立助詞"}},
+		{Surface: "海", POS: []string{"名詞", "一般"}},
+	}
+
+	keywords := NewKeywordExtractor().Extract(morphemes, 1)
+
+	assert.Len(t, keywords, 1)
+	assert.Equal(t, "公園", keywords[0].Word)
+}
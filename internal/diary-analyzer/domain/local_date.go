@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"log/slog"
+	"time"
+)
+
+// DefaultTimezone is the time zone of members whose time zone is unknown (not synced from user-context yet)
+const DefaultTimezone = "Asia/Tokyo"
+
+// LocalDate returns the date of t in the IANA time zone, at midnight UTC like a DATE column.
+// The daily rollups bucket the analyses on it, so a diary belongs to the day its author wrote it.
+// An unknown time zone falls back to DefaultTimezone.
+func LocalDate(t time.Time, timezone string) time.Time {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" {
+		if timezone != "" {
+			slog.Warn("unknown time zone, using the default", "timezone", timezone, "default", DefaultTimezone)
+		}
+		loc, err = time.LoadLocation(DefaultTimezone)
+		if err != nil {
+			loc = time.FixedZone(DefaultTimezone, 9*60*60)
+		}
+	}
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
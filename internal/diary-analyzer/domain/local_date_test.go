@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalDate(t *testing.T) {
+	at := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name     string
+		at       string
+		timezone string
+		want     string
+	}{
+		// 08:00 JST is still the previous day in UTC
+		{"morning in Tokyo", "2026-01-19T23:00:00Z", "Asia/Tokyo", "2026-01-20"},
+		{"just before midnight in Tokyo", "2026-01-20T14:59:59Z", "Asia/Tokyo", "2026-01-20"},
+		{"midnight in Tokyo", "2026-01-20T15:00:00Z", "Asia/Tokyo", "2026-01-21"},
+
+		// the same instant is on three dates around the date line
+		{"date line west (UTC+14)", "2026-01-20T10:30:00Z", "Pacific/Kiritimati", "2026-01-21"},
+		{"date line east (UTC-11)", "2026-01-20T10:30:00Z", "Pacific/Pago_Pago", "2026-01-19"},
+		{"UTC", "2026-01-20T10:30:00Z", "UTC", "2026-01-20"},
+
+		// New York springs forward on 2026-03-08 (EST UTC-5 to EDT UTC-4)
+		{"before spring forward, late evening", "2026-03-08T04:30:00Z", "America/New_York", "2026-03-07"},
+		{"spring forward night", "2026-03-08T06:59:59Z", "America/New_York", "2026-03-08"},
+		{"after spring forward, late evening", "2026-03-09T03:30:00Z", "America/New_York", "2026-03-08"},
+		{"after spring forward, just after midnight", "2026-03-09T04:30:00Z", "America/New_York", "2026-03-09"},
+
+		// and falls back on 2026-11-01 (EDT UTC-4 to EST UTC-5)
+		{"before fall back, just after midnight", "2026-11-01T04:30:00Z", "America/New_York", "2026-11-01"},
+		{"after fall back, late evening", "2026-11-02T04:30:00Z", "America/New_York", "2026-11-01"},
+		{"after fall back, just after midnight", "2026-11-02T05:30:00Z", "America/New_York", "2026-11-02"},
+
+		// Sydney is ahead of UTC and leaves DST on 2026-04-05 (AEDT UTC+11 to AEST UTC+10)
+		{"Sydney during DST", "2026-04-04T13:30:00Z", "Australia/Sydney", "2026-04-05"},
+		{"Sydney after DST", "2026-04-05T13:30:00Z", "Australia/Sydney", "2026-04-05"},
+
+		{"unknown time zone", "2026-01-19T23:00:00Z", "Mars/Olympus", "2026-01-20"},
+		{"no time zone", "2026-01-19T23:00:00Z", "", "2026-01-20"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LocalDate(at(tt.at), tt.timezone)
+			assert.Equal(t, tt.want, got.Format("2006-01-02"))
+			assert.Equal(t, time.UTC, got.Location())
+			assert.Zero(t, got.Hour())
+		})
+	}
+}
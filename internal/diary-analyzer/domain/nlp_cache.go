@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// NLPCacheEntry is the result of an NLP provider for a text, keyed by the SHA-256 of the text
+// so that identical content is never sent to the provider twice.
+// RulesVersion keeps the results of the rules of the provider before and after a change apart.
+type NLPCacheEntry struct {
+	Provider     string          `gorm:"column:provider;type:varchar(20);primaryKey"`
+	RulesVersion string          `gorm:"column:rules_version;type:varchar(20);primaryKey"`
+	ContentHash  string          `gorm:"column:content_hash;type:char(64);primaryKey"`
+	Suggestions  json.RawMessage `gorm:"column:suggestions;type:jsonb"`
+	CreatedAt    time.Time       `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name
+func (NLPCacheEntry) TableName() string {
+	return "nlp_accuracy_cache"
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// ReadabilityMetrics are the writing style metrics of a diary.
+// The script ratios are shares of the letters (kanji, kana, latin letters and digits), excluding spaces and punctuation.
+// They are nil for English diaries, which have no kanji or kana to measure.
+// Sentence lengths are in characters in every language, so that diaries can be compared.
+type ReadabilityMetrics struct {
+	Language              string
+	KanjiRatio            *float64
+	HiraganaRatio         *float64
+	KatakanaRatio         *float64
+	AverageSentenceLength float64
+	LongestSentenceLength int
+}
+
+// ReadabilityAnalyzer computes the readability metrics of Japanese and English text
+type ReadabilityAnalyzer struct{}
+
+// NewReadabilityAnalyzer creates a new ReadabilityAnalyzer
+func NewReadabilityAnalyzer() *ReadabilityAnalyzer {
+	return &ReadabilityAnalyzer{}
+}
+
+// Analyze computes the metrics of the text split into sentences. Ratios are rounded to 3 decimals.
+func (a *ReadabilityAnalyzer) Analyze(text string, sentences []Sentence) ReadabilityMetrics {
+	metrics := ReadabilityMetrics{Language: DetectLanguage(sentences)}
+
+	letters, kanji, hiragana, katakana := 0, 0, 0, 0
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			kanji++
+		case unicode.Is(unicode.Hiragana, r):
+			hiragana++
+		// 長音符「ー」は Common に分類されるためカタカナとして数える
+		case unicode.Is(unicode.Katakana, r) || r == 'ー':
+			katakana++
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+		default:
+			continue
+		}
+		letters++
+	}
+	if letters > 0 && metrics.Language != LanguageEnglish {
+		metrics.KanjiRatio = ratio(kanji, letters)
+		metrics.HiraganaRatio = ratio(hiragana, letters)
+		metrics.KatakanaRatio = ratio(katakana, letters)
+	}
+
+	total := 0
+	for _, s := range sentences {
+		total += s.Length
+		if s.Length > metrics.LongestSentenceLength {
+			metrics.LongestSentenceLength = s.Length
+		}
+	}
+	if len(sentences) > 0 {
+		metrics.AverageSentenceLength = round3(float64(total) / float64(len(sentences)))
+	}
+
+	return metrics
+}
+
+// TypeTokenRatio is the number of distinct words divided by the number of words, a measure of vocabulary richness.
+// Symbols and whitespace are not words. It returns nil when there is no word.
+// The ratio decreases as the text gets longer, so compare diaries of similar length.
+func TypeTokenRatio(morphemes []Morpheme) *float64 {
+	types := make(map[string]bool)
+	tokens := 0
+	for _, m := range morphemes {
+		surface := strings.TrimSpace(m.Surface)
+		if surface == "" || (len(m.POS) > 0 && m.POS[0] == "記号") {
+			continue
+		}
+		types[surface] = true
+		tokens++
+	}
+	if tokens == 0 {
+		return nil
+	}
+	return ratio(len(types), tokens)
+}
+
+func ratio(n, total int) *float64 {
+	r := round3(float64(n) / float64(total))
+	return &r
+}
+
+func round3(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
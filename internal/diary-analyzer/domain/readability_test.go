@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadabilityAnalyzerAnalyze(t *testing.T) {
+	// 漢字: 今日 運動会 走 (6) / ひらがな: はでりました (6) / カタカナ: ゴール (3)
+	metrics := analyzeReadability("今日は運動会で走りました。ゴール！")
+
+	assert.Equal(t, LanguageJapanese, metrics.Language)
+	assert.Equal(t, 0.4, *metrics.KanjiRatio)
+	assert.Equal(t, 0.4, *metrics.HiraganaRatio)
+	assert.Equal(t, 0.2, *metrics.KatakanaRatio)
+	assert.Equal(t, 13, metrics.LongestSentenceLength)
+	assert.Equal(t, 8.5, metrics.AverageSentenceLength)
+}
+
+func TestReadabilityAnalyzerAnalyzeSentences(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		longest int
+		average float64
+	}{
+		{"trailing sentence without terminator", "晴れ。楽しかった", 5, 4},
+		{"repeated terminators", "やった！！ すごい？", 5, 4.5},
+		{"line breaks", "朝ごはん\n\nパン", 4, 3},
+		{"empty", "", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := analyzeReadability(tt.text)
+			assert.Equal(t, tt.longest, metrics.LongestSentenceLength)
+			assert.Equal(t, tt.average, metrics.AverageSentenceLength)
+		})
+	}
+}
+
+func TestReadabilityAnalyzerAnalyzeNoLetters(t *testing.T) {
+	metrics := analyzeReadability("。。！")
+
+	assert.Nil(t, metrics.KanjiRatio)
+	assert.Nil(t, metrics.HiraganaRatio)
+	assert.Nil(t, metrics.KatakanaRatio)
+}
+
+func TestReadabilityAnalyzerAnalyzeEnglish(t *testing.T) {
+	metrics := analyzeReadability("I ran. We won the race!")
+
+	assert.Equal(t, LanguageEnglish, metrics.Language)
+	assert.Nil(t, metrics.KanjiRatio)
+	assert.Equal(t, 16, metrics.LongestSentenceLength)
+	assert.Equal(t, 11.0, metrics.AverageSentenceLength)
+}
+
+func analyzeReadability(text string) ReadabilityMetrics {
+	return NewReadabilityAnalyzer().Analyze(text, NewSentenceSegmenter().Segment(text))
+}
+
+func TestTypeTokenRatio(t *testing.T) {
+	morpheme := func(surface string, pos string) Morpheme {
+		return Morpheme{Surface: surface, POS: []string{pos}}
+	}
+
+	ratio := TypeTokenRatio([]Morpheme{
+		morpheme("猫", "名詞"), morpheme("と", "助詞"), morpheme("犬", "名詞"), morpheme("と", "助詞"),
+		morpheme("。", "記号"), morpheme(" ", "記号"),
+	})
+
+	require.NotNil(t, ratio)
+	assert.Equal(t, 0.75, *ratio)
+	assert.Nil(t, TypeTokenRatio([]Morpheme{morpheme("。", "記号")}))
+}
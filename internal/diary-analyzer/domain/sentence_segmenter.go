@@ -0,0 +1,266 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Languages of a diary. A diary is mixed when it has both Japanese and English sentences.
+const (
+	LanguageJapanese = "ja"
+	LanguageEnglish  = "en"
+	LanguageMixed    = "mixed"
+	// LanguageUnknown is a diary without letters, e.g. only emoji
+	LanguageUnknown = "und"
+)
+
+// Sentence is a sentence of a text. Offset and Length are in Unicode code points of the text,
+// excluding the whitespace around the sentence.
+// Language is ja for a sentence with kana or kanji, en for one with latin letters only, otherwise und.
+type Sentence struct {
+	Text     string
+	Offset   int
+	Length   int
+	Language string
+}
+
+// 英語の文末ピリオドとみなさない略語（小文字で比較）
+var sentenceAbbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true, "st": true, "jr": true, "sr": true,
+	"vs": true, "etc": true, "e.g": true, "i.e": true, "a.m": true, "p.m": true,
+}
+
+// SentenceSegmenter splits Japanese and English text into sentences
+type SentenceSegmenter struct{}
+
+// NewSentenceSegmenter creates a new SentenceSegmenter
+func NewSentenceSegmenter() *SentenceSegmenter {
+	return &SentenceSegmenter{}
+}
+
+// Segment splits the text into sentences.
+//   - 。．！？!? end a sentence; consecutive terminators and closing parentheses stay in the sentence (やった！！）
+//   - . ends a sentence unless it is a decimal point (3.5), part of a word (example.com) or follows an abbreviation (Mr.)
+//   - an ellipsis (… or ...) ends a sentence only at a line break or before a capitalized English word
+//   - terminators inside 「」『』 and "" do not end the sentence, but a closing 」 or 』 right after a terminator does,
+//     unless the quote is followed by a quotation particle (「ただいま！」と言った), and so does a closing " before a capitalized word
+//   - a line break always ends a sentence, and trailing text without a terminator is a sentence too
+func (s *SentenceSegmenter) Segment(text string) []Sentence {
+	var sentences []Sentence
+	for _, line := range splitLines(text) {
+		sentences = append(sentences, s.segmentLine(line.runes, line.offset)...)
+	}
+	return sentences
+}
+
+type textLine struct {
+	runes  []rune
+	offset int
+}
+
+func splitLines(text string) []textLine {
+	var lines []textLine
+	runes := []rune(text)
+	start := 0
+	for i, r := range runes {
+		if r == '\n' || r == '\r' {
+			lines = append(lines, textLine{runes: runes[start:i], offset: start})
+			start = i + 1
+		}
+	}
+	return append(lines, textLine{runes: runes[start:], offset: start})
+}
+
+func (s *SentenceSegmenter) segmentLine(runes []rune, offset int) []Sentence {
+	var sentences []Sentence
+	start := 0
+	emit := func(end int) {
+		if sentence, ok := newSentence(runes[start:end], offset+start); ok {
+			sentences = append(sentences, sentence)
+		}
+		start = end
+	}
+
+	// 閉じられていない括弧があると行全体が1文になるため、その場合は括弧を無視する
+	quotes := balancedQuotes(runes)
+	depth := 0
+	inDoubleQuote := false
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quotes && (r == '「' || r == '『'):
+			depth++
+			continue
+		case quotes && (r == '」' || r == '』'):
+			depth--
+			if depth == 0 && !inDoubleQuote && i > 0 && isStrongTerminator(runes[i-1]) && !isQuotationParticle(runes, i+1) {
+				emit(i + 1)
+			}
+			continue
+		case quotes && r == '"':
+			inDoubleQuote = !inDoubleQuote
+			// "Hi!" She left. は2文、"Hi!" she said. は1文
+			if !inDoubleQuote && depth == 0 && i > 0 && isTerminator(runes[i-1]) &&
+				(i+1 == len(runes) || unicode.IsSpace(runes[i+1]) && startsWithUpper(runes[i+1:])) {
+				emit(i + 1)
+			}
+			continue
+		case depth > 0 || inDoubleQuote:
+			continue
+		case !isTerminator(r):
+			continue
+		}
+
+		end := i + 1
+		strong := isStrongTerminator(r)
+		for end < len(runes) && (isTerminator(runes[end]) || isClosingBracket(runes[end])) {
+			strong = strong || isStrongTerminator(runes[end])
+			end++
+		}
+		if strong || endsWithPeriod(runes, start, i, end) {
+			emit(end)
+		}
+		i = end - 1
+	}
+	emit(len(runes))
+
+	return sentences
+}
+
+// endsWithPeriod reports whether the run of periods and ellipses runes[i:end] ends the sentence
+func endsWithPeriod(runes []rune, start, i, end int) bool {
+	if end == len(runes) {
+		return true
+	}
+	next := runes[end]
+	ellipsis := runes[i] == '…' || end-i > 1
+
+	if ellipsis {
+		// 「えっと…明日は」のような言いよどみは文の途中とみなす
+		return unicode.IsSpace(next) && startsWithUpper(runes[end:])
+	}
+	if unicode.IsDigit(next) && i > 0 && unicode.IsDigit(runes[i-1]) {
+		return false
+	}
+	if next < unicode.MaxASCII && (unicode.IsLetter(next) || unicode.IsDigit(next)) {
+		return false
+	}
+	return !sentenceAbbreviations[strings.ToLower(lastWord(runes[start:i]))]
+}
+
+// DetectLanguage detects the language of the text from the languages of its sentences
+func DetectLanguage(sentences []Sentence) string {
+	japanese, english := false, false
+	for _, s := range sentences {
+		switch s.Language {
+		case LanguageJapanese:
+			japanese = true
+		case LanguageEnglish:
+			english = true
+		}
+	}
+	switch {
+	case japanese && english:
+		return LanguageMixed
+	case japanese:
+		return LanguageJapanese
+	case english:
+		return LanguageEnglish
+	}
+	return LanguageUnknown
+}
+
+func newSentence(runes []rune, offset int) (Sentence, bool) {
+	lead := 0
+	for lead < len(runes) && unicode.IsSpace(runes[lead]) {
+		lead++
+	}
+	trail := len(runes)
+	for trail > lead && unicode.IsSpace(runes[trail-1]) {
+		trail--
+	}
+	if lead == trail {
+		return Sentence{}, false
+	}
+
+	trimmed := runes[lead:trail]
+	return Sentence{
+		Text:     string(trimmed),
+		Offset:   offset + lead,
+		Length:   len(trimmed),
+		Language: sentenceLanguage(trimmed),
+	}, true
+}
+
+func sentenceLanguage(runes []rune) string {
+	latin := false
+	for _, r := range runes {
+		if isJapaneseScript(r) {
+			return LanguageJapanese
+		}
+		if unicode.Is(unicode.Latin, r) {
+			latin = true
+		}
+	}
+	if latin {
+		return LanguageEnglish
+	}
+	return LanguageUnknown
+}
+
+func isJapaneseScript(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) || r == 'ー'
+}
+
+func isStrongTerminator(r rune) bool {
+	return r == '。' || r == '．' || r == '！' || r == '？' || r == '!' || r == '?'
+}
+
+func isTerminator(r rune) bool {
+	return isStrongTerminator(r) || r == '.' || r == '…'
+}
+
+func isClosingBracket(r rune) bool {
+	return r == ')' || r == '）'
+}
+
+// isQuotationParticle reports whether the quote closed before runes[i] is followed by と or って
+func isQuotationParticle(runes []rune, i int) bool {
+	return i < len(runes) && (runes[i] == 'と' || runes[i] == 'っ')
+}
+
+func balancedQuotes(runes []rune) bool {
+	depth, doubleQuotes := 0, 0
+	for _, r := range runes {
+		switch r {
+		case '「', '『':
+			depth++
+		case '」', '』':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		case '"':
+			doubleQuotes++
+		}
+	}
+	return depth == 0 && doubleQuotes%2 == 0
+}
+
+func startsWithUpper(runes []rune) bool {
+	for _, r := range runes {
+		if !unicode.IsSpace(r) {
+			return unicode.IsUpper(r)
+		}
+	}
+	return false
+}
+
+// lastWord returns the word before a period, including inner periods (e.g)
+func lastWord(runes []rune) string {
+	i := len(runes)
+	for i > 0 && (unicode.IsLetter(runes[i-1]) || runes[i-1] == '.') {
+		i--
+	}
+	return string(runes[i:])
+}
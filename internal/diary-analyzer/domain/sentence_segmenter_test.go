@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSentenceSegmenterSegment(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{"single sentence", "これは一つの文です。", []string{"これは一つの文です。"}},
+		{"multiple sentences", "最初の文。次の文。三番目の文。", []string{"最初の文。", "次の文。", "三番目の文。"}},
+		{"with exclamation", "すごい！素晴らしい？", []string{"すごい！", "素晴らしい？"}},
+		{"trailing sentence without terminator", "句点がない", []string{"句点がない"}},
+		{"repeated terminators", "やった！！本当？！", []string{"やった！！", "本当？！"}},
+		{"closing parenthesis", "晴れた（うれしい。）次は雨。", []string{"晴れた（うれしい。）", "次は雨。"}},
+		{"line breaks", "朝ごはん\n\nパンを食べた", []string{"朝ごはん", "パンを食べた"}},
+		{"english", "I went to the park. It was fun! Did you go?", []string{"I went to the park.", "It was fun!", "Did you go?"}},
+		{"ascii terminators in japanese", "楽しかった.明日も行く!", []string{"楽しかった.", "明日も行く!"}},
+		{"decimal and abbreviation", "Mr. Tanaka ran 3.5 km. Then we ate.", []string{"Mr. Tanaka ran 3.5 km.", "Then we ate."}},
+		{"domain name", "I read example.com today.", []string{"I read example.com today."}},
+		{"japanese ellipsis", "えっと…明日は晴れるかな…", []string{"えっと…明日は晴れるかな…"}},
+		{"english ellipsis", "I waited... Then it rained... and stopped.", []string{"I waited...", "Then it rained... and stopped."}},
+		{"ellipsis and terminator", "どうしよう…。決めた。", []string{"どうしよう…。", "決めた。"}},
+		{"quoted dialogue", "母が「おかえり。ご飯できてるよ！」と言った。うれしかった。", []string{"母が「おかえり。ご飯できてるよ！」と言った。", "うれしかった。"}},
+		{"consecutive quotes", "「ただいま！」「おかえり！」", []string{"「ただいま！」", "「おかえり！」"}},
+		{"unbalanced quote", "「やった。うれしい。", []string{"「やった。", "うれしい。"}},
+		{"english quote", `"Hi!" she said. "Bye." Then she left.`, []string{`"Hi!" she said.`, `"Bye."`, "Then she left."}},
+		{"empty", " \n ", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var texts []string
+			for _, s := range NewSentenceSegmenter().Segment(tt.text) {
+				texts = append(texts, s.Text)
+			}
+			assert.Equal(t, tt.expected, texts)
+		})
+	}
+}
+
+func TestSentenceSegmenterSegmentOffsets(t *testing.T) {
+	sentences := NewSentenceSegmenter().Segment("晴れ。 Good day.\n楽しい")
+
+	assert.Equal(t, []Sentence{
+		{Text: "晴れ。", Offset: 0, Length: 3, Language: LanguageJapanese},
+		{Text: "Good day.", Offset: 4, Length: 9, Language: LanguageEnglish},
+		{Text: "楽しい", Offset: 14, Length: 3, Language: LanguageJapanese},
+	}, sentences)
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"japanese", "今日はiPhoneを買った。", LanguageJapanese},
+		{"english", "Today I bought a new bike.", LanguageEnglish},
+		{"mixed", "Today was fun. 明日も遊ぶ。", LanguageMixed},
+		{"unknown", "🙂🙂！", LanguageUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DetectLanguage(NewSentenceSegmenter().Segment(tt.text)))
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"bufio"
+	_ "embed"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+//go:embed sentiment_lexicon.tsv
+var sentimentLexiconTSV string
+
+// 否定表現（楽しくない、好きじゃなかった、嬉しくありません、頑張らず）
+var negations = []string{"ない", "なかっ", "なく", "ません", "ありません", "ず"}
+
+// maxInflectionBeforeNegation is the number of hiragana allowed between a word and the negation,
+// e.g. く in 楽し・く・ない and じゃ in 好き・じゃ・ない
+const maxInflectionBeforeNegation = 2
+
+// SentimentResult is the emotional tone of a text.
+// Score is the average polarity of the matched words in [-1, 1], or 0 when no word matched.
+type SentimentResult struct {
+	Score         float64
+	PositiveCount int
+	NegativeCount int
+}
+
+// SentimentAnalyzer scores the emotional tone of Japanese text with a bundled polarity lexicon
+type SentimentAnalyzer struct {
+	// entries by first rune, longest first
+	entries map[rune][]lexiconEntry
+}
+
+type lexiconEntry struct {
+	word     []rune
+	polarity float64
+}
+
+var (
+	defaultLexicon     map[rune][]lexiconEntry
+	defaultLexiconOnce sync.Once
+)
+
+// NewSentimentAnalyzer creates a new SentimentAnalyzer with the bundled lexicon
+func NewSentimentAnalyzer() *SentimentAnalyzer {
+	defaultLexiconOnce.Do(func() {
+		defaultLexicon = parseLexicon(sentimentLexiconTSV)
+	})
+	return &SentimentAnalyzer{entries: defaultLexicon}
+}
+
+// Analyze scores the text. Words are matched longest first, and the polarity of a word
+// followed by a negation in the same inflection (楽しくない) is reversed.
+func (a *SentimentAnalyzer) Analyze(text string) SentimentResult {
+	runes := []rune(text)
+
+	var result SentimentResult
+	var sum float64
+	matched := 0
+	for i := 0; i < len(runes); {
+		entry, ok := a.match(runes[i:])
+		if !ok {
+			i++
+			continue
+		}
+
+		end := i + len(entry.word)
+		polarity := entry.polarity
+		if isNegated(runes[end:]) {
+			polarity = -polarity
+		}
+
+		switch {
+		case polarity > 0:
+			result.PositiveCount++
+		case polarity < 0:
+			result.NegativeCount++
+		}
+		sum += polarity
+		matched++
+		i = end
+	}
+
+	if matched > 0 {
+		result.Score = sum / float64(matched)
+	}
+	return result
+}
+
+func (a *SentimentAnalyzer) match(runes []rune) (lexiconEntry, bool) {
+	for _, entry := range a.entries[runes[0]] {
+		if len(entry.word) <= len(runes) && string(runes[:len(entry.word)]) == string(entry.word) {
+			return entry, true
+		}
+	}
+	return lexiconEntry{}, false
+}
+
+// isNegated reports whether the inflection following a word is a negation
+func isNegated(rest []rune) bool {
+	n := 0
+	for n < len(rest) && unicode.Is(unicode.Hiragana, rest[n]) {
+		n++
+	}
+	tail := rest[:n]
+
+	for k := 0; k <= maxInflectionBeforeNegation && k < len(tail); k++ {
+		for _, neg := range negations {
+			if strings.HasPrefix(string(tail[k:]), neg) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parseLexicon(tsv string) map[rune][]lexiconEntry {
+	entries := make(map[rune][]lexiconEntry)
+
+	scanner := bufio.NewScanner(strings.NewReader(tsv))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 2 {
+			continue
+		}
+		polarity, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			continue
+		}
+		word := []rune(fields[0])
+		entries[word[0]] = append(entries[word[0]], lexiconEntry{word: word, polarity: polarity})
+	}
+
+	for _, list := range entries {
+		sort.SliceStable(list, func(i, j int) bool {
+			return len(list[i].word) > len(list[j].word)
+		})
+	}
+	return entries
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSentimentAnalyzerAnalyze(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		expectedSign  int
+		positiveCount int
+		negativeCount int
+	}{
+		{name: "No lexicon words - neutral", text: "今日は学校に行った。", expectedSign: 0},
+		{name: "Positive", text: "公園で遊んで楽しかった。", expectedSign: 1, positiveCount: 2},
+		{name: "Negative", text: "友達とけんかして悲しかった。", expectedSign: -1, positiveCount: 1, negativeCount: 2},
+		{name: "Negated positive", text: "運動会は楽しくなかった。", expectedSign: -1, negativeCount: 1},
+		{name: "Negated with じゃ", text: "にんじんは好きじゃない。", expectedSign: -1, negativeCount: 1},
+		{name: "Negated negative", text: "注射は怖くなかった。", expectedSign: 1, positiveCount: 1},
+		{name: "Longest word wins", text: "ママが大好き。", expectedSign: 1, positiveCount: 1},
+		// 〜ていた and いただきます are not 痛い
+		{name: "Past progressive - neutral", text: "ずっと日記を書いていた。", expectedSign: 0},
+		{name: "いただきます - neutral", text: "みんなでいただきますをした。", expectedSign: 0},
+		{name: "痛 is negative", text: "転んで足が痛かった。", expectedSign: -1, negativeCount: 1},
+		{name: "いやー is not いやだ", text: "いやー楽しかった。", expectedSign: 1, positiveCount: 1},
+		{name: "いやだ is negative", text: "歯医者はいやだ。", expectedSign: -1, negativeCount: 1},
+		{name: "かわいそう is not かわいい", text: "子犬がかわいそうだった。", expectedSign: 0},
+		{name: "かわいい is positive", text: "子犬がかわいかった。", expectedSign: 1, positiveCount: 1},
+	}
+
+	analyzer := NewSentimentAnalyzer()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := analyzer.Analyze(tt.text)
+
+			switch tt.expectedSign {
+			case 1:
+				assert.Greater(t, result.Score, 0.0)
+			case -1:
+				assert.Less(t, result.Score, 0.0)
+			default:
+				assert.Equal(t, 0.0, result.Score)
+			}
+			assert.Equal(t, tt.positiveCount, result.PositiveCount)
+			assert.Equal(t, tt.negativeCount, result.NegativeCount)
+			assert.GreaterOrEqual(t, result.Score, -1.0)
+			assert.LessOrEqual(t, result.Score, 1.0)
+		})
+	}
+}
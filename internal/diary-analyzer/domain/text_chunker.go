@@ -0,0 +1,47 @@
+package domain
+
+import "unicode/utf8"
+
+// TextChunk is a part of a text. Offset is in Unicode code points of the text.
+type TextChunk struct {
+	Text   string
+	Offset int
+}
+
+// SplitChunks splits the text into chunks of at most maxBytes UTF-8 bytes, cut at sentence boundaries.
+// A sentence longer than maxBytes is cut in the middle. The chunks cover the whole text, whitespace included,
+// so that offsets in a chunk plus its Offset are offsets in the text.
+func SplitChunks(text string, maxBytes int) []TextChunk {
+	if len(text) <= maxBytes || maxBytes < utf8.UTFMax {
+		return []TextChunk{{Text: text}}
+	}
+
+	runes := []rune(text)
+	sentences := NewSentenceSegmenter().Segment(text)
+
+	var chunks []TextChunk
+	start := 0
+	for start < len(runes) {
+		// the longest run of runes that fits
+		end, size := start, 0
+		for end < len(runes) && size+utf8.RuneLen(runes[end]) <= maxBytes {
+			size += utf8.RuneLen(runes[end])
+			end++
+		}
+
+		// back off to the end of the last sentence that fits
+		if end < len(runes) {
+			for i := len(sentences) - 1; i >= 0; i-- {
+				boundary := sentences[i].Offset + sentences[i].Length
+				if boundary <= end && boundary > start {
+					end = boundary
+					break
+				}
+			}
+		}
+
+		chunks = append(chunks, TextChunk{Text: string(runes[start:end]), Offset: start})
+		start = end
+	}
+	return chunks
+}
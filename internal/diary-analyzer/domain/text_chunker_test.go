@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitChunks(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxBytes int
+		want     []TextChunk
+	}{
+		{
+			name:     "short text is a single chunk",
+			text:     "今日は晴れ。",
+			maxBytes: 100,
+			want:     []TextChunk{{Text: "今日は晴れ。"}},
+		},
+		{
+			// 「今日は晴れ。」is 18 bytes
+			name:     "cut at sentence boundaries",
+			text:     "今日は晴れ。明日は雨。公園に行く。",
+			maxBytes: 36,
+			want: []TextChunk{
+				{Text: "今日は晴れ。明日は雨。", Offset: 0},
+				{Text: "公園に行く。", Offset: 11},
+			},
+		},
+		{
+			name:     "whitespace between sentences is kept",
+			text:     "It was sunny. We went out. It was fun.",
+			maxBytes: 20,
+			want: []TextChunk{
+				{Text: "It was sunny.", Offset: 0},
+				{Text: " We went out.", Offset: 13},
+				{Text: " It was fun.", Offset: 26},
+			},
+		},
+		{
+			name:     "long sentence is cut in the middle",
+			text:     "あいうえおかきくけこ。",
+			maxBytes: 15,
+			want: []TextChunk{
+				{Text: "あいうえお", Offset: 0},
+				{Text: "かきくけこ", Offset: 5},
+				{Text: "。", Offset: 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := SplitChunks(tt.text, tt.maxBytes)
+			assert.Equal(t, tt.want, chunks)
+
+			var joined strings.Builder
+			for _, c := range chunks {
+				assert.LessOrEqual(t, len(c.Text), tt.maxBytes)
+				joined.WriteString(c.Text)
+			}
+			assert.Equal(t, tt.text, joined.String())
+		})
+	}
+}
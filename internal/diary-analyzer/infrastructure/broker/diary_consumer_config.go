@@ -8,6 +8,6 @@ func DiaryConsumerConfig() consumer.Config {
 		ExchangeName: "diary.events",
 		ExchangeKind: "topic",
 		QueueName:    "diary-analyzer.analyze",
-		RoutingKeys:  []string{"diary.created"},
+		RoutingKeys:  []string{"diary.created", "diary.updated"},
 	}
 }
@@ -5,14 +5,22 @@ var Cfg *Config
 
 // Config struct
 type Config struct {
-	DB DBConfig
-	TestDB DBConfig
+	DB         DBConfig
+	TestDB     DBConfig
 	ThirdParty ThirdPartyConfig
+	Scheduler  SchedulerConfig
+	Scoring    ScoringConfig
+	Consumer   ConsumerConfig
+	Metrics    MetricsConfig
 }
 
 func Load() Config {
 	return Config{
-		DB:     loadDB(),
+		DB:         loadDB(),
 		ThirdParty: loadThirdParty(),
+		Scheduler:  loadScheduler(),
+		Scoring:    loadScoring(),
+		Consumer:   loadConsumer(),
+		Metrics:    loadMetrics(),
 	}
 }
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type ConsumerConfig struct {
+	// Concurrency is the number of diary events analyzed in parallel
+	Concurrency int
+	// Prefetch is the number of unacknowledged events delivered ahead (0 = Concurrency)
+	Prefetch int
+	// ShutdownTimeout bounds how long shutdown waits for in-flight analyses
+	ShutdownTimeout time.Duration
+}
+
+func loadConsumer() ConsumerConfig {
+	concurrency, err := strconv.Atoi(os.Getenv("CONSUMER_CONCURRENCY"))
+	if err != nil || concurrency <= 0 {
+		concurrency = 4 // Default: 4 workers
+	}
+
+	prefetch, err := strconv.Atoi(os.Getenv("CONSUMER_PREFETCH"))
+	if err != nil || prefetch < 0 {
+		prefetch = 0
+	}
+
+	timeoutSec, err := strconv.Atoi(os.Getenv("CONSUMER_SHUTDOWN_TIMEOUT"))
+	if err != nil || timeoutSec <= 0 {
+		timeoutSec = 30 // Default: 30 seconds
+	}
+
+	return ConsumerConfig{
+		Concurrency:     concurrency,
+		Prefetch:        prefetch,
+		ShutdownTimeout: time.Duration(timeoutSec) * time.Second,
+	}
+}
@@ -3,4 +3,7 @@ package config
 type DBConfig struct {
 	DatabaseURL     string
 	TestDatabaseURL string
+	// DiaryDatabaseURL is the diary database read by the backfill command and the retry of failed analyses.
+	// It is optional; events are analyzed from their own content.
+	DiaryDatabaseURL string
 }
@@ -1,16 +1,52 @@
 package config
 
-import "os"
+import (
+	"os"
+	"strconv"
+)
 
 func loadDB() DBConfig {
 	return DBConfig{
-		DatabaseURL:     os.Getenv("DATABASE_URL"),
-		TestDatabaseURL: os.Getenv("TEST_DATABASE_URL"),
+		DatabaseURL:      os.Getenv("DATABASE_URL"),
+		TestDatabaseURL:  os.Getenv("TEST_DATABASE_URL"),
+		DiaryDatabaseURL: os.Getenv("DIARY_DATABASE_URL"),
 	}
 }
 
 func loadThirdParty() ThirdPartyConfig {
+	appID := os.Getenv("YAHOO_NLP_APP_ID")
+
+	provider := os.Getenv("NLP_PROVIDER")
+	if provider == "" {
+		provider = NLPProviderLocal
+		if appID != "" {
+			provider = NLPProviderYahoo
+		}
+	}
+
 	return ThirdPartyConfig{
-		YahooNLPAppID: os.Getenv("YAHOO_NLP_APP_ID"),
+		NLPProvider:   provider,
+		YahooNLPAppID: appID,
+		NLPQuota:      loadNLPQuota(provider),
+	}
+}
+
+func loadNLPQuota(provider string) NLPQuotaConfig {
+	quota, err := strconv.Atoi(os.Getenv("NLP_DAILY_QUOTA"))
+	if err != nil || quota < 0 {
+		quota = 0 // Default: unlimited for the local provider
+		if provider == NLPProviderYahoo {
+			quota = 50000 // Default: the daily limit of a Yahoo! Client ID
+		}
+	}
+
+	burst, err := strconv.Atoi(os.Getenv("NLP_QUOTA_BURST"))
+	if err != nil || burst <= 0 {
+		burst = 100 // Default: 100 requests at once
+	}
+
+	return NLPQuotaConfig{
+		DailyQuota: quota,
+		Burst:      burst,
 	}
 }
@@ -0,0 +1,14 @@
+package config
+
+import "os"
+
+type MetricsConfig struct {
+	// Addr is the listen address of the expvar endpoint /debug/vars, e.g. :9090 (empty = disabled)
+	Addr string
+}
+
+func loadMetrics() MetricsConfig {
+	return MetricsConfig{
+		Addr: os.Getenv("METRICS_ADDR"),
+	}
+}
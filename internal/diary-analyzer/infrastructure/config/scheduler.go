@@ -0,0 +1,22 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type SchedulerConfig struct {
+	RetryInterval time.Duration
+}
+
+func loadScheduler() SchedulerConfig {
+	intervalSec, err := strconv.Atoi(os.Getenv("ANALYSIS_RETRY_INTERVAL"))
+	if err != nil || intervalSec <= 0 {
+		intervalSec = 60 // Default: 1 minute
+	}
+
+	return SchedulerConfig{
+		RetryInterval: time.Duration(intervalSec) * time.Second,
+	}
+}
@@ -0,0 +1,19 @@
+package config
+
+import "os"
+
+type ScoringConfig struct {
+	// AccuracyStrategy selects the accuracy scoring strategy: flat | per_100_chars | severity
+	AccuracyStrategy string
+}
+
+func loadScoring() ScoringConfig {
+	strategy := os.Getenv("ACCURACY_SCORING_STRATEGY")
+	if strategy == "" {
+		strategy = "flat" // Default: the legacy flat penalty
+	}
+
+	return ScoringConfig{
+		AccuracyStrategy: strategy,
+	}
+}
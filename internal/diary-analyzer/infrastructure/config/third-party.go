@@ -1,6 +1,24 @@
 package config
 
+// NLP providers selectable by NLP_PROVIDER
+const (
+	NLPProviderYahoo = "yahoo"
+	NLPProviderLocal = "local"
+)
+
 // ThirdPartyConfig holds configuration for third-party services
 type ThirdPartyConfig struct {
+	// NLPProvider selects the NLPGateway. Defaults to yahoo when YAHOO_NLP_APP_ID is set, otherwise local.
+	NLPProvider   string
 	YahooNLPAppID string
-}
\ No newline at end of file
+	NLPQuota      NLPQuotaConfig
+}
+
+// NLPQuotaConfig limits the requests to the NLP provider
+type NLPQuotaConfig struct {
+	// DailyQuota is the number of requests a day (0 = unlimited), shared by all analyzer processes through the database.
+	// The day resets at midnight JST.
+	DailyQuota int
+	// Burst is the number of requests a process can send at once
+	Burst int
+}
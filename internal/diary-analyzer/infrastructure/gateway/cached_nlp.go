@@ -0,0 +1,68 @@
+package gateway
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+)
+
+// SuggestionCache stores the suggestions of an NLP provider and version of its rules for texts by their SHA-256
+type SuggestionCache interface {
+	// Get returns the cached suggestions of the text, and false when there are none
+	Get(ctx context.Context, provider, rulesVersion, contentHash string) ([]Suggestion, bool, error)
+	Put(ctx context.Context, provider, rulesVersion, contentHash string, suggestions []Suggestion) error
+}
+
+// CachedNLPGateway serves the suggestions of texts checked before from the cache,
+// so that identical content is never sent to the NLP service twice.
+// Cache errors are logged and fall back to the NLP service.
+type CachedNLPGateway struct {
+	next         NLPGateway
+	cache        SuggestionCache
+	provider     string
+	rulesVersion string
+}
+
+// NewCachedNLPGateway creates a CachedNLPGateway. provider keeps the results of different NLP services apart,
+// and rulesVersion the results of their rules before and after a change (LocalRulesVersion, KouseiRulesVersion).
+func NewCachedNLPGateway(next NLPGateway, cache SuggestionCache, provider, rulesVersion string) *CachedNLPGateway {
+	return &CachedNLPGateway{
+		next:         next,
+		cache:        cache,
+		provider:     provider,
+		rulesVersion: rulesVersion,
+	}
+}
+
+// ContentHash is the hex SHA-256 of the text, the cache key
+func ContentHash(text string) string {
+	h := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(h[:])
+}
+
+func (g *CachedNLPGateway) CheckAccuracy(ctx context.Context, text string) ([]Suggestion, error) {
+	hash := ContentHash(text)
+
+	suggestions, ok, err := g.cache.Get(ctx, g.provider, g.rulesVersion, hash)
+	if err != nil {
+		nlpMetrics.Add("cache_errors", 1)
+		slog.Error("failed to read nlp cache", "provider", g.provider, "content_hash", hash, "error", err.Error())
+	} else if ok {
+		nlpMetrics.Add("cache_hits", 1)
+		slog.Info("nlp cache hit", "provider", g.provider, "content_hash", hash)
+		return suggestions, nil
+	}
+	nlpMetrics.Add("cache_misses", 1)
+
+	suggestions, err = g.next.CheckAccuracy(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.cache.Put(ctx, g.provider, g.rulesVersion, hash, suggestions); err != nil {
+		nlpMetrics.Add("cache_errors", 1)
+		slog.Error("failed to write nlp cache", "provider", g.provider, "content_hash", hash, "error", err.Error())
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,104 @@
+package gateway
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCache is an in-memory SuggestionCache
+type memoryCache struct {
+	mu      sync.Mutex
+	entries map[string][]Suggestion
+	getErr  error
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{entries: make(map[string][]Suggestion)}
+}
+
+func (c *memoryCache) Get(ctx context.Context, provider, rulesVersion, contentHash string) ([]Suggestion, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.getErr != nil {
+		return nil, false, c.getErr
+	}
+	s, ok := c.entries[provider+":"+rulesVersion+":"+contentHash]
+	return s, ok, nil
+}
+
+func (c *memoryCache) Put(ctx context.Context, provider, rulesVersion, contentHash string, suggestions []Suggestion) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[provider+":"+rulesVersion+":"+contentHash] = suggestions
+	return nil
+}
+
+func TestCachedNLPGateway_IdenticalContentSentOnce(t *testing.T) {
+	next := &countingGateway{}
+	g := NewCachedNLPGateway(next, newMemoryCache(), "yahoo", KouseiRulesVersion)
+
+	first, err := g.CheckAccuracy(context.Background(), "今日は晴れ。")
+	require.NoError(t, err)
+	second, err := g.CheckAccuracy(context.Background(), "今日は晴れ。")
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, next.calls)
+
+	_, err = g.CheckAccuracy(context.Background(), "今日は雨。")
+	require.NoError(t, err)
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestCachedNLPGateway_ProvidersKeptApart(t *testing.T) {
+	cache := newMemoryCache()
+	next := &countingGateway{}
+
+	NewCachedNLPGateway(next, cache, "yahoo", KouseiRulesVersion).CheckAccuracy(context.Background(), "今日は晴れ。")
+	NewCachedNLPGateway(next, cache, "local", LocalRulesVersion).CheckAccuracy(context.Background(), "今日は晴れ。")
+
+	assert.Equal(t, 2, next.calls)
+}
+
+// TestCachedNLPGateway_RulesVersionsKeptApart tests that suggestions of rules changed since are not served
+func TestCachedNLPGateway_RulesVersionsKeptApart(t *testing.T) {
+	cache := newMemoryCache()
+	next := &countingGateway{}
+
+	NewCachedNLPGateway(next, cache, "local", "1").CheckAccuracy(context.Background(), "今日は晴れ。")
+	NewCachedNLPGateway(next, cache, "local", "2").CheckAccuracy(context.Background(), "今日は晴れ。")
+	NewCachedNLPGateway(next, cache, "local", "2").CheckAccuracy(context.Background(), "今日は晴れ。")
+
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestCachedNLPGateway_ErrorsNotCached(t *testing.T) {
+	cache := newMemoryCache()
+	next := &countingGateway{err: assert.AnError}
+	g := NewCachedNLPGateway(next, cache, "yahoo", KouseiRulesVersion)
+
+	_, err := g.CheckAccuracy(context.Background(), "今日は晴れ。")
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Empty(t, cache.entries)
+}
+
+func TestCachedNLPGateway_CacheErrorFallsBack(t *testing.T) {
+	cache := newMemoryCache()
+	cache.getErr = assert.AnError
+	next := &countingGateway{}
+	g := NewCachedNLPGateway(next, cache, "yahoo", KouseiRulesVersion)
+
+	suggestions, err := g.CheckAccuracy(context.Background(), "今日は晴れ。")
+	require.NoError(t, err)
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, 1, next.calls)
+}
+
+func TestContentHash(t *testing.T) {
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", ContentHash(""))
+	assert.Len(t, ContentHash("今日は晴れ。"), 64)
+}
@@ -0,0 +1,90 @@
+package gateway
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/domain"
+)
+
+// ChunkedNLPGateway splits long texts at sentence boundaries into chunks the NLP service accepts,
+// checks them concurrently and merges the suggestions with offsets in the whole text.
+// Put it in front of CachedNLPGateway so that the chunks that succeeded are cached
+// and a retry after a partial failure only sends the chunks that failed.
+type ChunkedNLPGateway struct {
+	next        NLPGateway
+	maxBytes    int
+	concurrency int
+}
+
+// NewChunkedNLPGateway creates a ChunkedNLPGateway sending chunks of at most maxBytes,
+// at most concurrency at a time
+func NewChunkedNLPGateway(next NLPGateway, maxBytes, concurrency int) *ChunkedNLPGateway {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return &ChunkedNLPGateway{
+		next:        next,
+		maxBytes:    maxBytes,
+		concurrency: concurrency,
+	}
+}
+
+// CheckAccuracy checks every chunk of the text. When a chunk fails, the error of the first failed chunk is returned,
+// unless all failures are over quota: then a QuotaExceededError with the latest RetryAt is returned.
+func (g *ChunkedNLPGateway) CheckAccuracy(ctx context.Context, text string) ([]Suggestion, error) {
+	chunks := domain.SplitChunks(text, g.maxBytes)
+	if len(chunks) == 1 {
+		return g.next.CheckAccuracy(ctx, text)
+	}
+
+	results := make([][]Suggestion, len(chunks))
+	errs := make([]error, len(chunks))
+	sem := make(chan struct{}, g.concurrency)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], errs[i] = g.next.CheckAccuracy(ctx, chunk.Text)
+		}()
+	}
+	wg.Wait()
+
+	if err := mergeChunkErrors(errs); err != nil {
+		return nil, err
+	}
+
+	var suggestions []Suggestion
+	for i, chunk := range chunks {
+		for _, s := range results[i] {
+			s.Offset += chunk.Offset
+			suggestions = append(suggestions, s)
+		}
+	}
+	return suggestions, nil
+}
+
+// mergeChunkErrors prefers a real failure, retried with backoff, over a deferral
+func mergeChunkErrors(errs []error) error {
+	var retryAt time.Time
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		quotaErr, ok := AsQuotaExceeded(err)
+		if !ok {
+			return err
+		}
+		if quotaErr.RetryAt.After(retryAt) {
+			retryAt = quotaErr.RetryAt
+		}
+	}
+	if retryAt.IsZero() {
+		return nil
+	}
+	return &QuotaExceededError{RetryAt: retryAt}
+}
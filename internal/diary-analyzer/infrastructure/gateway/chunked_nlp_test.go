@@ -0,0 +1,125 @@
+package gateway
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chunkRecorder reports a suggestion at the start of every text and fails the texts in errs
+type chunkRecorder struct {
+	mu    sync.Mutex
+	texts []string
+	errs  map[string]error
+}
+
+func (g *chunkRecorder) CheckAccuracy(ctx context.Context, text string) ([]Suggestion, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.texts = append(g.texts, text)
+	if err := g.errs[text]; err != nil {
+		return nil, err
+	}
+	return []Suggestion{{Rule: "r", Offset: 0, Length: 1, Message: text}}, nil
+}
+
+// 「今日は晴れ。」「明日は雨。」「公園に行く。」 are 18, 15 and 18 bytes
+const chunkedText = "今日は晴れ。明日は雨。公園に行く。"
+
+func TestChunkedNLPGateway_MergesOffsets(t *testing.T) {
+	next := &chunkRecorder{}
+	g := NewChunkedNLPGateway(next, 20, 2)
+
+	suggestions, err := g.CheckAccuracy(context.Background(), chunkedText)
+
+	require.NoError(t, err)
+	assert.Len(t, next.texts, 3)
+	require.Len(t, suggestions, 3)
+	assert.Equal(t, 0, suggestions[0].Offset)
+	assert.Equal(t, 6, suggestions[1].Offset)
+	assert.Equal(t, 11, suggestions[2].Offset)
+	assert.Equal(t, "明日は雨。", suggestions[1].Message)
+}
+
+func TestChunkedNLPGateway_ShortTextSentAsIs(t *testing.T) {
+	next := &chunkRecorder{}
+	g := NewChunkedNLPGateway(next, 1024, 2)
+
+	_, err := g.CheckAccuracy(context.Background(), chunkedText)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{chunkedText}, next.texts)
+}
+
+func TestChunkedNLPGateway_PartialFailure(t *testing.T) {
+	retryAt := time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		errs      map[string]error
+		wantQuota bool
+	}{
+		{
+			name:      "a failure wins over a deferral",
+			errs:      map[string]error{"明日は雨。": &QuotaExceededError{RetryAt: retryAt}, "公園に行く。": assert.AnError},
+			wantQuota: false,
+		},
+		{
+			name:      "deferred until the latest retry",
+			errs:      map[string]error{"明日は雨。": &QuotaExceededError{RetryAt: retryAt}, "公園に行く。": &QuotaExceededError{RetryAt: retryAt.Add(time.Minute)}},
+			wantQuota: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewChunkedNLPGateway(&chunkRecorder{errs: tt.errs}, 20, 2)
+
+			suggestions, err := g.CheckAccuracy(context.Background(), chunkedText)
+
+			assert.Nil(t, suggestions)
+			quotaErr, ok := AsQuotaExceeded(err)
+			assert.Equal(t, tt.wantQuota, ok)
+			if tt.wantQuota {
+				assert.Equal(t, retryAt.Add(time.Minute), quotaErr.RetryAt)
+			} else {
+				assert.ErrorIs(t, err, assert.AnError)
+			}
+		})
+	}
+}
+
+func TestChunkedNLPGateway_RetryResendsFailedChunksOnly(t *testing.T) {
+	next := &chunkRecorder{errs: map[string]error{"公園に行く。": assert.AnError}}
+	g := NewChunkedNLPGateway(NewCachedNLPGateway(next, newMemoryCache(), "yahoo", KouseiRulesVersion), 20, 2)
+
+	_, err := g.CheckAccuracy(context.Background(), chunkedText)
+	require.Error(t, err)
+
+	next.errs = nil
+	next.texts = nil
+	suggestions, err := g.CheckAccuracy(context.Background(), chunkedText)
+
+	require.NoError(t, err)
+	assert.Len(t, suggestions, 3)
+	assert.Equal(t, []string{"公園に行く。"}, next.texts)
+}
+
+func TestChunkedNLPGateway_LongDiaryWithinKouseiLimit(t *testing.T) {
+	next := &chunkRecorder{}
+	g := NewChunkedNLPGateway(next, KouseiMaxChunkBytes, KouseiChunkConcurrency)
+
+	_, err := g.CheckAccuracy(context.Background(), strings.Repeat("今日は公園で遊んで楽しかった。", 300))
+
+	require.NoError(t, err)
+	assert.Greater(t, len(next.texts), 1)
+	for _, text := range next.texts {
+		assert.LessOrEqual(t, len(text), KouseiMaxChunkBytes)
+		assert.True(t, strings.HasSuffix(text, "。"), "expected chunk to end at a sentence boundary")
+	}
+}
@@ -0,0 +1,50 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// Tokenizer defines the interface for morphological analysis
+type Tokenizer interface {
+	// Tokenize splits the text into morphemes
+	Tokenize(ctx context.Context, text string) ([]Token, error)
+}
+
+// KagomeTokenizer is a pure-Go morphological analyzer with the bundled IPA dictionary
+type KagomeTokenizer struct {
+	t *tokenizer.Tokenizer
+}
+
+// NewKagomeTokenizer creates a new KagomeTokenizer. Loading the dictionary is expensive, so create it once.
+func NewKagomeTokenizer() (*KagomeTokenizer, error) {
+	t, err := tokenizer.New(ipa.Dict(), tokenizer.OmitBosEos())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kagome dictionary: %w", err)
+	}
+	return &KagomeTokenizer{t: t}, nil
+}
+
+// Tokenize returns the tokens of the text. POS is the comma-separated IPA part of speech (e.g. 名詞,固有名詞,地域,一般).
+func (k *KagomeTokenizer) Tokenize(ctx context.Context, text string) ([]Token, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tokens := k.t.Tokenize(text)
+	result := make([]Token, 0, len(tokens))
+	for _, t := range tokens {
+		reading, _ := t.Reading()
+		result = append(result, Token{
+			Surface: t.Surface,
+			ID:      t.ID,
+			Reading: reading,
+			POS:     strings.Join(t.POS(), ","),
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,25 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKagomeTokenizer_Tokenize(t *testing.T) {
+	k, err := NewKagomeTokenizer()
+	require.NoError(t, err)
+
+	tokens, err := k.Tokenize(context.Background(), "東京で寿司を食べた")
+	require.NoError(t, err)
+
+	surfaces := make([]string, len(tokens))
+	for i, token := range tokens {
+		surfaces[i] = token.Surface
+	}
+	assert.Equal(t, []string{"東京", "で", "寿司", "を", "食べ", "た"}, surfaces)
+	assert.Equal(t, "名詞,固有名詞,地域,一般", tokens[0].POS)
+	assert.Equal(t, "トウキョウ", tokens[0].Reading)
+}
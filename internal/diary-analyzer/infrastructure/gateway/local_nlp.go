@@ -0,0 +1,359 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Proofreading rules of LocalNLPGateway
+const (
+	RuleDoubledParticle = "doubled_particle"
+	RuleMixedStyle      = "mixed_style"
+	RuleRaNuki          = "ra_nuki"
+	RuleRepeatedWord    = "repeated_word"
+	RuleWidth           = "width_inconsistency"
+)
+
+// LocalRulesVersion identifies the rules of LocalNLPGateway in the NLP cache.
+// Bump it whenever a rule changes, so that suggestions of the previous rules are not served from the cache.
+const LocalRulesVersion = "2"
+
+// LocalNLPGateway is a dependency-free, rule-based Japanese proofreader.
+// It is meant for development, CI and self-hosting where the Yahoo API is not available.
+type LocalNLPGateway struct{}
+
+// NewLocalNLPGateway creates a new LocalNLPGateway
+func NewLocalNLPGateway() *LocalNLPGateway {
+	return &LocalNLPGateway{}
+}
+
+// CheckAccuracy returns the suggestions found (errors), like YahooNLPGateway
+func (g *LocalNLPGateway) CheckAccuracy(ctx context.Context, text string) ([]Suggestion, error) {
+	if text == "" {
+		return nil, fmt.Errorf("text is empty")
+	}
+	return g.Proofread(text), nil
+}
+
+// Proofread returns the suggestions ordered by rule
+func (g *LocalNLPGateway) Proofread(text string) []Suggestion {
+	runes := []rune(text)
+
+	suggestions := make([]Suggestion, 0)
+	suggestions = append(suggestions, checkDoubledParticles(runes)...)
+	suggestions = append(suggestions, checkMixedStyle(runes)...)
+	suggestions = append(suggestions, checkRaNuki(runes)...)
+	suggestions = append(suggestions, checkRepeatedWords(runes)...)
+	suggestions = append(suggestions, checkWidth(runes)...)
+	return suggestions
+}
+
+// は・と・の は「はは（母）」「とと」「のの」など正しい用法があるため対象外
+var doubledParticles = []string{"をを", "がが", "にに", "へへ", "でで"}
+
+func checkDoubledParticles(runes []rune) []Suggestion {
+	var suggestions []Suggestion
+	for _, p := range doubledParticles {
+		for _, offset := range indexAll(runes, []rune(p)) {
+			suggestions = append(suggestions, Suggestion{
+				Rule:       RuleDoubledParticle,
+				Offset:     offset,
+				Length:     2,
+				Message:    "助詞が重複しています",
+				Suggestion: string([]rune(p)[:1]),
+			})
+		}
+	}
+	return suggestions
+}
+
+var (
+	politeEndings = []string{"でした", "ました", "ません", "でしょう", "ましょう", "です", "ます"}
+	plainEndings  = []string{"だった", "である", "だろう", "だ"}
+)
+
+// checkMixedStyle reports sentences written in the minority style when です・ます and だ・である are mixed
+func checkMixedStyle(runes []rune) []Suggestion {
+	type sentence struct {
+		offset, length int
+	}
+	var polite, plain []sentence
+
+	start := 0
+	for i := 0; i <= len(runes); i++ {
+		if i < len(runes) && !isSentenceEnd(runes[i]) {
+			continue
+		}
+		body := strings.TrimRightFunc(string(runes[start:i]), func(r rune) bool {
+			return unicode.IsSpace(r) || r == '」' || r == '）' || r == ')'
+		})
+		s := sentence{offset: start, length: i - start}
+		switch {
+		case hasAnySuffix(body, politeEndings):
+			polite = append(polite, s)
+		case hasAnySuffix(body, plainEndings):
+			plain = append(plain, s)
+		}
+		start = i + 1
+	}
+
+	if len(polite) == 0 || len(plain) == 0 {
+		return nil
+	}
+
+	minority, message := plain, "です・ます調の文章に、だ・である調の文が混在しています"
+	if len(polite) < len(plain) {
+		minority, message = polite, "だ・である調の文章に、です・ます調の文が混在しています"
+	}
+
+	suggestions := make([]Suggestion, len(minority))
+	for i, s := range minority {
+		suggestions[i] = Suggestion{
+			Rule:    RuleMixedStyle,
+			Offset:  s.offset,
+			Length:  s.length,
+			Message: message,
+		}
+	}
+	return suggestions
+}
+
+// 一段活用・カ変動詞の可能形から「ら」が抜けたもの（見れる → 見られる）。
+// 「居れる」は五段の「居る（おる）」の可能形として正しいため含めない。
+var raNukiStems = []string{
+	"見", "来", "出", "寝", "着",
+	"食べ", "起き", "決め", "信じ", "覚え", "投げ", "借り", "降り", "考え", "逃げ", "助け", "受け", "教え", "調べ", "答え", "続け", "止め", "辞め",
+}
+
+func checkRaNuki(runes []rune) []Suggestion {
+	var suggestions []Suggestion
+	for _, stem := range raNukiStems {
+		pattern := []rune(stem + "れ")
+		for _, offset := range indexAll(runes, pattern) {
+			// 「見れば」「来れば」は仮定形なので正しい
+			next := offset + len(pattern)
+			if next >= len(runes) || !strings.ContainsRune("るなまたて", runes[next]) {
+				continue
+			}
+			// 「手助けれ」のような複合語の一部は対象外
+			if offset > 0 && isKanji(runes[offset-1]) {
+				continue
+			}
+			suggestions = append(suggestions, Suggestion{
+				Rule:       RuleRaNuki,
+				Offset:     offset,
+				Length:     len(pattern),
+				Message:    "ら抜き言葉です",
+				Suggestion: stem + "られ",
+			})
+		}
+	}
+	return suggestions
+}
+
+// checkRepeatedWords reports a word written twice in a row (今日今日, the the).
+// Words written only in hiragana and katakana are often repeated on purpose (いろいろ, ドキドキ, わーいわーい, よしよしよし),
+// so they are ignored.
+func checkRepeatedWords(runes []rune) []Suggestion {
+	var suggestions []Suggestion
+
+	for i := 0; i < len(runes); i++ {
+		for l := 8; l >= 2; l-- {
+			if i+2*l > len(runes) || string(runes[i:i+l]) != string(runes[i+l:i+2*l]) {
+				continue
+			}
+			word := runes[i : i+l]
+			if !isRepeatableWord(word) {
+				continue
+			}
+			// 単語の途中から始まる繰り返し（ASCII）は対象外
+			if isASCIIAlnum(word[0]) && ((i > 0 && isASCIIAlnum(runes[i-1])) || (i+2*l < len(runes) && isASCIIAlnum(runes[i+2*l]))) {
+				continue
+			}
+			suggestions = append(suggestions, Suggestion{
+				Rule:       RuleRepeatedWord,
+				Offset:     i,
+				Length:     2 * l,
+				Message:    "同じ語が繰り返されています",
+				Suggestion: string(word),
+			})
+			i += 2*l - 1
+			break
+		}
+	}
+
+	// 空白区切りの英単語（the the）
+	fields := asciiWords(runes)
+	for i := 1; i < len(fields); i++ {
+		prev, cur := fields[i-1], fields[i]
+		if !strings.EqualFold(prev.word, cur.word) || !onlySpacesBetween(runes, prev.end, cur.start) {
+			continue
+		}
+		suggestions = append(suggestions, Suggestion{
+			Rule:       RuleRepeatedWord,
+			Offset:     prev.start,
+			Length:     cur.end - prev.start,
+			Message:    "同じ語が繰り返されています",
+			Suggestion: prev.word,
+		})
+	}
+	return suggestions
+}
+
+func isRepeatableWord(word []rune) bool {
+	hasKanji := false
+	for _, r := range word {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || isSentenceEnd(r) || isASCIIAlnum(r) {
+			return false
+		}
+		if isKanji(r) {
+			hasKanji = true
+		}
+	}
+	return hasKanji
+}
+
+// checkWidth reports half-width katakana, and alphanumerics written in the minority width
+// when full-width (Ａ１) and half-width (A1) alphanumerics are mixed
+func checkWidth(runes []rune) []Suggestion {
+	type run struct {
+		offset, length int
+	}
+	var full, half, kana []run
+
+	for i := 0; i < len(runes); {
+		class := widthClass(runes[i])
+		if class == widthNone {
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && widthClass(runes[j]) == class {
+			j++
+		}
+		r := run{offset: i, length: j - i}
+		switch class {
+		case widthFull:
+			full = append(full, r)
+		case widthHalf:
+			half = append(half, r)
+		case widthHalfKana:
+			kana = append(kana, r)
+		}
+		i = j
+	}
+
+	var suggestions []Suggestion
+	for _, r := range kana {
+		suggestions = append(suggestions, Suggestion{
+			Rule:    RuleWidth,
+			Offset:  r.offset,
+			Length:  r.length,
+			Message: "半角カタカナが使われています",
+		})
+	}
+
+	if len(full) > 0 && len(half) > 0 {
+		minority, message := full, "全角英数字と半角英数字が混在しています"
+		if len(half) < len(full) {
+			minority = half
+		}
+		for _, r := range minority {
+			suggestions = append(suggestions, Suggestion{
+				Rule:    RuleWidth,
+				Offset:  r.offset,
+				Length:  r.length,
+				Message: message,
+			})
+		}
+	}
+	return suggestions
+}
+
+const (
+	widthNone = iota
+	widthFull
+	widthHalf
+	widthHalfKana
+)
+
+func widthClass(r rune) int {
+	switch {
+	case isASCIIAlnum(r):
+		return widthHalf
+	case (r >= 'Ａ' && r <= 'Ｚ') || (r >= 'ａ' && r <= 'ｚ') || (r >= '０' && r <= '９'):
+		return widthFull
+	case r >= 0xFF66 && r <= 0xFF9F:
+		return widthHalfKana
+	default:
+		return widthNone
+	}
+}
+
+type asciiWord struct {
+	word       string
+	start, end int
+}
+
+func asciiWords(runes []rune) []asciiWord {
+	var words []asciiWord
+	for i := 0; i < len(runes); {
+		if !unicode.IsLetter(runes[i]) || runes[i] > unicode.MaxASCII {
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && isASCIIAlnum(runes[j]) {
+			j++
+		}
+		words = append(words, asciiWord{word: string(runes[i:j]), start: i, end: j})
+		i = j
+	}
+	return words
+}
+
+func onlySpacesBetween(runes []rune, from, to int) bool {
+	if from == to {
+		return false
+	}
+	for _, r := range runes[from:to] {
+		if r != ' ' && r != '　' {
+			return false
+		}
+	}
+	return true
+}
+
+func indexAll(runes, pattern []rune) []int {
+	var offsets []int
+	for i := 0; i+len(pattern) <= len(runes); i++ {
+		if string(runes[i:i+len(pattern)]) == string(pattern) {
+			offsets = append(offsets, i)
+			i += len(pattern) - 1
+		}
+	}
+	return offsets
+}
+
+func hasAnySuffix(s string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSentenceEnd(r rune) bool {
+	return r == '。' || r == '！' || r == '？' || r == '!' || r == '?' || r == '\n'
+}
+
+func isKanji(r rune) bool {
+	return unicode.Is(unicode.Han, r)
+}
+
+func isASCIIAlnum(r rune) bool {
+	return r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
@@ -0,0 +1,67 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalNLPGateway_Proofread(t *testing.T) {
+	g := NewLocalNLPGateway()
+
+	tests := []struct {
+		name  string
+		text  string
+		rules []string
+	}{
+		{name: "clean polite text", text: "今日は公園に行きました。楽しかったです。", rules: nil},
+		{name: "clean plain text", text: "今日は雨だった。明日は晴れるだろう。", rules: nil},
+		{name: "doubled particle", text: "ごはんをを食べました。", rules: []string{RuleDoubledParticle}},
+		{name: "mixed style", text: "公園に行きました。楽しかったです。疲れた一日だった。", rules: []string{RuleMixedStyle}},
+		{name: "ra-nuki", text: "朝早く起きれなかった。", rules: []string{RuleRaNuki}},
+		{name: "conditional is not ra-nuki", text: "映画を見れば元気が出る。", rules: nil},
+		{name: "potential of 居る (おる) is not ra-nuki", text: "ずっと家に居れるのがうれしい。", rules: nil},
+		{name: "repeated kanji word", text: "今日今日は晴れ。", rules: []string{RuleRepeatedWord}},
+		{name: "reduplication is allowed", text: "いろいろあってドキドキした。", rules: nil},
+		{name: "repeated kana word is allowed", text: "とてもとても楽しい。", rules: nil},
+		{name: "repeated interjection is allowed", text: "わーいわーい、遊園地だ。", rules: nil},
+		{name: "tripled kana word is allowed", text: "犬をよしよしよしと撫でた。", rules: nil},
+		{name: "repeated word of kanji and kana", text: "帰り道帰り道で猫を見た。", rules: []string{RuleRepeatedWord}},
+		{name: "repeated english word", text: "I like the the park.", rules: []string{RuleRepeatedWord}},
+		{name: "half-width katakana", text: "ｶﾚｰを食べた。", rules: []string{RuleWidth}},
+		{name: "mixed width alphanumerics", text: "ＵＳＪに2回行った。ＮＨＫを見た。", rules: []string{RuleWidth}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rules []string
+			for _, s := range g.Proofread(tt.text) {
+				rules = append(rules, s.Rule)
+			}
+			assert.Equal(t, tt.rules, rules)
+		})
+	}
+}
+
+func TestLocalNLPGateway_Proofread_Offsets(t *testing.T) {
+	g := NewLocalNLPGateway()
+
+	suggestions := g.Proofread("昨日は映画を見れた。")
+
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, 6, suggestions[0].Offset)
+	assert.Equal(t, 2, suggestions[0].Length)
+	assert.Equal(t, "見られ", suggestions[0].Suggestion)
+}
+
+func TestLocalNLPGateway_CheckAccuracy(t *testing.T) {
+	g := NewLocalNLPGateway()
+
+	suggestions, err := g.CheckAccuracy(context.Background(), "ごはんをを食べた。ｶﾚｰだった。")
+	assert.NoError(t, err)
+	assert.Len(t, suggestions, 2)
+
+	_, err = g.CheckAccuracy(context.Background(), "")
+	assert.Error(t, err)
+}
@@ -18,8 +18,18 @@ type Token struct {
 	POS     string
 }
 
+// Suggestion is a proofreading issue found in the text.
+// Offset and Length are in Unicode code points.
+type Suggestion struct {
+	Rule       string `json:"rule"`
+	Offset     int    `json:"offset"`
+	Length     int    `json:"length"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion"`
+}
+
 // NLPGateway defines the interface for external NLP services
 type NLPGateway interface {
-	// CheckAccuracy checks grammar and spelling accuracy
-	CheckAccuracy(ctx context.Context, text string) (int, error)
+	// CheckAccuracy checks grammar and spelling accuracy and returns the issues found
+	CheckAccuracy(ctx context.Context, text string) ([]Suggestion, error)
 }
@@ -0,0 +1,8 @@
+package gateway
+
+import "expvar"
+
+// nlpMetrics are published by expvar under "nlp_gateway":
+// cache_hits, cache_misses and cache_errors of CachedNLPGateway,
+// quota_used (requests let through), quota_deferred (requests over quota) and quota_tokens (tokens left) of RateLimitedNLPGateway
+var nlpMetrics = expvar.NewMap("nlp_gateway")
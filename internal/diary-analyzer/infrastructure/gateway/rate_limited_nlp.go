@@ -0,0 +1,164 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+)
+
+// QuotaExceededError is returned without calling the NLP service when its quota is used up.
+// Callers should defer the check until RetryAt instead of treating it as a failure.
+type QuotaExceededError struct {
+	RetryAt time.Time
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("nlp quota exceeded, retry at %s", e.RetryAt.Format(time.RFC3339))
+}
+
+// AsQuotaExceeded returns the QuotaExceededError in the chain of err
+func AsQuotaExceeded(err error) (*QuotaExceededError, bool) {
+	var quotaErr *QuotaExceededError
+	ok := errors.As(err, &quotaErr)
+	return quotaErr, ok
+}
+
+// QuotaStore counts the requests sent to the NLP providers per day.
+// It is shared by all analyzer processes, so that together they stay within the daily quota.
+type QuotaStore interface {
+	// Take counts a request to the provider on the day unless limit requests were already counted, and reports whether it was counted
+	Take(ctx context.Context, provider string, day time.Time, limit int) (bool, error)
+}
+
+// quotaLocation is the time zone in which the daily quota of the NLP providers resets
+var quotaLocation = time.FixedZone("JST", 9*60*60)
+
+// RateLimitedNLPGateway spreads a daily request quota over the day with a token bucket,
+// so that a bulk import or backfill cannot exhaust the quota of the NLP service.
+// The bucket paces the requests of the process; the QuotaStore caps the requests of all processes on the day,
+// so that the consumer and the backfills share one quota and a restart does not hand it out again.
+type RateLimitedNLPGateway struct {
+	next       NLPGateway
+	quota      QuotaStore
+	provider   string
+	dailyQuota int
+	bucket     *tokenBucket
+	tokens     *expvar.Float
+	clk        clock.Clock
+}
+
+// NewRateLimitedNLPGateway creates a RateLimitedNLPGateway allowing dailyQuota requests a day
+// and bursts of up to burst requests. quota may be nil to limit the requests of this process only.
+func NewRateLimitedNLPGateway(next NLPGateway, quota QuotaStore, provider string, dailyQuota, burst int, clk clock.Clock) *RateLimitedNLPGateway {
+	rate := float64(dailyQuota) / (24 * time.Hour).Seconds()
+	tokens := new(expvar.Float)
+	tokens.Set(float64(burst))
+	nlpMetrics.Set("quota_tokens", tokens)
+
+	return &RateLimitedNLPGateway{
+		next:       next,
+		quota:      quota,
+		provider:   provider,
+		dailyQuota: dailyQuota,
+		bucket:     newTokenBucket(burst, rate, clk),
+		tokens:     tokens,
+		clk:        clk,
+	}
+}
+
+// CheckAccuracy calls the NLP service if a token is left and the quota of the day is not used up,
+// otherwise returns a QuotaExceededError
+func (g *RateLimitedNLPGateway) CheckAccuracy(ctx context.Context, text string) ([]Suggestion, error) {
+	ok, retryAt, tokens := g.bucket.take()
+	g.tokens.Set(tokens)
+
+	if ok && g.quota != nil {
+		day := quotaDay(g.clk.Now())
+		taken, err := g.quota.Take(ctx, g.provider, day, g.dailyQuota)
+		if err != nil {
+			// no request is sent, so the token goes back to the bucket
+			g.tokens.Set(g.bucket.refund())
+			return nil, fmt.Errorf("take nlp quota: %w", err)
+		}
+		if !taken {
+			g.tokens.Set(g.bucket.refund())
+		}
+		// when the quota of the day is used up, check again once it resets
+		ok, retryAt = taken, day.AddDate(0, 0, 1)
+	}
+
+	if !ok {
+		nlpMetrics.Add("quota_deferred", 1)
+		slog.Warn("nlp quota exceeded, deferring request", "retry_at", retryAt)
+		return nil, &QuotaExceededError{RetryAt: retryAt}
+	}
+
+	nlpMetrics.Add("quota_used", 1)
+	return g.next.CheckAccuracy(ctx, text)
+}
+
+// quotaDay returns the start of the quota day containing t
+func quotaDay(t time.Time) time.Time {
+	year, month, day := t.In(quotaLocation).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, quotaLocation)
+}
+
+// tokenBucket holds up to capacity tokens, refilled at rate tokens per second
+type tokenBucket struct {
+	mu       sync.Mutex
+	capacity float64
+	tokens   float64
+	rate     float64
+	last     time.Time
+	clk      clock.Clock
+}
+
+func newTokenBucket(capacity int, rate float64, clk clock.Clock) *tokenBucket {
+	return &tokenBucket{
+		capacity: float64(capacity),
+		tokens:   float64(capacity),
+		rate:     rate,
+		last:     clk.Now(),
+		clk:      clk,
+	}
+}
+
+// take consumes a token and returns the tokens left.
+// When the bucket is empty it returns false and when the next token is available.
+func (b *tokenBucket) take() (bool, time.Time, float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clk.Now()
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(b.capacity, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, now, b.tokens
+	}
+
+	if b.rate <= 0 {
+		// no refill: the quota is used up for good, check again in a day
+		return false, now.Add(24 * time.Hour), b.tokens
+	}
+	wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	return false, now.Add(wait), b.tokens
+}
+
+// refund returns a token taken for a request that was not sent, and returns the tokens left
+func (b *tokenBucket) refund() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.capacity, b.tokens+1)
+	return b.tokens
+}
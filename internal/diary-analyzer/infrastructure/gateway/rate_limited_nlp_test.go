@@ -0,0 +1,174 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+)
+
+// countingGateway counts the requests sent to the NLP service
+type countingGateway struct {
+	calls int
+	err   error
+}
+
+func (g *countingGateway) CheckAccuracy(ctx context.Context, text string) ([]Suggestion, error) {
+	g.calls++
+	if g.err != nil {
+		return nil, g.err
+	}
+	return []Suggestion{{Rule: "r", Offset: 0, Length: len([]rune(text))}}, nil
+}
+
+func TestRateLimitedNLPGateway_DefersOverQuota(t *testing.T) {
+	now := time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+	clk := &clock.Fixed{Time: now}
+	next := &countingGateway{}
+	// 1 request per second with bursts of 2
+	g := NewRateLimitedNLPGateway(next, nil, "", 86400, 2, clk)
+
+	for i := 0; i < 2; i++ {
+		_, err := g.CheckAccuracy(context.Background(), "晴れ。")
+		require.NoError(t, err)
+	}
+
+	_, err := g.CheckAccuracy(context.Background(), "晴れ。")
+	quotaErr, ok := AsQuotaExceeded(err)
+	require.True(t, ok, "expected QuotaExceededError, got %v", err)
+	assert.Equal(t, now.Add(time.Second), quotaErr.RetryAt)
+	assert.Equal(t, 2, next.calls)
+
+	clk.Time = quotaErr.RetryAt
+	_, err = g.CheckAccuracy(context.Background(), "晴れ。")
+	require.NoError(t, err)
+	assert.Equal(t, 3, next.calls)
+}
+
+func TestRateLimitedNLPGateway_RefillCappedAtBurst(t *testing.T) {
+	clk := &clock.Fixed{Time: time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)}
+	next := &countingGateway{}
+	g := NewRateLimitedNLPGateway(next, nil, "", 86400, 2, clk)
+
+	// a day without requests does not allow more than a burst
+	clk.Time = clk.Time.Add(24 * time.Hour)
+	for i := 0; i < 3; i++ {
+		g.CheckAccuracy(context.Background(), "晴れ。")
+	}
+	assert.Equal(t, 2, next.calls)
+}
+
+// memoryQuotaStore counts the requests per provider and day in memory
+type memoryQuotaStore struct {
+	used map[string]int
+	err  error
+}
+
+func (s *memoryQuotaStore) Take(ctx context.Context, provider string, day time.Time, limit int) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	key := provider + ":" + day.Format(time.DateOnly)
+	if s.used[key] >= limit {
+		return false, nil
+	}
+	s.used[key]++
+	return true, nil
+}
+
+func TestRateLimitedNLPGateway_SharedQuota(t *testing.T) {
+	// 2026-01-20 23:30 JST
+	clk := &clock.Fixed{Time: time.Date(2026, 1, 20, 14, 30, 0, 0, time.UTC)}
+	store := &memoryQuotaStore{used: map[string]int{}}
+	next := &countingGateway{}
+	// two processes with buckets full enough for the whole quota
+	consumer := NewRateLimitedNLPGateway(next, store, "yahoo", 3, 10, clk)
+	backfill := NewRateLimitedNLPGateway(next, store, "yahoo", 3, 10, clk)
+
+	for _, g := range []*RateLimitedNLPGateway{consumer, backfill, consumer} {
+		_, err := g.CheckAccuracy(context.Background(), "晴れ。")
+		require.NoError(t, err)
+	}
+
+	_, err := backfill.CheckAccuracy(context.Background(), "晴れ。")
+	quotaErr, ok := AsQuotaExceeded(err)
+	require.True(t, ok, "expected QuotaExceededError, got %v", err)
+	// the quota resets at midnight JST
+	assert.True(t, quotaErr.RetryAt.Equal(time.Date(2026, 1, 20, 15, 0, 0, 0, time.UTC)), "retry at %v", quotaErr.RetryAt)
+	assert.Equal(t, 3, next.calls)
+
+	// a restarted process does not get the quota again
+	restarted := NewRateLimitedNLPGateway(next, store, "yahoo", 3, 10, clk)
+	_, err = restarted.CheckAccuracy(context.Background(), "晴れ。")
+	_, ok = AsQuotaExceeded(err)
+	assert.True(t, ok)
+
+	clk.Time = quotaErr.RetryAt
+	_, err = restarted.CheckAccuracy(context.Background(), "晴れ。")
+	require.NoError(t, err)
+	assert.Equal(t, 4, next.calls)
+}
+
+func TestRateLimitedNLPGateway_QuotaStoreError(t *testing.T) {
+	clk := &clock.Fixed{Time: time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)}
+	next := &countingGateway{}
+	g := NewRateLimitedNLPGateway(next, &memoryQuotaStore{err: assert.AnError}, "yahoo", 3, 10, clk)
+
+	_, err := g.CheckAccuracy(context.Background(), "晴れ。")
+	require.ErrorIs(t, err, assert.AnError)
+	_, ok := AsQuotaExceeded(err)
+	assert.False(t, ok)
+	assert.Equal(t, 0, next.calls)
+}
+
+// TestRateLimitedNLPGateway_RefundsRefusedToken tests that a token is not lost when the shared quota refuses the request
+func TestRateLimitedNLPGateway_RefundsRefusedToken(t *testing.T) {
+	clk := &clock.Fixed{Time: time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)}
+	store := &memoryQuotaStore{used: map[string]int{}, err: assert.AnError}
+	next := &countingGateway{}
+	// a single token, refilled once a day
+	g := NewRateLimitedNLPGateway(next, store, "yahoo", 1, 1, clk)
+
+	_, err := g.CheckAccuracy(context.Background(), "晴れ。")
+	require.ErrorIs(t, err, assert.AnError)
+
+	// the token taken for the failed request is still there
+	store.err = nil
+	_, err = g.CheckAccuracy(context.Background(), "晴れ。")
+	require.NoError(t, err)
+	assert.Equal(t, 1, next.calls)
+	assert.Equal(t, float64(0), g.tokens.Value())
+}
+
+// TestRateLimitedNLPGateway_RefundsTokenOverSharedQuota tests that the bucket is not drained by requests refused by the shared quota
+func TestRateLimitedNLPGateway_RefundsTokenOverSharedQuota(t *testing.T) {
+	clk := &clock.Fixed{Time: time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)}
+	store := &memoryQuotaStore{used: map[string]int{"yahoo:2026-01-20": 2}}
+	next := &countingGateway{}
+	g := NewRateLimitedNLPGateway(next, store, "yahoo", 2, 2, clk)
+
+	for i := 0; i < 3; i++ {
+		_, err := g.CheckAccuracy(context.Background(), "晴れ。")
+		_, ok := AsQuotaExceeded(err)
+		require.True(t, ok, "expected QuotaExceededError, got %v", err)
+	}
+	assert.Equal(t, float64(2), g.tokens.Value())
+	assert.Equal(t, 0, next.calls)
+}
+
+func TestAsQuotaExceeded_Wrapped(t *testing.T) {
+	retryAt := time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+	err := fmt.Errorf("check accuracy: %w", &QuotaExceededError{RetryAt: retryAt})
+
+	quotaErr, ok := AsQuotaExceeded(err)
+	require.True(t, ok)
+	assert.Equal(t, retryAt, quotaErr.RetryAt)
+
+	_, ok = AsQuotaExceeded(assert.AnError)
+	assert.False(t, ok)
+}
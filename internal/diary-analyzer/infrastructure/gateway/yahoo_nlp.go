@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/furuya-3150/fam-diary-log/pkg/errors"
 	httputil "github.com/furuya-3150/fam-diary-log/pkg/http"
@@ -14,6 +15,13 @@ import (
 
 const (
 	KouseiEndpoint = "https://jlp.yahooapis.jp/KouseiService/V2/kousei"
+	// KouseiMaxChunkBytes is the longest text sent in one request. The API accepts requests up to 4KB,
+	// the rest is left for the JSON-RPC envelope and escapes. Longer texts go through ChunkedNLPGateway.
+	KouseiMaxChunkBytes = 3 * 1024
+	// KouseiChunkConcurrency is the number of chunks of a text checked at a time
+	KouseiChunkConcurrency = 4
+	// KouseiRulesVersion identifies how Kousei results are converted to suggestions in the NLP cache
+	KouseiRulesVersion = "1"
 )
 
 type YahooNLPGateway struct {
@@ -43,6 +51,7 @@ type kouseiResponse struct {
 		Suggestions []struct {
 			Offset      string `json:"offset"`
 			Length      string `json:"length"`
+			Rule        string `json:"rule"`
 			Message     string `json:"message"`
 			Suggestion  string `json:"suggestion"`
 			SurfaceForm string `json:"surface_form"`
@@ -51,14 +60,14 @@ type kouseiResponse struct {
 }
 
 // CheckAccuracy checks the accuracy of the given text using Yahoo Proofreading API
-// Returns the number of suggestions found (errors)
-func (g *YahooNLPGateway) CheckAccuracy(ctx context.Context, text string) (int, error) {
+// Returns the suggestions found (errors)
+func (g *YahooNLPGateway) CheckAccuracy(ctx context.Context, text string) ([]Suggestion, error) {
 	if g.appID == "" {
-		return 0, fmt.Errorf("Yahoo AppID not configured")
+		return nil, fmt.Errorf("Yahoo AppID not configured")
 	}
 
 	if text == "" {
-		return 0, fmt.Errorf("text is empty")
+		return nil, fmt.Errorf("text is empty")
 	}
 
 	request := kouseiRequest{
@@ -70,13 +79,13 @@ func (g *YahooNLPGateway) CheckAccuracy(ctx context.Context, text string) (int,
 
 	body, err := json.Marshal(request)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, KouseiEndpoint, bytes.NewReader(body))
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	// Set headers
@@ -86,13 +95,13 @@ func (g *YahooNLPGateway) CheckAccuracy(ctx context.Context, text string) (int,
 	// Execute request with retry logic
 	resp, err := g.client.Do(ctx, req)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		respBody, _ := io.ReadAll(resp.Body)
-		return 0, &errors.ExternalAPIError{
+		return nil, &errors.ExternalAPIError{
 			Message: fmt.Sprintf("yahoo kousei api error: status=%d", resp.StatusCode),
 			Cause:   fmt.Errorf("body=%s", string(respBody)),
 		}
@@ -101,9 +110,27 @@ func (g *YahooNLPGateway) CheckAccuracy(ctx context.Context, text string) (int,
 	// Parse response
 	var result kouseiResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	// Return suggestion count only (score calculation is domain responsibility)
-	return len(result.Result.Suggestions), nil
+	// score calculation is domain responsibility
+	suggestions := make([]Suggestion, 0, len(result.Result.Suggestions))
+	for _, s := range result.Result.Suggestions {
+		offset, err := strconv.Atoi(s.Offset)
+		if err != nil {
+			return nil, fmt.Errorf("invalid suggestion offset %q: %w", s.Offset, err)
+		}
+		length, err := strconv.Atoi(s.Length)
+		if err != nil {
+			return nil, fmt.Errorf("invalid suggestion length %q: %w", s.Length, err)
+		}
+		suggestions = append(suggestions, Suggestion{
+			Rule:       s.Rule,
+			Offset:     offset,
+			Length:     length,
+			Message:    s.Message,
+			Suggestion: s.Suggestion,
+		})
+	}
+	return suggestions, nil
 }
@@ -14,13 +14,16 @@ import (
 // DiaryEventHandler handles diary-specific events
 type DiaryEventHandler struct {
 	analyzerService usecase.DiaryAnalysisUsecase
-	l               *slog.Logger
+	// goalService celebrates the goals reached by the diary; nil checks no goals
+	goalService usecase.GoalUsecase
+	l           *slog.Logger
 }
 
 // NewDiaryEventHandler creates a new DiaryEventHandler
-func NewDiaryEventHandler(analyzerService usecase.DiaryAnalysisUsecase, l *slog.Logger) *DiaryEventHandler {
+func NewDiaryEventHandler(analyzerService usecase.DiaryAnalysisUsecase, goalService usecase.GoalUsecase, l *slog.Logger) *DiaryEventHandler {
 	return &DiaryEventHandler{
 		analyzerService: analyzerService,
+		goalService:     goalService,
 		l:               l,
 	}
 }
@@ -30,6 +33,8 @@ func (h *DiaryEventHandler) Handle(ctx context.Context, routingKey string, conte
 	switch routingKey {
 	case "diary.created":
 		return h.handleDiaryCreated(ctx, content)
+	case "diary.updated":
+		return h.handleDiaryUpdated(ctx, content)
 	default:
 		return fmt.Errorf("unknown routing key: %s", routingKey)
 	}
@@ -46,10 +51,48 @@ func (h *DiaryEventHandler) handleDiaryCreated(ctx context.Context, content []by
 
 
 	// Call analyzer service to analyze the diary
-	if _, err := h.analyzerService.Analyze(ctx, &diaryCreatedEvent); err != nil {
+	analysis, err := h.analyzerService.Analyze(ctx, &diaryCreatedEvent)
+	if err != nil {
 		h.l.Error("failed to analyze diary", "diary_id", diaryCreatedEvent.DiaryID, "error", err.Error())
 		return err
 	}
 
+	h.checkGoals(ctx, analysis)
 	return nil
 }
+
+// handleDiaryUpdated handles diary.updated events by replacing the analysis of the diary
+func (h *DiaryEventHandler) handleDiaryUpdated(ctx context.Context, content []byte) error {
+	var event domain.DiaryUpdatedEvent
+	if err := json.Unmarshal(content, &event); err != nil {
+		return fmt.Errorf("invalid event type for diary.updated %v", err)
+	}
+
+	diary := &domain.Diary{
+		ID:       event.DiaryID,
+		UserID:   event.UserID,
+		FamilyID: event.FamilyID,
+		Content:  event.Content,
+		UnlockAt: event.UnlockAt,
+	}
+	analysis, err := h.analyzerService.Reanalyze(ctx, diary)
+	if err != nil {
+		h.l.Error("failed to re-analyze diary", "diary_id", event.DiaryID, "error", err.Error())
+		return err
+	}
+
+	// 追記で目標に届くこともある
+	h.checkGoals(ctx, analysis)
+	return nil
+}
+
+// checkGoals celebrates the goals reached by the stored analysis.
+// Failed checks are retried by the scheduler, not by redelivering the event, which would analyze the diary again.
+func (h *DiaryEventHandler) checkGoals(ctx context.Context, analysis *domain.DiaryAnalysis) {
+	if h.goalService == nil || analysis == nil {
+		return
+	}
+	if err := h.goalService.CheckGoals(ctx, analysis); err != nil {
+		h.l.Error("failed to check goals", "diary_id", analysis.DiaryID, "error", err.Error())
+	}
+}
@@ -45,11 +45,11 @@ func TestDiaryEventHandlerIntegrationWithBroker(t *testing.T) {
 	// Create repository and usecase
 	repo := repository.NewDiaryAnalysisRepository(dbManager)
 	nlpGateway := gateway.NewYahooNLPGateway(config.ThirdParty.YahooNLPAppID)
-	analysisUsecase := usecase.NewDiaryAnalysisUsecaseWithNLPGateway(repo, nlpGateway)
+	analysisUsecase := usecase.NewDiaryAnalysisUsecaseWithNLPGateway(repo, nlpGateway, nil)
 
 	// Create handler
 	log := slog.Default()
-	eventHandler := NewDiaryEventHandler(analysisUsecase, log)
+	eventHandler := NewDiaryEventHandler(analysisUsecase, nil, log)
 
 	// Create consumer
 	consumerConfig := consumer.Config{
@@ -138,7 +138,8 @@ func TestDiaryEventHandlerIntegrationWithBroker(t *testing.T) {
 	assert.Equal(t, userID, analysis.UserID)
 	assert.Equal(t, familyID, analysis.FamilyID)
 	assert.Equal(t, len([]rune(content)), analysis.CharCount)
-	assert.Equal(t, 100, analysis.AccuracyScore) // Assuming the test content has no errors
+	assert.Equal(t, domain.AnalysisStatusComplete, analysis.AccuracyStatus)
+	assert.Equal(t, 100, *analysis.AccuracyScore) // Assuming the test content has no errors
 
 	// Cleanup
 	cancel()
@@ -165,10 +166,10 @@ func TestDiaryEventHandlerIntegrationInvalidMessage(t *testing.T) {
 
 	repo := repository.NewDiaryAnalysisRepository(dbManager)
 	nlpGateway := gateway.NewYahooNLPGateway(config.ThirdParty.YahooNLPAppID)
-	analysisUsecase := usecase.NewDiaryAnalysisUsecaseWithNLPGateway(repo, nlpGateway)
+	analysisUsecase := usecase.NewDiaryAnalysisUsecaseWithNLPGateway(repo, nlpGateway, nil)
 
 	log := slog.Default()
-	eventHandler := NewDiaryEventHandler(analysisUsecase, log)
+	eventHandler := NewDiaryEventHandler(analysisUsecase, nil, log)
 
 	consumerConfig := consumer.Config{
 		ExchangeName: "diary.events.invalid.test",
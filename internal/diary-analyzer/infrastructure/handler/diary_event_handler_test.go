@@ -26,6 +26,28 @@ func (m *MockDiaryAnalysisUsecase) Analyze(ctx context.Context, event *domain.Di
 	return args.Get(0).(*domain.DiaryAnalysis), args.Error(1)
 }
 
+func (m *MockDiaryAnalysisUsecase) Reanalyze(ctx context.Context, diary *domain.Diary) (*domain.DiaryAnalysis, error) {
+	args := m.Called(ctx, diary)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DiaryAnalysis), args.Error(1)
+}
+
+type MockGoalUsecase struct {
+	mock.Mock
+}
+
+func (m *MockGoalUsecase) CheckGoals(ctx context.Context, analysis *domain.DiaryAnalysis) error {
+	args := m.Called(ctx, analysis)
+	return args.Error(0)
+}
+
+func (m *MockGoalUsecase) RetryDue(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
 // TestDiaryEventHandlerHandleSuccess tests successful event handling
 func TestDiaryEventHandlerHandleSuccess(t *testing.T) {
 	// Arrange
@@ -49,14 +71,14 @@ func TestDiaryEventHandlerHandleSuccess(t *testing.T) {
 		DiaryID:       diaryID,
 		UserID:        userID,
 		FamilyID:      familyID,
-		AccuracyScore: 85,
+		AccuracyScore: intPtr(85),
 	}
 
 	mockUsecase.On("Analyze", mock.Anything, mock.MatchedBy(func (event *domain.DiaryCreatedEvent) bool {
 		return event.DiaryID == diaryID && event.UserID == userID && event.FamilyID == familyID && event.Content == content
 	})).Return(expectedAnalysis, nil)
 
-	handler := NewDiaryEventHandler(mockUsecase, log)
+	handler := NewDiaryEventHandler(mockUsecase, nil, log)
 
 	eventBytes, err := json.Marshal(event)
 	if err != nil {
@@ -86,7 +108,7 @@ func TestDiaryEventHandlerHandleInvalidRoutingKey(t *testing.T) {
 		Content:  "test content",
 	}
 
-	handler := NewDiaryEventHandler(mockUsecase, log)
+	handler := NewDiaryEventHandler(mockUsecase, nil, log)
 
 	eventBytes, err := json.Marshal(event)
 	if err != nil {
@@ -106,7 +128,7 @@ func TestDiaryEventHandlerHandleInvalidEventType(t *testing.T) {
 	mockUsecase := new(MockDiaryAnalysisUsecase)
 	log := slog.Default()
 
-	handler := NewDiaryEventHandler(mockUsecase, log)
+	handler := NewDiaryEventHandler(mockUsecase, nil, log)
 
 	// Act - passing wrong event type
 	err := handler.Handle(context.Background(), "diary.created", []byte("invalid event"))
@@ -133,7 +155,7 @@ func TestDiaryEventHandlerHandleUsecaseError(t *testing.T) {
 		return e.DiaryID == event.DiaryID && e.UserID == event.UserID && e.FamilyID == event.FamilyID && e.Content == event.Content
 	})).Return(nil, assert.AnError)
 
-	handler := NewDiaryEventHandler(mockUsecase, log)
+	handler := NewDiaryEventHandler(mockUsecase, nil, log)
 
 	eventBytes, err := json.Marshal(event)
 	if err != nil {
@@ -167,7 +189,7 @@ func TestDiaryEventHandlerHandleContextCancelled(t *testing.T) {
 		return e.DiaryID == event.DiaryID && e.UserID == event.UserID && e.FamilyID == event.FamilyID && e.Content == event.Content
 	})).Return(nil, context.Canceled)
 
-	handler := NewDiaryEventHandler(mockUsecase, log)
+	handler := NewDiaryEventHandler(mockUsecase, nil, log)
 
 	eventBytes, err := json.Marshal(event)
 	if err != nil {
@@ -179,4 +201,94 @@ func TestDiaryEventHandlerHandleContextCancelled(t *testing.T) {
 	// Assert
 	assert.Error(t, err)
 	mockUsecase.AssertExpectations(t)
-}
\ No newline at end of file
+}
+// TestDiaryEventHandlerHandleDiaryUpdated tests that diary.updated re-analyzes the edited content
+func TestDiaryEventHandlerHandleDiaryUpdated(t *testing.T) {
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+
+	diaryID := uuid.New()
+	userID := uuid.New()
+	familyID := uuid.New()
+	event := diarydomain.NewDiaryUpdatedEvent(diaryID, userID, familyID, userID, "title", "edited content")
+
+	mockUsecase.On("Reanalyze", mock.Anything, &domain.Diary{
+		ID:       diaryID,
+		UserID:   userID,
+		FamilyID: familyID,
+		Content:  "edited content",
+	}).Return(&domain.DiaryAnalysis{}, nil)
+
+	handler := NewDiaryEventHandler(mockUsecase, nil, slog.Default())
+
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("failed to marshal event: %v", err)
+	}
+
+	err = handler.Handle(context.Background(), "diary.updated", eventBytes)
+
+	assert.NoError(t, err)
+	mockUsecase.AssertExpectations(t)
+	mockUsecase.AssertNotCalled(t, "Analyze", mock.Anything, mock.Anything)
+}
+
+// TestDiaryEventHandlerHandleChecksGoals tests that the goals are checked with the stored analysis
+func TestDiaryEventHandlerHandleChecksGoals(t *testing.T) {
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+	mockGoals := new(MockGoalUsecase)
+
+	event := &diarydomain.DiaryCreatedEvent{
+		DiaryID:  uuid.New(),
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+		Content:  "test content",
+	}
+	analysis := &domain.DiaryAnalysis{ID: uuid.New(), DiaryID: event.DiaryID, UserID: event.UserID, FamilyID: event.FamilyID}
+
+	mockUsecase.On("Analyze", mock.Anything, mock.Anything).Return(analysis, nil)
+	mockGoals.On("CheckGoals", mock.Anything, analysis).Return(nil)
+
+	handler := NewDiaryEventHandler(mockUsecase, mockGoals, slog.Default())
+
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("failed to marshal event: %v", err)
+	}
+
+	err = handler.Handle(context.Background(), "diary.created", eventBytes)
+
+	assert.NoError(t, err)
+	mockUsecase.AssertExpectations(t)
+	mockGoals.AssertExpectations(t)
+}
+
+// TestDiaryEventHandlerHandleGoalError tests that a failed goal check does not fail the event,
+// which would analyze the diary again when redelivered
+func TestDiaryEventHandlerHandleGoalError(t *testing.T) {
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+	mockGoals := new(MockGoalUsecase)
+
+	diaryID, userID, familyID := uuid.New(), uuid.New(), uuid.New()
+	event := diarydomain.NewDiaryUpdatedEvent(diaryID, userID, familyID, userID, "title", "edited content")
+	analysis := &domain.DiaryAnalysis{ID: uuid.New(), DiaryID: diaryID, UserID: userID, FamilyID: familyID}
+
+	mockUsecase.On("Reanalyze", mock.Anything, mock.Anything).Return(analysis, nil).Once()
+	mockGoals.On("CheckGoals", mock.Anything, analysis).Return(assert.AnError)
+
+	handler := NewDiaryEventHandler(mockUsecase, mockGoals, slog.Default())
+
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("failed to marshal event: %v", err)
+	}
+
+	err = handler.Handle(context.Background(), "diary.updated", eventBytes)
+
+	assert.NoError(t, err)
+	mockUsecase.AssertExpectations(t)
+	mockGoals.AssertExpectations(t)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
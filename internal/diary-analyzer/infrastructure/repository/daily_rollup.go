@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// rollupMetrics are the columns of diary_analyses rolled up into diary_analysis_daily.
+// Each one has <metric>_count, _sum, _min and _max, aggregated over the analyses that have it.
+var rollupMetrics = []string{
+	"char_count", "sentence_count", "writing_time_seconds", "accuracy_score", "sentiment_score",
+	"kanji_ratio", "hiragana_ratio", "katakana_ratio", "average_sentence_length", "longest_sentence_length", "type_token_ratio",
+}
+
+// dailyRollupSQL returns the statement rolling up the analyses matching where into diary_analysis_daily,
+// one row per member, local date and unlock_at. The local date is the one stored with each analysis,
+// in the time zone of its author.
+func dailyRollupSQL(where string) string {
+	columns := []string{"family_id", "user_id", "local_date", "unlock_at", "diary_count"}
+	values := []string{"family_id", "user_id", "local_date", "unlock_at", "COUNT(*)"}
+	for _, m := range rollupMetrics {
+		columns = append(columns, m+"_count", m+"_sum", m+"_min", m+"_max")
+		values = append(values, fmt.Sprintf("COUNT(%[1]s), COALESCE(SUM(%[1]s), 0), MIN(%[1]s), MAX(%[1]s)", m))
+	}
+	columns = append(columns, "updated_at")
+	values = append(values, "NOW()")
+
+	updates := make([]string, 0, len(columns))
+	for _, c := range columns[4:] {
+		updates = append(updates, c+" = EXCLUDED."+c)
+	}
+
+	return "INSERT INTO diary_analysis_daily (" + strings.Join(columns, ", ") + ") " +
+		"SELECT " + strings.Join(values, ", ") + " FROM diary_analyses WHERE " + where +
+		" GROUP BY family_id, user_id, local_date, unlock_at" +
+		" ON CONFLICT (family_id, user_id, local_date, unlock_at) DO UPDATE SET " + strings.Join(updates, ", ")
+}
+
+var (
+	// refreshDailySQL rolls up the analyses of a member on @local_date
+	refreshDailySQL = dailyRollupSQL("family_id = @family_id AND user_id = @user_id AND local_date = CAST(@local_date AS date)")
+	// rebuildDailySQL rolls up the analyses of all members on @day
+	rebuildDailySQL = dailyRollupSQL("local_date = CAST(@day AS date)")
+)
+
+// dailyKey is the member and the local date of an analysis, which identify its rollup
+type dailyKey struct {
+	FamilyID  uuid.UUID
+	UserID    uuid.UUID
+	LocalDate time.Time
+}
+
+// findDailyKey returns the daily key of the analysis matching the condition, or nil if there is none
+func findDailyKey(tx *gorm.DB, query string, args ...interface{}) (*dailyKey, error) {
+	var keys []*dailyKey
+	err := tx.Table("diary_analyses").
+		Select("family_id, user_id, local_date").
+		Where(query, args...).
+		Limit(1).
+		Scan(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	return keys[0], nil
+}
+
+// refreshDaily recomputes the rollup of the member on the local date of the key.
+// The rollups of a member are refreshed one at a time so that concurrent analyses do not lose each other.
+func refreshDaily(tx *gorm.DB, key *dailyKey) error {
+	args := map[string]interface{}{
+		"family_id":  key.FamilyID,
+		"user_id":    key.UserID,
+		"local_date": key.LocalDate.Format("2006-01-02"),
+		"lock_key":   "diary_analysis_daily:" + key.FamilyID.String() + ":" + key.UserID.String(),
+	}
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended(@lock_key, 0))", args).Error; err != nil {
+		return err
+	}
+	err := tx.Exec("DELETE FROM diary_analysis_daily WHERE family_id = @family_id AND user_id = @user_id"+
+		" AND local_date = CAST(@local_date AS date)", args).Error
+	if err != nil {
+		return err
+	}
+	return tx.Exec(refreshDailySQL, args).Error
+}
+
+// refreshDailyKeys refreshes the rollups of the keys, once per member and date
+func refreshDailyKeys(tx *gorm.DB, keys ...*dailyKey) error {
+	var refreshed []*dailyKey
+	for _, key := range keys {
+		if key == nil {
+			continue
+		}
+		duplicate := false
+		for _, r := range refreshed {
+			if r.FamilyID == key.FamilyID && r.UserID == key.UserID && r.LocalDate.Equal(key.LocalDate) {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			continue
+		}
+		if err := refreshDaily(tx, key); err != nil {
+			return err
+		}
+		refreshed = append(refreshed, key)
+	}
+	return nil
+}
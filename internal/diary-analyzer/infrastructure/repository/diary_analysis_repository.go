@@ -2,13 +2,35 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/domain"
 	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type DiaryAnalysisRepository interface {
+	// Create stores the analysis of a diary unless the diary is already analyzed, and returns nil in that case.
+	// A redelivered diary.created, or one handled after diary.updated, does not overwrite the stored analysis;
+	// only Replace does.
 	Create(ctx context.Context, analysis *domain.DiaryAnalysis) (*domain.DiaryAnalysis, error)
+	// FindByDiaryID returns the latest analysis of the diary, or nil if there is none
+	FindByDiaryID(ctx context.Context, diaryID uuid.UUID) (*domain.DiaryAnalysis, error)
+	// Replace stores the analysis in place of the existing analysis of the same diary (upsert by diary_id),
+	// including its analysis date
+	Replace(ctx context.Context, analysis *domain.DiaryAnalysis) (*domain.DiaryAnalysis, error)
+	// ListFailedDiaryIDs returns the diaries with a failed enrichment, created in [from, to)
+	ListFailedDiaryIDs(ctx context.Context, from, to time.Time) ([]uuid.UUID, error)
+	// ListRetryDue returns the analyses whose retry of failed enrichments is due, with their suggestions and keywords
+	ListRetryDue(ctx context.Context, now time.Time, limit int) ([]*domain.DiaryAnalysis, error)
+	// UpdateEnrichments stores the enrichment results and retry schedule of the analysis,
+	// replacing its suggestions and keywords
+	UpdateEnrichments(ctx context.Context, analysis *domain.DiaryAnalysis) error
+	// RebuildDaily recomputes the daily rollups of all members on the day from their analyses,
+	// and returns the number of rollups stored
+	RebuildDaily(ctx context.Context, day time.Time) (int64, error)
 }
 
 type diaryAnalysisRepository struct {
@@ -22,10 +44,199 @@ func NewDiaryAnalysisRepository(dbManager *db.DBManager) DiaryAnalysisRepository
 }
 
 func (r *diaryAnalysisRepository) Create(ctx context.Context, analysis *domain.DiaryAnalysis) (*domain.DiaryAnalysis, error) {
-	result := r.dbManager.DB(ctx).Create(analysis)
-	if result.Error != nil {
-		return nil, result.Error
+	stored, err := r.upsert(ctx, analysis, clause.OnConflict{DoNothing: true})
+	if err != nil {
+		return nil, err
+	}
+	if !stored {
+		return nil, nil
+	}
+
+	return analysis, nil
+}
+
+func (r *diaryAnalysisRepository) FindByDiaryID(ctx context.Context, diaryID uuid.UUID) (*domain.DiaryAnalysis, error) {
+	var analyses []*domain.DiaryAnalysis
+	err := r.dbManager.DB(ctx).
+		Where("diary_id = ?", diaryID).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&analyses).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(analyses) == 0 {
+		return nil, nil
+	}
+	return analyses[0], nil
+}
+
+func (r *diaryAnalysisRepository) Replace(ctx context.Context, analysis *domain.DiaryAnalysis) (*domain.DiaryAnalysis, error) {
+	// UpdateAll keeps created_at, the analysis date is set explicitly
+	onConflict := clause.OnConflict{UpdateAll: true, DoUpdates: clause.AssignmentColumns([]string{"created_at"})}
+	if _, err := r.upsert(ctx, analysis, onConflict); err != nil {
+		return nil, err
 	}
 
 	return analysis, nil
 }
+
+// upsert inserts the analysis or updates the existing analysis of the diary, and replaces its suggestions, keywords
+// and entity references. It reports whether a row was written; nothing is replaced when onConflict skipped the row.
+// The daily rollups of the previous and the new local date are refreshed.
+// analysis.ID is set to the ID of the stored row.
+func (r *diaryAnalysisRepository) upsert(ctx context.Context, analysis *domain.DiaryAnalysis, onConflict clause.OnConflict) (bool, error) {
+	onConflict.Columns = []clause.Column{{Name: "diary_id"}}
+	stored := false
+	err := r.dbManager.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		previous, err := findDailyKey(tx, "diary_id = ?", analysis.DiaryID)
+		if err != nil {
+			return err
+		}
+		result := tx.Omit(clause.Associations).Clauses(onConflict).Create(analysis)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		stored = true
+		if err := replaceChildren(tx, analysis); err != nil {
+			return err
+		}
+		if err := replaceEntityReferences(tx, analysis); err != nil {
+			return err
+		}
+
+		// the stored local date may differ from analysis.LocalDate when an existing analysis keeps it
+		current, err := findDailyKey(tx, "id = ?", analysis.ID)
+		if err != nil {
+			return err
+		}
+		return refreshDailyKeys(tx, previous, current)
+	})
+	if err != nil {
+		return false, err
+	}
+	return stored, nil
+}
+
+func (r *diaryAnalysisRepository) ListFailedDiaryIDs(ctx context.Context, from, to time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.dbManager.DB(ctx).
+		Model(&domain.DiaryAnalysis{}).
+		Where("accuracy_status = ? OR keywords_status = ?", domain.AnalysisStatusFailed, domain.AnalysisStatusFailed).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Order("created_at ASC").
+		Pluck("diary_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *diaryAnalysisRepository) ListRetryDue(ctx context.Context, now time.Time, limit int) ([]*domain.DiaryAnalysis, error) {
+	var analyses []*domain.DiaryAnalysis
+	err := r.dbManager.DB(ctx).
+		Preload("Suggestions").
+		Preload("Keywords").
+		Where("next_retry_at IS NOT NULL AND next_retry_at <= ?", now).
+		Order("next_retry_at ASC").
+		Limit(limit).
+		Find(&analyses).Error
+	if err != nil {
+		return nil, err
+	}
+	return analyses, nil
+}
+
+func (r *diaryAnalysisRepository) UpdateEnrichments(ctx context.Context, analysis *domain.DiaryAnalysis) error {
+	return r.dbManager.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domain.DiaryAnalysis{}).
+			Where("id = ?", analysis.ID).
+			Updates(map[string]interface{}{
+				"accuracy_score":    analysis.AccuracyScore,
+				"accuracy_strategy": analysis.AccuracyStrategy,
+				"accuracy_status":   analysis.AccuracyStatus,
+				"accuracy_error":    analysis.AccuracyError,
+				"keywords_status":   analysis.KeywordsStatus,
+				"keywords_error":    analysis.KeywordsError,
+				"type_token_ratio":  analysis.TypeTokenRatio,
+				"retry_count":       analysis.RetryCount,
+				"next_retry_at":     analysis.NextRetryAt,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := replaceChildren(tx, analysis); err != nil {
+			return err
+		}
+
+		key, err := findDailyKey(tx, "id = ?", analysis.ID)
+		if err != nil {
+			return err
+		}
+		return refreshDailyKeys(tx, key)
+	})
+}
+
+func (r *diaryAnalysisRepository) RebuildDaily(ctx context.Context, day time.Time) (int64, error) {
+	var rows int64
+	err := r.dbManager.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		args := map[string]interface{}{"day": day.Format("2006-01-02")}
+		if err := tx.Exec("DELETE FROM diary_analysis_daily WHERE local_date = CAST(@day AS date)", args).Error; err != nil {
+			return err
+		}
+		result := tx.Exec(rebuildDailySQL, args)
+		rows = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return rows, nil
+}
+
+// replaceChildren replaces the suggestions and keywords of the analysis
+func replaceChildren(tx *gorm.DB, analysis *domain.DiaryAnalysis) error {
+	if err := tx.Where("analysis_id = ?", analysis.ID).Delete(&domain.DiaryAnalysisSuggestion{}).Error; err != nil {
+		return err
+	}
+	if len(analysis.Suggestions) > 0 {
+		for _, s := range analysis.Suggestions {
+			s.AnalysisID = analysis.ID
+		}
+		if err := tx.Create(analysis.Suggestions).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Where("analysis_id = ?", analysis.ID).Delete(&domain.DiaryKeyword{}).Error; err != nil {
+		return err
+	}
+	if len(analysis.Keywords) > 0 {
+		for _, k := range analysis.Keywords {
+			k.AnalysisID = analysis.ID
+		}
+		if err := tx.Create(analysis.Keywords).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceEntityReferences replaces the entity references of the analysis.
+// They depend on the content only, so the retry of enrichments keeps them.
+func replaceEntityReferences(tx *gorm.DB, analysis *domain.DiaryAnalysis) error {
+	if err := tx.Where("analysis_id = ?", analysis.ID).Delete(&domain.DiaryEntityReference{}).Error; err != nil {
+		return err
+	}
+	if len(analysis.EntityReferences) == 0 {
+		return nil
+	}
+	for _, r := range analysis.EntityReferences {
+		r.AnalysisID = analysis.ID
+	}
+	return tx.Create(analysis.EntityReferences).Error
+}
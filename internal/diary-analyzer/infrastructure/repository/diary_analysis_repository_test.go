@@ -33,7 +33,7 @@ func TestDiaryAnalysisRepositoryCreateSuccess(t *testing.T) {
 		FamilyID:      familyID,
 		CharCount:     150,
 		SentenceCount: 5,
-		AccuracyScore: 85,
+		AccuracyScore: intPtr(85),
 	}
 
 	// Act
@@ -48,7 +48,7 @@ func TestDiaryAnalysisRepositoryCreateSuccess(t *testing.T) {
 	assert.Equal(t, familyID, result.FamilyID)
 	assert.Equal(t, 150, result.CharCount)
 	assert.Equal(t, 5, result.SentenceCount)
-	assert.Equal(t, 85, result.AccuracyScore)
+	assert.Equal(t, intPtr(85), result.AccuracyScore)
 }
 
 // TestDiaryAnalysisRepositoryCreateContextCanceled tests context cancellation handling
@@ -69,7 +69,7 @@ func TestDiaryAnalysisRepositoryCreateContextCanceled(t *testing.T) {
 		FamilyID:      uuid.New(),
 		CharCount:     150,
 		SentenceCount: 5,
-		AccuracyScore: 85,
+		AccuracyScore: intPtr(85),
 	}
 
 	// Create a canceled context
@@ -83,3 +83,111 @@ func TestDiaryAnalysisRepositoryCreateContextCanceled(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, result)
 }
+
+// TestDiaryAnalysisRepositoryReplace tests that Replace leaves a single analysis for the diary
+func TestDiaryAnalysisRepositoryReplace(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	dbManager := helper.SetupTestDB(t)
+	defer helper.TeardownTestDB(t, dbManager.GetGorm())
+
+	repo := NewDiaryAnalysisRepository(dbManager)
+
+	diaryID := uuid.New()
+	first := &domain.DiaryAnalysis{DiaryID: diaryID, UserID: uuid.New(), FamilyID: uuid.New(), CharCount: 10}
+	_, err := repo.Create(context.Background(), first)
+	require.NoError(t, err)
+
+	second := &domain.DiaryAnalysis{DiaryID: diaryID, UserID: first.UserID, FamilyID: first.FamilyID, CharCount: 20}
+	_, err = repo.Replace(context.Background(), second)
+	require.NoError(t, err)
+
+	latest, err := repo.FindByDiaryID(context.Background(), diaryID)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, 20, latest.CharCount)
+
+	var count int64
+	require.NoError(t, dbManager.GetGorm().Model(&domain.DiaryAnalysis{}).Where("diary_id = ?", diaryID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+// TestDiaryAnalysisRepositoryCreateRedelivered tests that creating the analysis of the same diary twice
+// keeps the stored analysis instead of duplicating or overwriting it
+func TestDiaryAnalysisRepositoryCreateRedelivered(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	dbManager := helper.SetupTestDB(t)
+	defer helper.TeardownTestDB(t, dbManager.GetGorm())
+
+	repo := NewDiaryAnalysisRepository(dbManager)
+
+	diaryID := uuid.New()
+	first := &domain.DiaryAnalysis{
+		DiaryID:     diaryID,
+		UserID:      uuid.New(),
+		FamilyID:    uuid.New(),
+		CharCount:   10,
+		Suggestions: []*domain.DiaryAnalysisSuggestion{{DiaryID: diaryID, Rule: "ra_nuki"}},
+	}
+	_, err := repo.Create(context.Background(), first)
+	require.NoError(t, err)
+
+	second := &domain.DiaryAnalysis{
+		DiaryID:     diaryID,
+		UserID:      first.UserID,
+		FamilyID:    first.FamilyID,
+		CharCount:   10,
+		Suggestions: []*domain.DiaryAnalysisSuggestion{{DiaryID: diaryID, Rule: "mixed_style"}},
+	}
+	result, err := repo.Create(context.Background(), second)
+	require.NoError(t, err)
+	assert.Nil(t, result)
+
+	var count int64
+	require.NoError(t, dbManager.GetGorm().Model(&domain.DiaryAnalysis{}).Where("diary_id = ?", diaryID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	var suggestions []*domain.DiaryAnalysisSuggestion
+	require.NoError(t, dbManager.GetGorm().Where("diary_id = ?", diaryID).Find(&suggestions).Error)
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, "ra_nuki", suggestions[0].Rule)
+	assert.Equal(t, first.ID, suggestions[0].AnalysisID)
+}
+
+// TestDiaryAnalysisRepositoryCreateAfterReplace tests that a diary.created handled after diary.updated
+// does not overwrite the analysis of the updated content
+func TestDiaryAnalysisRepositoryCreateAfterReplace(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database test in short mode")
+	}
+
+	dbManager := helper.SetupTestDB(t)
+	defer helper.TeardownTestDB(t, dbManager.GetGorm())
+
+	repo := NewDiaryAnalysisRepository(dbManager)
+
+	diaryID := uuid.New()
+	updated := &domain.DiaryAnalysis{DiaryID: diaryID, UserID: uuid.New(), FamilyID: uuid.New(), CharCount: 20}
+	_, err := repo.Replace(context.Background(), updated)
+	require.NoError(t, err)
+
+	created := &domain.DiaryAnalysis{DiaryID: diaryID, UserID: updated.UserID, FamilyID: updated.FamilyID, CharCount: 10}
+	result, err := repo.Create(context.Background(), created)
+	require.NoError(t, err)
+	assert.Nil(t, result)
+
+	latest, err := repo.FindByDiaryID(context.Background(), diaryID)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, updated.ID, latest.ID)
+	assert.Equal(t, 20, latest.CharCount)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"github.com/google/uuid"
+)
+
+// DiaryRepository reads diaries from the diary database (read-only)
+type DiaryRepository interface {
+	// ListIDsCreatedBetween returns the IDs of the diaries created in [from, to), oldest first
+	ListIDsCreatedBetween(ctx context.Context, from, to time.Time) ([]uuid.UUID, error)
+	// FindByID returns the diary, or nil if it does not exist
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Diary, error)
+}
+
+type diaryRepository struct {
+	dbManager *db.DBManager
+}
+
+func NewDiaryRepository(dbManager *db.DBManager) DiaryRepository {
+	return &diaryRepository{
+		dbManager: dbManager,
+	}
+}
+
+func (r *diaryRepository) ListIDsCreatedBetween(ctx context.Context, from, to time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.dbManager.DB(ctx).
+		Model(&domain.Diary{}).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Order("created_at ASC").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *diaryRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Diary, error) {
+	var diaries []*domain.Diary
+	if err := r.dbManager.DB(ctx).Where("id = ?", id).Limit(1).Find(&diaries).Error; err != nil {
+		return nil, err
+	}
+	if len(diaries) == 0 {
+		return nil, nil
+	}
+	return diaries[0], nil
+}
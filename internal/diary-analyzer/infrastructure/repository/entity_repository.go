@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// EntityRepository reads the members, places and pets of families linked in diaries
+type EntityRepository interface {
+	// UpsertMember stores the member unless a newer version is already stored
+	UpsertMember(ctx context.Context, member *domain.FamilyMember) error
+	// RemoveMember marks the member removed unless a newer version is already stored
+	RemoveMember(ctx context.Context, member *domain.FamilyMember) error
+	// ListMembers returns the members not removed from the family
+	ListMembers(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyMember, error)
+	// FindMember returns the member of the family, or nil if it is not synced yet.
+	// Removed members are returned too, so their diaries keep the time zone of the member.
+	FindMember(ctx context.Context, familyID, userID uuid.UUID) (*domain.FamilyMember, error)
+	// ListEntities returns the places and pets maintained by the family
+	ListEntities(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyEntity, error)
+}
+
+type entityRepository struct {
+	dbManager *db.DBManager
+}
+
+func NewEntityRepository(dbManager *db.DBManager) EntityRepository {
+	return &entityRepository{
+		dbManager: dbManager,
+	}
+}
+
+func (r *entityRepository) UpsertMember(ctx context.Context, member *domain.FamilyMember) error {
+	// イベントは順不同で届くため、古いイベントで上書きしない
+	return r.dbManager.DB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "family_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "nicknames", "timezone", "synced_at", "removed_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "family_members.synced_at < excluded.synced_at"},
+		}},
+	}).Create(member).Error
+}
+
+func (r *entityRepository) RemoveMember(ctx context.Context, member *domain.FamilyMember) error {
+	// 削除済みの行を残し、削除より古い upsert で復活させない
+	return r.dbManager.DB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "family_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"synced_at", "removed_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "family_members.synced_at < excluded.synced_at"},
+		}},
+	}).Create(member).Error
+}
+
+func (r *entityRepository) ListMembers(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyMember, error) {
+	var members []*domain.FamilyMember
+	if err := r.dbManager.DB(ctx).Where("family_id = ? AND removed_at IS NULL", familyID).Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *entityRepository) FindMember(ctx context.Context, familyID, userID uuid.UUID) (*domain.FamilyMember, error) {
+	var members []*domain.FamilyMember
+	err := r.dbManager.DB(ctx).
+		Where("family_id = ? AND user_id = ?", familyID, userID).
+		Limit(1).
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+	return members[0], nil
+}
+
+func (r *entityRepository) ListEntities(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyEntity, error) {
+	var entities []*domain.FamilyEntity
+	if err := r.dbManager.DB(ctx).Where("family_id = ?", familyID).Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
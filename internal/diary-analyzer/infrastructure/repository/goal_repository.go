@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"github.com/google/uuid"
+)
+
+// GoalRepository reads the writing goals maintained by diary-analysis and records the goals reached
+type GoalRepository interface {
+	ListGoals(ctx context.Context, familyID, userID uuid.UUID) ([]*domain.Goal, error)
+	// SumDaily totals the daily rollups of the member over the local dates in [from, to], including locked time capsules
+	SumDaily(ctx context.Context, familyID, userID uuid.UUID, from, to time.Time) (*domain.GoalTotals, error)
+	// IsAchieved reports whether the goal was recorded as reached with the target in the period starting on periodStart
+	IsAchieved(ctx context.Context, goal *domain.Goal, periodStart time.Time) (bool, error)
+	// ClaimAchievement records the goal as reached with its target in the period starting on periodStart.
+	// It reports false when the goal was recorded before, so that of concurrent claims only one succeeds.
+	ClaimAchievement(ctx context.Context, goal *domain.Goal, periodStart time.Time) (bool, error)
+	// ReleaseAchievement removes the record made by ClaimAchievement, so that the goal can be claimed again
+	ReleaseAchievement(ctx context.Context, goal *domain.Goal, periodStart time.Time) error
+	// SaveCheck stores a failed goal check with its retry schedule, replacing the one of the member and local date
+	SaveCheck(ctx context.Context, check *domain.GoalCheck) error
+	// ListChecksDue returns the failed goal checks whose retry is due
+	ListChecksDue(ctx context.Context, now time.Time, limit int) ([]*domain.GoalCheck, error)
+	DeleteCheck(ctx context.Context, check *domain.GoalCheck) error
+}
+
+type goalRepository struct {
+	dbManager *db.DBManager
+}
+
+func NewGoalRepository(dbManager *db.DBManager) GoalRepository {
+	return &goalRepository{
+		dbManager: dbManager,
+	}
+}
+
+func (r *goalRepository) ListGoals(ctx context.Context, familyID, userID uuid.UUID) ([]*domain.Goal, error) {
+	var goals []*domain.Goal
+	err := r.dbManager.DB(ctx).
+		Where("family_id = ? AND user_id = ?", familyID, userID).
+		Find(&goals).Error
+	if err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+func (r *goalRepository) SumDaily(ctx context.Context, familyID, userID uuid.UUID, from, to time.Time) (*domain.GoalTotals, error) {
+	var totals domain.GoalTotals
+	// 日付は文字列で渡し、セッションのタイムゾーンで日付がずれないようにする
+	err := r.dbManager.DB(ctx).Table("diary_analysis_daily").
+		Select("COALESCE(SUM(diary_count), 0) AS posts, "+
+			"COALESCE(SUM(char_count_sum), 0)::bigint AS char_count, "+
+			"COALESCE(SUM(writing_time_seconds_sum), 0)::bigint AS writing_time_seconds").
+		Where("family_id = ? AND user_id = ?", familyID, userID).
+		Where("local_date >= CAST(? AS date) AND local_date <= CAST(? AS date)", from.Format(time.DateOnly), to.Format(time.DateOnly)).
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return &totals, nil
+}
+
+func (r *goalRepository) IsAchieved(ctx context.Context, goal *domain.Goal, periodStart time.Time) (bool, error) {
+	var count int64
+	err := r.dbManager.DB(ctx).Table("writing_goal_achievements").
+		Where("goal_id = ? AND period_start = CAST(? AS date) AND target = ?", goal.ID, periodStart.Format(time.DateOnly), goal.Target).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *goalRepository) ClaimAchievement(ctx context.Context, goal *domain.Goal, periodStart time.Time) (bool, error) {
+	var claimed []uuid.UUID
+	// 記録済みなら何も返らない。同時に呼ばれても一意制約により一方だけが行を挿入する
+	err := r.dbManager.DB(ctx).Raw(
+		"INSERT INTO writing_goal_achievements (goal_id, period_start, target, achieved_at) VALUES (?, CAST(? AS date), ?, NOW())"+
+			" ON CONFLICT (goal_id, period_start, target) DO NOTHING RETURNING goal_id",
+		goal.ID, periodStart.Format(time.DateOnly), goal.Target).
+		Scan(&claimed).Error
+	if err != nil {
+		return false, err
+	}
+	return len(claimed) > 0, nil
+}
+
+func (r *goalRepository) ReleaseAchievement(ctx context.Context, goal *domain.Goal, periodStart time.Time) error {
+	return r.dbManager.DB(ctx).Exec(
+		"DELETE FROM writing_goal_achievements WHERE goal_id = ? AND period_start = CAST(? AS date) AND target = ?",
+		goal.ID, periodStart.Format(time.DateOnly), goal.Target).Error
+}
+
+func (r *goalRepository) SaveCheck(ctx context.Context, check *domain.GoalCheck) error {
+	return r.dbManager.DB(ctx).Exec(
+		"INSERT INTO writing_goal_checks (family_id, user_id, local_date, retry_count, next_retry_at) VALUES (?, ?, CAST(? AS date), ?, ?)"+
+			" ON CONFLICT (family_id, user_id, local_date) DO UPDATE SET retry_count = EXCLUDED.retry_count, next_retry_at = EXCLUDED.next_retry_at",
+		check.FamilyID, check.UserID, check.LocalDate.Format(time.DateOnly), check.RetryCount, check.NextRetryAt).Error
+}
+
+func (r *goalRepository) ListChecksDue(ctx context.Context, now time.Time, limit int) ([]*domain.GoalCheck, error) {
+	var checks []*domain.GoalCheck
+	err := r.dbManager.DB(ctx).
+		Where("next_retry_at <= ?", now).
+		Order("next_retry_at ASC").
+		Limit(limit).
+		Find(&checks).Error
+	if err != nil {
+		return nil, err
+	}
+	return checks, nil
+}
+
+func (r *goalRepository) DeleteCheck(ctx context.Context, check *domain.GoalCheck) error {
+	return r.dbManager.DB(ctx).Exec(
+		"DELETE FROM writing_goal_checks WHERE family_id = ? AND user_id = ? AND local_date = CAST(? AS date)",
+		check.FamilyID, check.UserID, check.LocalDate.Format(time.DateOnly)).Error
+}
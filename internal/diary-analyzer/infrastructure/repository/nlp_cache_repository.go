@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/gateway"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"gorm.io/gorm/clause"
+)
+
+// NLPCacheRepository stores the suggestions of the NLP providers by rules version and content hash
+type NLPCacheRepository interface {
+	gateway.SuggestionCache
+}
+
+type nlpCacheRepository struct {
+	dbManager *db.DBManager
+}
+
+func NewNLPCacheRepository(dbManager *db.DBManager) NLPCacheRepository {
+	return &nlpCacheRepository{
+		dbManager: dbManager,
+	}
+}
+
+func (r *nlpCacheRepository) Get(ctx context.Context, provider, rulesVersion, contentHash string) ([]gateway.Suggestion, bool, error) {
+	var entries []*domain.NLPCacheEntry
+	err := r.dbManager.DB(ctx).
+		Where("provider = ? AND rules_version = ? AND content_hash = ?", provider, rulesVersion, contentHash).
+		Limit(1).
+		Find(&entries).Error
+	if err != nil {
+		return nil, false, err
+	}
+	if len(entries) == 0 {
+		return nil, false, nil
+	}
+
+	var suggestions []gateway.Suggestion
+	if err := json.Unmarshal(entries[0].Suggestions, &suggestions); err != nil {
+		return nil, false, err
+	}
+	return suggestions, true, nil
+}
+
+func (r *nlpCacheRepository) Put(ctx context.Context, provider, rulesVersion, contentHash string, suggestions []gateway.Suggestion) error {
+	if suggestions == nil {
+		suggestions = []gateway.Suggestion{}
+	}
+	data, err := json.Marshal(suggestions)
+	if err != nil {
+		return err
+	}
+
+	entry := &domain.NLPCacheEntry{
+		Provider:     provider,
+		RulesVersion: rulesVersion,
+		ContentHash:  contentHash,
+		Suggestions:  data,
+	}
+	// the same text checked concurrently yields the same suggestions, keep the first
+	return r.dbManager.DB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(entry).Error
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/gateway"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+)
+
+// NLPQuotaRepository counts the requests sent to the NLP providers per day
+type NLPQuotaRepository interface {
+	gateway.QuotaStore
+}
+
+type nlpQuotaRepository struct {
+	dbManager *db.DBManager
+}
+
+func NewNLPQuotaRepository(dbManager *db.DBManager) NLPQuotaRepository {
+	return &nlpQuotaRepository{
+		dbManager: dbManager,
+	}
+}
+
+func (r *nlpQuotaRepository) Take(ctx context.Context, provider string, day time.Time, limit int) (bool, error) {
+	var used []int
+	// 上限に達した行は更新されず何も返らないため、同時に呼ばれても上限を超えない
+	err := r.dbManager.DB(ctx).Raw(
+		"INSERT INTO nlp_quota_usage (provider, day, used, updated_at) VALUES (?, CAST(? AS date), 1, NOW())"+
+			" ON CONFLICT (provider, day) DO UPDATE SET used = nlp_quota_usage.used + 1, updated_at = NOW()"+
+			" WHERE nlp_quota_usage.used < ? RETURNING used",
+		provider, day.Format(time.DateOnly), limit).
+		Scan(&used).Error
+	if err != nil {
+		return false, err
+	}
+	return len(used) > 0, nil
+}
@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Retrier re-attempts the failed work whose retry is due and returns the number of items retried,
+// e.g. usecase.AnalysisRetryUsecase and usecase.GoalUsecase
+type Retrier interface {
+	RetryDue(ctx context.Context) (int, error)
+}
+
+// RetryScheduler periodically re-attempts failed work: the enrichments of diary analyses or goal checks
+type RetryScheduler struct {
+	name     string
+	r        Retrier
+	interval time.Duration
+}
+
+// NewRetryScheduler creates a new instance of RetryScheduler. name tells the schedulers apart in the logs.
+func NewRetryScheduler(name string, r Retrier, interval time.Duration) *RetryScheduler {
+	return &RetryScheduler{
+		name:     name,
+		r:        r,
+		interval: interval,
+	}
+}
+
+// Start runs the scheduler until ctx is cancelled
+func (s *RetryScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	slog.Info("retry scheduler started", "name", s.name, "interval", s.interval.String())
+	s.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("retry scheduler stopped", "name", s.name)
+			return
+		case <-ticker.C:
+			s.run(ctx)
+		}
+	}
+}
+
+func (s *RetryScheduler) run(ctx context.Context) {
+	retried, err := s.r.RetryDue(ctx)
+	if err != nil {
+		slog.Error("failed to retry", "name", s.name, "error", err.Error())
+	}
+	if retried > 0 {
+		slog.Info("retried", "name", s.name, "count", retried)
+	}
+}
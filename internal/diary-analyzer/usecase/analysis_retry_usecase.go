@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/gateway"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+)
+
+type AnalysisRetryUsecase interface {
+	// RetryDue re-attempts the failed and deferred enrichments whose retry is due and returns the number of analyses retried
+	RetryDue(ctx context.Context) (int, error)
+}
+
+type analysisRetryUsecase struct {
+	ar         repository.DiaryAnalysisRepository
+	dr         repository.DiaryRepository
+	nlpGateway gateway.NLPGateway
+	tokenizer  gateway.Tokenizer
+	scoring    domain.AccuracyScoringStrategy
+	clk        clock.Clock
+}
+
+// NewAnalysisRetryUsecase creates a new AnalysisRetryUsecase.
+// The diary content is read again from the diary database because analyses do not keep it.
+func NewAnalysisRetryUsecase(
+	ar repository.DiaryAnalysisRepository,
+	dr repository.DiaryRepository,
+	nlpGateway gateway.NLPGateway,
+	tokenizer gateway.Tokenizer,
+	scoring domain.AccuracyScoringStrategy,
+	clk clock.Clock,
+) AnalysisRetryUsecase {
+	return &analysisRetryUsecase{
+		ar:         ar,
+		dr:         dr,
+		nlpGateway: nlpGateway,
+		tokenizer:  tokenizer,
+		scoring:    scoring,
+		clk:        clk,
+	}
+}
+
+func (u *analysisRetryUsecase) RetryDue(ctx context.Context) (int, error) {
+	now := u.clk.Now()
+	analyses, err := u.ar.ListRetryDue(ctx, now, domain.AnalysisRetryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	retried := 0
+	for _, analysis := range analyses {
+		diary, err := u.dr.FindByID(ctx, analysis.DiaryID)
+		if err != nil {
+			return retried, err
+		}
+
+		if diary == nil {
+			// 日記が削除された場合は再試行しない
+			analysis.NextRetryAt = nil
+		} else {
+			// deferred checks waited for the quota and do not use up retries
+			failed := analysis.HasFailedEnrichment()
+			if analysis.AccuracyStatus == domain.AnalysisStatusFailed || analysis.AccuracyStatus == domain.AnalysisStatusDeferred {
+				checkAccuracy(ctx, u.nlpGateway, u.scoring, analysis, diary.Content)
+			}
+			if analysis.KeywordsStatus == domain.AnalysisStatusFailed {
+				extractKeywords(ctx, u.tokenizer, analysis, diary.Content)
+			}
+			if failed {
+				analysis.RetryCount++
+			}
+			analysis.ScheduleRetry(now)
+		}
+
+		if err := u.ar.UpdateEnrichments(ctx, analysis); err != nil {
+			return retried, err
+		}
+		retried++
+
+		if analysis.HasFailedEnrichment() && analysis.NextRetryAt == nil {
+			slog.Warn("gave up retrying diary analysis", "diary_id", analysis.DiaryID, "retry_count", analysis.RetryCount)
+		}
+	}
+
+	return retried, nil
+}
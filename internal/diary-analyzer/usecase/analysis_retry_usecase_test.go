@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/gateway"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+)
+
+// TestAnalysisRetryUsecaseRetryDueSuccess tests that only the failed enrichment is retried and the retry is cleared
+func TestAnalysisRetryUsecaseRetryDueSuccess(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockDiaryRepo := new(MockDiaryRepository)
+	mockGateway := new(MockNLPGateway)
+	now := time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+
+	reason := "timeout"
+	keyword := &domain.DiaryKeyword{Word: "公園"}
+	analysis := &domain.DiaryAnalysis{
+		ID:             uuid.New(),
+		DiaryID:        uuid.New(),
+		AccuracyStatus: domain.AnalysisStatusFailed,
+		AccuracyError:  &reason,
+		KeywordsStatus: domain.AnalysisStatusComplete,
+		Keywords:       []*domain.DiaryKeyword{keyword},
+		NextRetryAt:    &now,
+	}
+	content := "公園で遊んだ。"
+
+	mockRepo.On("ListRetryDue", mock.Anything, now, domain.AnalysisRetryBatchSize).Return([]*domain.DiaryAnalysis{analysis}, nil)
+	mockDiaryRepo.On("FindByID", mock.Anything, analysis.DiaryID).Return(&domain.Diary{ID: analysis.DiaryID, Content: content}, nil)
+	mockGateway.On("CheckAccuracy", mock.Anything, content).Return([]gateway.Suggestion{{Rule: "r"}}, nil)
+	mockRepo.On("UpdateEnrichments", mock.Anything, mock.MatchedBy(func(a *domain.DiaryAnalysis) bool {
+		return a.AccuracyStatus == domain.AnalysisStatusComplete &&
+			*a.AccuracyScore == 90 &&
+			a.AccuracyError == nil &&
+			len(a.Suggestions) == 1 &&
+			len(a.Keywords) == 1 && a.Keywords[0] == keyword &&
+			a.RetryCount == 1 &&
+			a.NextRetryAt == nil
+	})).Return(nil)
+
+	tokenizer := stubTokenizer{err: assert.AnError} // must not be called
+	usecase := NewAnalysisRetryUsecase(mockRepo, mockDiaryRepo, mockGateway, tokenizer, domain.FlatPenaltyStrategy{}, &clock.Fixed{Time: now})
+	retried, err := usecase.RetryDue(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, retried)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestAnalysisRetryUsecaseRetryDueFailsAgain tests that a failing retry is rescheduled with backoff
+func TestAnalysisRetryUsecaseRetryDueFailsAgain(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockDiaryRepo := new(MockDiaryRepository)
+	mockGateway := new(MockNLPGateway)
+	now := time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+
+	analysis := &domain.DiaryAnalysis{
+		ID:             uuid.New(),
+		DiaryID:        uuid.New(),
+		AccuracyStatus: domain.AnalysisStatusFailed,
+		KeywordsStatus: domain.AnalysisStatusComplete,
+		RetryCount:     1,
+	}
+
+	mockRepo.On("ListRetryDue", mock.Anything, now, domain.AnalysisRetryBatchSize).Return([]*domain.DiaryAnalysis{analysis}, nil)
+	mockDiaryRepo.On("FindByID", mock.Anything, analysis.DiaryID).Return(&domain.Diary{ID: analysis.DiaryID, Content: "晴れ。"}, nil)
+	mockGateway.On("CheckAccuracy", mock.Anything, "晴れ。").Return(nil, assert.AnError)
+	mockRepo.On("UpdateEnrichments", mock.Anything, mock.MatchedBy(func(a *domain.DiaryAnalysis) bool {
+		return a.AccuracyStatus == domain.AnalysisStatusFailed &&
+			a.AccuracyScore == nil &&
+			a.RetryCount == 2 &&
+			a.NextRetryAt != nil && a.NextRetryAt.Equal(now.Add(4*time.Minute))
+	})).Return(nil)
+
+	usecase := NewAnalysisRetryUsecase(mockRepo, mockDiaryRepo, mockGateway, stubTokenizer{}, domain.FlatPenaltyStrategy{}, &clock.Fixed{Time: now})
+	_, err := usecase.RetryDue(context.Background())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestAnalysisRetryUsecaseRetryDueDeletedDiary tests that the retry of a deleted diary is cancelled
+func TestAnalysisRetryUsecaseRetryDueDeletedDiary(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockDiaryRepo := new(MockDiaryRepository)
+	mockGateway := new(MockNLPGateway)
+	now := time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+
+	analysis := &domain.DiaryAnalysis{ID: uuid.New(), DiaryID: uuid.New(), AccuracyStatus: domain.AnalysisStatusFailed, NextRetryAt: &now}
+
+	mockRepo.On("ListRetryDue", mock.Anything, now, domain.AnalysisRetryBatchSize).Return([]*domain.DiaryAnalysis{analysis}, nil)
+	mockDiaryRepo.On("FindByID", mock.Anything, analysis.DiaryID).Return(nil, nil)
+	mockRepo.On("UpdateEnrichments", mock.Anything, mock.MatchedBy(func(a *domain.DiaryAnalysis) bool {
+		return a.NextRetryAt == nil
+	})).Return(nil)
+
+	usecase := NewAnalysisRetryUsecase(mockRepo, mockDiaryRepo, mockGateway, stubTokenizer{}, domain.FlatPenaltyStrategy{}, &clock.Fixed{Time: now})
+	_, err := usecase.RetryDue(context.Background())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockGateway.AssertNotCalled(t, "CheckAccuracy", mock.Anything, mock.Anything)
+}
+
+// TestAnalysisRetryUsecaseRetryDueDeferred tests that a deferred check is retried without using up retries
+func TestAnalysisRetryUsecaseRetryDueDeferred(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockDiaryRepo := new(MockDiaryRepository)
+	mockGateway := new(MockNLPGateway)
+	now := time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+
+	analysis := &domain.DiaryAnalysis{
+		ID:             uuid.New(),
+		DiaryID:        uuid.New(),
+		AccuracyStatus: domain.AnalysisStatusDeferred,
+		KeywordsStatus: domain.AnalysisStatusComplete,
+		RetryCount:     domain.MaxAnalysisRetries,
+		NextRetryAt:    &now,
+	}
+
+	mockRepo.On("ListRetryDue", mock.Anything, now, domain.AnalysisRetryBatchSize).Return([]*domain.DiaryAnalysis{analysis}, nil)
+	mockDiaryRepo.On("FindByID", mock.Anything, analysis.DiaryID).Return(&domain.Diary{ID: analysis.DiaryID, Content: "晴れ。"}, nil)
+	mockGateway.On("CheckAccuracy", mock.Anything, "晴れ。").Return([]gateway.Suggestion{}, nil)
+	mockRepo.On("UpdateEnrichments", mock.Anything, mock.MatchedBy(func(a *domain.DiaryAnalysis) bool {
+		return a.AccuracyStatus == domain.AnalysisStatusComplete &&
+			*a.AccuracyScore == 100 &&
+			a.RetryCount == domain.MaxAnalysisRetries &&
+			a.NextRetryAt == nil
+	})).Return(nil)
+
+	usecase := NewAnalysisRetryUsecase(mockRepo, mockDiaryRepo, mockGateway, stubTokenizer{}, domain.FlatPenaltyStrategy{}, &clock.Fixed{Time: now})
+	retried, err := usecase.RetryDue(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, retried)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+	"github.com/google/uuid"
+)
+
+// BackfillInput is the input for BackfillUsecase.Run.
+// Diaries created in [From, To) are re-analyzed; with FailedOnly, only those with a failed enrichment.
+type BackfillInput struct {
+	From       time.Time
+	To         time.Time
+	FailedOnly bool
+	// Interval is the wait between diaries to respect the NLP API rate limit
+	Interval time.Duration
+}
+
+// BackfillProgress is reported after each diary
+type BackfillProgress struct {
+	Total     int
+	Processed int
+	Succeeded int
+	Failed    int
+	// Skipped counts diaries that no longer exist or are empty
+	Skipped int
+}
+
+// BackfillUsecase re-runs the analysis of past diaries
+type BackfillUsecase interface {
+	Run(ctx context.Context, input *BackfillInput, progress func(BackfillProgress)) (*BackfillProgress, error)
+}
+
+type backfillUsecase struct {
+	ar repository.DiaryAnalysisRepository
+	dr repository.DiaryRepository
+	au DiaryAnalysisUsecase
+}
+
+// NewBackfillUsecase creates a new BackfillUsecase
+func NewBackfillUsecase(ar repository.DiaryAnalysisRepository, dr repository.DiaryRepository, au DiaryAnalysisUsecase) BackfillUsecase {
+	return &backfillUsecase{
+		ar: ar,
+		dr: dr,
+		au: au,
+	}
+}
+
+// Run re-analyzes the diaries one by one. A diary that fails is counted and the backfill goes on;
+// it stops only when the target diaries cannot be listed or ctx is cancelled.
+func (u *backfillUsecase) Run(ctx context.Context, input *BackfillInput, progress func(BackfillProgress)) (*BackfillProgress, error) {
+	if input.From.IsZero() || input.To.IsZero() || !input.From.Before(input.To) {
+		return nil, &errors.ValidationError{Message: "from must be before to"}
+	}
+	if input.Interval < 0 {
+		return nil, &errors.ValidationError{Message: "interval must not be negative"}
+	}
+
+	var ids []uuid.UUID
+	var err error
+	if input.FailedOnly {
+		ids, err = u.ar.ListFailedDiaryIDs(ctx, input.From, input.To)
+	} else {
+		ids, err = u.dr.ListIDsCreatedBetween(ctx, input.From, input.To)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p := BackfillProgress{Total: len(ids)}
+	for i, id := range ids {
+		if i > 0 && input.Interval > 0 {
+			select {
+			case <-ctx.Done():
+				return &p, ctx.Err()
+			case <-time.After(input.Interval):
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return &p, err
+		}
+
+		diary, err := u.dr.FindByID(ctx, id)
+		switch {
+		case err != nil:
+			slog.Error("failed to load diary for backfill", "diary_id", id, "error", err.Error())
+			p.Failed++
+		case diary == nil || diary.Content == "":
+			p.Skipped++
+		default:
+			if _, err := u.au.Reanalyze(ctx, diary); err != nil {
+				slog.Error("failed to re-analyze diary", "diary_id", id, "error", err.Error())
+				p.Failed++
+			} else {
+				p.Succeeded++
+			}
+		}
+
+		p.Processed++
+		if progress != nil {
+			progress(p)
+		}
+	}
+
+	return &p, nil
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+)
+
+type MockDiaryRepository struct {
+	mock.Mock
+}
+
+func (m *MockDiaryRepository) ListIDsCreatedBetween(ctx context.Context, from, to time.Time) ([]uuid.UUID, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockDiaryRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Diary, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Diary), args.Error(1)
+}
+
+type MockDiaryAnalysisUsecase struct {
+	mock.Mock
+}
+
+func (m *MockDiaryAnalysisUsecase) Analyze(ctx context.Context, event *domain.DiaryCreatedEvent) (*domain.DiaryAnalysis, error) {
+	args := m.Called(ctx, event)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DiaryAnalysis), args.Error(1)
+}
+
+func (m *MockDiaryAnalysisUsecase) Reanalyze(ctx context.Context, diary *domain.Diary) (*domain.DiaryAnalysis, error) {
+	args := m.Called(ctx, diary)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DiaryAnalysis), args.Error(1)
+}
+
+// TestBackfillUsecaseRunDateRange tests that every diary in the range is re-analyzed and progress is reported
+func TestBackfillUsecaseRunDateRange(t *testing.T) {
+	mockAnalysisRepo := new(MockDiaryAnalysisRepository)
+	mockDiaryRepo := new(MockDiaryRepository)
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	ok, failing, deleted := uuid.New(), uuid.New(), uuid.New()
+
+	mockDiaryRepo.On("ListIDsCreatedBetween", mock.Anything, from, to).Return([]uuid.UUID{ok, failing, deleted}, nil)
+	mockDiaryRepo.On("FindByID", mock.Anything, ok).Return(&domain.Diary{ID: ok, Content: "a"}, nil)
+	mockDiaryRepo.On("FindByID", mock.Anything, failing).Return(&domain.Diary{ID: failing, Content: "b"}, nil)
+	mockDiaryRepo.On("FindByID", mock.Anything, deleted).Return(nil, nil)
+	mockUsecase.On("Reanalyze", mock.Anything, mock.MatchedBy(func(d *domain.Diary) bool { return d.ID == ok })).Return(&domain.DiaryAnalysis{}, nil)
+	mockUsecase.On("Reanalyze", mock.Anything, mock.MatchedBy(func(d *domain.Diary) bool { return d.ID == failing })).Return(nil, assert.AnError)
+
+	var reported []BackfillProgress
+	usecase := NewBackfillUsecase(mockAnalysisRepo, mockDiaryRepo, mockUsecase)
+	result, err := usecase.Run(context.Background(), &BackfillInput{From: from, To: to}, func(p BackfillProgress) {
+		reported = append(reported, p)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, BackfillProgress{Total: 3, Processed: 3, Succeeded: 1, Failed: 1, Skipped: 1}, *result)
+	assert.Len(t, reported, 3)
+	assert.Equal(t, 1, reported[0].Processed)
+	mockAnalysisRepo.AssertNotCalled(t, "ListFailedDiaryIDs", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestBackfillUsecaseRunFailedOnly tests that only diaries whose analysis failed are re-analyzed
+func TestBackfillUsecaseRunFailedOnly(t *testing.T) {
+	mockAnalysisRepo := new(MockDiaryAnalysisRepository)
+	mockDiaryRepo := new(MockDiaryRepository)
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC)
+	id := uuid.New()
+	diary := &domain.Diary{ID: id, Content: "a"}
+
+	mockAnalysisRepo.On("ListFailedDiaryIDs", mock.Anything, from, to).Return([]uuid.UUID{id}, nil)
+	mockDiaryRepo.On("FindByID", mock.Anything, id).Return(diary, nil)
+	mockUsecase.On("Reanalyze", mock.Anything, diary).Return(&domain.DiaryAnalysis{}, nil)
+
+	usecase := NewBackfillUsecase(mockAnalysisRepo, mockDiaryRepo, mockUsecase)
+	result, err := usecase.Run(context.Background(), &BackfillInput{From: from, To: to, FailedOnly: true}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded)
+	mockDiaryRepo.AssertNotCalled(t, "ListIDsCreatedBetween", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestBackfillUsecaseRunCancelledWhileThrottled tests that the backfill stops during the wait between diaries
+func TestBackfillUsecaseRunCancelledWhileThrottled(t *testing.T) {
+	mockAnalysisRepo := new(MockDiaryAnalysisRepository)
+	mockDiaryRepo := new(MockDiaryRepository)
+	mockUsecase := new(MockDiaryAnalysisUsecase)
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	first, second := uuid.New(), uuid.New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockDiaryRepo.On("ListIDsCreatedBetween", mock.Anything, from, to).Return([]uuid.UUID{first, second}, nil)
+	mockDiaryRepo.On("FindByID", mock.Anything, first).Return(&domain.Diary{ID: first, Content: "a"}, nil)
+	mockUsecase.On("Reanalyze", mock.Anything, mock.Anything).Return(&domain.DiaryAnalysis{}, nil)
+
+	usecase := NewBackfillUsecase(mockAnalysisRepo, mockDiaryRepo, mockUsecase)
+	result, err := usecase.Run(ctx, &BackfillInput{From: from, To: to, Interval: time.Hour}, func(BackfillProgress) {
+		cancel()
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, result.Processed)
+	mockDiaryRepo.AssertNotCalled(t, "FindByID", mock.Anything, second)
+}
+
+// TestBackfillUsecaseRunInvalidRange tests the validation of the date range
+func TestBackfillUsecaseRunInvalidRange(t *testing.T) {
+	usecase := NewBackfillUsecase(new(MockDiaryAnalysisRepository), new(MockDiaryRepository), new(MockDiaryAnalysisUsecase))
+
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := usecase.Run(context.Background(), &BackfillInput{From: day, To: day}, nil)
+
+	assert.IsType(t, &errors.ValidationError{}, err)
+}
@@ -3,100 +3,386 @@ package usecase
 import (
 	"context"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/domain"
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/gateway"
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/pkg/broker/publisher"
 	"github.com/furuya-3150/fam-diary-log/pkg/errors"
 	"github.com/google/uuid"
 )
 
-const (
-	CheckAccuracyDefaultScore = 100
-)
-
 type DiaryAnalysisUsecase interface {
 	Analyze(ctx context.Context, event *domain.DiaryCreatedEvent) (*domain.DiaryAnalysis, error)
+	Reanalyze(ctx context.Context, diary *domain.Diary) (*domain.DiaryAnalysis, error)
 }
 
 type diaryAnalysisUsecase struct {
-	ar         repository.DiaryAnalysisRepository
+	ar repository.DiaryAnalysisRepository
+	// er supplies the members, places and pets linked in the content; nil links nothing
+	er         repository.EntityRepository
 	nlpGateway gateway.NLPGateway
+	tokenizer  gateway.Tokenizer
+	scoring    domain.AccuracyScoringStrategy
+	// publisher announces diary.analyzed; nil publishes nothing
+	publisher publisher.Publisher
 }
 
 func NewDiaryAnalysisUsecase(
 	ar repository.DiaryAnalysisRepository,
+	er repository.EntityRepository,
 	nlpGateway gateway.NLPGateway,
+	tokenizer gateway.Tokenizer,
+	scoring domain.AccuracyScoringStrategy,
+	pub publisher.Publisher,
 ) DiaryAnalysisUsecase {
 	return &diaryAnalysisUsecase{
 		ar:         ar,
+		er:         er,
 		nlpGateway: nlpGateway,
+		tokenizer:  tokenizer,
+		scoring:    scoring,
+		publisher:  pub,
 	}
 }
 
-func NewDiaryAnalysisUsecaseWithNLPGateway(ar repository.DiaryAnalysisRepository, nlpGateway gateway.NLPGateway) DiaryAnalysisUsecase {
-	return &diaryAnalysisUsecase{
-		ar:         ar,
-		nlpGateway: nlpGateway,
-	}
+// NewDiaryAnalysisUsecaseWithNLPGateway creates a DiaryAnalysisUsecase scoring the accuracy with the legacy flat penalty,
+// linking no entities and publishing no event
+func NewDiaryAnalysisUsecaseWithNLPGateway(ar repository.DiaryAnalysisRepository, nlpGateway gateway.NLPGateway, tokenizer gateway.Tokenizer) DiaryAnalysisUsecase {
+	return NewDiaryAnalysisUsecase(ar, nil, nlpGateway, tokenizer, domain.FlatPenaltyStrategy{}, nil)
 }
 
-// Analyze performs diary content analysis
+// Analyze performs diary content analysis. If the diary is already analyzed (a redelivered event, or diary.updated
+// handled first), the stored analysis is kept and returned.
 func (u *diaryAnalysisUsecase) Analyze(ctx context.Context, event *domain.DiaryCreatedEvent) (*domain.DiaryAnalysis, error) {
-	if event.DiaryID == uuid.Nil || event.UserID == uuid.Nil || event.FamilyID == uuid.Nil {
-		return nil, &errors.ValidationError{Message: "diary_id, user_id, and family_id are required"}
+	diary := &domain.Diary{
+		ID:                 event.DiaryID,
+		UserID:             event.UserID,
+		FamilyID:           event.FamilyID,
+		Content:            event.Content,
+		WritingTimeSeconds: event.WritingTimeSeconds,
+		UnlockAt:           event.UnlockAt,
+	}
+	if err := u.validate(diary); err != nil {
+		return nil, err
 	}
 
-	if event.Content == "" {
-		return nil, &errors.ValidationError{Message: "content is required"}
+	analysis := u.analyze(ctx, diary)
+	if err := u.linkEntities(ctx, analysis, diary.Content); err != nil {
+		return nil, err
+	}
+	// 再配信されても同じ日付になるよう、日記が書かれた時刻を分析日時とする
+	analysis.CreatedAt = event.Timestamp
+	if analysis.CreatedAt.IsZero() {
+		analysis.CreatedAt = time.Now()
+	}
+	if err := u.setLocalDate(ctx, analysis); err != nil {
+		return nil, err
 	}
 
-	if u.nlpGateway == nil {
-		return nil, &errors.LogicError{Message: "NLP gateway not configured"}
+	// Store result
+	stored, err := u.ar.Create(ctx, analysis)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		// 再配信、または diary.updated が先に届いた場合は保存済みの分析を残す
+		existing, err := u.ar.FindByDiaryID(ctx, diary.ID)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, &errors.LogicError{Message: "analysis of the diary is neither created nor found"}
+		}
+		analysis = existing
 	}
 
-	if event.DiaryID == uuid.Nil || event.UserID == uuid.Nil || event.FamilyID == uuid.Nil {
-		return nil, &errors.ValidationError{Message: "diary_id, user_id, and family_id are required"}
+	// Announce the result. On failure the event is redelivered and the stored analysis is announced again.
+	if err := u.publishAnalyzed(ctx, analysis); err != nil {
+		return nil, err
 	}
 
-	// Perform analysis
-	analysis := &domain.DiaryAnalysis{
-		ID:                 uuid.New(),
-		DiaryID:            event.DiaryID,
-		UserID:             event.UserID,
-		FamilyID:           event.FamilyID,
-		CharCount:          len([]rune(event.Content)),
-		SentenceCount:      u.countSentences(event.Content),
-		WritingTimeSeconds: event.WritingTimeSeconds,
+	return analysis, nil
+}
+
+// Reanalyze analyzes the diary again and replaces its existing analysis (upsert by diary_id).
+// The analysis date, its local date and the writing time of the existing analysis are kept.
+func (u *diaryAnalysisUsecase) Reanalyze(ctx context.Context, diary *domain.Diary) (*domain.DiaryAnalysis, error) {
+	if err := u.validate(diary); err != nil {
+		return nil, err
 	}
 
-	// Check accuracy (get suggestion count from gateway)
-	suggestionCount, err := u.nlpGateway.CheckAccuracy(ctx, event.Content)
-	if err == nil {
-		// Calculate accuracy score using domain logic
-		calculator := domain.NewAccuracyScoreCalculator()
-		analysis.AccuracyScore = calculator.Calculate(suggestionCount)
-	} else {
-		analysis.AccuracyScore = CheckAccuracyDefaultScore
-		slog.Error("Failed to check accuracy", "error", err)
+	existing, err := u.ar.FindByDiaryID(ctx, diary.ID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Store result
-	_, err = u.ar.Create(ctx, analysis)
+	analysis := u.analyze(ctx, diary)
+	if err := u.linkEntities(ctx, analysis, diary.Content); err != nil {
+		return nil, err
+	}
+	analysis.CreatedAt = diary.CreatedAt
+	if existing != nil {
+		analysis.CreatedAt = existing.CreatedAt
+		analysis.LocalDate = existing.LocalDate
+		if analysis.WritingTimeSeconds == 0 {
+			analysis.WritingTimeSeconds = existing.WritingTimeSeconds
+		}
+	}
+	if analysis.LocalDate.IsZero() {
+		if err := u.setLocalDate(ctx, analysis); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = u.ar.Replace(ctx, analysis)
 	if err != nil {
 		return nil, err
 	}
 
+	// Announce the new result. On failure the event is redelivered and the diary is analyzed again.
+	if err := u.publishAnalyzed(ctx, analysis); err != nil {
+		return nil, err
+	}
+
 	return analysis, nil
 }
 
-// countSentences counts sentences in content
-func (u *diaryAnalysisUsecase) countSentences(content string) int {
-	count := 0
-	for _, r := range content {
-		if r == '。' || r == '！' || r == '？' {
-			count++
+// publishAnalyzed publishes diary.analyzed for the stored analysis, unless the usecase has no publisher
+func (u *diaryAnalysisUsecase) publishAnalyzed(ctx context.Context, analysis *domain.DiaryAnalysis) error {
+	if u.publisher == nil {
+		return nil
+	}
+	if err := u.publisher.Publish(ctx, domain.NewDiaryAnalyzedEvent(analysis)); err != nil {
+		slog.Error("failed to publish diary analyzed event", "diary_id", analysis.DiaryID, "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (u *diaryAnalysisUsecase) validate(diary *domain.Diary) error {
+	if diary.ID == uuid.Nil || diary.UserID == uuid.Nil || diary.FamilyID == uuid.Nil {
+		return &errors.ValidationError{Message: "diary_id, user_id, and family_id are required"}
+	}
+
+	if diary.Content == "" {
+		return &errors.ValidationError{Message: "content is required"}
+	}
+
+	if u.nlpGateway == nil {
+		return &errors.LogicError{Message: "NLP gateway not configured"}
+	}
+
+	return nil
+}
+
+// analyze computes the metrics of the diary. Failures of the NLP gateway and the tokenizer do not fail the analysis;
+// they are recorded in the status of the enrichment and retried later.
+func (u *diaryAnalysisUsecase) analyze(ctx context.Context, diary *domain.Diary) *domain.DiaryAnalysis {
+	sentences := domain.NewSentenceSegmenter().Segment(diary.Content)
+	analysis := &domain.DiaryAnalysis{
+		ID:                 uuid.New(),
+		DiaryID:            diary.ID,
+		UserID:             diary.UserID,
+		FamilyID:           diary.FamilyID,
+		CharCount:          len([]rune(diary.Content)),
+		SentenceCount:      len(sentences),
+		WritingTimeSeconds: diary.WritingTimeSeconds,
+		UnlockAt:           diary.UnlockAt,
+	}
+
+	readability := domain.NewReadabilityAnalyzer().Analyze(diary.Content, sentences)
+	analysis.Language = readability.Language
+	analysis.KanjiRatio = readability.KanjiRatio
+	analysis.HiraganaRatio = readability.HiraganaRatio
+	analysis.KatakanaRatio = readability.KatakanaRatio
+	analysis.AverageSentenceLength = &readability.AverageSentenceLength
+	analysis.LongestSentenceLength = &readability.LongestSentenceLength
+
+	// 感情辞書は日本語のみのため、英語の日記は採点しない
+	if analysis.Language != domain.LanguageEnglish {
+		sentiment := domain.NewSentimentAnalyzer().Analyze(diary.Content)
+		analysis.SentimentScore = &sentiment.Score
+		analysis.SentimentPositiveCount = sentiment.PositiveCount
+		analysis.SentimentNegativeCount = sentiment.NegativeCount
+	}
+
+	checkAccuracy(ctx, u.nlpGateway, u.scoring, analysis, diary.Content)
+	extractKeywords(ctx, u.tokenizer, analysis, diary.Content)
+	analysis.ScheduleRetry(time.Now())
+
+	return analysis
+}
+
+// linkEntities finds the members, places and pets of the family in the content.
+// Unlike the enrichments it reads the analyzer database only, so a failure fails the analysis and the event is redelivered.
+func (u *diaryAnalysisUsecase) linkEntities(ctx context.Context, analysis *domain.DiaryAnalysis, content string) error {
+	if u.er == nil {
+		return nil
+	}
+
+	members, err := u.er.ListMembers(ctx, analysis.FamilyID)
+	if err != nil {
+		return err
+	}
+	entities, err := u.er.ListEntities(ctx, analysis.FamilyID)
+	if err != nil {
+		return err
+	}
+
+	mentions := domain.NewEntityLinker(members, entities).Link(content)
+	analysis.EntityReferences = toEntityReferences(analysis, mentions)
+	return nil
+}
+
+// setLocalDate dates the analysis in the time zone of its author, or in the default time zone
+// when the author is not synced from user-context yet
+func (u *diaryAnalysisUsecase) setLocalDate(ctx context.Context, analysis *domain.DiaryAnalysis) error {
+	timezone := domain.DefaultTimezone
+	if u.er != nil {
+		member, err := u.er.FindMember(ctx, analysis.FamilyID, analysis.UserID)
+		if err != nil {
+			return err
+		}
+		if member != nil {
+			timezone = member.Timezone
 		}
 	}
-	return count
+	analysis.LocalDate = domain.LocalDate(analysis.CreatedAt, timezone)
+	return nil
+}
+
+// checkAccuracy scores the content with the NLP gateway. On failure the score is left NULL
+// and the accuracy is marked failed so that the retry scheduler re-attempts it.
+// Over the NLP quota the check is deferred until the quota allows, which is not a failure.
+// English diaries are skipped, and suggestions in the English sentences of a mixed diary are dropped.
+func checkAccuracy(ctx context.Context, nlpGateway gateway.NLPGateway, scoring domain.AccuracyScoringStrategy, analysis *domain.DiaryAnalysis, content string) {
+	calculator := domain.NewAccuracyScoreCalculatorWithStrategy(scoring)
+	sentences := domain.NewSentenceSegmenter().Segment(content)
+	if !calculator.Supports(domain.DetectLanguage(sentences)) {
+		analysis.AccuracyScore = nil
+		analysis.AccuracyStrategy = nil
+		analysis.AccuracyStatus = domain.AnalysisStatusSkipped
+		analysis.AccuracyError = nil
+		analysis.Suggestions = nil
+		return
+	}
+
+	suggestions, err := nlpGateway.CheckAccuracy(ctx, content)
+	if quotaErr, ok := gateway.AsQuotaExceeded(err); ok {
+		analysis.AccuracyScore = nil
+		analysis.AccuracyStrategy = nil
+		analysis.AccuracyStatus = domain.AnalysisStatusDeferred
+		analysis.AccuracyError = nil
+		analysis.DeferredUntil = &quotaErr.RetryAt
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to check accuracy", "diary_id", analysis.DiaryID, "error", err)
+		reason := err.Error()
+		analysis.AccuracyScore = nil
+		analysis.AccuracyStrategy = nil
+		analysis.AccuracyStatus = domain.AnalysisStatusFailed
+		analysis.AccuracyError = &reason
+		return
+	}
+
+	applicable := make([]gateway.Suggestion, 0, len(suggestions))
+	issues := make([]domain.AccuracyIssue, 0, len(suggestions))
+	for _, s := range suggestions {
+		if calculator.Applies(sentences, s.Offset) {
+			applicable = append(applicable, s)
+			issues = append(issues, domain.AccuracyIssue{Rule: s.Rule})
+		}
+	}
+	suggestions = applicable
+
+	// Calculate accuracy score using domain logic
+	score := calculator.Calculate(issues, len([]rune(content)))
+	strategyID := calculator.StrategyID()
+	analysis.AccuracyScore = &score
+	analysis.AccuracyStrategy = &strategyID
+	analysis.AccuracyStatus = domain.AnalysisStatusComplete
+	analysis.AccuracyError = nil
+	analysis.Suggestions = toAnalysisSuggestions(analysis, suggestions)
+}
+
+// extractKeywords stores the top nouns and the vocabulary richness of the content. On failure the keywords are marked failed.
+func extractKeywords(ctx context.Context, tokenizer gateway.Tokenizer, analysis *domain.DiaryAnalysis, content string) {
+	if tokenizer == nil {
+		reason := "tokenizer not configured"
+		analysis.KeywordsStatus = domain.AnalysisStatusFailed
+		analysis.KeywordsError = &reason
+		return
+	}
+
+	tokens, err := tokenizer.Tokenize(ctx, content)
+	if err != nil {
+		slog.Error("Failed to tokenize diary", "diary_id", analysis.DiaryID, "error", err)
+		reason := err.Error()
+		analysis.KeywordsStatus = domain.AnalysisStatusFailed
+		analysis.KeywordsError = &reason
+		return
+	}
+
+	morphemes := toMorphemes(tokens)
+	analysis.KeywordsStatus = domain.AnalysisStatusComplete
+	analysis.KeywordsError = nil
+	analysis.Keywords = toAnalysisKeywords(analysis, morphemes)
+	analysis.TypeTokenRatio = domain.TypeTokenRatio(morphemes)
+}
+
+func toAnalysisSuggestions(analysis *domain.DiaryAnalysis, suggestions []gateway.Suggestion) []*domain.DiaryAnalysisSuggestion {
+	result := make([]*domain.DiaryAnalysisSuggestion, len(suggestions))
+	for i, s := range suggestions {
+		result[i] = &domain.DiaryAnalysisSuggestion{
+			AnalysisID: analysis.ID,
+			DiaryID:    analysis.DiaryID,
+			Rule:       s.Rule,
+			Offset:     s.Offset,
+			Length:     s.Length,
+			Message:    s.Message,
+			Suggestion: s.Suggestion,
+		}
+	}
+	return result
+}
+
+func toEntityReferences(analysis *domain.DiaryAnalysis, mentions []domain.EntityMention) []*domain.DiaryEntityReference {
+	result := make([]*domain.DiaryEntityReference, len(mentions))
+	for i, m := range mentions {
+		result[i] = &domain.DiaryEntityReference{
+			AnalysisID: analysis.ID,
+			DiaryID:    analysis.DiaryID,
+			UserID:     analysis.UserID,
+			FamilyID:   analysis.FamilyID,
+			EntityKind: m.Kind,
+			EntityID:   m.EntityID,
+			Surface:    m.Surface,
+			Offset:     m.Offset,
+			Length:     m.Length,
+		}
+	}
+	return result
+}
+
+func toMorphemes(tokens []gateway.Token) []domain.Morpheme {
+	morphemes := make([]domain.Morpheme, len(tokens))
+	for i, t := range tokens {
+		morphemes[i] = domain.Morpheme{Surface: t.Surface, POS: strings.Split(t.POS, ",")}
+	}
+	return morphemes
+}
+
+func toAnalysisKeywords(analysis *domain.DiaryAnalysis, morphemes []domain.Morpheme) []*domain.DiaryKeyword {
+	keywords := domain.NewKeywordExtractor().Extract(morphemes, domain.MaxKeywordsPerDiary)
+	for _, k := range keywords {
+		k.AnalysisID = analysis.ID
+		k.DiaryID = analysis.DiaryID
+		k.UserID = analysis.UserID
+		k.FamilyID = analysis.FamilyID
+	}
+	return keywords
 }
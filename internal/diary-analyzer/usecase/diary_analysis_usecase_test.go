@@ -2,14 +2,19 @@ package usecase
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/gateway"
 	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+	"github.com/furuya-3150/fam-diary-log/pkg/events"
 )
 
 type MockDiaryAnalysisRepository struct {
@@ -24,13 +29,123 @@ func (m *MockDiaryAnalysisRepository) Create(ctx context.Context, analysis *doma
 	return args.Get(0).(*domain.DiaryAnalysis), args.Error(1)
 }
 
+func (m *MockDiaryAnalysisRepository) FindByDiaryID(ctx context.Context, diaryID uuid.UUID) (*domain.DiaryAnalysis, error) {
+	args := m.Called(ctx, diaryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DiaryAnalysis), args.Error(1)
+}
+
+func (m *MockDiaryAnalysisRepository) Replace(ctx context.Context, analysis *domain.DiaryAnalysis) (*domain.DiaryAnalysis, error) {
+	args := m.Called(ctx, analysis)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DiaryAnalysis), args.Error(1)
+}
+
+func (m *MockDiaryAnalysisRepository) ListRetryDue(ctx context.Context, now time.Time, limit int) ([]*domain.DiaryAnalysis, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DiaryAnalysis), args.Error(1)
+}
+
+func (m *MockDiaryAnalysisRepository) UpdateEnrichments(ctx context.Context, analysis *domain.DiaryAnalysis) error {
+	args := m.Called(ctx, analysis)
+	return args.Error(0)
+}
+
+func (m *MockDiaryAnalysisRepository) ListFailedDiaryIDs(ctx context.Context, from, to time.Time) ([]uuid.UUID, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockDiaryAnalysisRepository) RebuildDaily(ctx context.Context, day time.Time) (int64, error) {
+	args := m.Called(ctx, day)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockEntityRepository struct {
+	mock.Mock
+}
+
+func (m *MockEntityRepository) UpsertMember(ctx context.Context, member *domain.FamilyMember) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (m *MockEntityRepository) RemoveMember(ctx context.Context, member *domain.FamilyMember) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (m *MockEntityRepository) ListMembers(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyMember, error) {
+	args := m.Called(ctx, familyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.FamilyMember), args.Error(1)
+}
+
+func (m *MockEntityRepository) FindMember(ctx context.Context, familyID, userID uuid.UUID) (*domain.FamilyMember, error) {
+	args := m.Called(ctx, familyID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FamilyMember), args.Error(1)
+}
+
+func (m *MockEntityRepository) ListEntities(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyEntity, error) {
+	args := m.Called(ctx, familyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.FamilyEntity), args.Error(1)
+}
+
 type MockNLPGateway struct {
 	mock.Mock
 }
 
-func (m *MockNLPGateway) CheckAccuracy(ctx context.Context, content string) (int, error) {
+func (m *MockNLPGateway) CheckAccuracy(ctx context.Context, content string) ([]gateway.Suggestion, error) {
 	args := m.Called(ctx, content)
-	return args.Get(0).(int), args.Error(1)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]gateway.Suggestion), args.Error(1)
+}
+
+type MockPublisher struct {
+	mock.Mock
+}
+
+func (m *MockPublisher) Publish(ctx context.Context, event events.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockPublisher) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+type stubTokenizer struct {
+	tokens []gateway.Token
+	err    error
+}
+
+func (s stubTokenizer) Tokenize(ctx context.Context, text string) ([]gateway.Token, error) {
+	return s.tokens, s.err
 }
 
 // TestDiaryAnalysisUsecaseAnalyzeSuccess tests successful analysis with NLP gateway
@@ -53,23 +168,24 @@ func TestDiaryAnalysisUsecaseAnalyzeSuccess(t *testing.T) {
 
 	mockGateway.On("CheckAccuracy", mock.Anything, mock.MatchedBy(func(content string) bool {
 		return content == event.Content
-	})).Return(2, nil)
+	})).Return(make([]gateway.Suggestion, 2), nil)
 
 	expectedAnalysis := &domain.DiaryAnalysis{
 		DiaryID:       diaryID,
 		UserID:        userID,
 		FamilyID:      familyID,
-		AccuracyScore: 80,
+		AccuracyScore: intPtr(80),
 	}
 
 	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(analysis *domain.DiaryAnalysis) bool {
 		return analysis.DiaryID == diaryID &&
 			analysis.UserID == userID &&
 			analysis.FamilyID == familyID &&
-			analysis.AccuracyScore == 80
+			*analysis.AccuracyScore == 80 &&
+			analysis.AccuracyStatus == domain.AnalysisStatusComplete
 	})).Return(expectedAnalysis, nil)
 
-	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway)
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, stubTokenizer{})
 
 	// Act
 	result, err := usecase.Analyze(context.Background(), event)
@@ -78,7 +194,7 @@ func TestDiaryAnalysisUsecaseAnalyzeSuccess(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, diaryID, result.DiaryID)
-	assert.Equal(t, 80, result.AccuracyScore)
+	assert.Equal(t, intPtr(80), result.AccuracyScore)
 	mockRepo.AssertExpectations(t)
 	mockGateway.AssertExpectations(t)
 }
@@ -96,7 +212,7 @@ func TestDiaryAnalysisUsecaseAnalyzeEmptyContent(t *testing.T) {
 		Content:  "",
 	}
 
-	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway)
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, stubTokenizer{})
 
 	// Act
 	result, err := usecase.Analyze(context.Background(), event)
@@ -109,7 +225,7 @@ func TestDiaryAnalysisUsecaseAnalyzeEmptyContent(t *testing.T) {
 	mockGateway.AssertNotCalled(t, "CheckAccuracy")
 }
 
-// TestDiaryAnalysisUsecaseAnalyzeNLPGatewayError tests NLP gateway error with default score
+// TestDiaryAnalysisUsecaseAnalyzeNLPGatewayError tests that an NLP gateway error stores no score and schedules a retry
 func TestDiaryAnalysisUsecaseAnalyzeNLPGatewayError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockDiaryAnalysisRepository)
@@ -118,7 +234,7 @@ func TestDiaryAnalysisUsecaseAnalyzeNLPGatewayError(t *testing.T) {
 	diaryID := uuid.New()
 	userID := uuid.New()
 	familyID := uuid.New()
-	content := "test content"
+	content := "テストの内容です。"
 
 	event := &domain.DiaryCreatedEvent{
 		DiaryID:  diaryID,
@@ -129,13 +245,16 @@ func TestDiaryAnalysisUsecaseAnalyzeNLPGatewayError(t *testing.T) {
 
 	mockGateway.On("CheckAccuracy", mock.Anything, mock.MatchedBy(func(content string) bool {
 		return content == event.Content
-	})).Return(0, assert.AnError)
+	})).Return(nil, assert.AnError)
 	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(analysis *domain.DiaryAnalysis) bool {
 		return analysis.DiaryID == diaryID &&
-			analysis.AccuracyScore == CheckAccuracyDefaultScore
+			analysis.AccuracyScore == nil &&
+			analysis.AccuracyStatus == domain.AnalysisStatusFailed &&
+			*analysis.AccuracyError == assert.AnError.Error() &&
+			analysis.NextRetryAt != nil
 	})).Return(&domain.DiaryAnalysis{}, nil)
 
-	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway)
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, stubTokenizer{})
 
 	// Act
 	result, err := usecase.Analyze(context.Background(), event)
@@ -143,7 +262,8 @@ func TestDiaryAnalysisUsecaseAnalyzeNLPGatewayError(t *testing.T) {
 	// Assert
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, CheckAccuracyDefaultScore, result.AccuracyScore)
+	assert.Nil(t, result.AccuracyScore)
+	assert.Equal(t, domain.AnalysisStatusFailed, result.AccuracyStatus)
 	mockRepo.AssertExpectations(t)
 	mockGateway.AssertExpectations(t)
 }
@@ -158,13 +278,13 @@ func TestDiaryAnalysisUsecaseAnalyzeRepositoryError(t *testing.T) {
 		DiaryID:  uuid.New(),
 		UserID:   uuid.New(),
 		FamilyID: uuid.New(),
-		Content:  "test content",
+		Content:  "テストの内容です。",
 	}
 
-	mockGateway.On("CheckAccuracy", mock.Anything, mock.Anything).Return(85, nil)
+	mockGateway.On("CheckAccuracy", mock.Anything, mock.Anything).Return(make([]gateway.Suggestion, 1), nil)
 	mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil, assert.AnError)
 
-	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway)
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, stubTokenizer{})
 
 	// Act
 	result, err := usecase.Analyze(context.Background(), event)
@@ -185,16 +305,16 @@ func TestDiaryAnalysisUsecaseAnalyzeContextCancelled(t *testing.T) {
 		DiaryID:  uuid.New(),
 		UserID:   uuid.New(),
 		FamilyID: uuid.New(),
-		Content:  "test content",
+		Content:  "テストの内容です。",
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 
-	mockGateway.On("CheckAccuracy", mock.Anything, mock.Anything).Return(0, context.Canceled)
+	mockGateway.On("CheckAccuracy", mock.Anything, mock.Anything).Return(nil, context.Canceled)
 	mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil, context.Canceled)
 
-	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway)
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, stubTokenizer{})
 
 	// Act
 	result, err := usecase.Analyze(ctx, event)
@@ -204,52 +324,135 @@ func TestDiaryAnalysisUsecaseAnalyzeContextCancelled(t *testing.T) {
 	assert.Nil(t, result)
 }
 
-// TestDiaryAnalysisUsecaseCountSentences tests sentence counting
+// TestDiaryAnalysisUsecaseCountSentences tests sentence counting and language detection
 func TestDiaryAnalysisUsecaseCountSentences(t *testing.T) {
-	// Arrange
-	mockRepo := new(MockDiaryAnalysisRepository)
-	mockGateway := new(MockNLPGateway)
-
-	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway).(*diaryAnalysisUsecase)
-
 	tests := []struct {
-		name          string
-		content       string
-		expectedCount int
+		name             string
+		content          string
+		expectedCount    int
+		expectedLanguage string
 	}{
 		{
-			name:          "single sentence",
-			content:       "これは一つの文です。",
-			expectedCount: 1,
+			name:             "single sentence",
+			content:          "これは一つの文です。",
+			expectedCount:    1,
+			expectedLanguage: domain.LanguageJapanese,
+		},
+		{
+			name:             "multiple sentences",
+			content:          "最初の文。次の文。三番目の文。",
+			expectedCount:    3,
+			expectedLanguage: domain.LanguageJapanese,
 		},
 		{
-			name:          "multiple sentences",
-			content:       "最初の文。次の文。三番目の文。",
-			expectedCount: 3,
+			name:             "with exclamation",
+			content:          "すごい！素晴らしい？",
+			expectedCount:    2,
+			expectedLanguage: domain.LanguageJapanese,
 		},
 		{
-			name:          "with exclamation",
-			content:       "すごい！素晴らしい？",
-			expectedCount: 2,
+			name:             "trailing sentence without terminator",
+			content:          "句点がない",
+			expectedCount:    1,
+			expectedLanguage: domain.LanguageJapanese,
 		},
 		{
-			name:          "no sentences",
-			content:       "句点がない",
-			expectedCount: 0,
+			name:             "english",
+			content:          "We went to the zoo. The lion was asleep!",
+			expectedCount:    2,
+			expectedLanguage: domain.LanguageEnglish,
+		},
+		{
+			name:             "mixed",
+			content:          "Happy birthday! ケーキを食べた。",
+			expectedCount:    2,
+			expectedLanguage: domain.LanguageMixed,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockDiaryAnalysisRepository)
+			mockGateway := new(MockNLPGateway)
+			mockGateway.On("CheckAccuracy", mock.Anything, tt.content).Return([]gateway.Suggestion{}, nil).Maybe()
+			mockRepo.On("Create", mock.Anything, mock.Anything).Return(&domain.DiaryAnalysis{}, nil)
+
+			usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, stubTokenizer{})
+
 			// Act
-			count := usecase.countSentences(tt.content)
+			analysis, err := usecase.Analyze(context.Background(), &domain.DiaryCreatedEvent{
+				DiaryID:  uuid.New(),
+				UserID:   uuid.New(),
+				FamilyID: uuid.New(),
+				Content:  tt.content,
+			})
 
 			// Assert
-			assert.Equal(t, tt.expectedCount, count)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCount, analysis.SentenceCount)
+			assert.Equal(t, tt.expectedLanguage, analysis.Language)
 		})
 	}
 }
 
+// TestDiaryAnalysisUsecaseAnalyzeEnglish tests that an English diary is not proofread nor scored with the Japanese lexicon
+func TestDiaryAnalysisUsecaseAnalyzeEnglish(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockGateway := new(MockNLPGateway)
+
+	event := &domain.DiaryCreatedEvent{
+		DiaryID:  uuid.New(),
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+		Content:  "I played soccer with my friends. It was great!",
+	}
+
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(analysis *domain.DiaryAnalysis) bool {
+		return analysis.AccuracyStatus == domain.AnalysisStatusSkipped &&
+			analysis.AccuracyScore == nil &&
+			analysis.SentimentScore == nil &&
+			analysis.KanjiRatio == nil &&
+			analysis.NextRetryAt == nil
+	})).Return(&domain.DiaryAnalysis{}, nil)
+
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, stubTokenizer{})
+	_, err := usecase.Analyze(context.Background(), event)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockGateway.AssertNotCalled(t, "CheckAccuracy", mock.Anything, mock.Anything)
+}
+
+// TestDiaryAnalysisUsecaseAnalyzeMixed tests that suggestions in the English sentences of a mixed diary are dropped
+func TestDiaryAnalysisUsecaseAnalyzeMixed(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockGateway := new(MockNLPGateway)
+
+	event := &domain.DiaryCreatedEvent{
+		DiaryID:  uuid.New(),
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+		Content:  "Happy birthday! ごはんをを食べた。",
+	}
+
+	mockGateway.On("CheckAccuracy", mock.Anything, event.Content).Return([]gateway.Suggestion{
+		{Rule: gateway.RuleDoubledParticle, Offset: 3, Length: 5, Message: "英単語"},
+		{Rule: gateway.RuleDoubledParticle, Offset: 19, Length: 2, Message: "助詞が重複しています", Suggestion: "を"},
+	}, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(analysis *domain.DiaryAnalysis) bool {
+		return analysis.Language == domain.LanguageMixed &&
+			*analysis.AccuracyScore == 90 &&
+			len(analysis.Suggestions) == 1 && analysis.Suggestions[0].Offset == 19
+	})).Return(&domain.DiaryAnalysis{}, nil)
+
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, stubTokenizer{})
+	_, err := usecase.Analyze(context.Background(), event)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
 // TestDiaryAnalysisUsecaseAnalyzeNilDiaryID tests diary_id validation
 func TestDiaryAnalysisUsecaseAnalyzeNilDiaryID(t *testing.T) {
 	// Arrange
@@ -263,7 +466,7 @@ func TestDiaryAnalysisUsecaseAnalyzeNilDiaryID(t *testing.T) {
 		Content:  "これは日記のテスト内容です。",
 	}
 
-	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway)
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, stubTokenizer{})
 
 	// Act
 	result, err := usecase.Analyze(context.Background(), event)
@@ -289,7 +492,7 @@ func TestDiaryAnalysisUsecaseAnalyzeNilUserID(t *testing.T) {
 		Content:  "これは日記のテスト内容です。",
 	}
 
-	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway)
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, stubTokenizer{})
 
 	// Act
 	result, err := usecase.Analyze(context.Background(), event)
@@ -315,7 +518,7 @@ func TestDiaryAnalysisUsecaseAnalyzeNilFamilyID(t *testing.T) {
 		Content:  "これは日記のテスト内容です。",
 	}
 
-	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway)
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, stubTokenizer{})
 
 	// Act
 	result, err := usecase.Analyze(context.Background(), event)
@@ -327,3 +530,575 @@ func TestDiaryAnalysisUsecaseAnalyzeNilFamilyID(t *testing.T) {
 	mockRepo.AssertNotCalled(t, "Create")
 	mockGateway.AssertNotCalled(t, "CheckAccuracy")
 }
+
+// TestDiaryAnalysisUsecaseAnalyzeStoresSuggestions tests that suggestions are stored with the analysis
+func TestDiaryAnalysisUsecaseAnalyzeStoresSuggestions(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockGateway := new(MockNLPGateway)
+
+	event := &domain.DiaryCreatedEvent{
+		DiaryID:  uuid.New(),
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+		Content:  "ごはんをを食べた。",
+	}
+
+	suggestions := []gateway.Suggestion{
+		{Rule: gateway.RuleDoubledParticle, Offset: 3, Length: 2, Message: "助詞が重複しています", Suggestion: "を"},
+	}
+	mockGateway.On("CheckAccuracy", mock.Anything, event.Content).Return(suggestions, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(analysis *domain.DiaryAnalysis) bool {
+		if *analysis.AccuracyScore != 90 || len(analysis.Suggestions) != 1 {
+			return false
+		}
+		s := analysis.Suggestions[0]
+		return s.AnalysisID == analysis.ID && s.DiaryID == event.DiaryID &&
+			s.Rule == gateway.RuleDoubledParticle && s.Offset == 3 && s.Length == 2 && s.Suggestion == "を"
+	})).Return(&domain.DiaryAnalysis{}, nil)
+
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, stubTokenizer{})
+
+	// Act
+	_, err := usecase.Analyze(context.Background(), event)
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestDiaryAnalysisUsecaseAnalyzeScoringStrategy tests that the accuracy is scored with the configured strategy and its ID is stored
+func TestDiaryAnalysisUsecaseAnalyzeScoringStrategy(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockGateway := new(MockNLPGateway)
+
+	event := &domain.DiaryCreatedEvent{
+		DiaryID:  uuid.New(),
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+		Content:  strings.Repeat("今日は公園で遊んだ。", 30),
+	}
+
+	mockGateway.On("CheckAccuracy", mock.Anything, event.Content).Return([]gateway.Suggestion{
+		{Rule: gateway.RuleWidth, Offset: 0, Length: 1},
+		{Rule: gateway.RuleDoubledParticle, Offset: 10, Length: 2},
+		{Rule: gateway.RuleRaNuki, Offset: 20, Length: 3},
+	}, nil)
+	var stored *domain.DiaryAnalysis
+	mockRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*domain.DiaryAnalysis)
+	}).Return(&domain.DiaryAnalysis{}, nil)
+
+	usecase := NewDiaryAnalysisUsecase(mockRepo, nil, mockGateway, stubTokenizer{}, domain.SeverityWeightedStrategy{}, nil)
+	_, err := usecase.Analyze(context.Background(), event)
+
+	// (0.3 + 1 + 0.5) issues in 300 characters = 0.6 per 100 characters
+	require.NoError(t, err)
+	assert.Equal(t, 94, *stored.AccuracyScore)
+	assert.Equal(t, domain.AccuracyStrategySeverityV1, *stored.AccuracyStrategy)
+	assert.Len(t, stored.Suggestions, 3)
+}
+
+// TestDiaryAnalysisUsecaseAnalyzeSentiment tests that the sentiment is scored offline
+func TestDiaryAnalysisUsecaseAnalyzeSentiment(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockGateway := new(MockNLPGateway)
+
+	event := &domain.DiaryCreatedEvent{
+		DiaryID:  uuid.New(),
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+		Content:  "運動会は楽しくなかった。",
+	}
+
+	mockGateway.On("CheckAccuracy", mock.Anything, event.Content).Return([]gateway.Suggestion{}, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(analysis *domain.DiaryAnalysis) bool {
+		return analysis.SentimentScore != nil && *analysis.SentimentScore < 0 &&
+			analysis.SentimentNegativeCount == 1 && analysis.SentimentPositiveCount == 0
+	})).Return(&domain.DiaryAnalysis{}, nil)
+
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, stubTokenizer{})
+
+	// Act
+	_, err := usecase.Analyze(context.Background(), event)
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestDiaryAnalysisUsecaseAnalyzeStoresKeywords tests that keywords extracted from the tokens are stored with the analysis
+func TestDiaryAnalysisUsecaseAnalyzeStoresKeywords(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockGateway := new(MockNLPGateway)
+	tokenizer := stubTokenizer{tokens: []gateway.Token{
+		{Surface: "京都", POS: "名詞,固有名詞,地域,一般"},
+		{Surface: "で", POS: "助詞,格助詞,一般"},
+		{Surface: "抹茶", POS: "名詞,一般"},
+		{Surface: "を", POS: "助詞,格助詞,一般"},
+		{Surface: "飲ん", POS: "動詞,自立"},
+		{Surface: "だ", POS: "助動詞"},
+		{Surface: "。", POS: "記号,句点"},
+		{Surface: "抹茶", POS: "名詞,一般"},
+	}}
+
+	event := &domain.DiaryCreatedEvent{
+		DiaryID:  uuid.New(),
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+		Content:  "京都で抹茶を飲んだ。抹茶",
+	}
+
+	mockGateway.On("CheckAccuracy", mock.Anything, event.Content).Return([]gateway.Suggestion{}, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(analysis *domain.DiaryAnalysis) bool {
+		if len(analysis.Keywords) != 2 {
+			return false
+		}
+		first, second := analysis.Keywords[0], analysis.Keywords[1]
+		return first.Word == "抹茶" && first.Count == 2 && !first.ProperNoun &&
+			second.Word == "京都" && second.ProperNoun &&
+			first.DiaryID == event.DiaryID && first.FamilyID == event.FamilyID && first.UserID == event.UserID
+	})).Return(&domain.DiaryAnalysis{}, nil)
+
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, tokenizer)
+	_, err := usecase.Analyze(context.Background(), event)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestDiaryAnalysisUsecaseAnalyzeTokenizerError tests that a tokenizer failure does not fail the analysis
+func TestDiaryAnalysisUsecaseAnalyzeTokenizerError(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockGateway := new(MockNLPGateway)
+
+	event := &domain.DiaryCreatedEvent{
+		DiaryID:  uuid.New(),
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+		Content:  "今日は晴れ。",
+	}
+
+	mockGateway.On("CheckAccuracy", mock.Anything, event.Content).Return([]gateway.Suggestion{}, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(analysis *domain.DiaryAnalysis) bool {
+		return len(analysis.Keywords) == 0 &&
+			analysis.KeywordsStatus == domain.AnalysisStatusFailed &&
+			analysis.AccuracyStatus == domain.AnalysisStatusComplete &&
+			analysis.NextRetryAt != nil &&
+			analysis.TypeTokenRatio == nil
+	})).Return(&domain.DiaryAnalysis{}, nil)
+
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, stubTokenizer{err: assert.AnError})
+	_, err := usecase.Analyze(context.Background(), event)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestDiaryAnalysisUsecaseAnalyzeReadability tests that the readability and vocabulary metrics are stored with the analysis
+func TestDiaryAnalysisUsecaseAnalyzeReadability(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockGateway := new(MockNLPGateway)
+	tokenizer := stubTokenizer{tokens: []gateway.Token{
+		{Surface: "抹茶", POS: "名詞,一般"},
+		{Surface: "を", POS: "助詞,格助詞,一般"},
+		{Surface: "飲ん", POS: "動詞,自立"},
+		{Surface: "だ", POS: "助動詞"},
+		{Surface: "。", POS: "記号,句点"},
+		{Surface: "抹茶", POS: "名詞,一般"},
+	}}
+
+	event := &domain.DiaryCreatedEvent{
+		DiaryID:  uuid.New(),
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+		Content:  "抹茶を飲んだ。抹茶",
+	}
+
+	mockGateway.On("CheckAccuracy", mock.Anything, event.Content).Return([]gateway.Suggestion{}, nil)
+	var stored *domain.DiaryAnalysis
+	mockRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*domain.DiaryAnalysis)
+	}).Return(&domain.DiaryAnalysis{}, nil)
+
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, tokenizer)
+	_, err := usecase.Analyze(context.Background(), event)
+
+	require.NoError(t, err)
+	require.NotNil(t, stored.KanjiRatio)
+	assert.Equal(t, 0.625, *stored.KanjiRatio)
+	assert.Equal(t, 0.375, *stored.HiraganaRatio)
+	assert.Equal(t, 0.0, *stored.KatakanaRatio)
+	assert.Equal(t, 7, *stored.LongestSentenceLength)
+	assert.Equal(t, 4.5, *stored.AverageSentenceLength)
+	require.NotNil(t, stored.TypeTokenRatio)
+	assert.Equal(t, 0.8, *stored.TypeTokenRatio)
+}
+
+// TestDiaryAnalysisUsecaseReanalyzeKeepsDate tests that re-analysis replaces the analysis but keeps its date and writing time
+func TestDiaryAnalysisUsecaseReanalyzeKeepsDate(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockGateway := new(MockNLPGateway)
+
+	diary := &domain.Diary{
+		ID:       uuid.New(),
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+		Content:  "書き直した日記です。",
+	}
+	writtenAt := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+	localDate := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+	existing := &domain.DiaryAnalysis{ID: uuid.New(), DiaryID: diary.ID, WritingTimeSeconds: 300, CreatedAt: writtenAt, LocalDate: localDate}
+
+	mockGateway.On("CheckAccuracy", mock.Anything, diary.Content).Return([]gateway.Suggestion{}, nil)
+	mockRepo.On("FindByDiaryID", mock.Anything, diary.ID).Return(existing, nil)
+	mockRepo.On("Replace", mock.Anything, mock.MatchedBy(func(analysis *domain.DiaryAnalysis) bool {
+		return analysis.DiaryID == diary.ID &&
+			analysis.CreatedAt.Equal(writtenAt) &&
+			analysis.LocalDate.Equal(localDate) &&
+			analysis.WritingTimeSeconds == 300 &&
+			analysis.CharCount == len([]rune(diary.Content)) &&
+			analysis.AccuracyStatus == domain.AnalysisStatusComplete &&
+			analysis.NextRetryAt == nil
+	})).Return(&domain.DiaryAnalysis{}, nil)
+
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, stubTokenizer{})
+	_, err := usecase.Reanalyze(context.Background(), diary)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// TestDiaryAnalysisUsecaseReanalyzeWithoutExisting tests that a diary never analyzed gets the diary's date,
+// dated in the default time zone when the author is unknown
+func TestDiaryAnalysisUsecaseReanalyzeWithoutExisting(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockGateway := new(MockNLPGateway)
+
+	writtenAt := time.Date(2025, 12, 24, 21, 0, 0, 0, time.UTC)
+	diary := &domain.Diary{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		FamilyID:  uuid.New(),
+		Content:   "クリスマスイブ。",
+		CreatedAt: writtenAt,
+	}
+
+	mockGateway.On("CheckAccuracy", mock.Anything, diary.Content).Return(nil, assert.AnError)
+	mockRepo.On("FindByDiaryID", mock.Anything, diary.ID).Return(nil, nil)
+	mockRepo.On("Replace", mock.Anything, mock.MatchedBy(func(analysis *domain.DiaryAnalysis) bool {
+		return analysis.CreatedAt.Equal(writtenAt) &&
+			analysis.LocalDate.Format("2006-01-02") == "2025-12-25" &&
+			analysis.AccuracyError != nil && *analysis.AccuracyError == assert.AnError.Error()
+	})).Return(&domain.DiaryAnalysis{}, nil)
+
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, stubTokenizer{})
+	_, err := usecase.Reanalyze(context.Background(), diary)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestDiaryAnalysisUsecaseReanalyzePublishesEvent tests that diary.analyzed is published for the analysis of the updated content
+func TestDiaryAnalysisUsecaseReanalyzePublishesEvent(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockGateway := new(MockNLPGateway)
+	mockPublisher := new(MockPublisher)
+
+	analysisID := uuid.New()
+	diary := &domain.Diary{
+		ID:       uuid.New(),
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+		Content:  "書き直した日記です。",
+	}
+	existing := &domain.DiaryAnalysis{ID: analysisID, DiaryID: diary.ID, WritingTimeSeconds: 300, CreatedAt: time.Now(), LocalDate: time.Now()}
+
+	mockGateway.On("CheckAccuracy", mock.Anything, diary.Content).Return([]gateway.Suggestion{}, nil)
+	mockRepo.On("FindByDiaryID", mock.Anything, diary.ID).Return(existing, nil)
+	mockRepo.On("Replace", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.DiaryAnalysis).ID = analysisID
+	}).Return(&domain.DiaryAnalysis{}, nil)
+	mockPublisher.On("Publish", mock.Anything, mock.MatchedBy(func(e *domain.DiaryAnalyzedEvent) bool {
+		return e.AnalysisID == analysisID &&
+			e.DiaryID == diary.ID &&
+			e.CharCount == len([]rune(diary.Content)) &&
+			e.WritingTimeSeconds == 300
+	})).Return(nil)
+
+	usecase := NewDiaryAnalysisUsecase(mockRepo, nil, mockGateway, stubTokenizer{}, domain.FlatPenaltyStrategy{}, mockPublisher)
+	_, err := usecase.Reanalyze(context.Background(), diary)
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+// TestDiaryAnalysisUsecaseReanalyzePublishError tests that a publish failure is returned so that diary.updated is redelivered
+func TestDiaryAnalysisUsecaseReanalyzePublishError(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockGateway := new(MockNLPGateway)
+	mockPublisher := new(MockPublisher)
+
+	diary := &domain.Diary{
+		ID:       uuid.New(),
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+		Content:  "書き直した日記です。",
+	}
+
+	mockGateway.On("CheckAccuracy", mock.Anything, diary.Content).Return([]gateway.Suggestion{}, nil)
+	mockRepo.On("FindByDiaryID", mock.Anything, diary.ID).Return(&domain.DiaryAnalysis{DiaryID: diary.ID, LocalDate: time.Now()}, nil)
+	mockRepo.On("Replace", mock.Anything, mock.Anything).Return(&domain.DiaryAnalysis{}, nil)
+	mockPublisher.On("Publish", mock.Anything, mock.Anything).Return(assert.AnError)
+
+	usecase := NewDiaryAnalysisUsecase(mockRepo, nil, mockGateway, stubTokenizer{}, domain.FlatPenaltyStrategy{}, mockPublisher)
+	result, err := usecase.Reanalyze(context.Background(), diary)
+
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, result)
+}
+
+// TestDiaryAnalysisUsecaseAnalyzePublishesEvent tests that diary.analyzed is published with the metrics after the analysis is stored
+func TestDiaryAnalysisUsecaseAnalyzePublishesEvent(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockGateway := new(MockNLPGateway)
+	mockPublisher := new(MockPublisher)
+
+	analysisID := uuid.New()
+	event := &domain.DiaryCreatedEvent{
+		DiaryID:            uuid.New(),
+		UserID:             uuid.New(),
+		FamilyID:           uuid.New(),
+		Content:            "今日は楽しかった。",
+		WritingTimeSeconds: 120,
+	}
+
+	mockGateway.On("CheckAccuracy", mock.Anything, event.Content).Return(make([]gateway.Suggestion, 1), nil)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.DiaryAnalysis).ID = analysisID
+	}).Return(&domain.DiaryAnalysis{}, nil)
+	mockPublisher.On("Publish", mock.Anything, mock.MatchedBy(func(e *domain.DiaryAnalyzedEvent) bool {
+		return e.EventType() == "diary.analyzed" &&
+			e.AnalysisID == analysisID &&
+			e.DiaryID == event.DiaryID &&
+			e.UserID == event.UserID &&
+			e.FamilyID == event.FamilyID &&
+			e.CharCount == 9 &&
+			e.SentenceCount == 1 &&
+			e.WritingTimeSeconds == 120 &&
+			e.Language == domain.LanguageJapanese &&
+			*e.AccuracyScore == 90 &&
+			e.AccuracyStatus == domain.AnalysisStatusComplete
+	})).Return(nil)
+
+	usecase := NewDiaryAnalysisUsecase(mockRepo, nil, mockGateway, stubTokenizer{}, domain.FlatPenaltyStrategy{}, mockPublisher)
+
+	_, err := usecase.Analyze(context.Background(), event)
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+// TestDiaryAnalysisUsecaseAnalyzePublishError tests that a publish failure is returned so that the event is redelivered
+func TestDiaryAnalysisUsecaseAnalyzePublishError(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockGateway := new(MockNLPGateway)
+	mockPublisher := new(MockPublisher)
+
+	event := &domain.DiaryCreatedEvent{
+		DiaryID:  uuid.New(),
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+		Content:  "今日は楽しかった。",
+	}
+
+	mockGateway.On("CheckAccuracy", mock.Anything, event.Content).Return([]gateway.Suggestion{}, nil)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(&domain.DiaryAnalysis{}, nil)
+	mockPublisher.On("Publish", mock.Anything, mock.Anything).Return(assert.AnError)
+
+	usecase := NewDiaryAnalysisUsecase(mockRepo, nil, mockGateway, stubTokenizer{}, domain.FlatPenaltyStrategy{}, mockPublisher)
+
+	result, err := usecase.Analyze(context.Background(), event)
+
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, result)
+}
+
+// TestDiaryAnalysisUsecaseAnalyzeAfterUpdate tests that a diary.created handled after diary.updated
+// keeps the analysis of the updated content, and announces it
+func TestDiaryAnalysisUsecaseAnalyzeAfterUpdate(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockGateway := new(MockNLPGateway)
+	mockPublisher := new(MockPublisher)
+
+	event := &domain.DiaryCreatedEvent{
+		DiaryID:  uuid.New(),
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+		Content:  "今日は楽しかった。",
+	}
+	updated := &domain.DiaryAnalysis{
+		ID:        uuid.New(),
+		DiaryID:   event.DiaryID,
+		UserID:    event.UserID,
+		FamilyID:  event.FamilyID,
+		CharCount: 30,
+	}
+
+	mockGateway.On("CheckAccuracy", mock.Anything, event.Content).Return([]gateway.Suggestion{}, nil)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("FindByDiaryID", mock.Anything, event.DiaryID).Return(updated, nil)
+	mockPublisher.On("Publish", mock.Anything, mock.MatchedBy(func(e *domain.DiaryAnalyzedEvent) bool {
+		return e.AnalysisID == updated.ID && e.CharCount == 30
+	})).Return(nil)
+
+	usecase := NewDiaryAnalysisUsecase(mockRepo, nil, mockGateway, stubTokenizer{}, domain.FlatPenaltyStrategy{}, mockPublisher)
+
+	result, err := usecase.Analyze(context.Background(), event)
+
+	require.NoError(t, err)
+	assert.Same(t, updated, result)
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+// TestDiaryAnalysisUsecaseAnalyzeQuotaExceeded tests that the accuracy check is deferred, not failed, over the NLP quota
+func TestDiaryAnalysisUsecaseAnalyzeQuotaExceeded(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockGateway := new(MockNLPGateway)
+
+	retryAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	event := &domain.DiaryCreatedEvent{
+		DiaryID:  uuid.New(),
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+		Content:  "今日は楽しかった。",
+	}
+
+	mockGateway.On("CheckAccuracy", mock.Anything, event.Content).Return(nil, &gateway.QuotaExceededError{RetryAt: retryAt})
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(analysis *domain.DiaryAnalysis) bool {
+		return analysis.AccuracyStatus == domain.AnalysisStatusDeferred &&
+			analysis.AccuracyScore == nil &&
+			analysis.AccuracyError == nil &&
+			analysis.NextRetryAt != nil && analysis.NextRetryAt.Equal(retryAt)
+	})).Return(&domain.DiaryAnalysis{}, nil)
+
+	usecase := NewDiaryAnalysisUsecaseWithNLPGateway(mockRepo, mockGateway, stubTokenizer{})
+	_, err := usecase.Analyze(context.Background(), event)
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestDiaryAnalysisUsecaseAnalyzeLinksEntities tests that the members, places and pets in the content are stored with the analysis
+func TestDiaryAnalysisUsecaseAnalyzeLinksEntities(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockEntities := new(MockEntityRepository)
+	mockGateway := new(MockNLPGateway)
+
+	grandpa := uuid.New()
+	pochi := uuid.New()
+	event := &domain.DiaryCreatedEvent{
+		DiaryID:  uuid.New(),
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+		Content:  "じいじとポチの散歩。",
+	}
+
+	mockEntities.On("ListMembers", mock.Anything, event.FamilyID).Return([]*domain.FamilyMember{
+		{FamilyID: event.FamilyID, UserID: grandpa, Name: "山田一郎", Nicknames: []string{"じいじ"}},
+	}, nil)
+	mockEntities.On("ListEntities", mock.Anything, event.FamilyID).Return([]*domain.FamilyEntity{
+		{ID: pochi, FamilyID: event.FamilyID, Kind: domain.EntityKindPet, Name: "ポチ"},
+	}, nil)
+	mockEntities.On("FindMember", mock.Anything, event.FamilyID, event.UserID).Return(nil, nil)
+	mockGateway.On("CheckAccuracy", mock.Anything, event.Content).Return([]gateway.Suggestion{}, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(analysis *domain.DiaryAnalysis) bool {
+		refs := analysis.EntityReferences
+		return len(refs) == 2 &&
+			refs[0].EntityKind == domain.EntityKindMember && refs[0].EntityID == grandpa && refs[0].Surface == "じいじ" &&
+			refs[1].EntityKind == domain.EntityKindPet && refs[1].EntityID == pochi && refs[1].Offset == 4 &&
+			refs[1].DiaryID == event.DiaryID && refs[1].UserID == event.UserID && refs[1].FamilyID == event.FamilyID
+	})).Return(&domain.DiaryAnalysis{}, nil)
+
+	usecase := NewDiaryAnalysisUsecase(mockRepo, mockEntities, mockGateway, stubTokenizer{}, domain.FlatPenaltyStrategy{}, nil)
+
+	_, err := usecase.Analyze(context.Background(), event)
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestDiaryAnalysisUsecaseAnalyzeEntityError tests that the analysis is not stored when the entities cannot be read
+func TestDiaryAnalysisUsecaseAnalyzeEntityError(t *testing.T) {
+	mockRepo := new(MockDiaryAnalysisRepository)
+	mockEntities := new(MockEntityRepository)
+	mockGateway := new(MockNLPGateway)
+
+	event := &domain.DiaryCreatedEvent{
+		DiaryID:  uuid.New(),
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+		Content:  "今日は楽しかった。",
+	}
+
+	mockGateway.On("CheckAccuracy", mock.Anything, event.Content).Return([]gateway.Suggestion{}, nil)
+	mockEntities.On("ListMembers", mock.Anything, event.FamilyID).Return(nil, assert.AnError)
+
+	usecase := NewDiaryAnalysisUsecase(mockRepo, mockEntities, mockGateway, stubTokenizer{}, domain.FlatPenaltyStrategy{}, nil)
+
+	result, err := usecase.Analyze(context.Background(), event)
+
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// TestDiaryAnalysisUsecaseAnalyzeLocalDate tests that the analysis is dated when the diary was written, in the time zone of its author
+func TestDiaryAnalysisUsecaseAnalyzeLocalDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		member   *domain.FamilyMember
+		written  time.Time
+		wantDate string
+	}{
+		// 08:00 JST is the previous day in UTC
+		{"default time zone", nil, time.Date(2026, 1, 19, 23, 0, 0, 0, time.UTC), "2026-01-20"},
+		// 23:30 EDT, the day after New York springs forward
+		{"member time zone", &domain.FamilyMember{Timezone: "America/New_York"}, time.Date(2026, 3, 9, 3, 30, 0, 0, time.UTC), "2026-03-08"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockDiaryAnalysisRepository)
+			mockEntities := new(MockEntityRepository)
+			mockGateway := new(MockNLPGateway)
+
+			event := &domain.DiaryCreatedEvent{
+				DiaryID:   uuid.New(),
+				UserID:    uuid.New(),
+				FamilyID:  uuid.New(),
+				Content:   "今日は楽しかった。",
+				Timestamp: tt.written,
+			}
+
+			mockEntities.On("ListMembers", mock.Anything, event.FamilyID).Return([]*domain.FamilyMember{}, nil)
+			mockEntities.On("ListEntities", mock.Anything, event.FamilyID).Return([]*domain.FamilyEntity{}, nil)
+			mockEntities.On("FindMember", mock.Anything, event.FamilyID, event.UserID).Return(tt.member, nil)
+			mockGateway.On("CheckAccuracy", mock.Anything, event.Content).Return([]gateway.Suggestion{}, nil)
+			mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(analysis *domain.DiaryAnalysis) bool {
+				return analysis.CreatedAt.Equal(tt.written) && analysis.LocalDate.Format("2006-01-02") == tt.wantDate
+			})).Return(&domain.DiaryAnalysis{}, nil)
+
+			usecase := NewDiaryAnalysisUsecase(mockRepo, mockEntities, mockGateway, stubTokenizer{}, domain.FlatPenaltyStrategy{}, nil)
+
+			_, err := usecase.Analyze(context.Background(), event)
+
+			require.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+	"github.com/google/uuid"
+)
+
+// EntityUsecase keeps the family members linked in diaries in sync with user-context
+type EntityUsecase interface {
+	SyncMember(ctx context.Context, event *domain.FamilyMemberUpsertedEvent) error
+	RemoveMember(ctx context.Context, event *domain.FamilyMemberRemovedEvent) error
+}
+
+type entityUsecase struct {
+	er repository.EntityRepository
+}
+
+func NewEntityUsecase(er repository.EntityRepository) EntityUsecase {
+	return &entityUsecase{
+		er: er,
+	}
+}
+
+// SyncMember stores the name, nicknames and time zone of the member. Diaries analyzed before are linked again by a backfill;
+// they keep the local date of the time zone they were written in.
+func (u *entityUsecase) SyncMember(ctx context.Context, event *domain.FamilyMemberUpsertedEvent) error {
+	if event.FamilyID == uuid.Nil || event.UserID == uuid.Nil {
+		return &errors.ValidationError{Message: "family_id and user_id are required"}
+	}
+
+	nicknames := event.Nicknames
+	if nicknames == nil {
+		nicknames = []string{}
+	}
+	timezone := event.Timezone
+	if timezone == "" {
+		timezone = domain.DefaultTimezone
+	}
+	member := &domain.FamilyMember{
+		FamilyID:  event.FamilyID,
+		UserID:    event.UserID,
+		Name:      event.Name,
+		Nicknames: nicknames,
+		Timezone:  timezone,
+		SyncedAt:  event.Timestamp,
+	}
+	return u.er.UpsertMember(ctx, member)
+}
+
+// RemoveMember stops linking the member in diaries analyzed from now on. Links of diaries analyzed before are kept.
+func (u *entityUsecase) RemoveMember(ctx context.Context, event *domain.FamilyMemberRemovedEvent) error {
+	if event.FamilyID == uuid.Nil || event.UserID == uuid.Nil {
+		return &errors.ValidationError{Message: "family_id and user_id are required"}
+	}
+
+	removedAt := event.Timestamp
+	member := &domain.FamilyMember{
+		FamilyID:  event.FamilyID,
+		UserID:    event.UserID,
+		Nicknames: []string{},
+		Timezone:  domain.DefaultTimezone,
+		SyncedAt:  event.Timestamp,
+		RemovedAt: &removedAt,
+	}
+	return u.er.RemoveMember(ctx, member)
+}
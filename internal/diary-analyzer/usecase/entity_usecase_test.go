@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+)
+
+func TestEntityUsecaseSyncMember(t *testing.T) {
+	mockEntities := new(MockEntityRepository)
+	event := &domain.FamilyMemberUpsertedEvent{
+		FamilyID:  uuid.New(),
+		UserID:    uuid.New(),
+		Name:      "山田一郎",
+		Timestamp: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// events published before nicknames and time zones were introduced have none
+	mockEntities.On("UpsertMember", mock.Anything, &domain.FamilyMember{
+		FamilyID:  event.FamilyID,
+		UserID:    event.UserID,
+		Name:      "山田一郎",
+		Nicknames: []string{},
+		Timezone:  domain.DefaultTimezone,
+		SyncedAt:  event.Timestamp,
+	}).Return(nil)
+
+	err := NewEntityUsecase(mockEntities).SyncMember(context.Background(), event)
+
+	require.NoError(t, err)
+	mockEntities.AssertExpectations(t)
+}
+
+func TestEntityUsecaseSyncMemberInvalid(t *testing.T) {
+	mockEntities := new(MockEntityRepository)
+
+	err := NewEntityUsecase(mockEntities).SyncMember(context.Background(), &domain.FamilyMemberUpsertedEvent{FamilyID: uuid.New()})
+
+	var validationErr *errors.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	mockEntities.AssertNotCalled(t, "UpsertMember", mock.Anything, mock.Anything)
+}
+
+func TestEntityUsecaseRemoveMember(t *testing.T) {
+	mockEntities := new(MockEntityRepository)
+	event := &domain.FamilyMemberRemovedEvent{
+		FamilyID:  uuid.New(),
+		UserID:    uuid.New(),
+		Timestamp: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	mockEntities.On("RemoveMember", mock.Anything, mock.MatchedBy(func(m *domain.FamilyMember) bool {
+		return m.FamilyID == event.FamilyID && m.UserID == event.UserID &&
+			m.SyncedAt.Equal(event.Timestamp) && m.RemovedAt != nil && m.RemovedAt.Equal(event.Timestamp)
+	})).Return(nil)
+
+	err := NewEntityUsecase(mockEntities).RemoveMember(context.Background(), event)
+
+	require.NoError(t, err)
+	mockEntities.AssertExpectations(t)
+}
+
+func TestEntityUsecaseRemoveMemberInvalid(t *testing.T) {
+	mockEntities := new(MockEntityRepository)
+
+	err := NewEntityUsecase(mockEntities).RemoveMember(context.Background(), &domain.FamilyMemberRemovedEvent{UserID: uuid.New()})
+
+	var validationErr *errors.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	mockEntities.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything)
+}
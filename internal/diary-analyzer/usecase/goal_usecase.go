@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/pkg/broker/publisher"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+	"github.com/google/uuid"
+)
+
+// GoalUsecase celebrates the writing goals reached by new and edited diaries
+type GoalUsecase interface {
+	// CheckGoals publishes goal.achieved for the goals of the author reached in the week and the month of the analysis.
+	// A failed check is stored and retried by RetryDue; an error is returned only when it could not be stored.
+	CheckGoals(ctx context.Context, analysis *domain.DiaryAnalysis) error
+	// RetryDue re-attempts the failed goal checks whose retry is due and returns the number of checks retried
+	RetryDue(ctx context.Context) (int, error)
+}
+
+type goalUsecase struct {
+	gr        repository.GoalRepository
+	publisher publisher.Publisher
+	clk       clock.Clock
+}
+
+func NewGoalUsecase(gr repository.GoalRepository, pub publisher.Publisher, clk clock.Clock) GoalUsecase {
+	return &goalUsecase{
+		gr:        gr,
+		publisher: pub,
+		clk:       clk,
+	}
+}
+
+// CheckGoals checks the goals apart from the analysis, so that a failure does not redeliver the diary event
+// and analyze the diary again.
+func (u *goalUsecase) CheckGoals(ctx context.Context, analysis *domain.DiaryAnalysis) error {
+	err := u.check(ctx, analysis.FamilyID, analysis.UserID, analysis.LocalDate)
+	if err == nil {
+		return nil
+	}
+	slog.Warn("failed to check goals, retrying later", "diary_id", analysis.DiaryID, "error", err.Error())
+
+	check := &domain.GoalCheck{
+		FamilyID:  analysis.FamilyID,
+		UserID:    analysis.UserID,
+		LocalDate: analysis.LocalDate,
+	}
+	check.ScheduleRetry(u.clk.Now())
+	return u.gr.SaveCheck(ctx, check)
+}
+
+func (u *goalUsecase) RetryDue(ctx context.Context) (int, error) {
+	now := u.clk.Now()
+	checks, err := u.gr.ListChecksDue(ctx, now, domain.AnalysisRetryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	retried := 0
+	for _, check := range checks {
+		if err := u.check(ctx, check.FamilyID, check.UserID, check.LocalDate); err != nil {
+			check.RetryCount++
+			if check.ScheduleRetry(now) {
+				if err := u.gr.SaveCheck(ctx, check); err != nil {
+					return retried, err
+				}
+				retried++
+				continue
+			}
+			slog.Warn("gave up retrying goal check", "user_id", check.UserID, "local_date", check.LocalDate.Format(time.DateOnly), "error", err.Error())
+		}
+
+		if err := u.gr.DeleteCheck(ctx, check); err != nil {
+			return retried, err
+		}
+		retried++
+	}
+
+	return retried, nil
+}
+
+// check reads the daily rollups, which are refreshed together with the analysis.
+// A goal is claimed as reached before the event is published, so that concurrent checks of the member announce it once,
+// and the claim is released when the event cannot be published, so that the goal is announced by a later check;
+// a goal reached again with the same target in the same period is not announced twice.
+func (u *goalUsecase) check(ctx context.Context, familyID, userID uuid.UUID, localDate time.Time) error {
+	goals, err := u.gr.ListGoals(ctx, familyID, userID)
+	if err != nil {
+		return err
+	}
+
+	// 同じ期間の目標は指標が違っても集計を使い回す
+	totals := make(map[[2]time.Time]*domain.GoalTotals)
+	for _, goal := range goals {
+		start, end := domain.GoalPeriodRange(goal.Period, localDate)
+
+		achieved, err := u.gr.IsAchieved(ctx, goal, start)
+		if err != nil {
+			return err
+		}
+		if achieved {
+			continue
+		}
+
+		t, ok := totals[[2]time.Time{start, end}]
+		if !ok {
+			t, err = u.gr.SumDaily(ctx, familyID, userID, start, end)
+			if err != nil {
+				return err
+			}
+			totals[[2]time.Time{start, end}] = t
+		}
+		value := t.Value(goal.Metric)
+		if value < goal.Target {
+			continue
+		}
+
+		claimed, err := u.gr.ClaimAchievement(ctx, goal, start)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			// announced by a concurrent check
+			continue
+		}
+
+		if err := u.publisher.Publish(ctx, domain.NewGoalAchievedEvent(goal, value, start, end)); err != nil {
+			slog.Error("failed to publish goal achieved event", "goal_id", goal.ID, "error", err.Error())
+			if releaseErr := u.gr.ReleaseAchievement(ctx, goal, start); releaseErr != nil {
+				slog.Error("failed to release goal achievement", "goal_id", goal.ID, "error", releaseErr.Error())
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+)
+
+type MockGoalRepository struct {
+	mock.Mock
+}
+
+func (m *MockGoalRepository) ListGoals(ctx context.Context, familyID, userID uuid.UUID) ([]*domain.Goal, error) {
+	args := m.Called(ctx, familyID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Goal), args.Error(1)
+}
+
+func (m *MockGoalRepository) SumDaily(ctx context.Context, familyID, userID uuid.UUID, from, to time.Time) (*domain.GoalTotals, error) {
+	args := m.Called(ctx, familyID, userID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GoalTotals), args.Error(1)
+}
+
+func (m *MockGoalRepository) IsAchieved(ctx context.Context, goal *domain.Goal, periodStart time.Time) (bool, error) {
+	args := m.Called(ctx, goal, periodStart)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGoalRepository) ClaimAchievement(ctx context.Context, goal *domain.Goal, periodStart time.Time) (bool, error) {
+	args := m.Called(ctx, goal, periodStart)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGoalRepository) ReleaseAchievement(ctx context.Context, goal *domain.Goal, periodStart time.Time) error {
+	args := m.Called(ctx, goal, periodStart)
+	return args.Error(0)
+}
+
+func (m *MockGoalRepository) SaveCheck(ctx context.Context, check *domain.GoalCheck) error {
+	args := m.Called(ctx, check)
+	return args.Error(0)
+}
+
+func (m *MockGoalRepository) ListChecksDue(ctx context.Context, now time.Time, limit int) ([]*domain.GoalCheck, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.GoalCheck), args.Error(1)
+}
+
+func (m *MockGoalRepository) DeleteCheck(ctx context.Context, check *domain.GoalCheck) error {
+	args := m.Called(ctx, check)
+	return args.Error(0)
+}
+
+// goalAnalysis is an analysis written on Wednesday 2026-01-14
+func goalAnalysis() *domain.DiaryAnalysis {
+	return &domain.DiaryAnalysis{
+		ID:        uuid.New(),
+		DiaryID:   uuid.New(),
+		UserID:    uuid.New(),
+		FamilyID:  uuid.New(),
+		LocalDate: time.Date(2026, 1, 14, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+var (
+	goalNow        = time.Date(2026, 1, 14, 12, 0, 0, 0, time.UTC)
+	goalWeekStart  = time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	goalWeekEnd    = time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC)
+	goalMonthStart = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	goalMonthEnd   = time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// TestGoalUsecaseCheckGoalsAchieved tests that goal.achieved is claimed and published for the goals reached in their periods
+func TestGoalUsecaseCheckGoalsAchieved(t *testing.T) {
+	mockRepo := new(MockGoalRepository)
+	mockPublisher := new(MockPublisher)
+	analysis := goalAnalysis()
+
+	weeklyPosts := &domain.Goal{ID: uuid.New(), UserID: analysis.UserID, FamilyID: analysis.FamilyID, Metric: domain.GoalMetricPosts, Period: domain.GoalPeriodWeekly, Target: 3}
+	weeklyMinutes := &domain.Goal{ID: uuid.New(), UserID: analysis.UserID, FamilyID: analysis.FamilyID, Metric: domain.GoalMetricWritingMinutes, Period: domain.GoalPeriodWeekly, Target: 60}
+	monthlyChars := &domain.Goal{ID: uuid.New(), UserID: analysis.UserID, FamilyID: analysis.FamilyID, Metric: domain.GoalMetricCharacters, Period: domain.GoalPeriodMonthly, Target: 5000}
+
+	mockRepo.On("ListGoals", mock.Anything, analysis.FamilyID, analysis.UserID).Return([]*domain.Goal{weeklyPosts, weeklyMinutes, monthlyChars}, nil)
+	mockRepo.On("IsAchieved", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	// the weekly goals share the totals of the week
+	mockRepo.On("SumDaily", mock.Anything, analysis.FamilyID, analysis.UserID, goalWeekStart, goalWeekEnd).
+		Return(&domain.GoalTotals{Posts: 3, CharCount: 900, WritingTimeSeconds: 3000}, nil).Once()
+	mockRepo.On("SumDaily", mock.Anything, analysis.FamilyID, analysis.UserID, goalMonthStart, goalMonthEnd).
+		Return(&domain.GoalTotals{Posts: 10, CharCount: 5200, WritingTimeSeconds: 9000}, nil).Once()
+	mockPublisher.On("Publish", mock.Anything, mock.MatchedBy(func(e *domain.GoalAchievedEvent) bool {
+		return e.GoalID == weeklyPosts.ID && e.Metric == domain.GoalMetricPosts && e.Period == domain.GoalPeriodWeekly &&
+			e.Target == 3 && e.Value == 3 && e.PeriodStart == "2026-01-12" && e.PeriodEnd == "2026-01-18" &&
+			e.UserID == analysis.UserID && e.FamilyID == analysis.FamilyID
+	})).Return(nil).Once()
+	mockPublisher.On("Publish", mock.Anything, mock.MatchedBy(func(e *domain.GoalAchievedEvent) bool {
+		return e.GoalID == monthlyChars.ID && e.Value == 5200 && e.PeriodStart == "2026-01-01" && e.PeriodEnd == "2026-01-31"
+	})).Return(nil).Once()
+	mockRepo.On("ClaimAchievement", mock.Anything, weeklyPosts, goalWeekStart).Return(true, nil).Once()
+	mockRepo.On("ClaimAchievement", mock.Anything, monthlyChars, goalMonthStart).Return(true, nil).Once()
+
+	err := NewGoalUsecase(mockRepo, mockPublisher, &clock.Fixed{Time: goalNow}).CheckGoals(context.Background(), analysis)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+	// 50 minutes of the weekly 60 minutes
+	mockRepo.AssertNotCalled(t, "ClaimAchievement", mock.Anything, weeklyMinutes, mock.Anything)
+	mockRepo.AssertNotCalled(t, "ReleaseAchievement", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestGoalUsecaseCheckGoalsAlreadyAchieved tests that a goal reached before in the period is not announced again
+func TestGoalUsecaseCheckGoalsAlreadyAchieved(t *testing.T) {
+	mockRepo := new(MockGoalRepository)
+	mockPublisher := new(MockPublisher)
+	analysis := goalAnalysis()
+
+	goal := &domain.Goal{ID: uuid.New(), UserID: analysis.UserID, FamilyID: analysis.FamilyID, Metric: domain.GoalMetricPosts, Period: domain.GoalPeriodWeekly, Target: 3}
+	mockRepo.On("ListGoals", mock.Anything, analysis.FamilyID, analysis.UserID).Return([]*domain.Goal{goal}, nil)
+	mockRepo.On("IsAchieved", mock.Anything, goal, goalWeekStart).Return(true, nil)
+
+	err := NewGoalUsecase(mockRepo, mockPublisher, &clock.Fixed{Time: goalNow}).CheckGoals(context.Background(), analysis)
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "SumDaily", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+// TestGoalUsecaseCheckGoalsClaimedConcurrently tests that a goal claimed by a concurrent check is not announced again
+func TestGoalUsecaseCheckGoalsClaimedConcurrently(t *testing.T) {
+	mockRepo := new(MockGoalRepository)
+	mockPublisher := new(MockPublisher)
+	analysis := goalAnalysis()
+
+	goal := &domain.Goal{ID: uuid.New(), UserID: analysis.UserID, FamilyID: analysis.FamilyID, Metric: domain.GoalMetricPosts, Period: domain.GoalPeriodWeekly, Target: 3}
+	mockRepo.On("ListGoals", mock.Anything, analysis.FamilyID, analysis.UserID).Return([]*domain.Goal{goal}, nil)
+	mockRepo.On("IsAchieved", mock.Anything, goal, goalWeekStart).Return(false, nil)
+	mockRepo.On("SumDaily", mock.Anything, analysis.FamilyID, analysis.UserID, goalWeekStart, goalWeekEnd).
+		Return(&domain.GoalTotals{Posts: 3}, nil)
+	mockRepo.On("ClaimAchievement", mock.Anything, goal, goalWeekStart).Return(false, nil)
+
+	err := NewGoalUsecase(mockRepo, mockPublisher, &clock.Fixed{Time: goalNow}).CheckGoals(context.Background(), analysis)
+
+	assert.NoError(t, err)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+// TestGoalUsecaseCheckGoalsPublishError tests that the claim of a goal is released when the event could not be published,
+// and that the check is stored to be retried instead of failing
+func TestGoalUsecaseCheckGoalsPublishError(t *testing.T) {
+	mockRepo := new(MockGoalRepository)
+	mockPublisher := new(MockPublisher)
+	analysis := goalAnalysis()
+
+	goal := &domain.Goal{ID: uuid.New(), UserID: analysis.UserID, FamilyID: analysis.FamilyID, Metric: domain.GoalMetricCharacters, Period: domain.GoalPeriodMonthly, Target: 100}
+	mockRepo.On("ListGoals", mock.Anything, analysis.FamilyID, analysis.UserID).Return([]*domain.Goal{goal}, nil)
+	mockRepo.On("IsAchieved", mock.Anything, goal, goalMonthStart).Return(false, nil)
+	mockRepo.On("SumDaily", mock.Anything, analysis.FamilyID, analysis.UserID, goalMonthStart, goalMonthEnd).
+		Return(&domain.GoalTotals{Posts: 1, CharCount: 120}, nil)
+	mockRepo.On("ClaimAchievement", mock.Anything, goal, goalMonthStart).Return(true, nil)
+	mockPublisher.On("Publish", mock.Anything, mock.Anything).Return(assert.AnError)
+	mockRepo.On("ReleaseAchievement", mock.Anything, goal, goalMonthStart).Return(nil).Once()
+	mockRepo.On("SaveCheck", mock.Anything, &domain.GoalCheck{
+		FamilyID:    analysis.FamilyID,
+		UserID:      analysis.UserID,
+		LocalDate:   analysis.LocalDate,
+		NextRetryAt: goalNow.Add(domain.AnalysisRetryBaseDelay),
+	}).Return(nil).Once()
+
+	err := NewGoalUsecase(mockRepo, mockPublisher, &clock.Fixed{Time: goalNow}).CheckGoals(context.Background(), analysis)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestGoalUsecaseCheckGoalsSaveCheckError tests that the error is returned when a failed check cannot be stored
+func TestGoalUsecaseCheckGoalsSaveCheckError(t *testing.T) {
+	mockRepo := new(MockGoalRepository)
+	analysis := goalAnalysis()
+
+	mockRepo.On("ListGoals", mock.Anything, analysis.FamilyID, analysis.UserID).Return(nil, assert.AnError)
+	mockRepo.On("SaveCheck", mock.Anything, mock.Anything).Return(errors.New("db down"))
+
+	err := NewGoalUsecase(mockRepo, new(MockPublisher), &clock.Fixed{Time: goalNow}).CheckGoals(context.Background(), analysis)
+
+	assert.EqualError(t, err, "db down")
+}
+
+// TestGoalUsecaseRetryDue tests that checks succeeding on retry are removed, failing ones rescheduled with backoff
+// and exhausted ones given up
+func TestGoalUsecaseRetryDue(t *testing.T) {
+	mockRepo := new(MockGoalRepository)
+	localDate := time.Date(2026, 1, 14, 0, 0, 0, 0, time.UTC)
+
+	succeeded := &domain.GoalCheck{FamilyID: uuid.New(), UserID: uuid.New(), LocalDate: localDate, NextRetryAt: goalNow}
+	failing := &domain.GoalCheck{FamilyID: uuid.New(), UserID: uuid.New(), LocalDate: localDate, RetryCount: 1, NextRetryAt: goalNow}
+	exhausted := &domain.GoalCheck{FamilyID: uuid.New(), UserID: uuid.New(), LocalDate: localDate, RetryCount: domain.MaxAnalysisRetries - 1, NextRetryAt: goalNow}
+
+	mockRepo.On("ListChecksDue", mock.Anything, goalNow, domain.AnalysisRetryBatchSize).
+		Return([]*domain.GoalCheck{succeeded, failing, exhausted}, nil)
+	mockRepo.On("ListGoals", mock.Anything, succeeded.FamilyID, succeeded.UserID).Return([]*domain.Goal{}, nil)
+	mockRepo.On("ListGoals", mock.Anything, failing.FamilyID, failing.UserID).Return(nil, assert.AnError)
+	mockRepo.On("ListGoals", mock.Anything, exhausted.FamilyID, exhausted.UserID).Return(nil, assert.AnError)
+	mockRepo.On("DeleteCheck", mock.Anything, succeeded).Return(nil).Once()
+	mockRepo.On("SaveCheck", mock.Anything, failing).Return(nil).Once()
+	mockRepo.On("DeleteCheck", mock.Anything, exhausted).Return(nil).Once()
+
+	retried, err := NewGoalUsecase(mockRepo, new(MockPublisher), &clock.Fixed{Time: goalNow}).RetryDue(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, retried)
+	assert.Equal(t, 2, failing.RetryCount)
+	assert.Equal(t, goalNow.Add(domain.AnalysisRetryBaseDelay<<2), failing.NextRetryAt)
+	mockRepo.AssertExpectations(t)
+}
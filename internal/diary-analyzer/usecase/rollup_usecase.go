@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary-analyzer/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+)
+
+// RollupProgress is reported after each day
+type RollupProgress struct {
+	Day time.Time
+	// Rollups is the number of rollups stored for the day
+	Rollups   int64
+	Processed int
+	Total     int
+}
+
+// RollupUsecase repairs the daily rollups of the analyses read by diary-analysis
+type RollupUsecase interface {
+	// RebuildDaily recomputes the rollups of the days in [from, to) from the analyses
+	RebuildDaily(ctx context.Context, from, to time.Time, progress func(RollupProgress)) error
+}
+
+type rollupUsecase struct {
+	ar repository.DiaryAnalysisRepository
+}
+
+// NewRollupUsecase creates a new RollupUsecase
+func NewRollupUsecase(ar repository.DiaryAnalysisRepository) RollupUsecase {
+	return &rollupUsecase{
+		ar: ar,
+	}
+}
+
+// RebuildDaily rebuilds one day at a time, so that a stopped rebuild leaves every day consistent
+func (u *rollupUsecase) RebuildDaily(ctx context.Context, from, to time.Time, progress func(RollupProgress)) error {
+	if from.IsZero() || to.IsZero() || !from.Before(to) {
+		return &errors.ValidationError{Message: "from must be before to"}
+	}
+
+	total := 0
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		total++
+	}
+
+	p := RollupProgress{Total: total}
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rollups, err := u.ar.RebuildDaily(ctx, day)
+		if err != nil {
+			return err
+		}
+
+		p.Day = day
+		p.Rollups = rollups
+		p.Processed++
+		if progress != nil {
+			progress(p)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+)
+
+// TestRollupUsecaseRebuildDaily tests that every day in the range is rebuilt and progress is reported
+func TestRollupUsecaseRebuildDaily(t *testing.T) {
+	mockAnalysisRepo := new(MockDiaryAnalysisRepository)
+
+	from := time.Date(2026, 1, 30, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	mockAnalysisRepo.On("RebuildDaily", mock.Anything, from).Return(int64(3), nil)
+	mockAnalysisRepo.On("RebuildDaily", mock.Anything, from.AddDate(0, 0, 1)).Return(int64(0), nil)
+	mockAnalysisRepo.On("RebuildDaily", mock.Anything, from.AddDate(0, 0, 2)).Return(int64(1), nil)
+
+	var reported []RollupProgress
+	usecase := NewRollupUsecase(mockAnalysisRepo)
+	err := usecase.RebuildDaily(context.Background(), from, to, func(p RollupProgress) {
+		reported = append(reported, p)
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, reported, 3)
+	assert.Equal(t, RollupProgress{Day: from, Rollups: 3, Processed: 1, Total: 3}, reported[0])
+	assert.Equal(t, "2026-02-01", reported[2].Day.Format("2006-01-02"))
+	mockAnalysisRepo.AssertExpectations(t)
+}
+
+// TestRollupUsecaseRebuildDailyError tests that the rebuild stops at the first failing day
+func TestRollupUsecaseRebuildDailyError(t *testing.T) {
+	mockAnalysisRepo := new(MockDiaryAnalysisRepository)
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockAnalysisRepo.On("RebuildDaily", mock.Anything, from).Return(int64(0), assert.AnError)
+
+	usecase := NewRollupUsecase(mockAnalysisRepo)
+	err := usecase.RebuildDaily(context.Background(), from, from.AddDate(0, 0, 7), nil)
+
+	assert.ErrorIs(t, err, assert.AnError)
+	mockAnalysisRepo.AssertNumberOfCalls(t, "RebuildDaily", 1)
+}
+
+// TestRollupUsecaseRebuildDailyInvalidRange tests that an empty range is rejected
+func TestRollupUsecaseRebuildDailyInvalidRange(t *testing.T) {
+	mockAnalysisRepo := new(MockDiaryAnalysisRepository)
+
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	usecase := NewRollupUsecase(mockAnalysisRepo)
+	err := usecase.RebuildDaily(context.Background(), day, day, nil)
+
+	assert.IsType(t, &errors.ValidationError{}, err)
+	mockAnalysisRepo.AssertNotCalled(t, "RebuildDaily", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bookmark is a personal favorite mark on a diary
+type Bookmark struct {
+	UserID    uuid.UUID `gorm:"column:user_id;type:uuid;primaryKey"`
+	FamilyID  uuid.UUID `gorm:"column:family_id;type:uuid;not null"`
+	DiaryID   uuid.UUID `gorm:"column:diary_id;type:uuid;primaryKey"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name
+func (Bookmark) TableName() string {
+	return "bookmarks"
+}
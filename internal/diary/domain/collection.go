@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Collection is a family-shared, named and ordered set of diaries (e.g. "Summer 2026")
+type Collection struct {
+	ID           uuid.UUID  `gorm:"column:id;type:uuid;primaryKey;default:gen_random_uuid()"`
+	FamilyID     uuid.UUID  `gorm:"column:family_id;type:uuid;not null"`
+	CreatedBy    uuid.UUID  `gorm:"column:created_by;type:uuid;not null"`
+	Name         string     `gorm:"column:name;type:varchar(100);not null"`
+	CoverDiaryID *uuid.UUID `gorm:"column:cover_diary_id;type:uuid"`
+	CreatedAt    time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name
+func (Collection) TableName() string {
+	return "collections"
+}
+
+// CollectionItem is a diary placed in a collection at the given position
+type CollectionItem struct {
+	CollectionID uuid.UUID `gorm:"column:collection_id;type:uuid;primaryKey"`
+	DiaryID      uuid.UUID `gorm:"column:diary_id;type:uuid;primaryKey"`
+	Position     int       `gorm:"column:position;type:integer;not null"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name
+func (CollectionItem) TableName() string {
+	return "collection_items"
+}
@@ -5,4 +5,12 @@ const (
 	MaxDiaryContentLength = 1000
 
 	DefaultStreakValue = 1
+
+	MaxCollectionNameLength = 100
+	MaxCollectionItems      = 500
+
+	UnlockBatchSize = 100
+
+	MaxEditableDays             = 3650
+	MaxEditOverrideReasonLength = 500
 )
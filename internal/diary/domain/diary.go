@@ -8,12 +8,24 @@ import (
 
 // domainがgorm（技術）にするが開発コストを下げるため容認
 type Diary struct {
-	ID                 uuid.UUID `gorm:"column:id;type:uuid;primaryKey;default:gen_random_uuid()"`
-	UserID             uuid.UUID `gorm:"column:user_id;type:uuid;not null"`
-	FamilyID           uuid.UUID `gorm:"column:family_id;type:uuid;not null"`
-	Title              string    `gorm:"column:title;type:varchar(255)"`
-	Content            string    `gorm:"column:content;type:text"`
-	WritingTimeSeconds int       `gorm:"column:writing_time_seconds;type:integer"`
-	CreatedAt          time.Time `gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt          time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	ID                 uuid.UUID       `gorm:"column:id;type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID             uuid.UUID       `gorm:"column:user_id;type:uuid;not null"`
+	FamilyID           uuid.UUID       `gorm:"column:family_id;type:uuid;not null"`
+	Title              string          `gorm:"column:title;type:varchar(255)"`
+	Content            string          `gorm:"column:content;type:text"`
+	WritingTimeSeconds int             `gorm:"column:writing_time_seconds;type:integer"`
+	UnlockAt           *time.Time      `gorm:"column:unlock_at"`
+	UnlockPublishedAt  *time.Time      `gorm:"column:unlock_published_at"`
+	CreatedAt          time.Time       `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt          time.Time       `gorm:"column:updated_at;autoUpdateTime"`
+	Mentions           []*DiaryMention `gorm:"-"`
+}
+
+// IsVisibleTo reports whether the viewer can read the diary at the given time.
+// A locked time capsule is visible only to its author.
+func (d *Diary) IsVisibleTo(viewerID uuid.UUID, at time.Time) bool {
+	if d.UnlockAt == nil || !d.UnlockAt.After(at) {
+		return true
+	}
+	return d.UserID == viewerID
 }
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FamilyDiaryPolicy is the per-family rule for editing diaries
+type FamilyDiaryPolicy struct {
+	FamilyID uuid.UUID `gorm:"column:family_id;type:uuid;primaryKey"`
+	// EditableDays is the number of days a diary can be edited after it was written. nil means no limit.
+	EditableDays *int      `gorm:"column:editable_days;type:integer"`
+	UpdatedBy    uuid.UUID `gorm:"column:updated_by;type:uuid;not null"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name
+func (FamilyDiaryPolicy) TableName() string {
+	return "family_diary_policies"
+}
+
+// IsEditable reports whether a diary written at createdAt can still be edited at the given time
+func (p *FamilyDiaryPolicy) IsEditable(createdAt, at time.Time) bool {
+	if p == nil || p.EditableDays == nil {
+		return true
+	}
+	return at.Before(createdAt.AddDate(0, 0, *p.EditableDays))
+}
+
+// DiaryEditAudit records an admin override of the edit lock or an admin edit of another member's diary
+type DiaryEditAudit struct {
+	ID              uuid.UUID `gorm:"column:id;type:uuid;primaryKey;default:gen_random_uuid()"`
+	DiaryID         uuid.UUID `gorm:"column:diary_id;type:uuid;not null"`
+	FamilyID        uuid.UUID `gorm:"column:family_id;type:uuid;not null"`
+	EditedBy        uuid.UUID `gorm:"column:edited_by;type:uuid;not null"`
+	Reason          string    `gorm:"column:reason;type:text;not null"`
+	PreviousTitle   string    `gorm:"column:previous_title;type:varchar(255)"`
+	PreviousContent string    `gorm:"column:previous_content;type:text"`
+	CreatedAt       time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name
+func (DiaryEditAudit) TableName() string {
+	return "diary_edit_audits"
+}
@@ -15,7 +15,9 @@ type DiaryCreatedEvent struct {
 	Title              string    `json:"title"`
 	Content            string    `json:"content"`
 	WritingTimeSeconds int       `json:"writing_time_seconds"`
-	Timestamp          time.Time `json:"timestamp"`
+	// UnlockAt is set for a time capsule hidden from other members until that time
+	UnlockAt  *time.Time `json:"unlock_at,omitempty"`
+	Timestamp time.Time  `json:"timestamp"`
 }
 
 func (e *DiaryCreatedEvent) EventType() string {
@@ -35,3 +37,109 @@ func NewDiaryCreatedEvent(diaryID, userID, familyID uuid.UUID, title, content st
 		Timestamp:          time.Now(),
 	}
 }
+
+// DiaryUnlockedEvent represents an event when a time capsule diary reaches its unlock time
+type DiaryUnlockedEvent struct {
+	ID        string    `json:"id"`
+	DiaryID   uuid.UUID `json:"diary_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	FamilyID  uuid.UUID `json:"family_id"`
+	Title     string    `json:"title"`
+	UnlockAt  time.Time `json:"unlock_at"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e *DiaryUnlockedEvent) EventType() string {
+	return "diary.unlocked"
+}
+
+// NewDiaryUnlockedEvent creates a new DiaryUnlockedEvent
+func NewDiaryUnlockedEvent(diaryID, userID, familyID uuid.UUID, title string, unlockAt time.Time) *DiaryUnlockedEvent {
+	return &DiaryUnlockedEvent{
+		ID:        uuid.New().String(),
+		DiaryID:   diaryID,
+		UserID:    userID,
+		FamilyID:  familyID,
+		Title:     title,
+		UnlockAt:  unlockAt,
+		Timestamp: time.Now(),
+	}
+}
+
+// DiaryUpdatedEvent represents an event when a diary is edited
+type DiaryUpdatedEvent struct {
+	ID        string    `json:"id"`
+	DiaryID   uuid.UUID `json:"diary_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	FamilyID  uuid.UUID `json:"family_id"`
+	EditedBy  uuid.UUID `json:"edited_by"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	Timestamp time.Time `json:"timestamp"`
+	// UnlockAt is set for a time capsule hidden from other members until that time
+	UnlockAt *time.Time `json:"unlock_at,omitempty"`
+}
+
+func (e *DiaryUpdatedEvent) EventType() string {
+	return "diary.updated"
+}
+
+// NewDiaryUpdatedEvent creates a new DiaryUpdatedEvent
+func NewDiaryUpdatedEvent(diaryID, userID, familyID, editedBy uuid.UUID, title, content string) *DiaryUpdatedEvent {
+	return &DiaryUpdatedEvent{
+		ID:        uuid.New().String(),
+		DiaryID:   diaryID,
+		UserID:    userID,
+		FamilyID:  familyID,
+		EditedBy:  editedBy,
+		Title:     title,
+		Content:   content,
+		Timestamp: time.Now(),
+	}
+}
+
+// DiaryMentionedEvent represents an event when a family member is mentioned in a diary
+type DiaryMentionedEvent struct {
+	ID              string    `json:"id"`
+	DiaryID         uuid.UUID `json:"diary_id"`
+	FamilyID        uuid.UUID `json:"family_id"`
+	AuthorID        uuid.UUID `json:"author_id"`
+	MentionedUserID uuid.UUID `json:"mentioned_user_id"`
+	Title           string    `json:"title"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+func (e *DiaryMentionedEvent) EventType() string {
+	return "diary.mentioned"
+}
+
+// NewDiaryMentionedEvent creates a new DiaryMentionedEvent
+func NewDiaryMentionedEvent(diaryID, familyID, authorID, mentionedUserID uuid.UUID, title string) *DiaryMentionedEvent {
+	return &DiaryMentionedEvent{
+		ID:              uuid.New().String(),
+		DiaryID:         diaryID,
+		FamilyID:        familyID,
+		AuthorID:        authorID,
+		MentionedUserID: mentionedUserID,
+		Title:           title,
+		Timestamp:       time.Now(),
+	}
+}
+
+// FamilyMemberUpsertedEvent is published by user-context when a user joins a family or changes the profile
+type FamilyMemberUpsertedEvent struct {
+	ID        string    `json:"id"`
+	FamilyID  uuid.UUID `json:"family_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// FamilyMemberRemovedEvent is published by user-context when a member is removed from a family
+type FamilyMemberRemovedEvent struct {
+	ID        string    `json:"id"`
+	FamilyID  uuid.UUID `json:"family_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// FamilyMember is the diary-side projection of a family member,
+// kept in sync by family.member_upserted and family.member_removed events
+type FamilyMember struct {
+	FamilyID uuid.UUID `gorm:"column:family_id;type:uuid;primaryKey"`
+	UserID   uuid.UUID `gorm:"column:user_id;type:uuid;primaryKey"`
+	Name     string    `gorm:"column:name;type:varchar(255);not null"`
+	SyncedAt time.Time `gorm:"column:synced_at;not null"`
+	// RemovedAt is set when the member left the family
+	RemovedAt *time.Time `gorm:"column:removed_at"`
+}
+
+// TableName specifies the table name
+func (FamilyMember) TableName() string {
+	return "family_members"
+}
+
+// DiaryMention is an @mention of a family member in the diary content.
+// Start and End are offsets in Unicode code points; End is exclusive and Start points at '@'.
+type DiaryMention struct {
+	DiaryID   uuid.UUID `gorm:"column:diary_id;type:uuid;primaryKey"`
+	UserID    uuid.UUID `gorm:"column:user_id;type:uuid;not null"`
+	Name      string    `gorm:"column:name;type:varchar(255);not null"`
+	Start     int       `gorm:"column:start_offset;primaryKey"`
+	End       int       `gorm:"column:end_offset;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name
+func (DiaryMention) TableName() string {
+	return "diary_mentions"
+}
+
+// ParseMentions finds "@name" mentions of the members in content.
+// Names are matched case-insensitively, preferring the longest name, so "@Ken" does not hide "@Kenta".
+// Japanese text often continues right after a name ("@太郎と"), so only names ending in an ASCII
+// letter or digit require a word boundary after them.
+func ParseMentions(content string, members []*FamilyMember) []*DiaryMention {
+	candidates := make([]*FamilyMember, 0, len(members))
+	for _, m := range members {
+		if strings.TrimSpace(m.Name) != "" {
+			candidates = append(candidates, m)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return len([]rune(candidates[i].Name)) > len([]rune(candidates[j].Name))
+	})
+
+	runes := []rune(content)
+	mentions := make([]*DiaryMention, 0)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '@' && runes[i] != '＠' {
+			continue
+		}
+		// メールアドレス（taro@example.com）はメンションとして扱わない
+		if i > 0 && isASCIIWordRune(runes[i-1]) {
+			continue
+		}
+
+		for _, m := range candidates {
+			name := []rune(m.Name)
+			end := i + 1 + len(name)
+			if end > len(runes) || !strings.EqualFold(string(runes[i+1:end]), m.Name) {
+				continue
+			}
+			if end < len(runes) && isASCIIWordRune(name[len(name)-1]) && isASCIIWordRune(runes[end]) {
+				continue
+			}
+
+			mentions = append(mentions, &DiaryMention{
+				UserID: m.UserID,
+				Name:   m.Name,
+				Start:  i,
+				End:    end,
+			})
+			i = end - 1
+			break
+		}
+	}
+	return mentions
+}
+
+func isASCIIWordRune(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_')
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseMentions(t *testing.T) {
+	t.Parallel()
+
+	ken := &FamilyMember{UserID: uuid.New(), Name: "Ken"}
+	kenta := &FamilyMember{UserID: uuid.New(), Name: "Kenta"}
+	taro := &FamilyMember{UserID: uuid.New(), Name: "太郎"}
+	members := []*FamilyMember{ken, kenta, taro}
+
+	type span struct {
+		userID     uuid.UUID
+		start, end int
+	}
+	tests := []struct {
+		name    string
+		content string
+		want    []span
+	}{
+		{name: "no mention", content: "今日は晴れ", want: nil},
+		{name: "simple", content: "with @Ken today", want: []span{{ken.UserID, 5, 9}}},
+		{name: "case insensitive", content: "@kEN", want: []span{{ken.UserID, 0, 4}}},
+		{name: "longest name wins", content: "@Kenta!", want: []span{{kenta.UserID, 0, 6}}},
+		{name: "unknown longer name", content: "@Kenji", want: nil},
+		{name: "japanese name followed by particle", content: "昨日@太郎と遊んだ", want: []span{{taro.UserID, 2, 5}}},
+		{name: "full-width at sign", content: "＠太郎", want: []span{{taro.UserID, 0, 3}}},
+		{name: "email address", content: "mail ken@Kenta.com", want: nil},
+		{name: "multiple", content: "@Ken,@Kenta", want: []span{{ken.UserID, 0, 4}, {kenta.UserID, 5, 11}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseMentions(tt.content, members)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseMentions(%q) returned %d mentions, want %d", tt.content, len(got), len(tt.want))
+			}
+			for i, w := range tt.want {
+				if got[i].UserID != w.userID || got[i].Start != w.start || got[i].End != w.end {
+					t.Errorf("mention %d = {%s %d %d}, want {%s %d %d}", i, got[i].UserID, got[i].Start, got[i].End, w.userID, w.start, w.end)
+				}
+			}
+		})
+	}
+}
@@ -11,6 +11,14 @@ type DiarySearchCriteria struct {
 	UserID    uuid.UUID
 	StartDate time.Time
 	EndDate   time.Time
+	// Visibility hides locked time capsules of other users when set
+	Visibility *DiaryVisibility
+}
+
+// DiaryVisibility represents who reads the diaries and when
+type DiaryVisibility struct {
+	ViewerID uuid.UUID
+	At       time.Time
 }
 
 // DiaryCountCriteria represents the criteria for counting diaries
@@ -1,7 +1,11 @@
 package domain
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/furuya-3150/fam-diary-log/pkg/validation"
+	"github.com/google/uuid"
 )
 
 func ValidateDiaryTitle(title string) error {
@@ -23,3 +27,58 @@ func ValidateCreateDiaryRequest(req *Diary) error {
 
 	return nil
 }
+
+func ValidateCollectionName(name string) error {
+	return validation.NotEmptyAndMaxLength(name, MaxCollectionNameLength, "name")
+}
+
+// ValidateCollectionItems checks the item count, duplicates and that the cover is one of the items
+func ValidateCollectionItems(diaryIDs []uuid.UUID, coverDiaryID *uuid.UUID) error {
+	if len(diaryIDs) > MaxCollectionItems {
+		return fmt.Errorf("diary_ids must contain at most %d items", MaxCollectionItems)
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(diaryIDs))
+	for _, id := range diaryIDs {
+		if id == uuid.Nil {
+			return fmt.Errorf("diary_ids contains an invalid id")
+		}
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("diary_ids contains a duplicate id: %s", id)
+		}
+		seen[id] = struct{}{}
+	}
+
+	if coverDiaryID != nil {
+		if _, ok := seen[*coverDiaryID]; !ok {
+			return fmt.Errorf("cover_diary_id must be one of diary_ids")
+		}
+	}
+
+	return nil
+}
+
+// ValidateUnlockAt checks that a time capsule unlocks in the future
+func ValidateUnlockAt(unlockAt *time.Time, now time.Time) error {
+	if unlockAt == nil {
+		return nil
+	}
+	if !unlockAt.After(now) {
+		return fmt.Errorf("unlock_at must be in the future")
+	}
+	return nil
+}
+
+func ValidateEditableDays(days *int) error {
+	if days == nil {
+		return nil
+	}
+	if *days < 0 || *days > MaxEditableDays {
+		return fmt.Errorf("editable_days must be between 0 and %d", MaxEditableDays)
+	}
+	return nil
+}
+
+func ValidateEditOverrideReason(reason string) error {
+	return validation.NotEmptyAndMaxLength(reason, MaxEditOverrideReasonLength, "reason")
+}
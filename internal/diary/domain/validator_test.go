@@ -2,6 +2,9 @@ package domain
 
 import (
 	"testing"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 // valid title tests
@@ -244,3 +247,112 @@ func generateString(length int) string {
 	}
 	return string(result)
 }
+
+func TestValidateCollectionItems(t *testing.T) {
+	t.Parallel()
+
+	d1, d2 := uuid.New(), uuid.New()
+	tooMany := make([]uuid.UUID, MaxCollectionItems+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.New()
+	}
+
+	tests := []struct {
+		name     string
+		diaryIDs []uuid.UUID
+		cover    *uuid.UUID
+		wantErr  bool
+	}{
+		{name: "empty collection", diaryIDs: nil, cover: nil, wantErr: false},
+		{name: "cover in items", diaryIDs: []uuid.UUID{d1, d2}, cover: &d2, wantErr: false},
+		{name: "cover not in items", diaryIDs: []uuid.UUID{d1}, cover: &d2, wantErr: true},
+		{name: "duplicate items", diaryIDs: []uuid.UUID{d1, d1}, cover: nil, wantErr: true},
+		{name: "nil id", diaryIDs: []uuid.UUID{uuid.Nil}, cover: nil, wantErr: true},
+		{name: "too many items", diaryIDs: tooMany, cover: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCollectionItems(tt.diaryIDs, tt.cover)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateCollectionItems() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDiary_IsVisibleTo(t *testing.T) {
+	t.Parallel()
+
+	author, other := uuid.New(), uuid.New()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	future := now.Add(24 * time.Hour)
+	past := now.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		unlockAt *time.Time
+		viewer   uuid.UUID
+		want     bool
+	}{
+		{name: "normal diary", unlockAt: nil, viewer: other, want: true},
+		{name: "locked capsule for author", unlockAt: &future, viewer: author, want: true},
+		{name: "locked capsule for other member", unlockAt: &future, viewer: other, want: false},
+		{name: "unlocked capsule for other member", unlockAt: &past, viewer: other, want: true},
+		{name: "capsule at unlock time", unlockAt: &now, viewer: other, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Diary{UserID: author, UnlockAt: tt.unlockAt}
+			if got := d.IsVisibleTo(tt.viewer, now); got != tt.want {
+				t.Errorf("IsVisibleTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateUnlockAt(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	future := now.Add(time.Minute)
+
+	if err := ValidateUnlockAt(nil, now); err != nil {
+		t.Errorf("nil unlock_at should be valid: %v", err)
+	}
+	if err := ValidateUnlockAt(&future, now); err != nil {
+		t.Errorf("future unlock_at should be valid: %v", err)
+	}
+	if err := ValidateUnlockAt(&now, now); err == nil {
+		t.Error("unlock_at equal to now should be invalid")
+	}
+}
+
+func TestFamilyDiaryPolicy_IsEditable(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	seven, zero := 7, 0
+
+	tests := []struct {
+		name   string
+		policy *FamilyDiaryPolicy
+		at     time.Time
+		want   bool
+	}{
+		{name: "no policy", policy: nil, at: createdAt.AddDate(5, 0, 0), want: true},
+		{name: "no limit", policy: &FamilyDiaryPolicy{}, at: createdAt.AddDate(5, 0, 0), want: true},
+		{name: "within period", policy: &FamilyDiaryPolicy{EditableDays: &seven}, at: createdAt.AddDate(0, 0, 6), want: true},
+		{name: "at the end of period", policy: &FamilyDiaryPolicy{EditableDays: &seven}, at: createdAt.AddDate(0, 0, 7), want: false},
+		{name: "zero days", policy: &FamilyDiaryPolicy{EditableDays: &zero}, at: createdAt, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.IsEditable(createdAt, tt.at); got != tt.want {
+				t.Errorf("IsEditable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
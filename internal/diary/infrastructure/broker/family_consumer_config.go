@@ -0,0 +1,13 @@
+package broker
+
+import "github.com/furuya-3150/fam-diary-log/pkg/broker/consumer"
+
+// FamilyConsumerConfig returns the consumer configuration for family events published by user-context
+func FamilyConsumerConfig() consumer.Config {
+	return consumer.Config{
+		ExchangeName: "family.events",
+		ExchangeKind: "topic",
+		QueueName:    "diary-api.family-members",
+		RoutingKeys:  []string{"family.member_upserted", "family.member_removed"},
+	}
+}
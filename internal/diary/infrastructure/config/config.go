@@ -5,6 +5,7 @@ type Config struct {
 	TestDB DBConfig
 	JWT JWTConfig
 	CORS CORSConfig
+	Scheduler SchedulerConfig
 }
 
 var Cfg Config
@@ -14,5 +15,6 @@ func Load() Config {
 		DB:     loadDB(),
 		JWT:    loadJWT(),
 		CORS:   loadCORS(),
+		Scheduler: loadScheduler(),
 	}
 }
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"strconv"
+	"time"
+)
+
+type SchedulerConfig struct {
+	UnlockInterval time.Duration
+}
+
+func loadScheduler() SchedulerConfig {
+	intervalStr := getEnv("UNLOCK_SCHEDULER_INTERVAL", "60") // Default: 1 minute
+	intervalSec, err := strconv.Atoi(intervalStr)
+	if err != nil || intervalSec <= 0 {
+		intervalSec = 60
+	}
+
+	return SchedulerConfig{
+		UnlockInterval: time.Duration(intervalSec) * time.Second,
+	}
+}
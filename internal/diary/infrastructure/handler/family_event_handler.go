@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary/usecase"
+)
+
+// FamilyEventHandler keeps the family member projection used for @mentions in sync
+type FamilyEventHandler struct {
+	mu usecase.MentionUsecase
+	l  *slog.Logger
+}
+
+// NewFamilyEventHandler creates a new FamilyEventHandler
+func NewFamilyEventHandler(mu usecase.MentionUsecase, l *slog.Logger) *FamilyEventHandler {
+	return &FamilyEventHandler{
+		mu: mu,
+		l:  l,
+	}
+}
+
+// Handle handles events based on routing key
+func (h *FamilyEventHandler) Handle(ctx context.Context, routingKey string, content []byte) error {
+	switch routingKey {
+	case "family.member_upserted":
+		return h.handleMemberUpserted(ctx, content)
+	case "family.member_removed":
+		return h.handleMemberRemoved(ctx, content)
+	default:
+		return fmt.Errorf("unknown routing key: %s", routingKey)
+	}
+}
+
+func (h *FamilyEventHandler) handleMemberUpserted(ctx context.Context, content []byte) error {
+	var event domain.FamilyMemberUpsertedEvent
+	if err := json.Unmarshal(content, &event); err != nil {
+		return fmt.Errorf("invalid event type for family.member_upserted %v", err)
+	}
+
+	if err := h.mu.SyncMember(ctx, &event); err != nil {
+		h.l.Error("failed to sync family member", "family_id", event.FamilyID, "user_id", event.UserID, "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (h *FamilyEventHandler) handleMemberRemoved(ctx context.Context, content []byte) error {
+	var event domain.FamilyMemberRemovedEvent
+	if err := json.Unmarshal(content, &event); err != nil {
+		return fmt.Errorf("invalid event type for family.member_removed %v", err)
+	}
+
+	if err := h.mu.RemoveMember(ctx, &event); err != nil {
+		h.l.Error("failed to remove family member", "family_id", event.FamilyID, "user_id", event.UserID, "error", err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/http/controller/dto"
+	"github.com/furuya-3150/fam-diary-log/internal/diary/usecase"
+	"github.com/google/uuid"
+)
+
+type BookmarkController interface {
+	Add(ctx context.Context, userID, familyID, diaryID uuid.UUID) error
+	Remove(ctx context.Context, userID, diaryID uuid.UUID) error
+	List(ctx context.Context, userID, familyID uuid.UUID) ([]dto.DiaryResponse, error)
+}
+
+type bookmarkController struct {
+	bu usecase.BookmarkUsecase
+}
+
+func NewBookmarkController(bu usecase.BookmarkUsecase) BookmarkController {
+	return &bookmarkController{bu: bu}
+}
+
+func (bc *bookmarkController) Add(ctx context.Context, userID, familyID, diaryID uuid.UUID) error {
+	return bc.bu.Add(ctx, userID, familyID, diaryID)
+}
+
+func (bc *bookmarkController) Remove(ctx context.Context, userID, diaryID uuid.UUID) error {
+	return bc.bu.Remove(ctx, userID, diaryID)
+}
+
+func (bc *bookmarkController) List(ctx context.Context, userID, familyID uuid.UUID) ([]dto.DiaryResponse, error) {
+	diaries, err := bc.bu.List(ctx, userID, familyID)
+	if err != nil {
+		return nil, err
+	}
+	return toDiaryResponses(diaries), nil
+}
+
+func toDiaryResponses(diaries []*domain.Diary) []dto.DiaryResponse {
+	responses := make([]dto.DiaryResponse, len(diaries))
+	for i, diary := range diaries {
+		responses[i] = dto.DiaryResponse{
+			ID:        diary.ID,
+			FamilyID:  diary.FamilyID,
+			UserID:    diary.UserID,
+			Title:     diary.Title,
+			Content:   diary.Content,
+			UnlockAt:  diary.UnlockAt,
+			Mentions:  toMentionResponses(diary.Mentions),
+			CreatedAt: diary.CreatedAt,
+		}
+	}
+	return responses
+}
+
+func toMentionResponses(mentions []*domain.DiaryMention) []dto.MentionResponse {
+	if len(mentions) == 0 {
+		return nil
+	}
+	responses := make([]dto.MentionResponse, len(mentions))
+	for i, m := range mentions {
+		responses[i] = dto.MentionResponse{
+			UserID: m.UserID,
+			Name:   m.Name,
+			Start:  m.Start,
+			End:    m.End,
+		}
+	}
+	return responses
+}
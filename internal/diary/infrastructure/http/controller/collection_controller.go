@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/http/controller/dto"
+	"github.com/furuya-3150/fam-diary-log/internal/diary/usecase"
+	"github.com/google/uuid"
+)
+
+type CollectionController interface {
+	Create(ctx context.Context, userID, familyID uuid.UUID, req *dto.SaveCollectionRequest) (*dto.CollectionDetailResponse, error)
+	Update(ctx context.Context, userID, familyID, collectionID uuid.UUID, req *dto.SaveCollectionRequest) (*dto.CollectionDetailResponse, error)
+	Delete(ctx context.Context, familyID, collectionID uuid.UUID) error
+	List(ctx context.Context, familyID uuid.UUID) ([]dto.CollectionSummaryResponse, error)
+	Get(ctx context.Context, familyID, userID, collectionID uuid.UUID) (*dto.CollectionDetailResponse, error)
+}
+
+type collectionController struct {
+	cu usecase.CollectionUsecase
+}
+
+func NewCollectionController(cu usecase.CollectionUsecase) CollectionController {
+	return &collectionController{cu: cu}
+}
+
+func (cc *collectionController) Create(ctx context.Context, userID, familyID uuid.UUID, req *dto.SaveCollectionRequest) (*dto.CollectionDetailResponse, error) {
+	detail, err := cc.cu.Create(ctx, toSaveCollectionInput(userID, familyID, req))
+	if err != nil {
+		return nil, err
+	}
+	return toCollectionDetailResponse(detail), nil
+}
+
+func (cc *collectionController) Update(ctx context.Context, userID, familyID, collectionID uuid.UUID, req *dto.SaveCollectionRequest) (*dto.CollectionDetailResponse, error) {
+	detail, err := cc.cu.Update(ctx, collectionID, toSaveCollectionInput(userID, familyID, req))
+	if err != nil {
+		return nil, err
+	}
+	return toCollectionDetailResponse(detail), nil
+}
+
+func (cc *collectionController) Delete(ctx context.Context, familyID, collectionID uuid.UUID) error {
+	return cc.cu.Delete(ctx, familyID, collectionID)
+}
+
+func (cc *collectionController) List(ctx context.Context, familyID uuid.UUID) ([]dto.CollectionSummaryResponse, error) {
+	summaries, err := cc.cu.List(ctx, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.CollectionSummaryResponse, len(summaries))
+	for i, s := range summaries {
+		responses[i] = dto.CollectionSummaryResponse{
+			ID:           s.Collection.ID,
+			FamilyID:     s.Collection.FamilyID,
+			CreatedBy:    s.Collection.CreatedBy,
+			Name:         s.Collection.Name,
+			CoverDiaryID: s.Collection.CoverDiaryID,
+			ItemCount:    s.ItemCount,
+			CreatedAt:    s.Collection.CreatedAt,
+			UpdatedAt:    s.Collection.UpdatedAt,
+		}
+	}
+	return responses, nil
+}
+
+func (cc *collectionController) Get(ctx context.Context, familyID, userID, collectionID uuid.UUID) (*dto.CollectionDetailResponse, error) {
+	detail, err := cc.cu.Get(ctx, familyID, userID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	return toCollectionDetailResponse(detail), nil
+}
+
+func toSaveCollectionInput(userID, familyID uuid.UUID, req *dto.SaveCollectionRequest) *usecase.SaveCollectionInput {
+	return &usecase.SaveCollectionInput{
+		FamilyID:     familyID,
+		UserID:       userID,
+		Name:         req.Name,
+		DiaryIDs:     req.DiaryIDs,
+		CoverDiaryID: req.CoverDiaryID,
+	}
+}
+
+func toCollectionDetailResponse(detail *usecase.CollectionDetail) *dto.CollectionDetailResponse {
+	return &dto.CollectionDetailResponse{
+		ID:           detail.Collection.ID,
+		FamilyID:     detail.Collection.FamilyID,
+		CreatedBy:    detail.Collection.CreatedBy,
+		Name:         detail.Collection.Name,
+		CoverDiaryID: detail.Collection.CoverDiaryID,
+		Diaries:      toDiaryResponses(detail.Diaries),
+		CreatedAt:    detail.Collection.CreatedAt,
+		UpdatedAt:    detail.Collection.UpdatedAt,
+	}
+}
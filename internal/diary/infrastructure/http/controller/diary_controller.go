@@ -10,7 +10,7 @@ import (
 
 type DiaryController interface {
 	Create(ctx context.Context, userID, familyID uuid.UUID, req *dto.CreateDiaryRequest) (*dto.DiaryResponse, error)
-	List(ctx context.Context, familyID uuid.UUID, targetDate string) ([]dto.DiaryResponse, error)
+	List(ctx context.Context, familyID, userID uuid.UUID, targetDate string) ([]dto.DiaryResponse, error)
 	GetCount(ctx context.Context, familyID, userID uuid.UUID, year, month string) (int, error)
 	GetStreak(ctx context.Context, userID, familyID uuid.UUID) (*dto.StreakResponse, error)
 }
@@ -30,6 +30,7 @@ func (dc *diaryController) Create(ctx context.Context, userID, familyID uuid.UUI
 		Title:              req.Title,
 		Content:            req.Content,
 		WritingTimeSeconds: req.WritingTimeSeconds,
+		UnlockAt:           req.UnlockAt,
 	}
 
 	diary, err := dc.du.Create(ctx, input)
@@ -43,29 +44,19 @@ func (dc *diaryController) Create(ctx context.Context, userID, familyID uuid.UUI
 		UserID:    diary.UserID,
 		Title:     diary.Title,
 		Content:   diary.Content,
+		UnlockAt:  diary.UnlockAt,
+		Mentions:  toMentionResponses(diary.Mentions),
 		CreatedAt: diary.CreatedAt,
 	}
 	return res, nil
 }
 
-func (dc *diaryController) List(ctx context.Context, familyID uuid.UUID, targetDate string) ([]dto.DiaryResponse, error) {
-	diaries, err := dc.du.List(ctx, familyID, targetDate)
+func (dc *diaryController) List(ctx context.Context, familyID, userID uuid.UUID, targetDate string) ([]dto.DiaryResponse, error) {
+	diaries, err := dc.du.List(ctx, familyID, userID, targetDate)
 	if err != nil {
 		return nil, err
 	}
-
-	responses := make([]dto.DiaryResponse, len(diaries))
-	for i, diary := range diaries {
-		responses[i] = dto.DiaryResponse{
-			ID:        diary.ID,
-			FamilyID:  diary.FamilyID,
-			UserID:    diary.UserID,
-			Title:     diary.Title,
-			Content:   diary.Content,
-			CreatedAt: diary.CreatedAt,
-		}
-	}
-	return responses, nil
+	return toDiaryResponses(diaries), nil
 }
 
 func (dc *diaryController) GetCount(ctx context.Context, familyID, userID uuid.UUID, year, month string) (int, error) {
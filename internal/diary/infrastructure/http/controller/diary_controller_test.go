@@ -25,8 +25,8 @@ func (m *MockDiaryUsecase) Create(ctx context.Context, input *usecase.CreateDiar
 	return args.Get(0).(*domain.Diary), args.Error(1)
 }
 
-func (m *MockDiaryUsecase) List(ctx context.Context, familyID uuid.UUID, targetDate string) ([]*domain.Diary, error) {
-	args := m.Called(ctx, familyID, targetDate)
+func (m *MockDiaryUsecase) List(ctx context.Context, familyID, userID uuid.UUID, targetDate string) ([]*domain.Diary, error) {
+	args := m.Called(ctx, familyID, userID, targetDate)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -391,10 +391,10 @@ func TestDiaryController_List_Success(t *testing.T) {
 		},
 	}
 
-	mockUsecase.On("List", mock.Anything, familyID, "2026-01-15").Return(expectedDiaries, nil)
+	mockUsecase.On("List", mock.Anything, familyID, mock.Anything, "2026-01-15").Return(expectedDiaries, nil)
 
 	// Call controller
-	result, err := controller.List(context.Background(), familyID, "2026-01-15")
+	result, err := controller.List(context.Background(), familyID, uuid.New(), "2026-01-15")
 
 	// Verify result
 	if err != nil {
@@ -430,10 +430,10 @@ func TestDiaryController_List_ValidationError(t *testing.T) {
 	familyID := uuid.New()
 
 	validationErr := &errors.ValidationError{Message: "invalid date format"}
-	mockUsecase.On("List", mock.Anything, familyID, mock.Anything).Return(nil, validationErr)
+	mockUsecase.On("List", mock.Anything, familyID, mock.Anything, mock.Anything).Return(nil, validationErr)
 
 	// Call controller
-	result, err := controller.List(context.Background(), familyID, "invalid-date")
+	result, err := controller.List(context.Background(), familyID, uuid.New(), "invalid-date")
 
 	// Verify result
 	if err == nil {
@@ -457,10 +457,10 @@ func TestDiaryController_List_InternalError(t *testing.T) {
 	familyID := uuid.New()
 
 	internalErr := &errors.InternalError{Message: "database error"}
-	mockUsecase.On("List", mock.Anything, familyID, mock.Anything).Return(nil, internalErr)
+	mockUsecase.On("List", mock.Anything, familyID, mock.Anything, mock.Anything).Return(nil, internalErr)
 
 	// Call controller
-	result, err := controller.List(context.Background(), familyID, "2026-01-15")
+	result, err := controller.List(context.Background(), familyID, uuid.New(), "2026-01-15")
 
 	// Verify result
 	if err == nil {
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/http/controller/dto"
+	"github.com/furuya-3150/fam-diary-log/internal/diary/usecase"
+	"github.com/google/uuid"
+)
+
+type DiaryEditController interface {
+	Update(ctx context.Context, userID, familyID, diaryID uuid.UUID, isAdmin bool, req *dto.UpdateDiaryRequest) (*dto.DiaryResponse, error)
+	GetPolicy(ctx context.Context, familyID uuid.UUID) (*dto.DiaryPolicyResponse, error)
+	UpdatePolicy(ctx context.Context, userID, familyID uuid.UUID, isAdmin bool, req *dto.UpdateDiaryPolicyRequest) (*dto.DiaryPolicyResponse, error)
+}
+
+type diaryEditController struct {
+	eu usecase.DiaryEditUsecase
+}
+
+func NewDiaryEditController(eu usecase.DiaryEditUsecase) DiaryEditController {
+	return &diaryEditController{eu: eu}
+}
+
+func (ec *diaryEditController) Update(ctx context.Context, userID, familyID, diaryID uuid.UUID, isAdmin bool, req *dto.UpdateDiaryRequest) (*dto.DiaryResponse, error) {
+	input := &usecase.UpdateDiaryInput{
+		DiaryID:      diaryID,
+		FamilyID:     familyID,
+		UserID:       userID,
+		IsAdmin:      isAdmin,
+		Title:        req.Title,
+		Content:      req.Content,
+		OverrideLock: req.OverrideLock,
+		Reason:       req.Reason,
+	}
+
+	diary, err := ec.eu.Update(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.DiaryResponse{
+		ID:        diary.ID,
+		FamilyID:  diary.FamilyID,
+		UserID:    diary.UserID,
+		Title:     diary.Title,
+		Content:   diary.Content,
+		UnlockAt:  diary.UnlockAt,
+		Mentions:  toMentionResponses(diary.Mentions),
+		CreatedAt: diary.CreatedAt,
+	}
+	return res, nil
+}
+
+func (ec *diaryEditController) GetPolicy(ctx context.Context, familyID uuid.UUID) (*dto.DiaryPolicyResponse, error) {
+	policy, err := ec.eu.GetPolicy(ctx, familyID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.DiaryPolicyResponse{FamilyID: policy.FamilyID, EditableDays: policy.EditableDays}, nil
+}
+
+func (ec *diaryEditController) UpdatePolicy(ctx context.Context, userID, familyID uuid.UUID, isAdmin bool, req *dto.UpdateDiaryPolicyRequest) (*dto.DiaryPolicyResponse, error) {
+	input := &usecase.UpdateDiaryPolicyInput{
+		FamilyID:     familyID,
+		UserID:       userID,
+		IsAdmin:      isAdmin,
+		EditableDays: req.EditableDays,
+	}
+
+	policy, err := ec.eu.UpdatePolicy(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.DiaryPolicyResponse{FamilyID: policy.FamilyID, EditableDays: policy.EditableDays}, nil
+}
@@ -11,15 +11,27 @@ type CreateDiaryRequest struct {
 	Title              string `json:"title" validate:"required,min=1,max=255"`
 	Content            string `json:"content" validate:"required,min=1"`
 	WritingTimeSeconds int    `json:"writing_time_seconds" validate:"required,min=0"`
+	// UnlockAt makes the diary a time capsule that only the author can read until then
+	UnlockAt *time.Time `json:"unlock_at"`
 }
 
 type DiaryResponse struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	FamilyID  uuid.UUID `json:"family_id"`
-	Title     string    `json:"title"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        uuid.UUID         `json:"id"`
+	UserID    uuid.UUID         `json:"user_id"`
+	FamilyID  uuid.UUID         `json:"family_id"`
+	Title     string            `json:"title"`
+	Content   string            `json:"content"`
+	UnlockAt  *time.Time        `json:"unlock_at,omitempty"`
+	Mentions  []MentionResponse `json:"mentions,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// MentionResponse is an @mention span in the content. start and end are offsets in Unicode code points.
+type MentionResponse struct {
+	UserID uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+	Start  int       `json:"start"`
+	End    int       `json:"end"`
 }
 
 type StreakResponse struct {
@@ -34,3 +46,53 @@ type StreakResponse struct {
 type DiaryListQuery struct {
 	TargetDate string `query:"target_date" validate:"required,datetime=2006-01-02"`
 }
+
+// SaveCollectionRequest represents a request to create or update a collection.
+// diary_ids is the ordered list of diaries and cover_diary_id must be one of them.
+type SaveCollectionRequest struct {
+	Name         string      `json:"name" validate:"required,min=1,max=100"`
+	DiaryIDs     []uuid.UUID `json:"diary_ids" validate:"max=500"`
+	CoverDiaryID *uuid.UUID  `json:"cover_diary_id"`
+}
+
+type CollectionSummaryResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	FamilyID     uuid.UUID  `json:"family_id"`
+	CreatedBy    uuid.UUID  `json:"created_by"`
+	Name         string     `json:"name"`
+	CoverDiaryID *uuid.UUID `json:"cover_diary_id"`
+	ItemCount    int        `json:"item_count"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type CollectionDetailResponse struct {
+	ID           uuid.UUID       `json:"id"`
+	FamilyID     uuid.UUID       `json:"family_id"`
+	CreatedBy    uuid.UUID       `json:"created_by"`
+	Name         string          `json:"name"`
+	CoverDiaryID *uuid.UUID      `json:"cover_diary_id"`
+	Diaries      []DiaryResponse `json:"diaries"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// UpdateDiaryRequest represents a request to edit a diary.
+// override_lock and reason are used by admins to edit a diary after the editable period or a diary of another member.
+type UpdateDiaryRequest struct {
+	Title        string `json:"title" validate:"required,min=1,max=255"`
+	Content      string `json:"content" validate:"required,min=1"`
+	OverrideLock bool   `json:"override_lock"`
+	Reason       string `json:"reason" validate:"max=500"`
+}
+
+// UpdateDiaryPolicyRequest represents a request to change the family's edit policy.
+// editable_days null means diaries are editable forever.
+type UpdateDiaryPolicyRequest struct {
+	EditableDays *int `json:"editable_days" validate:"omitempty,min=0,max=3650"`
+}
+
+type DiaryPolicyResponse struct {
+	FamilyID     uuid.UUID `json:"family_id"`
+	EditableDays *int      `json:"editable_days"`
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/http/controller"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+	"github.com/furuya-3150/fam-diary-log/pkg/middleware/auth"
+	"github.com/furuya-3150/fam-diary-log/pkg/response"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// BookmarkHandler handles HTTP requests for bookmark operations
+type BookmarkHandler struct {
+	bc controller.BookmarkController
+}
+
+// NewBookmarkHandler creates a new instance of BookmarkHandler
+func NewBookmarkHandler(bc controller.BookmarkController) *BookmarkHandler {
+	return &BookmarkHandler{bc: bc}
+}
+
+func (bh *BookmarkHandler) Add(e echo.Context) error {
+	userID := e.Request().Context().Value(auth.ContextKeyUserID).(uuid.UUID)
+	familyID := e.Request().Context().Value(auth.ContextKeyFamilyID).(uuid.UUID)
+
+	diaryID, err := uuid.Parse(e.Param("diary_id"))
+	if err != nil {
+		return errors.RespondWithError(e, &errors.ValidationError{Message: "invalid diary_id"})
+	}
+
+	if err := bh.bc.Add(e.Request().Context(), userID, familyID, diaryID); err != nil {
+		slog.Error("controller add bookmark error", "error", err.Error())
+		return errors.RespondWithError(e, err)
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+func (bh *BookmarkHandler) Remove(e echo.Context) error {
+	userID := e.Request().Context().Value(auth.ContextKeyUserID).(uuid.UUID)
+
+	diaryID, err := uuid.Parse(e.Param("diary_id"))
+	if err != nil {
+		return errors.RespondWithError(e, &errors.ValidationError{Message: "invalid diary_id"})
+	}
+
+	if err := bh.bc.Remove(e.Request().Context(), userID, diaryID); err != nil {
+		slog.Error("controller remove bookmark error", "error", err.Error())
+		return errors.RespondWithError(e, err)
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+func (bh *BookmarkHandler) List(e echo.Context) error {
+	userID := e.Request().Context().Value(auth.ContextKeyUserID).(uuid.UUID)
+	familyID := e.Request().Context().Value(auth.ContextKeyFamilyID).(uuid.UUID)
+
+	res, err := bh.bc.List(e.Request().Context(), userID, familyID)
+	if err != nil {
+		slog.Error("controller list bookmarks error", "error", err.Error())
+		return errors.RespondWithError(e, err)
+	}
+
+	return response.RespondSuccess(e, http.StatusOK, res)
+}
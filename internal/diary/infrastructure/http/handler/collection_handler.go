@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/http/controller"
+	dto "github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/http/controller/dto"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+	"github.com/furuya-3150/fam-diary-log/pkg/middleware/auth"
+	"github.com/furuya-3150/fam-diary-log/pkg/response"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// CollectionHandler handles HTTP requests for collection operations
+type CollectionHandler struct {
+	cc       controller.CollectionController
+	validate *validator.Validate
+}
+
+// NewCollectionHandler creates a new instance of CollectionHandler
+func NewCollectionHandler(cc controller.CollectionController) *CollectionHandler {
+	return &CollectionHandler{
+		cc:       cc,
+		validate: validator.New(),
+	}
+}
+
+func (ch *CollectionHandler) Create(e echo.Context) error {
+	req, err := ch.bindSaveRequest(e)
+	if err != nil {
+		return errors.RespondWithError(e, err)
+	}
+
+	userID := e.Request().Context().Value(auth.ContextKeyUserID).(uuid.UUID)
+	familyID := e.Request().Context().Value(auth.ContextKeyFamilyID).(uuid.UUID)
+
+	res, err := ch.cc.Create(e.Request().Context(), userID, familyID, req)
+	if err != nil {
+		slog.Error("controller create collection error", "error", err.Error())
+		return errors.RespondWithError(e, err)
+	}
+
+	return response.RespondSuccess(e, http.StatusOK, res)
+}
+
+func (ch *CollectionHandler) Update(e echo.Context) error {
+	collectionID, err := uuid.Parse(e.Param("collection_id"))
+	if err != nil {
+		return errors.RespondWithError(e, &errors.ValidationError{Message: "invalid collection_id"})
+	}
+
+	req, err := ch.bindSaveRequest(e)
+	if err != nil {
+		return errors.RespondWithError(e, err)
+	}
+
+	userID := e.Request().Context().Value(auth.ContextKeyUserID).(uuid.UUID)
+	familyID := e.Request().Context().Value(auth.ContextKeyFamilyID).(uuid.UUID)
+
+	res, err := ch.cc.Update(e.Request().Context(), userID, familyID, collectionID, req)
+	if err != nil {
+		slog.Error("controller update collection error", "error", err.Error())
+		return errors.RespondWithError(e, err)
+	}
+
+	return response.RespondSuccess(e, http.StatusOK, res)
+}
+
+func (ch *CollectionHandler) Delete(e echo.Context) error {
+	collectionID, err := uuid.Parse(e.Param("collection_id"))
+	if err != nil {
+		return errors.RespondWithError(e, &errors.ValidationError{Message: "invalid collection_id"})
+	}
+
+	familyID := e.Request().Context().Value(auth.ContextKeyFamilyID).(uuid.UUID)
+
+	if err := ch.cc.Delete(e.Request().Context(), familyID, collectionID); err != nil {
+		slog.Error("controller delete collection error", "error", err.Error())
+		return errors.RespondWithError(e, err)
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+func (ch *CollectionHandler) List(e echo.Context) error {
+	familyID := e.Request().Context().Value(auth.ContextKeyFamilyID).(uuid.UUID)
+
+	res, err := ch.cc.List(e.Request().Context(), familyID)
+	if err != nil {
+		slog.Error("controller list collections error", "error", err.Error())
+		return errors.RespondWithError(e, err)
+	}
+
+	return response.RespondSuccess(e, http.StatusOK, res)
+}
+
+func (ch *CollectionHandler) Get(e echo.Context) error {
+	collectionID, err := uuid.Parse(e.Param("collection_id"))
+	if err != nil {
+		return errors.RespondWithError(e, &errors.ValidationError{Message: "invalid collection_id"})
+	}
+
+	userID := e.Request().Context().Value(auth.ContextKeyUserID).(uuid.UUID)
+	familyID := e.Request().Context().Value(auth.ContextKeyFamilyID).(uuid.UUID)
+
+	res, err := ch.cc.Get(e.Request().Context(), familyID, userID, collectionID)
+	if err != nil {
+		slog.Error("controller get collection error", "error", err.Error())
+		return errors.RespondWithError(e, err)
+	}
+
+	return response.RespondSuccess(e, http.StatusOK, res)
+}
+
+func (ch *CollectionHandler) bindSaveRequest(e echo.Context) (*dto.SaveCollectionRequest, error) {
+	var req dto.SaveCollectionRequest
+	if err := e.Bind(&req); err != nil {
+		slog.Debug("bind error", "error", err)
+		return nil, &errors.ValidationError{Message: "invalid request body: " + err.Error()}
+	}
+
+	if err := ch.validate.Struct(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errorMessages := make([]string, 0, len(validationErrors))
+			for _, fieldError := range validationErrors {
+				errorMessages = append(errorMessages, formatValidationError(fieldError))
+			}
+			return nil, &errors.ValidationError{
+				Message: fmt.Sprintf("validation failed: %s", strings.Join(errorMessages, ", ")),
+			}
+		}
+		return nil, &errors.ValidationError{Message: "validation failed: " + err.Error()}
+	}
+
+	return &req, nil
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/http/controller"
+	dto "github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/http/controller/dto"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+	"github.com/furuya-3150/fam-diary-log/pkg/middleware/auth"
+	"github.com/furuya-3150/fam-diary-log/pkg/response"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// DiaryEditHandler handles HTTP requests for editing diaries and the edit policy
+type DiaryEditHandler struct {
+	ec       controller.DiaryEditController
+	validate *validator.Validate
+}
+
+// NewDiaryEditHandler creates a new instance of DiaryEditHandler
+func NewDiaryEditHandler(ec controller.DiaryEditController) *DiaryEditHandler {
+	return &DiaryEditHandler{
+		ec:       ec,
+		validate: validator.New(),
+	}
+}
+
+func (eh *DiaryEditHandler) Update(e echo.Context) error {
+	diaryID, err := uuid.Parse(e.Param("diary_id"))
+	if err != nil {
+		return errors.RespondWithError(e, &errors.ValidationError{Message: "invalid diary_id"})
+	}
+
+	var req dto.UpdateDiaryRequest
+	if err := eh.bind(e, &req); err != nil {
+		return errors.RespondWithError(e, err)
+	}
+
+	ctx := e.Request().Context()
+	userID := ctx.Value(auth.ContextKeyUserID).(uuid.UUID)
+	familyID := ctx.Value(auth.ContextKeyFamilyID).(uuid.UUID)
+	role, _ := auth.GetRoleFromContext(ctx)
+
+	res, err := eh.ec.Update(ctx, userID, familyID, diaryID, role == auth.RoleAdmin, &req)
+	if err != nil {
+		slog.Error("controller update diary error", "error", err.Error())
+		return errors.RespondWithError(e, err)
+	}
+
+	return response.RespondSuccess(e, http.StatusOK, res)
+}
+
+func (eh *DiaryEditHandler) GetPolicy(e echo.Context) error {
+	familyID := e.Request().Context().Value(auth.ContextKeyFamilyID).(uuid.UUID)
+
+	res, err := eh.ec.GetPolicy(e.Request().Context(), familyID)
+	if err != nil {
+		slog.Error("controller get diary policy error", "error", err.Error())
+		return errors.RespondWithError(e, err)
+	}
+
+	return response.RespondSuccess(e, http.StatusOK, res)
+}
+
+func (eh *DiaryEditHandler) UpdatePolicy(e echo.Context) error {
+	var req dto.UpdateDiaryPolicyRequest
+	if err := eh.bind(e, &req); err != nil {
+		return errors.RespondWithError(e, err)
+	}
+
+	ctx := e.Request().Context()
+	userID := ctx.Value(auth.ContextKeyUserID).(uuid.UUID)
+	familyID := ctx.Value(auth.ContextKeyFamilyID).(uuid.UUID)
+	role, _ := auth.GetRoleFromContext(ctx)
+
+	res, err := eh.ec.UpdatePolicy(ctx, userID, familyID, role == auth.RoleAdmin, &req)
+	if err != nil {
+		slog.Error("controller update diary policy error", "error", err.Error())
+		return errors.RespondWithError(e, err)
+	}
+
+	return response.RespondSuccess(e, http.StatusOK, res)
+}
+
+func (eh *DiaryEditHandler) bind(e echo.Context, req interface{}) error {
+	if err := e.Bind(req); err != nil {
+		slog.Debug("bind error", "error", err)
+		return &errors.ValidationError{Message: "invalid request body: " + err.Error()}
+	}
+
+	if err := eh.validate.Struct(req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errorMessages := make([]string, 0, len(validationErrors))
+			for _, fieldError := range validationErrors {
+				errorMessages = append(errorMessages, formatValidationError(fieldError))
+			}
+			return &errors.ValidationError{
+				Message: fmt.Sprintf("validation failed: %s", strings.Join(errorMessages, ", ")),
+			}
+		}
+		return &errors.ValidationError{Message: "validation failed: " + err.Error()}
+	}
+
+	return nil
+}
@@ -93,8 +93,9 @@ func (dh *DiaryHandler) List(e echo.Context) error {
 	slog.Debug("Query parameters validated successfully", "query", q)
 
 	ctx := e.Request().Context()
+	userID := ctx.Value(auth.ContextKeyUserID).(uuid.UUID)
 
-	res, err := dh.dc.List(ctx, familyID, q.TargetDate)
+	res, err := dh.dc.List(ctx, familyID, userID, q.TargetDate)
 	if err != nil {
 		slog.Error("controller list error", "error", err.Error())
 		return errors.RespondWithError(e, err)
@@ -30,8 +30,8 @@ func (m *MockDiaryController) Create(ctx context.Context, userID, familyID uuid.
 	return args.Get(0).(*dto.DiaryResponse), args.Error(1)
 }
 
-func (m *MockDiaryController) List(ctx context.Context, familyID uuid.UUID, targetDate string) ([]dto.DiaryResponse, error) {
-	args := m.Called(ctx, familyID, targetDate)
+func (m *MockDiaryController) List(ctx context.Context, familyID, userID uuid.UUID, targetDate string) ([]dto.DiaryResponse, error) {
+	args := m.Called(ctx, familyID, userID, targetDate)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -319,7 +319,7 @@ func TestDiaryHandler_List_Success(t *testing.T) {
 
 	mockController.On("List", mock.MatchedBy(func(ctx context.Context) bool {
 		return ctx.Value(auth.ContextKeyFamilyID) == familyID
-	}), familyID, mock.Anything).Return(expectedResponses, nil)
+	}), familyID, userID, mock.Anything).Return(expectedResponses, nil)
 
 	// Create request
 	req := httptest.NewRequest(http.MethodGet, "/families/me/diaries?target_date=2026-01-01", nil)
@@ -367,7 +367,7 @@ func TestDiaryHandler_List_Error(t *testing.T) {
 	userID := uuid.New()
 
 	internalErr := &errors.InternalError{Message: "database error"}
-	mockController.On("List", mock.Anything, familyID, mock.Anything).Return(nil, internalErr)
+	mockController.On("List", mock.Anything, familyID, userID, mock.Anything).Return(nil, internalErr)
 
 	// Create request
 	req := httptest.NewRequest(http.MethodGet, "/families/me/diaries?target_date=2026-01-01", nil)
@@ -28,10 +28,23 @@ func NewRouter() *echo.Echo {
 	clock := &clock.Real{}
 	diaryRepo := repository.NewDiaryRepository(dbManager)
 	streakRepo := repository.NewStreakRepository(dbManager)
-	diaryUsecase := usecase.NewDiaryUsecase(txManager, diaryRepo, streakRepo, pub, clock)
+	mentionUsecase := usecase.NewMentionUsecase(repository.NewFamilyMemberRepository(dbManager), repository.NewMentionRepository(dbManager), pub, clock)
+	diaryUsecase := usecase.NewDiaryUsecase(txManager, diaryRepo, streakRepo, pub, clock, mentionUsecase)
 	diaryController := controller.NewDiaryController(diaryUsecase)
 	diaryHandler := handler.NewDiaryHandler(diaryController)
 
+	bookmarkRepo := repository.NewBookmarkRepository(dbManager)
+	bookmarkUsecase := usecase.NewBookmarkUsecase(bookmarkRepo, diaryRepo, clock)
+	bookmarkHandler := handler.NewBookmarkHandler(controller.NewBookmarkController(bookmarkUsecase))
+
+	collectionRepo := repository.NewCollectionRepository(dbManager)
+	collectionUsecase := usecase.NewCollectionUsecase(txManager, collectionRepo, diaryRepo, clock)
+	collectionHandler := handler.NewCollectionHandler(controller.NewCollectionController(collectionUsecase))
+
+	policyRepo := repository.NewDiaryPolicyRepository(dbManager)
+	diaryEditUsecase := usecase.NewDiaryEditUsecase(txManager, diaryRepo, policyRepo, pub, clock, mentionUsecase)
+	diaryEditHandler := handler.NewDiaryEditHandler(controller.NewDiaryEditController(diaryEditUsecase))
+
 	e := echo.New()
 
 	// CORS middleware
@@ -53,6 +66,28 @@ func NewRouter() *echo.Echo {
 	diaries.GET("", diaryHandler.List)
 	diaries.GET("/count", diaryHandler.GetCount)
 	diaries.GET("/streak", diaryHandler.GetStreak)
+	diaries.PUT("/:diary_id", diaryEditHandler.Update)
+	diaries.PUT("/:diary_id/bookmark", bookmarkHandler.Add)
+	diaries.DELETE("/:diary_id/bookmark", bookmarkHandler.Remove)
+
+	bookmarks := e.Group("/families/me/bookmarks")
+	bookmarks.Use(auth.JWTAuthMiddleware(config.JWT.Secret), auth.RequireFamily())
+	bookmarks.GET("", bookmarkHandler.List)
+
+	// family edit policy - only admins can change it
+	policy := e.Group("/families/me/diary-policy")
+	policy.Use(auth.JWTAuthMiddleware(config.JWT.Secret), auth.RequireFamily())
+	policy.GET("", diaryEditHandler.GetPolicy)
+	policy.PUT("", diaryEditHandler.UpdatePolicy, auth.RequireRole(auth.RoleAdmin))
+
+	// family-shared highlight collections
+	collections := e.Group("/families/me/collections")
+	collections.Use(auth.JWTAuthMiddleware(config.JWT.Secret), auth.RequireFamily())
+	collections.POST("", collectionHandler.Create)
+	collections.GET("", collectionHandler.List)
+	collections.GET("/:collection_id", collectionHandler.Get)
+	collections.PUT("/:collection_id", collectionHandler.Update)
+	collections.DELETE("/:collection_id", collectionHandler.Delete)
 
 	return e
 }
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+type BookmarkRepository interface {
+	Create(ctx context.Context, bookmark *domain.Bookmark) error
+	Delete(ctx context.Context, userID, diaryID uuid.UUID) error
+	List(ctx context.Context, userID, familyID uuid.UUID) ([]*domain.Bookmark, error)
+}
+
+type bookmarkRepository struct {
+	dm *db.DBManager
+}
+
+func NewBookmarkRepository(dm *db.DBManager) BookmarkRepository {
+	return &bookmarkRepository{
+		dm: dm,
+	}
+}
+
+func (br *bookmarkRepository) Create(ctx context.Context, bookmark *domain.Bookmark) error {
+	db := br.dm.DB(ctx)
+
+	// 既にブックマーク済みの場合は何もしない
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(bookmark).Error
+}
+
+func (br *bookmarkRepository) Delete(ctx context.Context, userID, diaryID uuid.UUID) error {
+	db := br.dm.DB(ctx)
+	return db.Where("user_id = ? AND diary_id = ?", userID, diaryID).Delete(&domain.Bookmark{}).Error
+}
+
+// List returns the bookmarks of the user in the family, newest first
+func (br *bookmarkRepository) List(ctx context.Context, userID, familyID uuid.UUID) ([]*domain.Bookmark, error) {
+	db := br.dm.DB(ctx)
+	var bookmarks []*domain.Bookmark
+
+	err := db.Where("user_id = ? AND family_id = ?", userID, familyID).
+		Order("created_at DESC").
+		Find(&bookmarks).Error
+	if err != nil {
+		return nil, err
+	}
+	return bookmarks, nil
+}
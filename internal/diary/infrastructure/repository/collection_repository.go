@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CollectionRepository interface {
+	Create(ctx context.Context, collection *domain.Collection) (*domain.Collection, error)
+	Update(ctx context.Context, collection *domain.Collection) (*domain.Collection, error)
+	Delete(ctx context.Context, familyID, collectionID uuid.UUID) error
+	Get(ctx context.Context, familyID, collectionID uuid.UUID) (*domain.Collection, error)
+	List(ctx context.Context, familyID uuid.UUID) ([]*domain.Collection, error)
+	ReplaceItems(ctx context.Context, collectionID uuid.UUID, diaryIDs []uuid.UUID) error
+	ListItems(ctx context.Context, collectionID uuid.UUID) ([]*domain.CollectionItem, error)
+	CountItems(ctx context.Context, collectionIDs []uuid.UUID) (map[uuid.UUID]int, error)
+}
+
+type collectionRepository struct {
+	dm *db.DBManager
+}
+
+func NewCollectionRepository(dm *db.DBManager) CollectionRepository {
+	return &collectionRepository{
+		dm: dm,
+	}
+}
+
+func (cr *collectionRepository) Create(ctx context.Context, collection *domain.Collection) (*domain.Collection, error) {
+	db := cr.dm.DB(ctx)
+	if err := db.Create(collection).Error; err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// Update updates the name and the cover of the collection
+func (cr *collectionRepository) Update(ctx context.Context, collection *domain.Collection) (*domain.Collection, error) {
+	db := cr.dm.DB(ctx)
+	collection.UpdatedAt = time.Now()
+	err := db.Model(collection).
+		Select("name", "cover_diary_id", "updated_at").
+		Updates(collection).Error
+	if err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+func (cr *collectionRepository) Delete(ctx context.Context, familyID, collectionID uuid.UUID) error {
+	db := cr.dm.DB(ctx)
+	return db.Where("id = ? AND family_id = ?", collectionID, familyID).Delete(&domain.Collection{}).Error
+}
+
+// Get returns the collection of the family, or (nil, nil) if it does not exist
+func (cr *collectionRepository) Get(ctx context.Context, familyID, collectionID uuid.UUID) (*domain.Collection, error) {
+	db := cr.dm.DB(ctx)
+	var collection domain.Collection
+
+	err := db.Where("id = ? AND family_id = ?", collectionID, familyID).First(&collection).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &collection, nil
+}
+
+func (cr *collectionRepository) List(ctx context.Context, familyID uuid.UUID) ([]*domain.Collection, error) {
+	db := cr.dm.DB(ctx)
+	var collections []*domain.Collection
+
+	err := db.Where("family_id = ?", familyID).
+		Order("updated_at DESC").
+		Find(&collections).Error
+	if err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+// ReplaceItems replaces all items of the collection, keeping the order of diaryIDs
+func (cr *collectionRepository) ReplaceItems(ctx context.Context, collectionID uuid.UUID, diaryIDs []uuid.UUID) error {
+	db := cr.dm.DB(ctx)
+
+	if err := db.Where("collection_id = ?", collectionID).Delete(&domain.CollectionItem{}).Error; err != nil {
+		return err
+	}
+	if len(diaryIDs) == 0 {
+		return nil
+	}
+
+	items := make([]*domain.CollectionItem, len(diaryIDs))
+	for i, diaryID := range diaryIDs {
+		items[i] = &domain.CollectionItem{
+			CollectionID: collectionID,
+			DiaryID:      diaryID,
+			Position:     i,
+		}
+	}
+	return db.Create(&items).Error
+}
+
+// ListItems returns the items of the collection ordered by position
+func (cr *collectionRepository) ListItems(ctx context.Context, collectionID uuid.UUID) ([]*domain.CollectionItem, error) {
+	db := cr.dm.DB(ctx)
+	var items []*domain.CollectionItem
+
+	err := db.Where("collection_id = ?", collectionID).
+		Order("position ASC").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// CountItems returns the number of items per collection
+func (cr *collectionRepository) CountItems(ctx context.Context, collectionIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	counts := make(map[uuid.UUID]int, len(collectionIDs))
+	if len(collectionIDs) == 0 {
+		return counts, nil
+	}
+
+	db := cr.dm.DB(ctx)
+	var rows []struct {
+		CollectionID uuid.UUID `gorm:"column:collection_id"`
+		Count        int       `gorm:"column:count"`
+	}
+	err := db.Model(&domain.CollectionItem{}).
+		Select("collection_id, COUNT(*) AS count").
+		Where("collection_id IN ?", collectionIDs).
+		Group("collection_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range rows {
+		counts[r.CollectionID] = r.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DiaryPolicyRepository interface {
+	Get(ctx context.Context, familyID uuid.UUID) (*domain.FamilyDiaryPolicy, error)
+	Upsert(ctx context.Context, policy *domain.FamilyDiaryPolicy) (*domain.FamilyDiaryPolicy, error)
+	CreateEditAudit(ctx context.Context, audit *domain.DiaryEditAudit) error
+}
+
+type diaryPolicyRepository struct {
+	dm *db.DBManager
+}
+
+func NewDiaryPolicyRepository(dm *db.DBManager) DiaryPolicyRepository {
+	return &diaryPolicyRepository{
+		dm: dm,
+	}
+}
+
+// Get returns the policy of the family, or (nil, nil) if the family has not set one
+func (pr *diaryPolicyRepository) Get(ctx context.Context, familyID uuid.UUID) (*domain.FamilyDiaryPolicy, error) {
+	db := pr.dm.DB(ctx)
+	var policy domain.FamilyDiaryPolicy
+
+	err := db.Where("family_id = ?", familyID).First(&policy).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (pr *diaryPolicyRepository) Upsert(ctx context.Context, policy *domain.FamilyDiaryPolicy) (*domain.FamilyDiaryPolicy, error) {
+	db := pr.dm.DB(ctx)
+
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "family_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"editable_days", "updated_by", "updated_at"}),
+	}).Create(policy).Error
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (pr *diaryPolicyRepository) CreateEditAudit(ctx context.Context, audit *domain.DiaryEditAudit) error {
+	db := pr.dm.DB(ctx)
+	return db.Create(audit).Error
+}
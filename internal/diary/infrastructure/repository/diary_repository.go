@@ -2,17 +2,23 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
 	"github.com/furuya-3150/fam-diary-log/pkg/db"
 	"github.com/furuya-3150/fam-diary-log/pkg/pagination"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type DiaryRepository interface {
 	Create(ctx context.Context, diary *domain.Diary) (*domain.Diary, error)
+	Update(ctx context.Context, diary *domain.Diary) (*domain.Diary, error)
 	List(ctx context.Context, criteria *domain.DiarySearchCriteria, pag *pagination.Pagination) ([]*domain.Diary, error)
 	GetCount(ctx context.Context, criteria *domain.DiaryCountCriteria) (int, error)
+	FindByIDs(ctx context.Context, familyID uuid.UUID, ids []uuid.UUID, visibility *domain.DiaryVisibility) ([]*domain.Diary, error)
+	ListUnlockDue(ctx context.Context, at time.Time, limit int) ([]*domain.Diary, error)
+	MarkUnlockPublished(ctx context.Context, diaryID uuid.UUID, at time.Time) (bool, error)
 }
 
 type diaryRepository struct {
@@ -34,6 +40,18 @@ func (dr *diaryRepository) Create(ctx context.Context, diary *domain.Diary) (*do
 	return diary, nil
 }
 
+// Update updates the title and the content of the diary
+func (dr *diaryRepository) Update(ctx context.Context, diary *domain.Diary) (*domain.Diary, error) {
+	db := dr.dm.DB(ctx)
+	err := db.Model(diary).
+		Select("title", "content", "updated_at").
+		Updates(diary).Error
+	if err != nil {
+		return nil, err
+	}
+	return diary, nil
+}
+
 func (dr *diaryRepository) List(ctx context.Context, criteria *domain.DiarySearchCriteria, pag *pagination.Pagination) ([]*domain.Diary, error) {
 	db := dr.dm.DB(ctx)
 	var diaries []*domain.Diary
@@ -53,6 +71,8 @@ func (dr *diaryRepository) List(ctx context.Context, criteria *domain.DiarySearc
 		q = q.Where("created_at <= ?", criteria.EndDate)
 	}
 
+	q = applyVisibility(q, criteria.Visibility)
+
 	if pag != nil {
 		if pag.Limit > 0 {
 			q = q.Limit(pag.Limit)
@@ -91,3 +111,56 @@ func (dr *diaryRepository) GetCount(ctx context.Context, criteria *domain.DiaryC
 	}
 	return int(count), nil
 }
+
+// FindByIDs returns the diaries of the family whose id is in ids (order is not guaranteed)
+func (dr *diaryRepository) FindByIDs(ctx context.Context, familyID uuid.UUID, ids []uuid.UUID, visibility *domain.DiaryVisibility) ([]*domain.Diary, error) {
+	var diaries []*domain.Diary
+	if len(ids) == 0 {
+		return diaries, nil
+	}
+
+	db := dr.dm.DB(ctx)
+	q := applyVisibility(db.Where("family_id = ? AND id IN ?", familyID, ids), visibility)
+	err := q.Find(&diaries).Error
+	if err != nil {
+		return nil, err
+	}
+	return diaries, nil
+}
+
+// ListUnlockDue returns time capsules whose unlock time has passed but whose diary.unlocked event is not yet published
+func (dr *diaryRepository) ListUnlockDue(ctx context.Context, at time.Time, limit int) ([]*domain.Diary, error) {
+	db := dr.dm.DB(ctx)
+	var diaries []*domain.Diary
+
+	err := db.Where("unlock_at IS NOT NULL AND unlock_at <= ? AND unlock_published_at IS NULL", at).
+		Order("unlock_at ASC").
+		Limit(limit).
+		Find(&diaries).Error
+	if err != nil {
+		return nil, err
+	}
+	return diaries, nil
+}
+
+// MarkUnlockPublished sets unlock_published_at if it is not set yet.
+// It returns false when another worker has already marked the diary.
+func (dr *diaryRepository) MarkUnlockPublished(ctx context.Context, diaryID uuid.UUID, at time.Time) (bool, error) {
+	db := dr.dm.DB(ctx)
+
+	result := db.Model(&domain.Diary{}).
+		Where("id = ? AND unlock_published_at IS NULL", diaryID).
+		UpdateColumn("unlock_published_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// applyVisibility hides locked time capsules written by someone other than the viewer
+func applyVisibility(q *gorm.DB, visibility *domain.DiaryVisibility) *gorm.DB {
+	if visibility == nil {
+		return q
+	}
+	return q.Where("(unlock_at IS NULL OR unlock_at <= ? OR user_id = ?)", visibility.At, visibility.ViewerID)
+}
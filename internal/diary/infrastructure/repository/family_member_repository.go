@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+type FamilyMemberRepository interface {
+	// Upsert stores the member unless a newer version is already stored
+	Upsert(ctx context.Context, member *domain.FamilyMember) error
+	// Remove marks the member removed unless a newer version is already stored
+	Remove(ctx context.Context, member *domain.FamilyMember) error
+	// ListByFamily returns the members not removed from the family
+	ListByFamily(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyMember, error)
+}
+
+type familyMemberRepository struct {
+	dm *db.DBManager
+}
+
+func NewFamilyMemberRepository(dm *db.DBManager) FamilyMemberRepository {
+	return &familyMemberRepository{
+		dm: dm,
+	}
+}
+
+func (fr *familyMemberRepository) Upsert(ctx context.Context, member *domain.FamilyMember) error {
+	db := fr.dm.DB(ctx)
+
+	// イベントは順不同で届くため、古いイベントで上書きしない
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "family_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "synced_at", "removed_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "family_members.synced_at < excluded.synced_at"},
+		}},
+	}).Create(member).Error
+}
+
+func (fr *familyMemberRepository) Remove(ctx context.Context, member *domain.FamilyMember) error {
+	db := fr.dm.DB(ctx)
+
+	// 削除済みの行を残し、削除より古い upsert で復活させない
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "family_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"synced_at", "removed_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "family_members.synced_at < excluded.synced_at"},
+		}},
+	}).Create(member).Error
+}
+
+func (fr *familyMemberRepository) ListByFamily(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyMember, error) {
+	db := fr.dm.DB(ctx)
+	var members []*domain.FamilyMember
+
+	if err := db.Where("family_id = ? AND removed_at IS NULL", familyID).Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+type diaryMentionNotification struct {
+	DiaryID    uuid.UUID `gorm:"column:diary_id;type:uuid;primaryKey"`
+	UserID     uuid.UUID `gorm:"column:user_id;type:uuid;primaryKey"`
+	NotifiedAt time.Time `gorm:"column:notified_at;not null"`
+}
+
+func (diaryMentionNotification) TableName() string {
+	return "diary_mention_notifications"
+}
+
+type MentionRepository interface {
+	// Replace stores mentions as the only mentions of the diary
+	Replace(ctx context.Context, diaryID uuid.UUID, mentions []*domain.DiaryMention) error
+	ListByDiaryIDs(ctx context.Context, diaryIDs []uuid.UUID) ([]*domain.DiaryMention, error)
+	// MarkNotified records the notification of the user and reports false if the user was already notified
+	MarkNotified(ctx context.Context, diaryID, userID uuid.UUID, at time.Time) (bool, error)
+}
+
+type mentionRepository struct {
+	dm *db.DBManager
+}
+
+func NewMentionRepository(dm *db.DBManager) MentionRepository {
+	return &mentionRepository{
+		dm: dm,
+	}
+}
+
+func (mr *mentionRepository) Replace(ctx context.Context, diaryID uuid.UUID, mentions []*domain.DiaryMention) error {
+	db := mr.dm.DB(ctx)
+
+	if err := db.Where("diary_id = ?", diaryID).Delete(&domain.DiaryMention{}).Error; err != nil {
+		return err
+	}
+	if len(mentions) == 0 {
+		return nil
+	}
+	return db.Create(&mentions).Error
+}
+
+func (mr *mentionRepository) ListByDiaryIDs(ctx context.Context, diaryIDs []uuid.UUID) ([]*domain.DiaryMention, error) {
+	db := mr.dm.DB(ctx)
+	var mentions []*domain.DiaryMention
+	if len(diaryIDs) == 0 {
+		return mentions, nil
+	}
+
+	err := db.Where("diary_id IN ?", diaryIDs).
+		Order("diary_id, start_offset").
+		Find(&mentions).Error
+	if err != nil {
+		return nil, err
+	}
+	return mentions, nil
+}
+
+func (mr *mentionRepository) MarkNotified(ctx context.Context, diaryID, userID uuid.UUID, at time.Time) (bool, error) {
+	db := mr.dm.DB(ctx)
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&diaryMentionNotification{
+		DiaryID:    diaryID,
+		UserID:     userID,
+		NotifiedAt: at,
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/usecase"
+)
+
+// UnlockScheduler periodically publishes diary.unlocked events for time capsules
+type UnlockScheduler struct {
+	uu       usecase.DiaryUnlockUsecase
+	interval time.Duration
+}
+
+// NewUnlockScheduler creates a new instance of UnlockScheduler
+func NewUnlockScheduler(uu usecase.DiaryUnlockUsecase, interval time.Duration) *UnlockScheduler {
+	return &UnlockScheduler{
+		uu:       uu,
+		interval: interval,
+	}
+}
+
+// Start runs the scheduler until ctx is cancelled
+func (s *UnlockScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	slog.Info("unlock scheduler started", "interval", s.interval.String())
+	s.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("unlock scheduler stopped")
+			return
+		case <-ticker.C:
+			s.run(ctx)
+		}
+	}
+}
+
+func (s *UnlockScheduler) run(ctx context.Context) {
+	published, err := s.uu.PublishDue(ctx)
+	if err != nil {
+		slog.Error("failed to publish unlocked diaries", "error", err.Error())
+	}
+	if published > 0 {
+		slog.Info("published unlocked diaries", "count", published)
+	}
+}
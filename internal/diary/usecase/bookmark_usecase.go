@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+	"github.com/google/uuid"
+)
+
+type BookmarkUsecase interface {
+	Add(ctx context.Context, userID, familyID, diaryID uuid.UUID) error
+	Remove(ctx context.Context, userID, diaryID uuid.UUID) error
+	List(ctx context.Context, userID, familyID uuid.UUID) ([]*domain.Diary, error)
+}
+
+type bookmarkUsecase struct {
+	br  repository.BookmarkRepository
+	dr  repository.DiaryRepository
+	clk clock.Clock
+}
+
+// NewBookmarkUsecase creates a new BookmarkUsecase with all dependencies injected
+func NewBookmarkUsecase(br repository.BookmarkRepository, dr repository.DiaryRepository, clk clock.Clock) BookmarkUsecase {
+	return &bookmarkUsecase{
+		br:  br,
+		dr:  dr,
+		clk: clk,
+	}
+}
+
+// Add bookmarks a diary of the family. Bookmarking an already bookmarked diary is a no-op.
+func (bu *bookmarkUsecase) Add(ctx context.Context, userID, familyID, diaryID uuid.UUID) error {
+	if diaryID == uuid.Nil {
+		return &errors.ValidationError{Message: "invalid diary ID"}
+	}
+
+	visibility := &domain.DiaryVisibility{ViewerID: userID, At: bu.clk.Now()}
+	diaries, err := bu.dr.FindByIDs(ctx, familyID, []uuid.UUID{diaryID}, visibility)
+	if err != nil {
+		return err
+	}
+	if len(diaries) == 0 {
+		return &errors.NotFoundError{Message: "diary not found"}
+	}
+
+	bookmark := &domain.Bookmark{
+		UserID:   userID,
+		FamilyID: familyID,
+		DiaryID:  diaryID,
+	}
+	return bu.br.Create(ctx, bookmark)
+}
+
+func (bu *bookmarkUsecase) Remove(ctx context.Context, userID, diaryID uuid.UUID) error {
+	if diaryID == uuid.Nil {
+		return &errors.ValidationError{Message: "invalid diary ID"}
+	}
+	return bu.br.Delete(ctx, userID, diaryID)
+}
+
+// List returns the bookmarked diaries, most recently bookmarked first
+func (bu *bookmarkUsecase) List(ctx context.Context, userID, familyID uuid.UUID) ([]*domain.Diary, error) {
+	bookmarks, err := bu.br.List(ctx, userID, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(bookmarks))
+	for i, b := range bookmarks {
+		ids[i] = b.DiaryID
+	}
+
+	visibility := &domain.DiaryVisibility{ViewerID: userID, At: bu.clk.Now()}
+	diaries, err := bu.dr.FindByIDs(ctx, familyID, ids, visibility)
+	if err != nil {
+		return nil, err
+	}
+	return orderDiaries(diaries, ids), nil
+}
+
+// orderDiaries sorts diaries in the order of ids, dropping ids that were not found
+func orderDiaries(diaries []*domain.Diary, ids []uuid.UUID) []*domain.Diary {
+	byID := make(map[uuid.UUID]*domain.Diary, len(diaries))
+	for _, d := range diaries {
+		byID[d.ID] = d
+	}
+
+	ordered := make([]*domain.Diary, 0, len(ids))
+	for _, id := range ids {
+		if d, ok := byID[id]; ok {
+			ordered = append(ordered, d)
+		}
+	}
+	return ordered
+}
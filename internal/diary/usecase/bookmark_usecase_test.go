@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+	pkgerrors "github.com/furuya-3150/fam-diary-log/pkg/errors"
+)
+
+type MockBookmarkRepository struct {
+	mock.Mock
+}
+
+func (m *MockBookmarkRepository) Create(ctx context.Context, bookmark *domain.Bookmark) error {
+	args := m.Called(ctx, bookmark)
+	return args.Error(0)
+}
+
+func (m *MockBookmarkRepository) Delete(ctx context.Context, userID, diaryID uuid.UUID) error {
+	args := m.Called(ctx, userID, diaryID)
+	return args.Error(0)
+}
+
+func (m *MockBookmarkRepository) List(ctx context.Context, userID, familyID uuid.UUID) ([]*domain.Bookmark, error) {
+	args := m.Called(ctx, userID, familyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Bookmark), args.Error(1)
+}
+
+func TestBookmarkUsecase_Add_Success(t *testing.T) {
+	mockBookmarkRepo := new(MockBookmarkRepository)
+	mockDiaryRepo := new(MockDiaryRepository)
+
+	userID, familyID, diaryID := uuid.New(), uuid.New(), uuid.New()
+
+	mockDiaryRepo.On("FindByIDs", mock.Anything, familyID, []uuid.UUID{diaryID}, mock.Anything).
+		Return([]*domain.Diary{{ID: diaryID, FamilyID: familyID}}, nil)
+	mockBookmarkRepo.On("Create", mock.Anything, &domain.Bookmark{UserID: userID, FamilyID: familyID, DiaryID: diaryID}).
+		Return(nil)
+
+	uc := NewBookmarkUsecase(mockBookmarkRepo, mockDiaryRepo, &clock.Real{})
+	err := uc.Add(context.Background(), userID, familyID, diaryID)
+
+	assert.NoError(t, err)
+	mockBookmarkRepo.AssertExpectations(t)
+}
+
+func TestBookmarkUsecase_Add_DiaryOfAnotherFamily(t *testing.T) {
+	mockBookmarkRepo := new(MockBookmarkRepository)
+	mockDiaryRepo := new(MockDiaryRepository)
+
+	familyID, diaryID := uuid.New(), uuid.New()
+
+	mockDiaryRepo.On("FindByIDs", mock.Anything, familyID, []uuid.UUID{diaryID}, mock.Anything).Return([]*domain.Diary{}, nil)
+
+	uc := NewBookmarkUsecase(mockBookmarkRepo, mockDiaryRepo, &clock.Real{})
+	err := uc.Add(context.Background(), uuid.New(), familyID, diaryID)
+
+	assert.IsType(t, &pkgerrors.NotFoundError{}, err)
+	mockBookmarkRepo.AssertNotCalled(t, "Create")
+}
+
+func TestBookmarkUsecase_List_KeepsBookmarkOrder(t *testing.T) {
+	mockBookmarkRepo := new(MockBookmarkRepository)
+	mockDiaryRepo := new(MockDiaryRepository)
+
+	userID, familyID := uuid.New(), uuid.New()
+	first, second, deleted := uuid.New(), uuid.New(), uuid.New()
+
+	mockBookmarkRepo.On("List", mock.Anything, userID, familyID).Return([]*domain.Bookmark{
+		{DiaryID: first}, {DiaryID: deleted}, {DiaryID: second},
+	}, nil)
+	// repository does not guarantee the order and the deleted diary is missing
+	mockDiaryRepo.On("FindByIDs", mock.Anything, familyID, []uuid.UUID{first, deleted, second}, mock.Anything).
+		Return([]*domain.Diary{{ID: second}, {ID: first}}, nil)
+
+	uc := NewBookmarkUsecase(mockBookmarkRepo, mockDiaryRepo, &clock.Real{})
+	diaries, err := uc.List(context.Background(), userID, familyID)
+
+	assert.NoError(t, err)
+	assert.Len(t, diaries, 2)
+	assert.Equal(t, first, diaries[0].ID)
+	assert.Equal(t, second, diaries[1].ID)
+}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+	"github.com/google/uuid"
+)
+
+// SaveCollectionInput is the input DTO for creating or updating a collection.
+// DiaryIDs is the ordered list of diaries in the collection.
+type SaveCollectionInput struct {
+	FamilyID     uuid.UUID
+	UserID       uuid.UUID
+	Name         string
+	DiaryIDs     []uuid.UUID
+	CoverDiaryID *uuid.UUID
+}
+
+// CollectionSummary is a collection with the number of its diaries
+type CollectionSummary struct {
+	Collection *domain.Collection
+	ItemCount  int
+}
+
+// CollectionDetail is a collection with its diaries in order
+type CollectionDetail struct {
+	Collection *domain.Collection
+	Diaries    []*domain.Diary
+}
+
+type CollectionUsecase interface {
+	Create(ctx context.Context, input *SaveCollectionInput) (*CollectionDetail, error)
+	Update(ctx context.Context, collectionID uuid.UUID, input *SaveCollectionInput) (*CollectionDetail, error)
+	Delete(ctx context.Context, familyID, collectionID uuid.UUID) error
+	List(ctx context.Context, familyID uuid.UUID) ([]*CollectionSummary, error)
+	Get(ctx context.Context, familyID, userID, collectionID uuid.UUID) (*CollectionDetail, error)
+}
+
+type collectionUsecase struct {
+	tm  db.TransactionManager
+	cr  repository.CollectionRepository
+	dr  repository.DiaryRepository
+	clk clock.Clock
+}
+
+// NewCollectionUsecase creates a new CollectionUsecase with all dependencies injected
+func NewCollectionUsecase(tm db.TransactionManager, cr repository.CollectionRepository, dr repository.DiaryRepository, clk clock.Clock) CollectionUsecase {
+	return &collectionUsecase{
+		tm:  tm,
+		cr:  cr,
+		dr:  dr,
+		clk: clk,
+	}
+}
+
+func (cu *collectionUsecase) Create(ctx context.Context, input *SaveCollectionInput) (*CollectionDetail, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	diaries, err := cu.validateSaveInput(ctx, uuid.Nil, input)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = cu.tm.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := cu.cr.Create(ctx, &domain.Collection{
+		FamilyID:     input.FamilyID,
+		CreatedBy:    input.UserID,
+		Name:         input.Name,
+		CoverDiaryID: input.CoverDiaryID,
+	})
+	if err != nil {
+		cu.tm.RollbackTx(ctx)
+		return nil, err
+	}
+
+	if err := cu.cr.ReplaceItems(ctx, collection.ID, input.DiaryIDs); err != nil {
+		cu.tm.RollbackTx(ctx)
+		return nil, err
+	}
+
+	cu.tm.CommitTx(ctx)
+
+	return &CollectionDetail{Collection: collection, Diaries: diaries}, nil
+}
+
+// Update replaces the name, the items and the cover of the collection
+func (cu *collectionUsecase) Update(ctx context.Context, collectionID uuid.UUID, input *SaveCollectionInput) (*CollectionDetail, error) {
+	collection, err := cu.cr.Get(ctx, input.FamilyID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if collection == nil {
+		return nil, &errors.NotFoundError{Message: "collection not found"}
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	diaries, err := cu.validateSaveInput(ctx, collectionID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = cu.tm.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.Name = input.Name
+	collection.CoverDiaryID = input.CoverDiaryID
+	collection, err = cu.cr.Update(ctx, collection)
+	if err != nil {
+		cu.tm.RollbackTx(ctx)
+		return nil, err
+	}
+
+	if err := cu.cr.ReplaceItems(ctx, collectionID, input.DiaryIDs); err != nil {
+		cu.tm.RollbackTx(ctx)
+		return nil, err
+	}
+
+	cu.tm.CommitTx(ctx)
+
+	return &CollectionDetail{Collection: collection, Diaries: diaries}, nil
+}
+
+func (cu *collectionUsecase) Delete(ctx context.Context, familyID, collectionID uuid.UUID) error {
+	collection, err := cu.cr.Get(ctx, familyID, collectionID)
+	if err != nil {
+		return err
+	}
+	if collection == nil {
+		return &errors.NotFoundError{Message: "collection not found"}
+	}
+	return cu.cr.Delete(ctx, familyID, collectionID)
+}
+
+func (cu *collectionUsecase) List(ctx context.Context, familyID uuid.UUID) ([]*CollectionSummary, error) {
+	collections, err := cu.cr.List(ctx, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(collections))
+	for i, c := range collections {
+		ids[i] = c.ID
+	}
+	counts, err := cu.cr.CountItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*CollectionSummary, len(collections))
+	for i, c := range collections {
+		summaries[i] = &CollectionSummary{Collection: c, ItemCount: counts[c.ID]}
+	}
+	return summaries, nil
+}
+
+func (cu *collectionUsecase) Get(ctx context.Context, familyID, userID, collectionID uuid.UUID) (*CollectionDetail, error) {
+	collection, err := cu.cr.Get(ctx, familyID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if collection == nil {
+		return nil, &errors.NotFoundError{Message: "collection not found"}
+	}
+
+	diaries, err := cu.listItemDiaries(ctx, familyID, userID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 閲覧できないタイムカプセルが表紙の場合は表紙を隠す
+	if collection.CoverDiaryID != nil && !containsDiary(diaries, *collection.CoverDiaryID) {
+		collection.CoverDiaryID = nil
+	}
+	return &CollectionDetail{Collection: collection, Diaries: diaries}, nil
+}
+
+func (cu *collectionUsecase) listItemDiaries(ctx context.Context, familyID, userID, collectionID uuid.UUID) ([]*domain.Diary, error) {
+	items, err := cu.cr.ListItems(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		ids[i] = item.DiaryID
+	}
+
+	visibility := &domain.DiaryVisibility{ViewerID: userID, At: cu.clk.Now()}
+	diaries, err := cu.dr.FindByIDs(ctx, familyID, ids, visibility)
+	if err != nil {
+		return nil, err
+	}
+	return orderDiaries(diaries, ids), nil
+}
+
+func containsDiary(diaries []*domain.Diary, diaryID uuid.UUID) bool {
+	for _, d := range diaries {
+		if d.ID == diaryID {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSaveInput validates the input and returns the diaries of the collection in order.
+// collectionID is the collection being updated (uuid.Nil on create) and is excluded from the duplicate name check.
+func (cu *collectionUsecase) validateSaveInput(ctx context.Context, collectionID uuid.UUID, input *SaveCollectionInput) ([]*domain.Diary, error) {
+	if err := domain.ValidateCollectionName(input.Name); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+	if err := domain.ValidateCollectionItems(input.DiaryIDs, input.CoverDiaryID); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+
+	collections, err := cu.cr.List(ctx, input.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range collections {
+		if c.ID != collectionID && c.Name == input.Name {
+			return nil, &errors.ConflictError{Message: "collection name already exists"}
+		}
+	}
+
+	// 家族の日記のうち、閲覧できるものだけをコレクションに追加できる
+	visibility := &domain.DiaryVisibility{ViewerID: input.UserID, At: cu.clk.Now()}
+	diaries, err := cu.dr.FindByIDs(ctx, input.FamilyID, input.DiaryIDs, visibility)
+	if err != nil {
+		return nil, err
+	}
+	if len(diaries) != len(input.DiaryIDs) {
+		return nil, &errors.ValidationError{Message: "diary_ids contains a diary that does not belong to the family"}
+	}
+
+	return orderDiaries(diaries, input.DiaryIDs), nil
+}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+	pkgerrors "github.com/furuya-3150/fam-diary-log/pkg/errors"
+)
+
+type MockCollectionRepository struct {
+	mock.Mock
+}
+
+func (m *MockCollectionRepository) Create(ctx context.Context, collection *domain.Collection) (*domain.Collection, error) {
+	args := m.Called(ctx, collection)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Collection), args.Error(1)
+}
+
+func (m *MockCollectionRepository) Update(ctx context.Context, collection *domain.Collection) (*domain.Collection, error) {
+	args := m.Called(ctx, collection)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Collection), args.Error(1)
+}
+
+func (m *MockCollectionRepository) Delete(ctx context.Context, familyID, collectionID uuid.UUID) error {
+	args := m.Called(ctx, familyID, collectionID)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepository) Get(ctx context.Context, familyID, collectionID uuid.UUID) (*domain.Collection, error) {
+	args := m.Called(ctx, familyID, collectionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Collection), args.Error(1)
+}
+
+func (m *MockCollectionRepository) List(ctx context.Context, familyID uuid.UUID) ([]*domain.Collection, error) {
+	args := m.Called(ctx, familyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Collection), args.Error(1)
+}
+
+func (m *MockCollectionRepository) ReplaceItems(ctx context.Context, collectionID uuid.UUID, diaryIDs []uuid.UUID) error {
+	args := m.Called(ctx, collectionID, diaryIDs)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepository) ListItems(ctx context.Context, collectionID uuid.UUID) ([]*domain.CollectionItem, error) {
+	args := m.Called(ctx, collectionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.CollectionItem), args.Error(1)
+}
+
+func (m *MockCollectionRepository) CountItems(ctx context.Context, collectionIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	args := m.Called(ctx, collectionIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]int), args.Error(1)
+}
+
+func TestCollectionUsecase_Create_Success(t *testing.T) {
+	mockTm := new(MockTransactionManager)
+	mockCollectionRepo := new(MockCollectionRepository)
+	mockDiaryRepo := new(MockDiaryRepository)
+
+	familyID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	d1, d2 := uuid.New(), uuid.New()
+	input := &SaveCollectionInput{
+		FamilyID:     familyID,
+		UserID:       userID,
+		Name:         " Summer 2026 ",
+		DiaryIDs:     []uuid.UUID{d2, d1},
+		CoverDiaryID: &d1,
+	}
+
+	mockCollectionRepo.On("List", mock.Anything, familyID).Return([]*domain.Collection{{ID: uuid.New(), Name: "Baby's firsts"}}, nil)
+	mockDiaryRepo.On("FindByIDs", mock.Anything, familyID, []uuid.UUID{d2, d1}, mock.Anything).
+		Return([]*domain.Diary{{ID: d1}, {ID: d2}}, nil)
+	mockTm.On("BeginTx", mock.Anything).Return(context.Background(), nil)
+	mockCollectionRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *domain.Collection) bool {
+		return c.Name == "Summer 2026" && c.FamilyID == familyID && c.CreatedBy == userID && *c.CoverDiaryID == d1
+	})).Return(&domain.Collection{ID: collectionID, FamilyID: familyID, Name: "Summer 2026", CoverDiaryID: &d1}, nil)
+	mockCollectionRepo.On("ReplaceItems", mock.Anything, collectionID, []uuid.UUID{d2, d1}).Return(nil)
+	mockTm.On("CommitTx", mock.Anything).Return(nil)
+
+	uc := NewCollectionUsecase(mockTm, mockCollectionRepo, mockDiaryRepo, &clock.Real{})
+	detail, err := uc.Create(context.Background(), input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, collectionID, detail.Collection.ID)
+	assert.Equal(t, d2, detail.Diaries[0].ID)
+	assert.Equal(t, d1, detail.Diaries[1].ID)
+	mockTm.AssertExpectations(t)
+	mockCollectionRepo.AssertExpectations(t)
+}
+
+func TestCollectionUsecase_Create_ValidationError(t *testing.T) {
+	t.Parallel()
+
+	d1, other := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name  string
+		input *SaveCollectionInput
+	}{
+		{
+			name:  "empty name",
+			input: &SaveCollectionInput{FamilyID: uuid.New(), Name: "  "},
+		},
+		{
+			name:  "duplicate diary",
+			input: &SaveCollectionInput{FamilyID: uuid.New(), Name: "Summer", DiaryIDs: []uuid.UUID{d1, d1}},
+		},
+		{
+			name:  "cover not in items",
+			input: &SaveCollectionInput{FamilyID: uuid.New(), Name: "Summer", DiaryIDs: []uuid.UUID{d1}, CoverDiaryID: &other},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCollectionRepo := new(MockCollectionRepository)
+			uc := NewCollectionUsecase(new(MockTransactionManager), mockCollectionRepo, new(MockDiaryRepository), &clock.Real{})
+
+			_, err := uc.Create(context.Background(), tt.input)
+
+			assert.IsType(t, &pkgerrors.ValidationError{}, err)
+			mockCollectionRepo.AssertNotCalled(t, "Create")
+		})
+	}
+}
+
+func TestCollectionUsecase_Create_DuplicateName(t *testing.T) {
+	mockCollectionRepo := new(MockCollectionRepository)
+	familyID := uuid.New()
+
+	mockCollectionRepo.On("List", mock.Anything, familyID).Return([]*domain.Collection{{ID: uuid.New(), Name: "Summer 2026"}}, nil)
+
+	uc := NewCollectionUsecase(new(MockTransactionManager), mockCollectionRepo, new(MockDiaryRepository), &clock.Real{})
+	_, err := uc.Create(context.Background(), &SaveCollectionInput{FamilyID: familyID, Name: "Summer 2026"})
+
+	assert.IsType(t, &pkgerrors.ConflictError{}, err)
+	mockCollectionRepo.AssertNotCalled(t, "Create")
+}
+
+func TestCollectionUsecase_Create_DiaryOfAnotherFamily(t *testing.T) {
+	mockCollectionRepo := new(MockCollectionRepository)
+	mockDiaryRepo := new(MockDiaryRepository)
+	familyID, d1, d2 := uuid.New(), uuid.New(), uuid.New()
+
+	mockCollectionRepo.On("List", mock.Anything, familyID).Return([]*domain.Collection{}, nil)
+	mockDiaryRepo.On("FindByIDs", mock.Anything, familyID, []uuid.UUID{d1, d2}, mock.Anything).Return([]*domain.Diary{{ID: d1}}, nil)
+
+	uc := NewCollectionUsecase(new(MockTransactionManager), mockCollectionRepo, mockDiaryRepo, &clock.Real{})
+	_, err := uc.Create(context.Background(), &SaveCollectionInput{FamilyID: familyID, Name: "Summer", DiaryIDs: []uuid.UUID{d1, d2}})
+
+	assert.IsType(t, &pkgerrors.ValidationError{}, err)
+	mockCollectionRepo.AssertNotCalled(t, "Create")
+}
+
+func TestCollectionUsecase_Update_KeepsOwnName(t *testing.T) {
+	mockTm := new(MockTransactionManager)
+	mockCollectionRepo := new(MockCollectionRepository)
+	mockDiaryRepo := new(MockDiaryRepository)
+
+	familyID, collectionID, d1 := uuid.New(), uuid.New(), uuid.New()
+	existing := &domain.Collection{ID: collectionID, FamilyID: familyID, Name: "Summer 2026"}
+
+	mockCollectionRepo.On("Get", mock.Anything, familyID, collectionID).Return(existing, nil)
+	mockCollectionRepo.On("List", mock.Anything, familyID).Return([]*domain.Collection{existing}, nil)
+	mockDiaryRepo.On("FindByIDs", mock.Anything, familyID, []uuid.UUID{d1}, mock.Anything).Return([]*domain.Diary{{ID: d1}}, nil)
+	mockTm.On("BeginTx", mock.Anything).Return(context.Background(), nil)
+	mockCollectionRepo.On("Update", mock.Anything, existing).Return(existing, nil)
+	mockCollectionRepo.On("ReplaceItems", mock.Anything, collectionID, []uuid.UUID{d1}).Return(nil)
+	mockTm.On("CommitTx", mock.Anything).Return(nil)
+
+	uc := NewCollectionUsecase(mockTm, mockCollectionRepo, mockDiaryRepo, &clock.Real{})
+	detail, err := uc.Update(context.Background(), collectionID, &SaveCollectionInput{
+		FamilyID: familyID, Name: "Summer 2026", DiaryIDs: []uuid.UUID{d1},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, detail.Diaries, 1)
+	mockCollectionRepo.AssertExpectations(t)
+}
+
+func TestCollectionUsecase_Get_NotFound(t *testing.T) {
+	mockCollectionRepo := new(MockCollectionRepository)
+	familyID, collectionID := uuid.New(), uuid.New()
+
+	mockCollectionRepo.On("Get", mock.Anything, familyID, collectionID).Return(nil, nil)
+
+	uc := NewCollectionUsecase(new(MockTransactionManager), mockCollectionRepo, new(MockDiaryRepository), &clock.Real{})
+	_, err := uc.Get(context.Background(), familyID, uuid.New(), collectionID)
+
+	assert.IsType(t, &pkgerrors.NotFoundError{}, err)
+}
+
+func TestCollectionUsecase_List_WithItemCount(t *testing.T) {
+	mockCollectionRepo := new(MockCollectionRepository)
+	familyID, c1, c2 := uuid.New(), uuid.New(), uuid.New()
+
+	mockCollectionRepo.On("List", mock.Anything, familyID).Return([]*domain.Collection{{ID: c1}, {ID: c2}}, nil)
+	mockCollectionRepo.On("CountItems", mock.Anything, []uuid.UUID{c1, c2}).Return(map[uuid.UUID]int{c1: 3}, nil)
+
+	uc := NewCollectionUsecase(new(MockTransactionManager), mockCollectionRepo, new(MockDiaryRepository), &clock.Real{})
+	summaries, err := uc.List(context.Background(), familyID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, summaries[0].ItemCount)
+	assert.Equal(t, 0, summaries[1].ItemCount)
+}
+
+func TestCollectionUsecase_Get_InCollectionOrder(t *testing.T) {
+	mockCollectionRepo := new(MockCollectionRepository)
+	mockDiaryRepo := new(MockDiaryRepository)
+	familyID, collectionID, d1, d2 := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	mockCollectionRepo.On("Get", mock.Anything, familyID, collectionID).Return(&domain.Collection{ID: collectionID}, nil)
+	mockCollectionRepo.On("ListItems", mock.Anything, collectionID).Return([]*domain.CollectionItem{
+		{DiaryID: d2, Position: 0}, {DiaryID: d1, Position: 1},
+	}, nil)
+	mockDiaryRepo.On("FindByIDs", mock.Anything, familyID, []uuid.UUID{d2, d1}, mock.Anything).Return([]*domain.Diary{{ID: d1}, {ID: d2}}, nil)
+
+	uc := NewCollectionUsecase(new(MockTransactionManager), mockCollectionRepo, mockDiaryRepo, &clock.Real{})
+	detail, err := uc.Get(context.Background(), familyID, uuid.New(), collectionID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{d2, d1}, []uuid.UUID{detail.Diaries[0].ID, detail.Diaries[1].ID})
+}
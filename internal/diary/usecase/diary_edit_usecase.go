@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/pkg/broker/publisher"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+	"github.com/google/uuid"
+)
+
+// UpdateDiaryInput is the input DTO for editing a diary
+type UpdateDiaryInput struct {
+	DiaryID  uuid.UUID
+	FamilyID uuid.UUID
+	UserID   uuid.UUID
+	IsAdmin  bool
+	Title    string
+	Content  string
+	// OverrideLock lets an admin edit a diary after the editable period or a diary of another member.
+	// Reason is recorded in the audit trail.
+	OverrideLock bool
+	Reason       string
+}
+
+// UpdateDiaryPolicyInput is the input DTO for changing the family's edit policy
+type UpdateDiaryPolicyInput struct {
+	FamilyID     uuid.UUID
+	UserID       uuid.UUID
+	IsAdmin      bool
+	EditableDays *int
+}
+
+type DiaryEditUsecase interface {
+	Update(ctx context.Context, input *UpdateDiaryInput) (*domain.Diary, error)
+	GetPolicy(ctx context.Context, familyID uuid.UUID) (*domain.FamilyDiaryPolicy, error)
+	UpdatePolicy(ctx context.Context, input *UpdateDiaryPolicyInput) (*domain.FamilyDiaryPolicy, error)
+}
+
+type diaryEditUsecase struct {
+	tm        db.TransactionManager
+	dr        repository.DiaryRepository
+	pr        repository.DiaryPolicyRepository
+	publisher publisher.Publisher
+	clk       clock.Clock
+	mu        MentionUsecase
+}
+
+// NewDiaryEditUsecase creates a new DiaryEditUsecase with all dependencies injected
+func NewDiaryEditUsecase(tm db.TransactionManager, dr repository.DiaryRepository, pr repository.DiaryPolicyRepository, pub publisher.Publisher, clk clock.Clock, mu MentionUsecase) DiaryEditUsecase {
+	return &diaryEditUsecase{
+		tm:        tm,
+		dr:        dr,
+		pr:        pr,
+		publisher: pub,
+		clk:       clk,
+		mu:        mu,
+	}
+}
+
+func (eu *diaryEditUsecase) Update(ctx context.Context, input *UpdateDiaryInput) (*domain.Diary, error) {
+	if err := domain.ValidateDiaryTitle(input.Title); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+	if err := domain.ValidateDiaryContent(input.Content); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+	if eu.publisher == nil {
+		return nil, &errors.LogicError{Message: "publisher is not set"}
+	}
+
+	now := eu.clk.Now()
+	visibility := &domain.DiaryVisibility{ViewerID: input.UserID, At: now}
+	diaries, err := eu.dr.FindByIDs(ctx, input.FamilyID, []uuid.UUID{input.DiaryID}, visibility)
+	if err != nil {
+		return nil, err
+	}
+	if len(diaries) == 0 {
+		return nil, &errors.NotFoundError{Message: "diary not found"}
+	}
+	diary := diaries[0]
+
+	// 管理者は理由を記録すれば他のメンバーの日記も修正できる（期間内でも監査に残す）
+	override := false
+	if diary.UserID != input.UserID {
+		if !input.IsAdmin || !input.OverrideLock {
+			return nil, &errors.ForbiddenError{Message: "only the author can edit the diary"}
+		}
+		override = true
+	}
+
+	policy, err := eu.pr.Get(ctx, input.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.IsEditable(diary.CreatedAt, now) {
+		if !input.OverrideLock {
+			return nil, &errors.ForbiddenError{Message: "diary is read-only after the editable period"}
+		}
+		if !input.IsAdmin {
+			return nil, &errors.ForbiddenError{Message: "only an admin can override the edit lock"}
+		}
+		override = true
+	}
+
+	var audit *domain.DiaryEditAudit
+	if override {
+		if err := domain.ValidateEditOverrideReason(input.Reason); err != nil {
+			return nil, &errors.ValidationError{Message: err.Error()}
+		}
+		audit = &domain.DiaryEditAudit{
+			DiaryID:         diary.ID,
+			FamilyID:        diary.FamilyID,
+			EditedBy:        input.UserID,
+			Reason:          input.Reason,
+			PreviousTitle:   diary.Title,
+			PreviousContent: diary.Content,
+		}
+	}
+
+	ctx, err = eu.tm.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	diary.Title = input.Title
+	diary.Content = input.Content
+	diary, err = eu.dr.Update(ctx, diary)
+	if err != nil {
+		eu.tm.RollbackTx(ctx)
+		return nil, err
+	}
+
+	if audit != nil {
+		if err := eu.pr.CreateEditAudit(ctx, audit); err != nil {
+			eu.tm.RollbackTx(ctx)
+			return nil, err
+		}
+	}
+
+	if err := eu.mu.Link(ctx, diary); err != nil {
+		eu.tm.RollbackTx(ctx)
+		return nil, err
+	}
+
+	event := domain.NewDiaryUpdatedEvent(diary.ID, diary.UserID, diary.FamilyID, input.UserID, diary.Title, diary.Content)
+	event.UnlockAt = diary.UnlockAt
+	if err := eu.publisher.Publish(ctx, event); err != nil {
+		eu.tm.RollbackTx(ctx)
+		slog.Error("failed to publish diary updated event", "error", err.Error())
+		return nil, err
+	}
+
+	eu.tm.CommitTx(ctx)
+
+	return diary, nil
+}
+
+// GetPolicy returns the family's policy. A family without a policy can edit diaries forever.
+func (eu *diaryEditUsecase) GetPolicy(ctx context.Context, familyID uuid.UUID) (*domain.FamilyDiaryPolicy, error) {
+	policy, err := eu.pr.Get(ctx, familyID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return &domain.FamilyDiaryPolicy{FamilyID: familyID}, nil
+	}
+	return policy, nil
+}
+
+func (eu *diaryEditUsecase) UpdatePolicy(ctx context.Context, input *UpdateDiaryPolicyInput) (*domain.FamilyDiaryPolicy, error) {
+	if !input.IsAdmin {
+		return nil, &errors.ForbiddenError{Message: "only an admin can change the edit policy"}
+	}
+	if err := domain.ValidateEditableDays(input.EditableDays); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
+
+	policy := &domain.FamilyDiaryPolicy{
+		FamilyID:     input.FamilyID,
+		EditableDays: input.EditableDays,
+		UpdatedBy:    input.UserID,
+	}
+	return eu.pr.Upsert(ctx, policy)
+}
@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+	pkgerrors "github.com/furuya-3150/fam-diary-log/pkg/errors"
+)
+
+type MockDiaryPolicyRepository struct {
+	mock.Mock
+}
+
+func (m *MockDiaryPolicyRepository) Get(ctx context.Context, familyID uuid.UUID) (*domain.FamilyDiaryPolicy, error) {
+	args := m.Called(ctx, familyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FamilyDiaryPolicy), args.Error(1)
+}
+
+func (m *MockDiaryPolicyRepository) Upsert(ctx context.Context, policy *domain.FamilyDiaryPolicy) (*domain.FamilyDiaryPolicy, error) {
+	args := m.Called(ctx, policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FamilyDiaryPolicy), args.Error(1)
+}
+
+func (m *MockDiaryPolicyRepository) CreateEditAudit(ctx context.Context, audit *domain.DiaryEditAudit) error {
+	args := m.Called(ctx, audit)
+	return args.Error(0)
+}
+
+func newEditTestDiary(userID, familyID uuid.UUID, createdAt time.Time) *domain.Diary {
+	return &domain.Diary{
+		ID:        uuid.New(),
+		UserID:    userID,
+		FamilyID:  familyID,
+		Title:     "old title",
+		Content:   "old content",
+		CreatedAt: createdAt,
+	}
+}
+
+func TestDiaryEditUsecase_Update_WithinEditablePeriod(t *testing.T) {
+	mockRepo := new(MockDiaryRepository)
+	mockPolicyRepo := new(MockDiaryPolicyRepository)
+	mockTm := new(MockTransactionManager)
+	mockPub := new(MockPublisher)
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	userID, familyID := uuid.New(), uuid.New()
+	diary := newEditTestDiary(userID, familyID, now.AddDate(0, 0, -2))
+	days := 7
+
+	mockRepo.On("FindByIDs", mock.Anything, familyID, []uuid.UUID{diary.ID}, mock.Anything).Return([]*domain.Diary{diary}, nil)
+	mockPolicyRepo.On("Get", mock.Anything, familyID).Return(&domain.FamilyDiaryPolicy{FamilyID: familyID, EditableDays: &days}, nil)
+	mockTm.On("BeginTx", mock.Anything).Return(context.Background(), nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(d *domain.Diary) bool {
+		return d.Title == "new title" && d.Content == "new content"
+	})).Return(diary, nil)
+	mockPub.On("Publish", mock.Anything, mock.MatchedBy(func(e *domain.DiaryUpdatedEvent) bool {
+		return e.EventType() == "diary.updated" && e.DiaryID == diary.ID && e.EditedBy == userID
+	})).Return(nil)
+	mockTm.On("CommitTx", mock.Anything).Return(nil)
+
+	uc := NewDiaryEditUsecase(mockTm, mockRepo, mockPolicyRepo, mockPub, &clock.Fixed{Time: now}, new(stubMentionUsecase))
+	_, err := uc.Update(context.Background(), &UpdateDiaryInput{
+		DiaryID: diary.ID, FamilyID: familyID, UserID: userID, Title: "new title", Content: "new content",
+	})
+
+	assert.NoError(t, err)
+	mockPolicyRepo.AssertNotCalled(t, "CreateEditAudit", mock.Anything, mock.Anything)
+	mockPub.AssertExpectations(t)
+}
+
+func TestDiaryEditUsecase_Update_Forbidden(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	days := 7
+
+	tests := []struct {
+		name   string
+		author bool
+		input  func(in *UpdateDiaryInput)
+	}{
+		{name: "not the author", author: false, input: func(in *UpdateDiaryInput) {}},
+		{name: "after editable period", author: true, input: func(in *UpdateDiaryInput) {}},
+		{name: "member cannot override", author: true, input: func(in *UpdateDiaryInput) {
+			in.OverrideLock = true
+			in.Reason = "typo"
+		}},
+		{name: "admin without override is not the author", author: false, input: func(in *UpdateDiaryInput) {
+			in.IsAdmin = true
+		}},
+		{name: "member cannot override another member's diary", author: false, input: func(in *UpdateDiaryInput) {
+			in.OverrideLock = true
+			in.Reason = "typo"
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockDiaryRepository)
+			mockPolicyRepo := new(MockDiaryPolicyRepository)
+
+			userID, familyID := uuid.New(), uuid.New()
+			authorID := uuid.New()
+			if tt.author {
+				authorID = userID
+			}
+			diary := newEditTestDiary(authorID, familyID, now.AddDate(0, 0, -30))
+
+			mockRepo.On("FindByIDs", mock.Anything, familyID, []uuid.UUID{diary.ID}, mock.Anything).Return([]*domain.Diary{diary}, nil)
+			mockPolicyRepo.On("Get", mock.Anything, familyID).Return(&domain.FamilyDiaryPolicy{FamilyID: familyID, EditableDays: &days}, nil)
+
+			uc := NewDiaryEditUsecase(new(MockTransactionManager), mockRepo, mockPolicyRepo, new(MockPublisher), &clock.Fixed{Time: now}, new(stubMentionUsecase))
+			input := &UpdateDiaryInput{DiaryID: diary.ID, FamilyID: familyID, UserID: userID, Title: "new title", Content: "new content"}
+			tt.input(input)
+
+			_, err := uc.Update(context.Background(), input)
+
+			assert.IsType(t, &pkgerrors.ForbiddenError{}, err)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDiaryEditUsecase_Update_AdminOverrideIsAudited(t *testing.T) {
+	mockRepo := new(MockDiaryRepository)
+	mockPolicyRepo := new(MockDiaryPolicyRepository)
+	mockTm := new(MockTransactionManager)
+	mockPub := new(MockPublisher)
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	userID, familyID := uuid.New(), uuid.New()
+	diary := newEditTestDiary(userID, familyID, now.AddDate(0, 0, -30))
+	days := 7
+
+	mockRepo.On("FindByIDs", mock.Anything, familyID, []uuid.UUID{diary.ID}, mock.Anything).Return([]*domain.Diary{diary}, nil)
+	mockPolicyRepo.On("Get", mock.Anything, familyID).Return(&domain.FamilyDiaryPolicy{FamilyID: familyID, EditableDays: &days}, nil)
+	mockTm.On("BeginTx", mock.Anything).Return(context.Background(), nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(diary, nil)
+	mockPolicyRepo.On("CreateEditAudit", mock.Anything, mock.MatchedBy(func(a *domain.DiaryEditAudit) bool {
+		return a.DiaryID == diary.ID && a.EditedBy == userID && a.Reason == "fix a name" &&
+			a.PreviousTitle == "old title" && a.PreviousContent == "old content"
+	})).Return(nil)
+	mockPub.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	mockTm.On("CommitTx", mock.Anything).Return(nil)
+
+	uc := NewDiaryEditUsecase(mockTm, mockRepo, mockPolicyRepo, mockPub, &clock.Fixed{Time: now}, new(stubMentionUsecase))
+	_, err := uc.Update(context.Background(), &UpdateDiaryInput{
+		DiaryID: diary.ID, FamilyID: familyID, UserID: userID, IsAdmin: true,
+		Title: "new title", Content: "new content", OverrideLock: true, Reason: "fix a name",
+	})
+
+	assert.NoError(t, err)
+	mockPolicyRepo.AssertExpectations(t)
+}
+
+func TestDiaryEditUsecase_Update_AdminOverridesAnotherMembersDiary(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	days := 7
+
+	tests := []struct {
+		name string
+		age  int
+	}{
+		{name: "within editable period", age: 2},
+		{name: "after editable period", age: 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockDiaryRepository)
+			mockPolicyRepo := new(MockDiaryPolicyRepository)
+			mockTm := new(MockTransactionManager)
+			mockPub := new(MockPublisher)
+
+			adminID, authorID, familyID := uuid.New(), uuid.New(), uuid.New()
+			diary := newEditTestDiary(authorID, familyID, now.AddDate(0, 0, -tt.age))
+
+			mockRepo.On("FindByIDs", mock.Anything, familyID, []uuid.UUID{diary.ID}, mock.Anything).Return([]*domain.Diary{diary}, nil)
+			mockPolicyRepo.On("Get", mock.Anything, familyID).Return(&domain.FamilyDiaryPolicy{FamilyID: familyID, EditableDays: &days}, nil)
+			mockTm.On("BeginTx", mock.Anything).Return(context.Background(), nil)
+			mockRepo.On("Update", mock.Anything, mock.Anything).Return(diary, nil)
+			mockPolicyRepo.On("CreateEditAudit", mock.Anything, mock.MatchedBy(func(a *domain.DiaryEditAudit) bool {
+				return a.DiaryID == diary.ID && a.EditedBy == adminID && a.Reason == "fix a name"
+			})).Return(nil)
+			mockPub.On("Publish", mock.Anything, mock.MatchedBy(func(e *domain.DiaryUpdatedEvent) bool {
+				return e.UserID == authorID && e.EditedBy == adminID
+			})).Return(nil)
+			mockTm.On("CommitTx", mock.Anything).Return(nil)
+
+			uc := NewDiaryEditUsecase(mockTm, mockRepo, mockPolicyRepo, mockPub, &clock.Fixed{Time: now}, new(stubMentionUsecase))
+			_, err := uc.Update(context.Background(), &UpdateDiaryInput{
+				DiaryID: diary.ID, FamilyID: familyID, UserID: adminID, IsAdmin: true,
+				Title: "new title", Content: "new content", OverrideLock: true, Reason: "fix a name",
+			})
+
+			assert.NoError(t, err)
+			mockPolicyRepo.AssertExpectations(t)
+			mockPub.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDiaryEditUsecase_Update_NotFound(t *testing.T) {
+	mockRepo := new(MockDiaryRepository)
+	familyID, diaryID := uuid.New(), uuid.New()
+
+	mockRepo.On("FindByIDs", mock.Anything, familyID, []uuid.UUID{diaryID}, mock.Anything).Return([]*domain.Diary{}, nil)
+
+	uc := NewDiaryEditUsecase(new(MockTransactionManager), mockRepo, new(MockDiaryPolicyRepository), new(MockPublisher), &clock.Real{}, new(stubMentionUsecase))
+	_, err := uc.Update(context.Background(), &UpdateDiaryInput{
+		DiaryID: diaryID, FamilyID: familyID, UserID: uuid.New(), Title: "title", Content: "content",
+	})
+
+	assert.IsType(t, &pkgerrors.NotFoundError{}, err)
+}
+
+func TestDiaryEditUsecase_UpdatePolicy(t *testing.T) {
+	familyID, userID := uuid.New(), uuid.New()
+	days := 30
+	negative := -1
+
+	t.Run("member is forbidden", func(t *testing.T) {
+		uc := NewDiaryEditUsecase(nil, nil, new(MockDiaryPolicyRepository), nil, &clock.Real{}, new(stubMentionUsecase))
+		_, err := uc.UpdatePolicy(context.Background(), &UpdateDiaryPolicyInput{FamilyID: familyID, UserID: userID, EditableDays: &days})
+		assert.IsType(t, &pkgerrors.ForbiddenError{}, err)
+	})
+
+	t.Run("negative days", func(t *testing.T) {
+		uc := NewDiaryEditUsecase(nil, nil, new(MockDiaryPolicyRepository), nil, &clock.Real{}, new(stubMentionUsecase))
+		_, err := uc.UpdatePolicy(context.Background(), &UpdateDiaryPolicyInput{FamilyID: familyID, UserID: userID, IsAdmin: true, EditableDays: &negative})
+		assert.IsType(t, &pkgerrors.ValidationError{}, err)
+	})
+
+	t.Run("admin sets policy", func(t *testing.T) {
+		mockPolicyRepo := new(MockDiaryPolicyRepository)
+		mockPolicyRepo.On("Upsert", mock.Anything, mock.MatchedBy(func(p *domain.FamilyDiaryPolicy) bool {
+			return p.FamilyID == familyID && *p.EditableDays == days && p.UpdatedBy == userID
+		})).Return(&domain.FamilyDiaryPolicy{FamilyID: familyID, EditableDays: &days}, nil)
+
+		uc := NewDiaryEditUsecase(nil, nil, mockPolicyRepo, nil, &clock.Real{}, new(stubMentionUsecase))
+		policy, err := uc.UpdatePolicy(context.Background(), &UpdateDiaryPolicyInput{FamilyID: familyID, UserID: userID, IsAdmin: true, EditableDays: &days})
+
+		assert.NoError(t, err)
+		assert.Equal(t, days, *policy.EditableDays)
+	})
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/pkg/broker/publisher"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+)
+
+type DiaryUnlockUsecase interface {
+	// PublishDue publishes diary.unlocked for every time capsule whose unlock time has passed
+	// and returns the number of published events
+	PublishDue(ctx context.Context) (int, error)
+}
+
+type diaryUnlockUsecase struct {
+	tm        db.TransactionManager
+	dr        repository.DiaryRepository
+	publisher publisher.Publisher
+	clk       clock.Clock
+	mu        MentionUsecase
+}
+
+// NewDiaryUnlockUsecase creates a new DiaryUnlockUsecase with all dependencies injected
+func NewDiaryUnlockUsecase(tm db.TransactionManager, dr repository.DiaryRepository, pub publisher.Publisher, clk clock.Clock, mu MentionUsecase) DiaryUnlockUsecase {
+	return &diaryUnlockUsecase{
+		tm:        tm,
+		dr:        dr,
+		publisher: pub,
+		clk:       clk,
+		mu:        mu,
+	}
+}
+
+func (uu *diaryUnlockUsecase) PublishDue(ctx context.Context) (int, error) {
+	if uu.publisher == nil {
+		return 0, &errors.LogicError{Message: "publisher is not set"}
+	}
+
+	now := uu.clk.Now()
+	published := 0
+	for {
+		diaries, err := uu.dr.ListUnlockDue(ctx, now, domain.UnlockBatchSize)
+		if err != nil {
+			return published, err
+		}
+
+		for _, d := range diaries {
+			ok, err := uu.publishUnlocked(ctx, d)
+			if err != nil {
+				return published, err
+			}
+			if ok {
+				published++
+			}
+		}
+
+		if len(diaries) < domain.UnlockBatchSize {
+			return published, nil
+		}
+	}
+}
+
+// publishUnlocked marks the diary as published and publishes the event in one transaction,
+// so that a failed publish is retried on the next run
+func (uu *diaryUnlockUsecase) publishUnlocked(ctx context.Context, d *domain.Diary) (bool, error) {
+	ctx, err := uu.tm.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	claimed, err := uu.dr.MarkUnlockPublished(ctx, d.ID, uu.clk.Now())
+	if err != nil {
+		uu.tm.RollbackTx(ctx)
+		return false, err
+	}
+	if !claimed {
+		// 他のワーカーが既に公開済み
+		uu.tm.RollbackTx(ctx)
+		return false, nil
+	}
+
+	event := domain.NewDiaryUnlockedEvent(d.ID, d.UserID, d.FamilyID, d.Title, *d.UnlockAt)
+	if err := uu.publisher.Publish(ctx, event); err != nil {
+		uu.tm.RollbackTx(ctx)
+		slog.Error("failed to publish diary unlocked event", "diary_id", d.ID, "error", err.Error())
+		return false, err
+	}
+
+	// 開封まで保留していたメンション通知を送る
+	if err := uu.mu.NotifyPending(ctx, d); err != nil {
+		uu.tm.RollbackTx(ctx)
+		return false, err
+	}
+
+	uu.tm.CommitTx(ctx)
+
+	return true, nil
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+)
+
+func TestDiaryUnlockUsecase_PublishDue_Success(t *testing.T) {
+	mockRepo := new(MockDiaryRepository)
+	mockTm := new(MockTransactionManager)
+	mockPub := new(MockPublisher)
+
+	now := time.Date(2026, 5, 5, 0, 0, 0, 0, time.UTC)
+	unlockAt := now.Add(-time.Minute)
+	diary := &domain.Diary{ID: uuid.New(), UserID: uuid.New(), FamilyID: uuid.New(), Title: "Happy 10th birthday", UnlockAt: &unlockAt}
+
+	mockRepo.On("ListUnlockDue", mock.Anything, now, domain.UnlockBatchSize).Return([]*domain.Diary{diary}, nil)
+	mockTm.On("BeginTx", mock.Anything).Return(context.Background(), nil)
+	mockRepo.On("MarkUnlockPublished", mock.Anything, diary.ID, now).Return(true, nil)
+	mockPub.On("Publish", mock.Anything, mock.MatchedBy(func(e *domain.DiaryUnlockedEvent) bool {
+		return e.EventType() == "diary.unlocked" && e.DiaryID == diary.ID && e.FamilyID == diary.FamilyID && e.UnlockAt.Equal(unlockAt)
+	})).Return(nil)
+	mockTm.On("CommitTx", mock.Anything).Return(nil)
+
+	uc := NewDiaryUnlockUsecase(mockTm, mockRepo, mockPub, &clock.Fixed{Time: now}, new(stubMentionUsecase))
+	published, err := uc.PublishDue(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, published)
+	mockPub.AssertExpectations(t)
+	mockTm.AssertExpectations(t)
+}
+
+func TestDiaryUnlockUsecase_PublishDue_AlreadyClaimed(t *testing.T) {
+	mockRepo := new(MockDiaryRepository)
+	mockTm := new(MockTransactionManager)
+	mockPub := new(MockPublisher)
+
+	now := time.Now()
+	unlockAt := now.Add(-time.Minute)
+	diary := &domain.Diary{ID: uuid.New(), UnlockAt: &unlockAt}
+
+	mockRepo.On("ListUnlockDue", mock.Anything, now, domain.UnlockBatchSize).Return([]*domain.Diary{diary}, nil)
+	mockTm.On("BeginTx", mock.Anything).Return(context.Background(), nil)
+	mockRepo.On("MarkUnlockPublished", mock.Anything, diary.ID, now).Return(false, nil)
+	mockTm.On("RollbackTx", mock.Anything).Return(nil)
+
+	uc := NewDiaryUnlockUsecase(mockTm, mockRepo, mockPub, &clock.Fixed{Time: now}, new(stubMentionUsecase))
+	published, err := uc.PublishDue(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, published)
+	mockPub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestDiaryUnlockUsecase_PublishDue_RollbackOnPublishError(t *testing.T) {
+	mockRepo := new(MockDiaryRepository)
+	mockTm := new(MockTransactionManager)
+	mockPub := new(MockPublisher)
+
+	now := time.Now()
+	unlockAt := now.Add(-time.Minute)
+	diary := &domain.Diary{ID: uuid.New(), UnlockAt: &unlockAt}
+	publishErr := errors.New("broker unavailable")
+
+	mockRepo.On("ListUnlockDue", mock.Anything, now, domain.UnlockBatchSize).Return([]*domain.Diary{diary}, nil)
+	mockTm.On("BeginTx", mock.Anything).Return(context.Background(), nil)
+	mockRepo.On("MarkUnlockPublished", mock.Anything, diary.ID, now).Return(true, nil)
+	mockPub.On("Publish", mock.Anything, mock.Anything).Return(publishErr)
+	mockTm.On("RollbackTx", mock.Anything).Return(nil)
+
+	uc := NewDiaryUnlockUsecase(mockTm, mockRepo, mockPub, &clock.Fixed{Time: now}, new(stubMentionUsecase))
+	published, err := uc.PublishDue(context.Background())
+
+	assert.ErrorIs(t, err, publishErr)
+	assert.Equal(t, 0, published)
+	mockTm.AssertCalled(t, "RollbackTx", mock.Anything)
+	mockTm.AssertNotCalled(t, "CommitTx", mock.Anything)
+}
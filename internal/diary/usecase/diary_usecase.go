@@ -25,11 +25,13 @@ type CreateDiaryInput struct {
 	Title              string
 	Content            string
 	WritingTimeSeconds int
+	// UnlockAt makes the diary a time capsule hidden from other members until that time
+	UnlockAt *time.Time
 }
 
 type DiaryUsecase interface {
 	Create(ctx context.Context, input *CreateDiaryInput) (*domain.Diary, error)
-	List(ctx context.Context, familyID uuid.UUID, targetDate string) ([]*domain.Diary, error)
+	List(ctx context.Context, familyID, userID uuid.UUID, targetDate string) ([]*domain.Diary, error)
 	GetCount(ctx context.Context, familyID, userID uuid.UUID, year, month string) (int, error)
 	GetStreak(ctx context.Context, userID, familyID uuid.UUID) (*domain.Streak, error)
 }
@@ -40,41 +42,47 @@ type diaryUsecase struct {
 	sr        repository.StreakRepository
 	publisher publisher.Publisher
 	clk       clock.Clock
+	mu        MentionUsecase
 }
 
 // NewDiaryUsecase creates a new DiaryUsecase with all dependencies injected
-func NewDiaryUsecase(tm db.TransactionManager, dr repository.DiaryRepository, sr repository.StreakRepository, pub publisher.Publisher, clk clock.Clock) DiaryUsecase {
+func NewDiaryUsecase(tm db.TransactionManager, dr repository.DiaryRepository, sr repository.StreakRepository, pub publisher.Publisher, clk clock.Clock, mu MentionUsecase) DiaryUsecase {
 	return &diaryUsecase{
 		tm:        tm,
 		dr:        dr,
 		sr:        sr,
 		publisher: pub,
 		clk:       clk,
+		mu:        mu,
 	}
 }
 
 func (du *diaryUsecase) Create(ctx context.Context, input *CreateDiaryInput) (*domain.Diary, error) {
 	d := &domain.Diary{
-		FamilyID:           input.FamilyID,
-		UserID:             input.UserID,
-		Title:              input.Title,
-		Content:            input.Content,
+		FamilyID: input.FamilyID,
+		UserID:   input.UserID,
+		Title:    input.Title,
+		Content:  input.Content,
+		UnlockAt: input.UnlockAt,
 	}
 
 	err := domain.ValidateCreateDiaryRequest(d)
 	if err != nil {
 		return nil, &errors.ValidationError{Message: err.Error()}
 	}
+	now := du.clk.Now()
+	if err := domain.ValidateUnlockAt(d.UnlockAt, now); err != nil {
+		return nil, &errors.ValidationError{Message: err.Error()}
+	}
 	if du.publisher == nil {
 		return nil, &errors.LogicError{Message: "publisher is not set"}
 	}
 
-	now := du.clk.Now()
 	jst, err := time.LoadLocation("Asia/Tokyo")
 	if err != nil {
 		return nil, err
 	}
-	
+
 	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, jst)
 	endOfDay := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, jst)
 
@@ -114,8 +122,14 @@ func (du *diaryUsecase) Create(ctx context.Context, input *CreateDiaryInput) (*d
 		return nil, err
 	}
 
+	if err := du.mu.Link(ctx, diary); err != nil {
+		du.tm.RollbackTx(ctx)
+		return nil, err
+	}
+
 	// Publish diary created event
 	event := domain.NewDiaryCreatedEvent(diary.ID, diary.UserID, diary.FamilyID, diary.Title, diary.Content, input.WritingTimeSeconds)
+	event.UnlockAt = diary.UnlockAt
 	if err := du.publisher.Publish(ctx, event); err != nil {
 		du.tm.RollbackTx(ctx)
 		slog.Error("failed to publish diary created event", "error", err.Error())
@@ -167,7 +181,7 @@ func (du *diaryUsecase) updateStreak(ctx context.Context, userID, familyID uuid.
 	return nil
 }
 
-func (du *diaryUsecase) List(ctx context.Context, familyID uuid.UUID, targetDate string) ([]*domain.Diary, error) {
+func (du *diaryUsecase) List(ctx context.Context, familyID, userID uuid.UUID, targetDate string) ([]*domain.Diary, error) {
 	var query *domain.DiarySearchCriteria
 	parsedDate, err := time.Parse("2006-01-02", targetDate)
 	if err != nil {
@@ -178,12 +192,19 @@ func (du *diaryUsecase) List(ctx context.Context, familyID uuid.UUID, targetDate
 		FamilyID:  familyID,
 		StartDate: weekStart,
 		EndDate:   weekEnd,
+		Visibility: &domain.DiaryVisibility{
+			ViewerID: userID,
+			At:       du.clk.Now(),
+		},
 	}
 
 	diaries, err := du.dr.List(ctx, query, nil)
 	if err != nil {
 		return nil, err
 	}
+	if err := du.mu.Attach(ctx, diaries); err != nil {
+		return nil, err
+	}
 
 	return diaries, nil
 }
@@ -77,7 +77,7 @@ func TestDiaryUsecaseIntegration_CreateWithTransaction(t *testing.T) {
 
 	fixedTime := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
 	clk := &clock.Fixed{Time: fixedTime}
-	usecase := NewDiaryUsecase(deps.TM, deps.DR, deps.SR, deps.Publisher, clk)
+	usecase := NewDiaryUsecase(deps.TM, deps.DR, deps.SR, deps.Publisher, clk, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.Create(context.Background(), &CreateDiaryInput{
@@ -125,7 +125,7 @@ func TestDiaryUsecaseIntegration_StreakCalculationFlow(t *testing.T) {
 	day1Time := time.Date(2026, 1, 13, 10, 0, 0, 0, time.Local)
 	log.Println("Day 1 Time:", day1Time)
 	clk1 := &clock.Fixed{Time: day1Time}
-	usecase1 := NewDiaryUsecase(deps.TM, deps.DR, deps.SR, deps.Publisher, clk1)
+	usecase1 := NewDiaryUsecase(deps.TM, deps.DR, deps.SR, deps.Publisher, clk1, new(stubMentionUsecase))
 
 	diary1 := &domain.Diary{
 		UserID:   userID,
@@ -152,7 +152,7 @@ func TestDiaryUsecaseIntegration_StreakCalculationFlow(t *testing.T) {
 	// Day 2: Create second diary (consecutive)
 	day2Time := time.Date(2026, 1, 14, 10, 0, 0, 0, time.Local)
 	clk2 := &clock.Fixed{Time: day2Time}
-	usecase2 := NewDiaryUsecase(deps.TM, deps.DR, deps.SR, deps.Publisher, clk2)
+	usecase2 := NewDiaryUsecase(deps.TM, deps.DR, deps.SR, deps.Publisher, clk2, new(stubMentionUsecase))
 
 	diary2 := &domain.Diary{
 		UserID:   userID,
@@ -179,7 +179,7 @@ func TestDiaryUsecaseIntegration_StreakCalculationFlow(t *testing.T) {
 	// Day 4 (Gap): Create third diary (non-consecutive)
 	day4Time := time.Date(2026, 1, 16, 10, 0, 0, 0, time.Local)
 	clk4 := &clock.Fixed{Time: day4Time}
-	usecase4 := NewDiaryUsecase(deps.TM, deps.DR, deps.SR, deps.Publisher, clk4)
+	usecase4 := NewDiaryUsecase(deps.TM, deps.DR, deps.SR, deps.Publisher, clk4, new(stubMentionUsecase))
 
 	diary4 := &domain.Diary{
 		UserID:   userID,
@@ -226,7 +226,7 @@ func TestDiaryUsecaseIntegration_PublishEventToBroker(t *testing.T) {
 
 	fixedTime := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
 	clk := &clock.Fixed{Time: fixedTime}
-	usecase := NewDiaryUsecase(deps.TM, deps.DR, deps.SR, deps.Publisher, clk)
+	usecase := NewDiaryUsecase(deps.TM, deps.DR, deps.SR, deps.Publisher, clk, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.Create(context.Background(), &CreateDiaryInput{
@@ -285,7 +285,7 @@ func TestDiaryUsecaseIntegration_StreakEventPublished(t *testing.T) {
 
 	fixedTime1 := time.Date(2026, 1, 13, 10, 0, 0, 0, time.UTC)
 	clk1 := &clock.Fixed{Time: fixedTime1}
-	usecase1 := NewDiaryUsecase(deps.TM, deps.DR, deps.SR, deps.Publisher, clk1)
+	usecase1 := NewDiaryUsecase(deps.TM, deps.DR, deps.SR, deps.Publisher, clk1, new(stubMentionUsecase))
 
 	result1, err := usecase1.Create(context.Background(), &CreateDiaryInput{
 		UserID:             diary1.UserID,
@@ -312,7 +312,7 @@ func TestDiaryUsecaseIntegration_StreakEventPublished(t *testing.T) {
 
 	fixedTime2 := time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC)
 	clk2 := &clock.Fixed{Time: fixedTime2}
-	usecase2 := NewDiaryUsecase(deps.TM, deps.DR, deps.SR, deps.Publisher, clk2)
+	usecase2 := NewDiaryUsecase(deps.TM, deps.DR, deps.SR, deps.Publisher, clk2, new(stubMentionUsecase))
 
 	result2, err := usecase2.Create(context.Background(), &CreateDiaryInput{
 		UserID:             diary2.UserID,
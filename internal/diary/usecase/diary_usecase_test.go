@@ -28,6 +28,14 @@ func (m *MockDiaryRepository) Create(ctx context.Context, diary *domain.Diary) (
 	return args.Get(0).(*domain.Diary), args.Error(1)
 }
 
+func (m *MockDiaryRepository) Update(ctx context.Context, diary *domain.Diary) (*domain.Diary, error) {
+	args := m.Called(ctx, diary)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Diary), args.Error(1)
+}
+
 func (m *MockDiaryRepository) List(ctx context.Context, criteria *domain.DiarySearchCriteria, pag *pagination.Pagination) ([]*domain.Diary, error) {
 	args := m.Called(ctx, criteria, pag)
 	if args.Get(0) == nil {
@@ -41,6 +49,27 @@ func (m *MockDiaryRepository) GetCount(ctx context.Context, criteria *domain.Dia
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockDiaryRepository) FindByIDs(ctx context.Context, familyID uuid.UUID, ids []uuid.UUID, visibility *domain.DiaryVisibility) ([]*domain.Diary, error) {
+	args := m.Called(ctx, familyID, ids, visibility)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Diary), args.Error(1)
+}
+
+func (m *MockDiaryRepository) ListUnlockDue(ctx context.Context, at time.Time, limit int) ([]*domain.Diary, error) {
+	args := m.Called(ctx, at, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Diary), args.Error(1)
+}
+
+func (m *MockDiaryRepository) MarkUnlockPublished(ctx context.Context, diaryID uuid.UUID, at time.Time) (bool, error) {
+	args := m.Called(ctx, diaryID, at)
+	return args.Bool(0), args.Error(1)
+}
+
 type MockTransactionManager struct {
 	mock.Mock
 }
@@ -181,7 +210,7 @@ func TestDiaryUsecaseCreateValidationError(t *testing.T) {
 			mockPub := new(MockPublisher)
 			mockStreakRepo := new(MockStreakRepository)
 
-			usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{})
+			usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{}, new(stubMentionUsecase))
 
 			_, err := usecase.Create(context.Background(), tt.diary)
 
@@ -226,7 +255,7 @@ func TestDiaryUsecaseCreateRepositoryError(t *testing.T) {
 	mockRepo.On("Create", mock.Anything, diary).Return(nil, expectedErr)
 	mockRepo.On("List", mock.Anything, mock.Anything, mock.Anything).Return([]*domain.Diary{}, nil)
 	mockTm.On("RollbackTx", mock.Anything).Return(nil)
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{})
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{}, new(stubMentionUsecase))
 
 	_, err := usecase.Create(context.Background(), input)
 
@@ -279,7 +308,7 @@ func TestDiaryUsecase_Create_Success(t *testing.T) {
 	mockStreakRepo := new(MockStreakRepository)
 	mockStreakRepo.On("Get", mock.Anything, userID, familyID).Return(nil, nil)
 	mockStreakRepo.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(&domain.Streak{}, nil)
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{})
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{}, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.Create(context.Background(), input)
@@ -320,7 +349,7 @@ func TestDiaryUsecase_Create_RepositoryError(t *testing.T) {
 	mockTm.On("RollbackTx", mock.Anything).Return(nil)
 
 	mockStreakRepo := new(MockStreakRepository)
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{})
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{}, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.Create(context.Background(), input)
@@ -361,7 +390,7 @@ func TestDiaryUsecaseCreateContextCancelled(t *testing.T) {
 	mockTm.On("RollbackTx", mock.Anything).Return(nil)
 
 	mockStreakRepo := new(MockStreakRepository)
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{})
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{}, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.Create(ctx, input)
@@ -385,7 +414,7 @@ func TestDiaryUsecaseListRepositoryError(t *testing.T) {
 
 	// Clock を注入
 	mockStreakRepo := new(MockStreakRepository)
-	usecase := NewDiaryUsecase(mockTxManager, mockRepo, mockStreakRepo, nil, clk)
+	usecase := NewDiaryUsecase(mockTxManager, mockRepo, mockStreakRepo, nil, clk, new(stubMentionUsecase))
 
 	familyID := uuid.New()
 
@@ -399,7 +428,7 @@ func TestDiaryUsecaseListRepositoryError(t *testing.T) {
 	}), mock.Anything).Return(nil, repositoryErr)
 
 	// Call usecase
-	result, err := usecase.List(context.Background(), familyID, "2026-01-15")
+	result, err := usecase.List(context.Background(), familyID, uuid.New(), "2026-01-15")
 
 	// Verify error
 	assert.Error(t, err)
@@ -442,7 +471,7 @@ func TestDiaryUsecaseCreateAlreadyPostedToday(t *testing.T) {
 		return c.FamilyID == familyID && c.UserID == userID && c.StartDate.Equal(expectedStart) && c.EndDate.Equal(expectedEnd)
 	}), mock.Anything).Return([]*domain.Diary{existing}, nil)
 
-	usecase := NewDiaryUsecase(mockTm, mockRepo, new(MockStreakRepository), mockPub, clk)
+	usecase := NewDiaryUsecase(mockTm, mockRepo, new(MockStreakRepository), mockPub, clk, new(stubMentionUsecase))
 
 	// Act
 	_, err := usecase.Create(context.Background(), input)
@@ -508,7 +537,7 @@ func TestDiaryUsecase_Create_PublishEvent(t *testing.T) {
 	mockStreakRepo := new(MockStreakRepository)
 	mockStreakRepo.On("Get", mock.Anything, input.UserID, input.FamilyID).Return(nil, nil)
 	mockStreakRepo.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(&domain.Streak{}, nil)
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{})
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{}, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.Create(context.Background(), input)
@@ -567,7 +596,7 @@ func TestDiaryUsecase_Create_PublishEventError(t *testing.T) {
 	mockStreakRepo := new(MockStreakRepository)
 	mockStreakRepo.On("Get", mock.Anything, input.UserID, input.FamilyID).Return(nil, nil)
 	mockStreakRepo.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(&domain.Streak{}, nil)
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{})
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{}, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.Create(context.Background(), input)
@@ -590,7 +619,7 @@ func TestDiaryUsecase_Create_NilPublisher(t *testing.T) {
 
 	// Create usecase with nil publisher
 	mockStreakRepo := new(MockStreakRepository)
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, nil, &clock.Real{})
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, nil, &clock.Real{}, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.Create(context.Background(), input)
@@ -627,7 +656,7 @@ func TestDiaryUsecase_GetCount_Success(t *testing.T) {
 	mockRepo.On("GetCount", mock.Anything, criteria).Return(5, nil)
 
 	mockStreakRepo := new(MockStreakRepository)
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, nil, &clock.Real{})
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, nil, &clock.Real{}, new(stubMentionUsecase))
 
 	// Act
 	count, err := usecase.GetCount(context.Background(), familyID, userID, "2026", "01")
@@ -646,7 +675,7 @@ func TestDiaryUsecase_GetCount_InvalidMonth(t *testing.T) {
 
 	familyID := uuid.New()
 	mockStreakRepo := new(MockStreakRepository)
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, nil, &clock.Real{})
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, nil, &clock.Real{}, new(stubMentionUsecase))
 
 	userID := uuid.New()
 
@@ -669,7 +698,7 @@ func TestDiaryUsecase_GetCount_InvalidYear(t *testing.T) {
 	familyID := uuid.New()
 	userID := uuid.New()
 	mockStreakRepo := new(MockStreakRepository)
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, nil, &clock.Real{})
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, nil, &clock.Real{}, new(stubMentionUsecase))
 
 	// Act
 	count, err := usecase.GetCount(context.Background(), familyID, userID, "0", "01")
@@ -698,7 +727,7 @@ func TestDiaryUsecase_GetCount_ZeroCount(t *testing.T) {
 	mockRepo.On("GetCount", mock.Anything, criteria).Return(0, nil)
 
 	mockStreakRepo := new(MockStreakRepository)
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, nil, &clock.Real{})
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, nil, &clock.Real{}, new(stubMentionUsecase))
 
 	// Act
 	count, err := usecase.GetCount(context.Background(), familyID, userID, "2026", "02")
@@ -727,7 +756,7 @@ func TestDiaryUsecase_GetCount_RepositoryError(t *testing.T) {
 	mockRepo.On("GetCount", mock.Anything, criteria).Return(0, expectedErr)
 
 	mockStreakRepo := new(MockStreakRepository)
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, nil, &clock.Real{})
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, nil, &clock.Real{}, new(stubMentionUsecase))
 
 	// Act
 	count, err := usecase.GetCount(context.Background(), familyID, userID, "2026", "01")
@@ -785,7 +814,7 @@ func TestDiaryUsecase_Create_CommitOnSuccess(t *testing.T) {
 	mockTm.On("CommitTx", mock.Anything).Return(nil)
 	mockPub.On("Close").Return(nil)
 
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{})
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{}, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.Create(context.Background(), input)
@@ -814,7 +843,7 @@ func TestDiaryUsecase_Create_RollbackOnDiaryCreateError(t *testing.T) {
 	mockTm.On("RollbackTx", mock.Anything).Return(nil)
 
 	mockStreakRepo := new(MockStreakRepository)
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{})
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{}, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.Create(context.Background(), input)
@@ -867,7 +896,7 @@ func TestDiaryUsecase_Create_RollbackOnStreakUpdateError(t *testing.T) {
 	})).Return(nil)
 	mockPub.On("Close").Return(nil)
 
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{})
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{}, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.Create(context.Background(), input)
@@ -912,7 +941,7 @@ func TestDiaryUsecase_Create_RollbackOnPublishError(t *testing.T) {
 	mockPub.On("Publish", mock.Anything, mock.Anything).Return(publishErr)
 	mockTm.On("RollbackTx", mock.Anything).Return(nil)
 
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{})
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, &clock.Real{}, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.Create(context.Background(), input)
@@ -967,7 +996,7 @@ func TestDiaryUsecase_Create_StreakCalculationOnFirstEntry(t *testing.T) {
 
 	fixedTime := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
 	clk := &clock.Fixed{Time: fixedTime}
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk)
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.Create(context.Background(), input)
@@ -1033,7 +1062,7 @@ func TestDiaryUsecase_Create_StreakIncrementOnConsecutiveEntry(t *testing.T) {
 	mockPub.On("Close").Return(nil)
 
 	clk := &clock.Fixed{Time: fixedTime}
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk)
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.Create(context.Background(), input)
@@ -1098,7 +1127,7 @@ func TestDiaryUsecase_Create_StreakResetOnNonConsecutiveEntry(t *testing.T) {
 	mockPub.On("Close").Return(nil)
 
 	clk := &clock.Fixed{Time: fixedTime}
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk)
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.Create(context.Background(), input)
@@ -1155,7 +1184,7 @@ func TestDiaryUsecase_Create_DuplicatePostError(t *testing.T) {
 	mockPub.On("Publish", mock.Anything, mock.Anything).Return(nil)
 
 	clk := &clock.Fixed{Time: fixedTime}
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk)
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.Create(context.Background(), input)
@@ -1194,7 +1223,7 @@ func TestDiaryUsecase_GetStreak_Success(t *testing.T) {
 	mockStreakRepo.On("Get", mock.Anything, userID, familyID).Return(expectedStreak, nil)
 
 	clk := &clock.Real{}
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk)
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.GetStreak(context.Background(), userID, familyID)
@@ -1226,7 +1255,7 @@ func TestDiaryUsecase_GetStreak_NotFound(t *testing.T) {
 	mockStreakRepo.On("Get", mock.Anything, input.UserID, input.FamilyID).Return(nil, nil)
 
 	clk := &clock.Real{}
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk)
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.GetStreak(context.Background(), input.UserID, input.FamilyID)
@@ -1252,7 +1281,7 @@ func TestDiaryUsecase_GetStreak_InvalidUserID(t *testing.T) {
 	familyID := input.FamilyID
 
 	clk := &clock.Real{}
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk)
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.GetStreak(context.Background(), uuid.Nil, familyID)
@@ -1278,7 +1307,7 @@ func TestDiaryUsecase_GetStreak_InvalidFamilyID(t *testing.T) {
 	userID := input.UserID
 
 	clk := &clock.Real{}
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk)
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.GetStreak(context.Background(), userID, uuid.Nil)
@@ -1306,7 +1335,7 @@ func TestDiaryUsecase_GetStreak_RepositoryError(t *testing.T) {
 	mockStreakRepo.On("Get", mock.Anything, input.UserID, input.FamilyID).Return(nil, repositoryErr)
 
 	clk := &clock.Real{}
-	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk)
+	usecase := NewDiaryUsecase(mockTm, mockRepo, mockStreakRepo, mockPub, clk, new(stubMentionUsecase))
 
 	// Act
 	result, err := usecase.GetStreak(context.Background(), input.UserID, input.FamilyID)
@@ -1318,3 +1347,46 @@ func TestDiaryUsecase_GetStreak_RepositoryError(t *testing.T) {
 
 	mockStreakRepo.AssertExpectations(t)
 }
+
+// TestDiaryUsecase_List_HidesLockedCapsulesFromOthers ensures the viewer and the current time are passed to the repository
+func TestDiaryUsecase_List_HidesLockedCapsulesFromOthers(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDiaryRepository)
+	fixedTime := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+	usecase := NewDiaryUsecase(new(MockTransactionManager), mockRepo, new(MockStreakRepository), nil, &clock.Fixed{Time: fixedTime}, new(stubMentionUsecase))
+
+	familyID := uuid.New()
+	viewerID := uuid.New()
+
+	mockRepo.On("List", mock.Anything, mock.MatchedBy(func(criteria *domain.DiarySearchCriteria) bool {
+		return criteria.Visibility != nil &&
+			criteria.Visibility.ViewerID == viewerID &&
+			criteria.Visibility.At.Equal(fixedTime)
+	}), mock.Anything).Return([]*domain.Diary{}, nil)
+
+	_, err := usecase.List(context.Background(), familyID, viewerID, "2026-01-15")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestDiaryUsecase_Create_UnlockAtInPast ensures a time capsule must unlock in the future
+func TestDiaryUsecase_Create_UnlockAtInPast(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDiaryRepository)
+	fixedTime := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+	usecase := NewDiaryUsecase(new(MockTransactionManager), mockRepo, new(MockStreakRepository), new(MockPublisher), &clock.Fixed{Time: fixedTime}, new(stubMentionUsecase))
+
+	input := newValidDiaryInput()
+	past := fixedTime.Add(-time.Hour)
+	input.UnlockAt = &past
+
+	_, err := usecase.Create(context.Background(), input)
+
+	if _, ok := err.(*pkgerrors.ValidationError); !ok {
+		t.Fatalf("expected ValidationError, got %T", err)
+	}
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
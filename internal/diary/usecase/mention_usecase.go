@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/diary/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/pkg/broker/publisher"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+	"github.com/google/uuid"
+)
+
+// MentionUsecase links @mentions in diaries to family members and notifies them
+type MentionUsecase interface {
+	// Link parses the mentions of the diary, stores them and publishes diary.mentioned
+	// to each newly mentioned member. Notifications of a locked time capsule are deferred to NotifyPending.
+	Link(ctx context.Context, diary *domain.Diary) error
+	// NotifyPending publishes diary.mentioned for stored mentions that were not notified yet
+	NotifyPending(ctx context.Context, diary *domain.Diary) error
+	// Attach sets Mentions of the diaries
+	Attach(ctx context.Context, diaries []*domain.Diary) error
+	// SyncMember updates the member projection from a user-context event
+	SyncMember(ctx context.Context, event *domain.FamilyMemberUpsertedEvent) error
+	// RemoveMember removes the member from the projection, so the member can no longer be mentioned
+	RemoveMember(ctx context.Context, event *domain.FamilyMemberRemovedEvent) error
+}
+
+type mentionUsecase struct {
+	fmr       repository.FamilyMemberRepository
+	mr        repository.MentionRepository
+	publisher publisher.Publisher
+	clk       clock.Clock
+}
+
+// NewMentionUsecase creates a new MentionUsecase with all dependencies injected
+func NewMentionUsecase(fmr repository.FamilyMemberRepository, mr repository.MentionRepository, pub publisher.Publisher, clk clock.Clock) MentionUsecase {
+	return &mentionUsecase{
+		fmr:       fmr,
+		mr:        mr,
+		publisher: pub,
+		clk:       clk,
+	}
+}
+
+func (mu *mentionUsecase) Link(ctx context.Context, diary *domain.Diary) error {
+	members, err := mu.fmr.ListByFamily(ctx, diary.FamilyID)
+	if err != nil {
+		return err
+	}
+
+	mentions := domain.ParseMentions(diary.Content, members)
+	for _, m := range mentions {
+		m.DiaryID = diary.ID
+	}
+	if err := mu.mr.Replace(ctx, diary.ID, mentions); err != nil {
+		return err
+	}
+	diary.Mentions = mentions
+
+	// タイムカプセルは開封されるまで通知しない
+	if !diary.IsVisibleTo(uuid.Nil, mu.clk.Now()) {
+		return nil
+	}
+	return mu.notify(ctx, diary, mentions)
+}
+
+func (mu *mentionUsecase) NotifyPending(ctx context.Context, diary *domain.Diary) error {
+	mentions, err := mu.mr.ListByDiaryIDs(ctx, []uuid.UUID{diary.ID})
+	if err != nil {
+		return err
+	}
+	return mu.notify(ctx, diary, mentions)
+}
+
+// notify publishes one event per mentioned member. The author and already notified members are skipped,
+// so editing a diary only notifies members added by the edit.
+func (mu *mentionUsecase) notify(ctx context.Context, diary *domain.Diary, mentions []*domain.DiaryMention) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+	if mu.publisher == nil {
+		return &errors.LogicError{Message: "publisher is not set"}
+	}
+
+	now := mu.clk.Now()
+	seen := make(map[uuid.UUID]bool, len(mentions))
+	for _, m := range mentions {
+		if m.UserID == diary.UserID || seen[m.UserID] {
+			continue
+		}
+		seen[m.UserID] = true
+
+		claimed, err := mu.mr.MarkNotified(ctx, diary.ID, m.UserID, now)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			continue
+		}
+
+		event := domain.NewDiaryMentionedEvent(diary.ID, diary.FamilyID, diary.UserID, m.UserID, diary.Title)
+		if err := mu.publisher.Publish(ctx, event); err != nil {
+			slog.Error("failed to publish diary mentioned event", "diary_id", diary.ID, "user_id", m.UserID, "error", err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+func (mu *mentionUsecase) Attach(ctx context.Context, diaries []*domain.Diary) error {
+	if len(diaries) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(diaries))
+	for i, d := range diaries {
+		ids[i] = d.ID
+	}
+	mentions, err := mu.mr.ListByDiaryIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	byDiary := make(map[uuid.UUID][]*domain.DiaryMention, len(diaries))
+	for _, m := range mentions {
+		byDiary[m.DiaryID] = append(byDiary[m.DiaryID], m)
+	}
+	for _, d := range diaries {
+		d.Mentions = byDiary[d.ID]
+	}
+	return nil
+}
+
+func (mu *mentionUsecase) SyncMember(ctx context.Context, event *domain.FamilyMemberUpsertedEvent) error {
+	if event.FamilyID == uuid.Nil || event.UserID == uuid.Nil {
+		return &errors.ValidationError{Message: "family_id and user_id are required"}
+	}
+
+	member := &domain.FamilyMember{
+		FamilyID: event.FamilyID,
+		UserID:   event.UserID,
+		Name:     event.Name,
+		SyncedAt: event.Timestamp,
+	}
+	return mu.fmr.Upsert(ctx, member)
+}
+
+func (mu *mentionUsecase) RemoveMember(ctx context.Context, event *domain.FamilyMemberRemovedEvent) error {
+	if event.FamilyID == uuid.Nil || event.UserID == uuid.Nil {
+		return &errors.ValidationError{Message: "family_id and user_id are required"}
+	}
+
+	removedAt := event.Timestamp
+	member := &domain.FamilyMember{
+		FamilyID:  event.FamilyID,
+		UserID:    event.UserID,
+		SyncedAt:  event.Timestamp,
+		RemovedAt: &removedAt,
+	}
+	return mu.fmr.Remove(ctx, member)
+}
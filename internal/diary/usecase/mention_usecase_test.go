@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/furuya-3150/fam-diary-log/internal/diary/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+)
+
+// stubMentionUsecase is used by tests that do not cover mentions
+type stubMentionUsecase struct{}
+
+func (s *stubMentionUsecase) Link(ctx context.Context, diary *domain.Diary) error { return nil }
+
+func (s *stubMentionUsecase) NotifyPending(ctx context.Context, diary *domain.Diary) error {
+	return nil
+}
+
+func (s *stubMentionUsecase) Attach(ctx context.Context, diaries []*domain.Diary) error { return nil }
+
+func (s *stubMentionUsecase) SyncMember(ctx context.Context, event *domain.FamilyMemberUpsertedEvent) error {
+	return nil
+}
+
+func (s *stubMentionUsecase) RemoveMember(ctx context.Context, event *domain.FamilyMemberRemovedEvent) error {
+	return nil
+}
+
+type MockFamilyMemberRepository struct {
+	mock.Mock
+}
+
+func (m *MockFamilyMemberRepository) Upsert(ctx context.Context, member *domain.FamilyMember) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (m *MockFamilyMemberRepository) Remove(ctx context.Context, member *domain.FamilyMember) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (m *MockFamilyMemberRepository) ListByFamily(ctx context.Context, familyID uuid.UUID) ([]*domain.FamilyMember, error) {
+	args := m.Called(ctx, familyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.FamilyMember), args.Error(1)
+}
+
+type MockMentionRepository struct {
+	mock.Mock
+}
+
+func (m *MockMentionRepository) Replace(ctx context.Context, diaryID uuid.UUID, mentions []*domain.DiaryMention) error {
+	args := m.Called(ctx, diaryID, mentions)
+	return args.Error(0)
+}
+
+func (m *MockMentionRepository) ListByDiaryIDs(ctx context.Context, diaryIDs []uuid.UUID) ([]*domain.DiaryMention, error) {
+	args := m.Called(ctx, diaryIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DiaryMention), args.Error(1)
+}
+
+func (m *MockMentionRepository) MarkNotified(ctx context.Context, diaryID, userID uuid.UUID, at time.Time) (bool, error) {
+	args := m.Called(ctx, diaryID, userID, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestMentionUsecase_Link_NotifiesMentionedMembers(t *testing.T) {
+	mockMemberRepo := new(MockFamilyMemberRepository)
+	mockMentionRepo := new(MockMentionRepository)
+	mockPub := new(MockPublisher)
+
+	now := time.Date(2026, 5, 5, 12, 0, 0, 0, time.UTC)
+	familyID := uuid.New()
+	author := &domain.FamilyMember{FamilyID: familyID, UserID: uuid.New(), Name: "Mom"}
+	hanako := &domain.FamilyMember{FamilyID: familyID, UserID: uuid.New(), Name: "Hanako"}
+	taro := &domain.FamilyMember{FamilyID: familyID, UserID: uuid.New(), Name: "Taro"}
+	diary := &domain.Diary{
+		ID:       uuid.New(),
+		FamilyID: familyID,
+		UserID:   author.UserID,
+		Title:    "Picnic",
+		Content:  "@Hanako and @Taro came with @Mom. @hanako was happy.",
+	}
+
+	mockMemberRepo.On("ListByFamily", mock.Anything, familyID).Return([]*domain.FamilyMember{author, hanako, taro}, nil)
+	mockMentionRepo.On("Replace", mock.Anything, diary.ID, mock.MatchedBy(func(ms []*domain.DiaryMention) bool {
+		return len(ms) == 4 && ms[0].DiaryID == diary.ID
+	})).Return(nil)
+	mockMentionRepo.On("MarkNotified", mock.Anything, diary.ID, hanako.UserID, now).Return(true, nil).Once()
+	// Taro was notified by an earlier version of the diary
+	mockMentionRepo.On("MarkNotified", mock.Anything, diary.ID, taro.UserID, now).Return(false, nil).Once()
+	mockPub.On("Publish", mock.Anything, mock.MatchedBy(func(e *domain.DiaryMentionedEvent) bool {
+		return e.EventType() == "diary.mentioned" && e.MentionedUserID == hanako.UserID && e.AuthorID == author.UserID
+	})).Return(nil).Once()
+
+	uc := NewMentionUsecase(mockMemberRepo, mockMentionRepo, mockPub, &clock.Fixed{Time: now})
+	err := uc.Link(context.Background(), diary)
+
+	assert.NoError(t, err)
+	assert.Len(t, diary.Mentions, 4)
+	mockMentionRepo.AssertExpectations(t)
+	mockPub.AssertExpectations(t)
+}
+
+func TestMentionUsecase_Link_DefersNotificationOfLockedTimeCapsule(t *testing.T) {
+	mockMemberRepo := new(MockFamilyMemberRepository)
+	mockMentionRepo := new(MockMentionRepository)
+	mockPub := new(MockPublisher)
+
+	now := time.Now()
+	unlockAt := now.Add(24 * time.Hour)
+	familyID := uuid.New()
+	hanako := &domain.FamilyMember{FamilyID: familyID, UserID: uuid.New(), Name: "Hanako"}
+	diary := &domain.Diary{ID: uuid.New(), FamilyID: familyID, UserID: uuid.New(), Content: "Dear @Hanako", UnlockAt: &unlockAt}
+
+	mockMemberRepo.On("ListByFamily", mock.Anything, familyID).Return([]*domain.FamilyMember{hanako}, nil)
+	mockMentionRepo.On("Replace", mock.Anything, diary.ID, mock.Anything).Return(nil)
+
+	uc := NewMentionUsecase(mockMemberRepo, mockMentionRepo, mockPub, &clock.Fixed{Time: now})
+	err := uc.Link(context.Background(), diary)
+
+	assert.NoError(t, err)
+	assert.Len(t, diary.Mentions, 1)
+	mockMentionRepo.AssertNotCalled(t, "MarkNotified", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockPub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestMentionUsecase_NotifyPending(t *testing.T) {
+	mockMentionRepo := new(MockMentionRepository)
+	mockPub := new(MockPublisher)
+
+	now := time.Now()
+	diary := &domain.Diary{ID: uuid.New(), FamilyID: uuid.New(), UserID: uuid.New()}
+	mentioned := uuid.New()
+
+	mockMentionRepo.On("ListByDiaryIDs", mock.Anything, []uuid.UUID{diary.ID}).
+		Return([]*domain.DiaryMention{{DiaryID: diary.ID, UserID: mentioned, Start: 0, End: 7}}, nil)
+	mockMentionRepo.On("MarkNotified", mock.Anything, diary.ID, mentioned, now).Return(true, nil)
+	mockPub.On("Publish", mock.Anything, mock.AnythingOfType("*domain.DiaryMentionedEvent")).Return(nil)
+
+	uc := NewMentionUsecase(new(MockFamilyMemberRepository), mockMentionRepo, mockPub, &clock.Fixed{Time: now})
+	err := uc.NotifyPending(context.Background(), diary)
+
+	assert.NoError(t, err)
+	mockPub.AssertExpectations(t)
+}
+
+func TestMentionUsecase_Attach(t *testing.T) {
+	mockMentionRepo := new(MockMentionRepository)
+
+	d1 := &domain.Diary{ID: uuid.New()}
+	d2 := &domain.Diary{ID: uuid.New()}
+	mention := &domain.DiaryMention{DiaryID: d2.ID, UserID: uuid.New(), Start: 3, End: 8}
+	mockMentionRepo.On("ListByDiaryIDs", mock.Anything, []uuid.UUID{d1.ID, d2.ID}).Return([]*domain.DiaryMention{mention}, nil)
+
+	uc := NewMentionUsecase(new(MockFamilyMemberRepository), mockMentionRepo, new(MockPublisher), &clock.Real{})
+	err := uc.Attach(context.Background(), []*domain.Diary{d1, d2})
+
+	assert.NoError(t, err)
+	assert.Empty(t, d1.Mentions)
+	assert.Equal(t, []*domain.DiaryMention{mention}, d2.Mentions)
+}
+
+func TestMentionUsecase_SyncMember(t *testing.T) {
+	mockMemberRepo := new(MockFamilyMemberRepository)
+
+	event := &domain.FamilyMemberUpsertedEvent{FamilyID: uuid.New(), UserID: uuid.New(), Name: "Hanako", Timestamp: time.Now()}
+	mockMemberRepo.On("Upsert", mock.Anything, mock.MatchedBy(func(m *domain.FamilyMember) bool {
+		return m.FamilyID == event.FamilyID && m.UserID == event.UserID && m.Name == "Hanako" && m.SyncedAt.Equal(event.Timestamp)
+	})).Return(nil)
+
+	uc := NewMentionUsecase(mockMemberRepo, new(MockMentionRepository), new(MockPublisher), &clock.Real{})
+
+	assert.NoError(t, uc.SyncMember(context.Background(), event))
+	assert.Error(t, uc.SyncMember(context.Background(), &domain.FamilyMemberUpsertedEvent{Name: "Hanako"}))
+	mockMemberRepo.AssertExpectations(t)
+}
+
+func TestMentionUsecase_RemoveMember(t *testing.T) {
+	mockMemberRepo := new(MockFamilyMemberRepository)
+
+	event := &domain.FamilyMemberRemovedEvent{FamilyID: uuid.New(), UserID: uuid.New(), Timestamp: time.Now()}
+	mockMemberRepo.On("Remove", mock.Anything, mock.MatchedBy(func(m *domain.FamilyMember) bool {
+		return m.FamilyID == event.FamilyID && m.UserID == event.UserID &&
+			m.SyncedAt.Equal(event.Timestamp) && m.RemovedAt != nil && m.RemovedAt.Equal(event.Timestamp)
+	})).Return(nil)
+
+	uc := NewMentionUsecase(mockMemberRepo, new(MockMentionRepository), new(MockPublisher), &clock.Real{})
+
+	assert.NoError(t, uc.RemoveMember(context.Background(), event))
+	assert.Error(t, uc.RemoveMember(context.Background(), &domain.FamilyMemberRemovedEvent{FamilyID: event.FamilyID}))
+	mockMemberRepo.AssertExpectations(t)
+}
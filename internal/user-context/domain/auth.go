@@ -13,6 +13,9 @@ const (
 	AuthProviderGoogle AuthProvider = "google"
 )
 
+// DefaultTimezone is the time zone of users who have not chosen one
+const DefaultTimezone = "Asia/Tokyo"
+
 // User represents a user in the system
 type User struct {
 	ID         uuid.UUID    `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid();"`
@@ -20,6 +23,7 @@ type User struct {
 	Name       string       `json:"name" gorm:"type:varchar(255);not null"`
 	Provider   AuthProvider `json:"provider" gorm:"type:varchar(50);not null;uniqueIndex:idx_provider_id"`
 	ProviderID string       `json:"provider_id" gorm:"type:varchar(255);not null;uniqueIndex:idx_provider_id"` // ID from OAuth provider
+	Timezone   string       `json:"timezone" gorm:"type:varchar(64);not null;default:Asia/Tokyo"`              // IANA time zone, e.g. Asia/Tokyo
 	CreatedAt  time.Time    `json:"created_at" gorm:"autoCreateTime;"`
 	UpdatedAt  time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
 }
@@ -1,6 +1,11 @@
 package domain
 
-import "github.com/furuya-3150/fam-diary-log/pkg/events"
+import (
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/pkg/events"
+	"github.com/google/uuid"
+)
 
 // MailSendEvent represents an event to request sending a mail
 type MailSendEvent struct {
@@ -22,3 +27,70 @@ func (m *MailSendEvent) EventType() string {
 }
 
 var _ events.Event = (*MailSendEvent)(nil)
+
+// FamilyMemberUpsertedEvent represents an event when a user joins a family or a member's profile changes
+type FamilyMemberUpsertedEvent struct {
+	ID        string    `json:"id"`
+	FamilyID  uuid.UUID `json:"family_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name"`
+	Nicknames []string  `json:"nicknames"`
+	Role      string    `json:"role"`
+	// Timezone is the IANA time zone of the user, used to bucket their diaries by local date
+	Timezone  string    `json:"timezone"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// EventType implements events.Event
+func (e *FamilyMemberUpsertedEvent) EventType() string {
+	return "family.member_upserted"
+}
+
+var _ events.Event = (*FamilyMemberUpsertedEvent)(nil)
+
+// NewFamilyMemberUpsertedEvent creates a new FamilyMemberUpsertedEvent of the member and its user
+func NewFamilyMemberUpsertedEvent(member *FamilyMember, user *User, now time.Time) *FamilyMemberUpsertedEvent {
+	nicknames := member.Nicknames
+	if nicknames == nil {
+		nicknames = []string{}
+	}
+	timezone := user.Timezone
+	if timezone == "" {
+		timezone = DefaultTimezone
+	}
+	return &FamilyMemberUpsertedEvent{
+		ID:        uuid.New().String(),
+		FamilyID:  member.FamilyID,
+		UserID:    member.UserID,
+		Name:      user.Name,
+		Nicknames: nicknames,
+		Role:      member.Role.String(),
+		Timezone:  timezone,
+		Timestamp: now,
+	}
+}
+
+// FamilyMemberRemovedEvent represents an event when a member is removed from a family
+type FamilyMemberRemovedEvent struct {
+	ID        string    `json:"id"`
+	FamilyID  uuid.UUID `json:"family_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// EventType implements events.Event
+func (e *FamilyMemberRemovedEvent) EventType() string {
+	return "family.member_removed"
+}
+
+var _ events.Event = (*FamilyMemberRemovedEvent)(nil)
+
+// NewFamilyMemberRemovedEvent creates a new FamilyMemberRemovedEvent of the member
+func NewFamilyMemberRemovedEvent(member *FamilyMember, now time.Time) *FamilyMemberRemovedEvent {
+	return &FamilyMemberRemovedEvent{
+		ID:        uuid.New().String(),
+		FamilyID:  member.FamilyID,
+		UserID:    member.UserID,
+		Timestamp: now,
+	}
+}
@@ -48,6 +48,7 @@ type FamilyMember struct {
 	FamilyID  uuid.UUID `gorm:"type:uuid;not null;index"`
 	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
 	Role      Role      `gorm:"type:int;not null"`
+	Nicknames []string  `gorm:"serializer:json;type:jsonb;not null;default:'[]'"` // 家族内での呼び名（例: おじいちゃん）
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
 }
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/pkg/events"
+)
+
+// OutboxEvent is a family event stored in the transaction of the change it describes.
+// The outbox relay publishes it until the broker accepts it, so that no event is lost when the broker is down.
+type OutboxEvent struct {
+	ID          int64           `gorm:"primaryKey;autoIncrement"`
+	RoutingKey  string          `gorm:"type:varchar(100);not null"`
+	Payload     json.RawMessage `gorm:"type:jsonb;not null"`
+	Attempts    int             `gorm:"not null;default:0"`
+	CreatedAt   time.Time       `gorm:"autoCreateTime"`
+	PublishedAt *time.Time
+}
+
+// TableName specifies the table name
+func (OutboxEvent) TableName() string {
+	return "family_event_outbox"
+}
+
+// NewOutboxEvent stores the event as it is published
+func NewOutboxEvent(event events.Event) (*OutboxEvent, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxEvent{
+		RoutingKey: event.EventType(),
+		Payload:    payload,
+	}, nil
+}
+
+// EventType implements events.Event
+func (e *OutboxEvent) EventType() string {
+	return e.RoutingKey
+}
+
+// MarshalJSON publishes the stored payload as is
+func (e *OutboxEvent) MarshalJSON() ([]byte, error) {
+	return e.Payload, nil
+}
+
+var _ events.Event = (*OutboxEvent)(nil)
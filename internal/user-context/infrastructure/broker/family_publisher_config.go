@@ -0,0 +1,11 @@
+package broker
+
+import "github.com/furuya-3150/fam-diary-log/pkg/broker/publisher"
+
+// FamilyPublisherConfig returns the publisher configuration for family events
+func FamilyPublisherConfig() publisher.Config {
+	return publisher.Config{
+		ExchangeName: "family.events",
+		ExchangeKind: "topic",
+	}
+}
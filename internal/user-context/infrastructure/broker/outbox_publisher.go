@@ -0,0 +1,37 @@
+package broker
+
+import (
+	"context"
+
+	"github.com/furuya-3150/fam-diary-log/internal/user-context/domain"
+	"github.com/furuya-3150/fam-diary-log/internal/user-context/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/pkg/broker/publisher"
+	"github.com/furuya-3150/fam-diary-log/pkg/events"
+)
+
+// OutboxPublisher stores family events in the outbox instead of sending them to the broker.
+// Publish joins the transaction of ctx, so that an event is stored only with the change it describes.
+type OutboxPublisher struct {
+	or repository.OutboxRepository
+}
+
+// NewOutboxPublisher creates a new OutboxPublisher
+func NewOutboxPublisher(or repository.OutboxRepository) *OutboxPublisher {
+	return &OutboxPublisher{or: or}
+}
+
+// Publish stores the event in the outbox
+func (p *OutboxPublisher) Publish(ctx context.Context, event events.Event) error {
+	outboxEvent, err := domain.NewOutboxEvent(event)
+	if err != nil {
+		return err
+	}
+	return p.or.Add(ctx, outboxEvent)
+}
+
+// Close does nothing; the outbox has no connection of its own
+func (p *OutboxPublisher) Close() error {
+	return nil
+}
+
+var _ publisher.Publisher = (*OutboxPublisher)(nil)
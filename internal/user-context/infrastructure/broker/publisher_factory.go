@@ -59,3 +59,21 @@ func NewDiaryMailerPublisher(log *slog.Logger) publisher.Publisher {
 
 	return pub
 }
+
+// NewFamilyEventPublisher initializes and returns a RabbitMQ publisher for family events
+func NewFamilyEventPublisher(log *slog.Logger) publisher.Publisher {
+	conn, err := GetMailRabbitConnection()
+	if err != nil {
+		log.Error("failed to get RabbitMQ connection for family event publisher", "error", err.Error())
+		os.Exit(1)
+	}
+
+	cfg := FamilyPublisherConfig()
+	pub, err := publisher.NewRabbitMQPublisher(conn, cfg, log)
+	if err != nil {
+		log.Error("failed to create family event publisher", "error", err.Error())
+		os.Exit(1)
+	}
+
+	return pub
+}
@@ -1,25 +1,27 @@
 package config
 
 type Config struct {
-	DB      DBConfig
-	OAuth   OAuthConfig
-	Session SessionConfig
-	JWT     JWTConfig
-	App     AppConfig
+	DB        DBConfig
+	OAuth     OAuthConfig
+	Session   SessionConfig
+	JWT       JWTConfig
+	App       AppConfig
 	ClientApp ClientAppConfig
-	CORS    CORSConfig
+	CORS      CORSConfig
+	Scheduler SchedulerConfig
 }
 
 var Cfg Config
 
 func Load() Config {
 	return Config{
-		DB:      loadDB(),
-		OAuth:   loadOAuth(),
-		Session: loadSession(),
-		JWT:     loadJWT(),
-		App:     loadApp(),
+		DB:        loadDB(),
+		OAuth:     loadOAuth(),
+		Session:   loadSession(),
+		JWT:       loadJWT(),
+		App:       loadApp(),
 		ClientApp: loadClientApp(),
-		CORS:    loadCORS(),
+		CORS:      loadCORS(),
+		Scheduler: loadScheduler(),
 	}
 }
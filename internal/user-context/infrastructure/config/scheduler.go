@@ -0,0 +1,22 @@
+package config
+
+import (
+	"strconv"
+	"time"
+)
+
+type SchedulerConfig struct {
+	OutboxRelayInterval time.Duration
+}
+
+func loadScheduler() SchedulerConfig {
+	intervalStr := getEnv("OUTBOX_RELAY_INTERVAL", "5") // Default: 5 seconds
+	intervalSec, err := strconv.Atoi(intervalStr)
+	if err != nil || intervalSec <= 0 {
+		intervalSec = 5
+	}
+
+	return SchedulerConfig{
+		OutboxRelayInterval: time.Duration(intervalSec) * time.Second,
+	}
+}
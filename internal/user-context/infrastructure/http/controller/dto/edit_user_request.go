@@ -6,4 +6,8 @@ type EditUserRequest struct {
 	ID    uuid.UUID `json:"id"`
 	Name  string    `json:"name" validate:"required,min=1,max=100"`
 	Email string    `json:"email" validate:"required,email,max=255"`
+	// Nicknames are the names the family calls the user by; omitted keeps the current nicknames
+	Nicknames []string `json:"nicknames" validate:"omitempty,max=10,dive,min=1,max=50"`
+	// Timezone is the IANA time zone of the user (e.g. Asia/Tokyo); omitted keeps the current time zone
+	Timezone string `json:"timezone" validate:"omitempty,timezone"`
 }
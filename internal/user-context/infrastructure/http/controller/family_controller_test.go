@@ -37,6 +37,11 @@ func (m *MockFamilyUsecase) ApplyToFamily(ctx context.Context, token string, use
 	return args.Get(0).(string), args.Error(1)
 }
 
+func (m *MockFamilyUsecase) RemoveMember(ctx context.Context, familyID, adminID, userID uuid.UUID) error {
+	args := m.Called(ctx, familyID, adminID, userID)
+	return args.Error(0)
+}
+
 func TestFamilyController_InviteMembers_Success(t *testing.T) {
 	mockUsecase := new(MockFamilyUsecase)
 	controller := NewFamilyController(mockUsecase)
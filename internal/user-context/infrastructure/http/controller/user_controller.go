@@ -19,9 +19,10 @@ func (c *userController) GetProfile(ctx context.Context, userID uuid.UUID) (*dto
 		return nil, err
 	}
 	return &dto.UserResponse{
-		ID:    user.ID,
-		Email: user.Email,
-		Name:  user.Name,
+		ID:       user.ID,
+		Email:    user.Email,
+		Name:     user.Name,
+		Timezone: user.Timezone,
 	}, nil
 }
 
@@ -36,17 +37,20 @@ func NewUserController(usecase usecase.UserUsecase) UserController {
 
 func (c *userController) EditProfile(ctx context.Context, req *dto.EditUserRequest) (*dto.UserResponse, error) {
 	input := &usecase.EditUserInput{
-		ID:    req.ID.String(),
-		Name:  req.Name,
-		Email: req.Email,
+		ID:        req.ID.String(),
+		Name:      req.Name,
+		Email:     req.Email,
+		Nicknames: req.Nicknames,
+		Timezone:  req.Timezone,
 	}
 	user, err := c.usecase.EditUser(ctx, input)
 	if err != nil {
 		return nil, err
 	}
 	return &dto.UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		Name:      user.Name,
+		ID:       user.ID,
+		Email:    user.Email,
+		Name:     user.Name,
+		Timezone: user.Timezone,
 	}, nil
 }
@@ -20,6 +20,7 @@ type FamilyHandler interface {
 	CreateFamily(c echo.Context) error
 	InviteMembers(c echo.Context) error
 	ApplyToFamily(c echo.Context) error
+	RemoveMember(c echo.Context) error
 }
 
 type familyHandler struct {
@@ -156,3 +157,27 @@ func (h *familyHandler) ApplyToFamily(c echo.Context) error {
 	c.SetCookie(accessTokenCookie)
 	return response.RespondSuccess(c, http.StatusNoContent, nil)
 }
+
+// RemoveMember DELETE /families/me/members/:user_id
+func (h *familyHandler) RemoveMember(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		return errors.RespondWithError(c, &errors.BadRequestError{Message: "invalid user_id"})
+	}
+
+	ctx := c.Request().Context()
+	familyID, ok := ctx.Value(auth.ContextKeyFamilyID).(uuid.UUID)
+	if !ok || familyID == uuid.Nil {
+		return errors.RespondWithError(c, &errors.BadRequestError{Message: "invalid family_id context"})
+	}
+	adminID, ok := ctx.Value(auth.ContextKeyUserID).(uuid.UUID)
+	if !ok || adminID == uuid.Nil {
+		return errors.RespondWithError(c, &errors.BadRequestError{Message: "invalid user_id context"})
+	}
+
+	if err := h.fu.RemoveMember(ctx, familyID, adminID, userID); err != nil {
+		slog.Error("RemoveMember: failed to remove family member", "error", err)
+		return errors.RespondWithError(c, err)
+	}
+	return response.RespondSuccess(c, http.StatusNoContent, nil)
+}
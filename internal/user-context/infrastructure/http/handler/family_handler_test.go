@@ -89,6 +89,11 @@ func (m *MockFamilyUsecase) ApplyToFamily(ctx context.Context, token string, use
 	return args.Get(0).(string), args.Error(1)
 }
 
+func (m *MockFamilyUsecase) RemoveMember(ctx context.Context, familyID, adminID, userID uuid.UUID) error {
+	args := m.Called(ctx, familyID, adminID, userID)
+	return args.Error(0)
+}
+
 func TestFamilyHandler_CreateFamily_Success(t *testing.T) {
 	e := echo.New()
 	mockController := new(MockFamilyControllerForHandler)
@@ -436,3 +441,45 @@ func TestFamilyHandler_ApplyToFamily_Success_WithCookie(t *testing.T) {
 	require.True(t, found, "Auth cookie not found")
 	mockUsecase.AssertExpectations(t)
 }
+
+func TestFamilyHandler_RemoveMember_Success(t *testing.T) {
+	e := echo.New()
+	mockUsecase := new(MockFamilyUsecase)
+	h := &familyHandler{fc: new(MockFamilyControllerForHandler), fu: mockUsecase}
+
+	familyID := uuid.New()
+	adminID := uuid.New()
+	userID := uuid.New()
+	req := httptest.NewRequest(http.MethodDelete, "/families/me/members/"+userID.String(), nil)
+	ctx := context.WithValue(context.Background(), auth.ContextKeyFamilyID, familyID)
+	ctx = context.WithValue(ctx, auth.ContextKeyUserID, adminID)
+	req = req.WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("user_id")
+	c.SetParamValues(userID.String())
+
+	mockUsecase.On("RemoveMember", mock.Anything, familyID, adminID, userID).Return(nil)
+
+	err := h.RemoveMember(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestFamilyHandler_RemoveMember_InvalidUserID(t *testing.T) {
+	e := echo.New()
+	mockUsecase := new(MockFamilyUsecase)
+	h := &familyHandler{fc: new(MockFamilyControllerForHandler), fu: mockUsecase}
+
+	req := httptest.NewRequest(http.MethodDelete, "/families/me/members/xxx", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("user_id")
+	c.SetParamValues("xxx")
+
+	err := h.RemoveMember(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUsecase.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
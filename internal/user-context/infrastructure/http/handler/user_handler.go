@@ -47,6 +47,8 @@ func formatValidationError(fe validator.FieldError) string {
 		return fmt.Sprintf("%s must be at least %s characters", fe.Field(), fe.Param())
 	case "max":
 		return fmt.Sprintf("%s must be at most %s characters", fe.Field(), fe.Param())
+	case "timezone":
+		return fmt.Sprintf("%s must be an IANA time zone (e.g. Asia/Tokyo)", fe.Field())
 	default:
 		return fmt.Sprintf("%s is invalid", fe.Field())
 	}
@@ -48,15 +48,19 @@ func NewRouter() *echo.Echo {
 	authController := controller.NewAuthController(authUsecase)
 	authHandler := handler.NewAuthHandler(authController)
 
+	// family events (consumed by diary-api and diary-analyzer) are stored in the outbox with the change
+	// and published by the outbox relay
+	familyPub := broker.NewOutboxPublisher(repository.NewOutboxRepository(dbManager))
+
 	// User
-	userUsecase := usecase.NewUserUsecase(authRepo, txManager)
+	userUsecase := usecase.NewUserUsecase(authRepo, familyMemberRepo, txManager, familyPub)
 	userController := controller.NewUserController(userUsecase)
 	userHandler := handler.NewUserHandler(userController, userUsecase)
 
 	// mail broker publisher
 	pub := broker.NewDiaryMailerPublisher(slog.Default())
 
-	familyUsecase := usecase.NewFamilyUsecase(familyRepo, familyMemberRepo, familyInvitationRepo, authRepo, txManager, &clock.Real{}, tokenGenerator, refreshTokenRepo, pub)
+	familyUsecase := usecase.NewFamilyUsecase(familyRepo, familyMemberRepo, familyInvitationRepo, authRepo, txManager, &clock.Real{}, tokenGenerator, refreshTokenRepo, pub, familyPub)
 	familyController := controller.NewFamilyController(familyUsecase)
 	familyHandler := handler.NewFamilyHandler(familyController, familyUsecase)
 
@@ -108,6 +112,7 @@ func NewRouter() *echo.Echo {
 	families.POST("/me/invitations", familyHandler.InviteMembers, middAuth.JWTAuthMiddleware(cfg.JWT.Secret), middAuth.RequireFamily())
 	families.POST("/join-requests", familyHandler.ApplyToFamily, middAuth.JWTAuthMiddleware(cfg.JWT.Secret))
 	families.GET("/me/members", userHandler.GetFamilyMembers, middAuth.JWTAuthMiddleware(cfg.JWT.Secret), middAuth.RequireFamily())
+	families.DELETE("/me/members/:user_id", familyHandler.RemoveMember, middAuth.JWTAuthMiddleware(cfg.JWT.Secret), middAuth.RequireFamily(), middAuth.RequireRole(middAuth.RoleAdmin))
 
 	// Notification settings routes
 	notifications := e.Group("/families/me/settings")
@@ -13,6 +13,9 @@ type FamilyMemberRepository interface {
 	IsUserAlreadyMember(ctx context.Context, userID uuid.UUID) (bool, error)
 	AddFamilyMember(ctx context.Context, member *domain.FamilyMember) error
 	GetFamilyMemberByUserID(ctx context.Context, userID uuid.UUID) (*domain.FamilyMember, error)
+	// UpdateNicknames replaces the nicknames of the member
+	UpdateNicknames(ctx context.Context, member *domain.FamilyMember) error
+	RemoveFamilyMember(ctx context.Context, member *domain.FamilyMember) error
 }
 
 type familyMemberRepository struct {
@@ -49,4 +52,17 @@ func (r *familyMemberRepository) GetFamilyMemberByUserID(ctx context.Context, us
 		return nil, err
 	}
 	return &member, nil
-}
\ No newline at end of file
+}
+
+func (r *familyMemberRepository) UpdateNicknames(ctx context.Context, member *domain.FamilyMember) error {
+	dbConn := r.dm.DB(ctx)
+	return dbConn.Model(member).
+		Select("nicknames", "updated_at").
+		Updates(member).Error
+}
+
+func (r *familyMemberRepository) RemoveFamilyMember(ctx context.Context, member *domain.FamilyMember) error {
+	dbConn := r.dm.DB(ctx)
+	return dbConn.Where("family_id = ? AND user_id = ?", member.FamilyID, member.UserID).
+		Delete(&domain.FamilyMember{}).Error
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/user-context/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/db"
+	"gorm.io/gorm"
+)
+
+type OutboxRepository interface {
+	// Add stores the event in the transaction of ctx, if any
+	Add(ctx context.Context, event *domain.OutboxEvent) error
+	// ListPending returns the events not published yet, oldest first
+	ListPending(ctx context.Context, limit int) ([]*domain.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id int64, at time.Time) error
+	// MarkFailed counts a failed attempt to publish the event
+	MarkFailed(ctx context.Context, id int64) error
+}
+
+type outboxRepository struct {
+	dm *db.DBManager
+}
+
+func NewOutboxRepository(dm *db.DBManager) OutboxRepository {
+	return &outboxRepository{dm: dm}
+}
+
+func (r *outboxRepository) Add(ctx context.Context, event *domain.OutboxEvent) error {
+	dbConn := r.dm.DB(ctx)
+	return dbConn.Create(event).Error
+}
+
+func (r *outboxRepository) ListPending(ctx context.Context, limit int) ([]*domain.OutboxEvent, error) {
+	dbConn := r.dm.DB(ctx)
+	var events []*domain.OutboxEvent
+	err := dbConn.Where("published_at IS NULL").Order("id ASC").Limit(limit).Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *outboxRepository) MarkPublished(ctx context.Context, id int64, at time.Time) error {
+	dbConn := r.dm.DB(ctx)
+	return dbConn.Model(&domain.OutboxEvent{}).Where("id = ?", id).Update("published_at", at).Error
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id int64) error {
+	dbConn := r.dm.DB(ctx)
+	return dbConn.Model(&domain.OutboxEvent{}).Where("id = ?", id).Update("attempts", gorm.Expr("attempts + 1")).Error
+}
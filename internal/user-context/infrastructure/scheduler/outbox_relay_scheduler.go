@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/user-context/usecase"
+)
+
+// OutboxRelayScheduler periodically publishes the family events stored in the outbox
+type OutboxRelayScheduler struct {
+	ou       usecase.OutboxUsecase
+	interval time.Duration
+}
+
+// NewOutboxRelayScheduler creates a new instance of OutboxRelayScheduler
+func NewOutboxRelayScheduler(ou usecase.OutboxUsecase, interval time.Duration) *OutboxRelayScheduler {
+	return &OutboxRelayScheduler{
+		ou:       ou,
+		interval: interval,
+	}
+}
+
+// Start runs the scheduler until ctx is cancelled
+func (s *OutboxRelayScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	slog.Info("outbox relay scheduler started", "interval", s.interval.String())
+	s.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("outbox relay scheduler stopped")
+			return
+		case <-ticker.C:
+			s.run(ctx)
+		}
+	}
+}
+
+func (s *OutboxRelayScheduler) run(ctx context.Context) {
+	published, err := s.ou.RelayPending(ctx)
+	if err != nil {
+		slog.Error("failed to relay family events", "error", err.Error())
+	}
+	if published > 0 {
+		slog.Info("relayed family events", "count", published)
+	}
+}
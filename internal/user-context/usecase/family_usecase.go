@@ -28,6 +28,8 @@ type FamilyUsecase interface {
 	CreateFamily(ctx context.Context, name string, userID uuid.UUID) (accessToken string, refreshToken string, err error)
 	InviteMembers(ctx context.Context, in InviteMembersInput) error
 	ApplyToFamily(ctx context.Context, token string, userID uuid.UUID) (string, error)
+	// RemoveMember removes the user from the family of the admin
+	RemoveMember(ctx context.Context, familyID, adminID, userID uuid.UUID) error
 }
 
 type familyUsecase struct {
@@ -40,6 +42,7 @@ type familyUsecase struct {
 	tg  jwtgen.TokenGenerator
 	rtr repository.RefreshTokenRepository
 	mp  publisher.Publisher
+	ep  publisher.Publisher
 }
 
 func NewFamilyUsecase(
@@ -52,6 +55,7 @@ func NewFamilyUsecase(
 	tg jwtgen.TokenGenerator,
 	rtr repository.RefreshTokenRepository,
 	mp publisher.Publisher,
+	ep publisher.Publisher,
 ) FamilyUsecase {
 	return &familyUsecase{
 		fr:  fr,
@@ -63,6 +67,7 @@ func NewFamilyUsecase(
 		tg:  tg,
 		rtr: rtr,
 		mp:  mp,
+		ep:  ep,
 	}
 }
 
@@ -111,11 +116,34 @@ func (u *familyUsecase) CreateFamily(ctx context.Context, name string, userID uu
 		u.tm.RollbackTx(ctx)
 		return "", "", err
 	}
+	if err := u.publishMemberUpserted(ctx, member); err != nil {
+		u.tm.RollbackTx(ctx)
+		return "", "", err
+	}
 	u.tm.CommitTx(ctx)
 
 	return accessToken, rt.Token, nil
 }
 
+// publishMemberUpserted notifies other services of the member.
+// ep stores the event in the outbox in the transaction of ctx, so a failure fails the request.
+func (fu *familyUsecase) publishMemberUpserted(ctx context.Context, member *domain.FamilyMember) error {
+	user, err := fu.ur.GetUserByID(ctx, member.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return &errors.BadRequestError{Message: "invalid user"}
+	}
+
+	event := domain.NewFamilyMemberUpsertedEvent(member, user, fu.clk.Now())
+	if err := fu.ep.Publish(ctx, event); err != nil {
+		slog.Error("failed to publish family member upserted event", "user_id", member.UserID, "error", err.Error())
+		return err
+	}
+	return nil
+}
+
 func (fu *familyUsecase) InviteMembers(ctx context.Context, input InviteMembersInput) error {
 	slog.Debug("InviteMembers: start", "family_id", input.FamilyID, "inviter_user_id", input.InviterUserID, "emails", input.Emails)
 
@@ -227,7 +255,13 @@ func (fu *familyUsecase) ApplyToFamily(ctx context.Context, token string, userID
 		UserID:   userID,
 		Role:     domain.RoleMember,
 	}
+	// メンバー追加と家族イベントは同じトランザクションで保存する
+	ctx, err = fu.tm.BeginTx(ctx)
+	if err != nil {
+		return "", err
+	}
 	if err := fu.fmr.AddFamilyMember(ctx, member); err != nil {
+		fu.tm.RollbackTx(ctx)
 		return "", err
 	}
 
@@ -235,11 +269,53 @@ func (fu *familyUsecase) ApplyToFamily(ctx context.Context, token string, userID
 
 	signed, err := fu.tg.GenerateToken(ctx, userID, inv.FamilyID, domain.RoleMember)
 	if err != nil {
+		fu.tm.RollbackTx(ctx)
+		return "", err
+	}
+
+	event := domain.NewFamilyMemberUpsertedEvent(member, user, now)
+	if err := fu.ep.Publish(ctx, event); err != nil {
+		slog.Error("failed to publish family member upserted event", "user_id", userID, "error", err.Error())
+		fu.tm.RollbackTx(ctx)
+		return "", err
+	}
+	if err := fu.tm.CommitTx(ctx); err != nil {
 		return "", err
 	}
+
 	return signed, nil
 }
 
+func (fu *familyUsecase) RemoveMember(ctx context.Context, familyID, adminID, userID uuid.UUID) error {
+	if userID == adminID {
+		return &errors.ValidationError{Message: "you cannot remove yourself from the family"}
+	}
+	member, err := fu.fmr.GetFamilyMemberByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if member == nil || member.FamilyID != familyID {
+		return &errors.NotFoundError{Message: "family member not found"}
+	}
+
+	// メンバー削除と家族イベントは同じトランザクションで保存する
+	ctx, err = fu.tm.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	if err := fu.fmr.RemoveFamilyMember(ctx, member); err != nil {
+		fu.tm.RollbackTx(ctx)
+		return err
+	}
+	event := domain.NewFamilyMemberRemovedEvent(member, fu.clk.Now())
+	if err := fu.ep.Publish(ctx, event); err != nil {
+		slog.Error("failed to publish family member removed event", "user_id", userID, "error", err.Error())
+		fu.tm.RollbackTx(ctx)
+		return err
+	}
+	return fu.tm.CommitTx(ctx)
+}
+
 // isEmailInvited checks if the given email is in the invited emails list
 func (fu *familyUsecase) isEmailInvited(email string, invitedEmails []string) bool {
 	for _, invitedEmail := range invitedEmails {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/furuya-3150/fam-diary-log/internal/user-context/domain"
 	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+	pkgerrors "github.com/furuya-3150/fam-diary-log/pkg/errors"
 	"github.com/furuya-3150/fam-diary-log/pkg/events"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -50,6 +51,14 @@ func (m *MockFamilyMemberRepo) GetFamilyMemberByUserID(ctx context.Context, user
 	}
 	return args.Get(0).(*domain.FamilyMember), args.Error(1)
 }
+func (m *MockFamilyMemberRepo) UpdateNicknames(ctx context.Context, member *domain.FamilyMember) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
+func (m *MockFamilyMemberRepo) RemoveFamilyMember(ctx context.Context, member *domain.FamilyMember) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
 
 type MockFamilyInvitationRepository struct{ mock.Mock }
 
@@ -160,14 +169,15 @@ func newTestEnv() (context.Context,
 	wsp := new(MockWSPblisher)
 	mp := new(MockMailPublisher)
 
-	u := NewFamilyUsecase(fr, fmr, fir, ur, tm, &clock.Fixed{}, tg, rtr, mp)
+	// family events are published through the same mock as mail commands
+	u := NewFamilyUsecase(fr, fmr, fir, ur, tm, &clock.Fixed{}, tg, rtr, mp, mp)
 	ctx := context.Background()
 
 	return ctx, fr, fmr, fir, ur, tm, tg, rtr, wsp, mp, u
 }
 
 func TestFamilyUsecase_CreateFamily_Success(t *testing.T) {
-	ctx, fr, fmr, _, _, tm, tg, rtr, _, _, u := newTestEnv()
+	ctx, fr, fmr, _, ur, tm, tg, rtr, _, mp, u := newTestEnv()
 	userID := uuid.New()
 
 	fmr.On("IsUserAlreadyMember", ctx, userID).Return(false, nil)
@@ -178,9 +188,14 @@ func TestFamilyUsecase_CreateFamily_Success(t *testing.T) {
 	tm.On("CommitTx", ctx).Return(nil)
 	tg.On("GenerateToken", ctx, mock.Anything, mock.Anything, mock.Anything).Return("signed", nil)
 	rtr.On("Create", ctx, mock.AnythingOfType("*domain.RefreshToken")).Return(&domain.RefreshToken{Token: "refresh-token"}, nil)
+	ur.On("GetUserByID", ctx, userID).Return(&domain.User{ID: userID, Name: "Taro"}, nil)
+	mp.On("Publish", ctx, mock.MatchedBy(func(e *domain.FamilyMemberUpsertedEvent) bool {
+		return e.FamilyID == family.ID && e.UserID == userID && e.Name == "Taro" && e.Role == "admin"
+	})).Return(nil)
 
 	_, _, err := u.CreateFamily(ctx, "TestFamily", userID)
 	require.NoError(t, err)
+	mp.AssertExpectations(t)
 	fr.AssertExpectations(t)
 	fmr.AssertExpectations(t)
 	tm.AssertExpectations(t)
@@ -326,7 +341,7 @@ func TestFamilyUsecase_InviteMembers_UpdateError(t *testing.T) {
 }
 
 func TestFamilyUsecase_ApplyToFamily_Success(t *testing.T) {
-	ctx, _, fmr, fir, ur, _, tg, _, _, _, u := newTestEnv()
+	ctx, _, fmr, fir, ur, tm, tg, _, _, mp, u := newTestEnv()
 	userID := uuid.New()
 	token := "tok-123"
 	familyID := uuid.New()
@@ -342,19 +357,57 @@ func TestFamilyUsecase_ApplyToFamily_Success(t *testing.T) {
 	fir.On("FindInvitationByToken", mock.Anything, token).Return(inv, nil)
 	fmr.On("IsUserAlreadyMember", mock.Anything, userID).Return(false, nil)
 	ur.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID, Email: userEmail}, nil)
+	tm.On("BeginTx", ctx).Return(ctx, nil)
+	tm.On("CommitTx", ctx).Return(nil)
 	fmr.On("AddFamilyMember", mock.Anything, mock.AnythingOfType("*domain.FamilyMember")).Return(nil)
 	tg.On("GenerateToken", ctx, userID, familyID, mock.Anything).Return("test-token", nil)
+	mp.On("Publish", mock.Anything, mock.MatchedBy(func(e *domain.FamilyMemberUpsertedEvent) bool {
+		return e.FamilyID == familyID && e.UserID == userID && e.Role == "member"
+	})).Return(nil)
 
 	signed, err := u.ApplyToFamily(ctx, token, userID)
 
 	require.NotEmpty(t, signed)
 	require.NoError(t, err)
+	mp.AssertExpectations(t)
+	tm.AssertExpectations(t)
 	fir.AssertExpectations(t)
 	fmr.AssertExpectations(t)
 	ur.AssertExpectations(t)
 	tg.AssertExpectations(t)
 }
 
+// TestFamilyUsecase_ApplyToFamily_PublishError tests that the member is not added without its family event
+func TestFamilyUsecase_ApplyToFamily_PublishError(t *testing.T) {
+	ctx, _, fmr, fir, ur, tm, tg, _, _, mp, u := newTestEnv()
+	userID := uuid.New()
+	token := "tok-123"
+	familyID := uuid.New()
+	userEmail := "user@example.com"
+
+	inv := &domain.FamilyInvitation{
+		ID:              uuid.New(),
+		FamilyID:        familyID,
+		InvitationToken: token,
+		InvitedEmails:   []string{userEmail},
+		ExpiresAt:       time.Now().Add(24 * time.Hour),
+	}
+	fir.On("FindInvitationByToken", mock.Anything, token).Return(inv, nil)
+	fmr.On("IsUserAlreadyMember", mock.Anything, userID).Return(false, nil)
+	ur.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID, Email: userEmail}, nil)
+	tm.On("BeginTx", ctx).Return(ctx, nil)
+	fmr.On("AddFamilyMember", mock.Anything, mock.AnythingOfType("*domain.FamilyMember")).Return(nil)
+	tg.On("GenerateToken", ctx, userID, familyID, mock.Anything).Return("test-token", nil)
+	mp.On("Publish", mock.Anything, mock.Anything).Return(errors.New("outbox error"))
+	tm.On("RollbackTx", ctx).Return(nil)
+
+	_, err := u.ApplyToFamily(ctx, token, userID)
+
+	require.Error(t, err)
+	tm.AssertExpectations(t)
+	tm.AssertNotCalled(t, "CommitTx", mock.Anything)
+}
+
 func TestFamilyUsecase_ApplyToFamily_NotFound(t *testing.T) {
 	ctx, _, _, fir, _, _, _, _, _, _, u := newTestEnv()
 	userID := uuid.New()
@@ -422,7 +475,7 @@ func TestFamilyUsecase_ApplyToFamily_EmailNotInvited(t *testing.T) {
 }
 
 func TestFamilyUsecase_ApplyToFamily_AddMemberError(t *testing.T) {
-	ctx, _, fmr, fir, ur, _, _, _, _, _, u := newTestEnv()
+	ctx, _, fmr, fir, ur, tm, _, _, _, _, u := newTestEnv()
 	userID := uuid.New()
 	token := "tok-add-err"
 	familyID := uuid.New()
@@ -438,6 +491,8 @@ func TestFamilyUsecase_ApplyToFamily_AddMemberError(t *testing.T) {
 	fir.On("FindInvitationByToken", mock.Anything, token).Return(inv, nil)
 	fmr.On("IsUserAlreadyMember", mock.Anything, userID).Return(false, nil)
 	ur.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID, Email: userEmail}, nil)
+	tm.On("BeginTx", ctx).Return(ctx, nil)
+	tm.On("RollbackTx", ctx).Return(nil)
 	fmr.On("AddFamilyMember", mock.Anything, mock.AnythingOfType("*domain.FamilyMember")).Return(errors.New("add member error"))
 
 	_, err := u.ApplyToFamily(ctx, token, userID)
@@ -456,7 +511,7 @@ func TestFamilyUsecase_ApplyToFamily_ExpiredToken(t *testing.T) {
 
 	// Clockを2025年1月1日に設定
 	fixedClock := &clock.Fixed{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
-	u := NewFamilyUsecase(fr, fmr, fir, ur, tm, fixedClock, tg, new(MockRefreshTokenRepository), mp)
+	u := NewFamilyUsecase(fr, fmr, fir, ur, tm, fixedClock, tg, new(MockRefreshTokenRepository), mp, mp)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -478,7 +533,7 @@ func TestFamilyUsecase_ApplyToFamily_ExpiredToken(t *testing.T) {
 }
 
 func TestFamilyUsecase_ApplyToFamily_TokenGenerationError(t *testing.T) {
-	ctx, _, fmr, fir, ur, _, tg, _, _, _, u := newTestEnv()
+	ctx, _, fmr, fir, ur, tm, tg, _, _, _, u := newTestEnv()
 	userID := uuid.New()
 	token := "tok-123"
 	familyID := uuid.New()
@@ -494,6 +549,8 @@ func TestFamilyUsecase_ApplyToFamily_TokenGenerationError(t *testing.T) {
 	fir.On("FindInvitationByToken", mock.Anything, token).Return(inv, nil)
 	fmr.On("IsUserAlreadyMember", mock.Anything, userID).Return(false, nil)
 	ur.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID, Email: userEmail}, nil)
+	tm.On("BeginTx", ctx).Return(ctx, nil)
+	tm.On("RollbackTx", ctx).Return(nil)
 	fmr.On("AddFamilyMember", mock.Anything, mock.AnythingOfType("*domain.FamilyMember")).Return(nil)
 	tg.On("GenerateToken", ctx, userID, familyID, mock.Anything).Return("", errors.New("token error"))
 
@@ -522,3 +579,67 @@ func TestFamilyUsecase_ApplyToFamily_InvalidUser(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "invalid user")
 }
+
+func TestFamilyUsecase_RemoveMember_Success(t *testing.T) {
+	ctx, _, fmr, _, _, tm, _, _, _, mp, u := newTestEnv()
+	familyID := uuid.New()
+	adminID := uuid.New()
+	member := &domain.FamilyMember{FamilyID: familyID, UserID: uuid.New(), Role: domain.RoleMember}
+
+	fmr.On("GetFamilyMemberByUserID", ctx, member.UserID).Return(member, nil)
+	tm.On("BeginTx", ctx).Return(ctx, nil)
+	fmr.On("RemoveFamilyMember", ctx, member).Return(nil)
+	mp.On("Publish", ctx, mock.MatchedBy(func(e *domain.FamilyMemberRemovedEvent) bool {
+		return e.FamilyID == familyID && e.UserID == member.UserID
+	})).Return(nil)
+	tm.On("CommitTx", ctx).Return(nil)
+
+	err := u.RemoveMember(ctx, familyID, adminID, member.UserID)
+
+	require.NoError(t, err)
+	fmr.AssertExpectations(t)
+	mp.AssertExpectations(t)
+	tm.AssertExpectations(t)
+}
+
+func TestFamilyUsecase_RemoveMember_Self(t *testing.T) {
+	ctx, _, fmr, _, _, _, _, _, _, _, u := newTestEnv()
+	adminID := uuid.New()
+
+	err := u.RemoveMember(ctx, uuid.New(), adminID, adminID)
+
+	var verr *pkgerrors.ValidationError
+	require.ErrorAs(t, err, &verr)
+	fmr.AssertNotCalled(t, "RemoveFamilyMember", mock.Anything, mock.Anything)
+}
+
+func TestFamilyUsecase_RemoveMember_OtherFamily(t *testing.T) {
+	ctx, _, fmr, _, _, _, _, _, _, _, u := newTestEnv()
+	member := &domain.FamilyMember{FamilyID: uuid.New(), UserID: uuid.New(), Role: domain.RoleMember}
+	fmr.On("GetFamilyMemberByUserID", ctx, member.UserID).Return(member, nil)
+
+	err := u.RemoveMember(ctx, uuid.New(), uuid.New(), member.UserID)
+
+	var nerr *pkgerrors.NotFoundError
+	require.ErrorAs(t, err, &nerr)
+	fmr.AssertNotCalled(t, "RemoveFamilyMember", mock.Anything, mock.Anything)
+}
+
+// TestFamilyUsecase_RemoveMember_PublishError tests that the member is kept without its family event
+func TestFamilyUsecase_RemoveMember_PublishError(t *testing.T) {
+	ctx, _, fmr, _, _, tm, _, _, _, mp, u := newTestEnv()
+	familyID := uuid.New()
+	member := &domain.FamilyMember{FamilyID: familyID, UserID: uuid.New(), Role: domain.RoleMember}
+
+	fmr.On("GetFamilyMemberByUserID", ctx, member.UserID).Return(member, nil)
+	tm.On("BeginTx", ctx).Return(ctx, nil)
+	fmr.On("RemoveFamilyMember", ctx, member).Return(nil)
+	mp.On("Publish", ctx, mock.Anything).Return(errors.New("outbox error"))
+	tm.On("RollbackTx", ctx).Return(nil)
+
+	err := u.RemoveMember(ctx, familyID, uuid.New(), member.UserID)
+
+	require.Error(t, err)
+	tm.AssertExpectations(t)
+	tm.AssertNotCalled(t, "CommitTx", mock.Anything)
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/furuya-3150/fam-diary-log/internal/user-context/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/pkg/broker/publisher"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+)
+
+// outboxRelayBatchSize is the number of events published per relay run
+const outboxRelayBatchSize = 100
+
+type OutboxUsecase interface {
+	// RelayPending publishes the pending family events in order and returns the number of events published.
+	// It stops at the first failure, which is retried on the next run.
+	RelayPending(ctx context.Context) (int, error)
+}
+
+type outboxUsecase struct {
+	or  repository.OutboxRepository
+	pub publisher.Publisher
+	clk clock.Clock
+}
+
+func NewOutboxUsecase(or repository.OutboxRepository, pub publisher.Publisher, clk clock.Clock) OutboxUsecase {
+	return &outboxUsecase{
+		or:  or,
+		pub: pub,
+		clk: clk,
+	}
+}
+
+func (u *outboxUsecase) RelayPending(ctx context.Context) (int, error) {
+	events, err := u.or.ListPending(ctx, outboxRelayBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, event := range events {
+		if err := u.pub.Publish(ctx, event); err != nil {
+			if markErr := u.or.MarkFailed(ctx, event.ID); markErr != nil {
+				slog.Error("failed to count outbox event attempt", "id", event.ID, "error", markErr.Error())
+			}
+			return published, err
+		}
+		// 送信済みの記録に失敗すると次回もう一度送られるが、受信側は timestamp で古い更新を無視する
+		if err := u.or.MarkPublished(ctx, event.ID, u.clk.Now()); err != nil {
+			return published, err
+		}
+		published++
+	}
+	return published, nil
+}
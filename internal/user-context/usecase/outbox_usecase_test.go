@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/internal/user-context/domain"
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockOutboxRepo struct{ mock.Mock }
+
+func (m *MockOutboxRepo) Add(ctx context.Context, event *domain.OutboxEvent) error {
+	return m.Called(ctx, event).Error(0)
+}
+
+func (m *MockOutboxRepo) ListPending(ctx context.Context, limit int) ([]*domain.OutboxEvent, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.OutboxEvent), args.Error(1)
+}
+
+func (m *MockOutboxRepo) MarkPublished(ctx context.Context, id int64, at time.Time) error {
+	return m.Called(ctx, id, at).Error(0)
+}
+
+func (m *MockOutboxRepo) MarkFailed(ctx context.Context, id int64) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func TestOutboxUsecase_RelayPending_PublishesInOrder(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	or := new(MockOutboxRepo)
+	pub := new(MockMailPublisher)
+	first := &domain.OutboxEvent{ID: 1, RoutingKey: "family.member_upserted"}
+	second := &domain.OutboxEvent{ID: 2, RoutingKey: "family.member_upserted"}
+
+	or.On("ListPending", ctx, outboxRelayBatchSize).Return([]*domain.OutboxEvent{first, second}, nil)
+	pub.On("Publish", ctx, first).Return(nil).Once()
+	or.On("MarkPublished", ctx, int64(1), now).Return(nil).Once()
+	pub.On("Publish", ctx, second).Return(nil).Once()
+	or.On("MarkPublished", ctx, int64(2), now).Return(nil).Once()
+
+	u := NewOutboxUsecase(or, pub, &clock.Fixed{Time: now})
+	n, err := u.RelayPending(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	or.AssertExpectations(t)
+	pub.AssertExpectations(t)
+}
+
+// TestOutboxUsecase_RelayPending_StopsAtFailure tests that later events wait so they are not delivered out of order
+func TestOutboxUsecase_RelayPending_StopsAtFailure(t *testing.T) {
+	ctx := context.Background()
+	or := new(MockOutboxRepo)
+	pub := new(MockMailPublisher)
+	first := &domain.OutboxEvent{ID: 1, RoutingKey: "family.member_upserted"}
+	second := &domain.OutboxEvent{ID: 2, RoutingKey: "family.member_upserted"}
+
+	or.On("ListPending", ctx, outboxRelayBatchSize).Return([]*domain.OutboxEvent{first, second}, nil)
+	pub.On("Publish", ctx, first).Return(errors.New("broker down"))
+	or.On("MarkFailed", ctx, int64(1)).Return(nil)
+
+	u := NewOutboxUsecase(or, pub, &clock.Fixed{Time: time.Now()})
+	n, err := u.RelayPending(ctx)
+
+	require.Error(t, err)
+	require.Equal(t, 0, n)
+	or.AssertExpectations(t)
+	or.AssertNotCalled(t, "MarkPublished", mock.Anything, mock.Anything, mock.Anything)
+	pub.AssertNotCalled(t, "Publish", ctx, second)
+}
@@ -2,10 +2,13 @@ package usecase
 
 import (
 	"context"
+	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/furuya-3150/fam-diary-log/internal/user-context/domain"
 	"github.com/furuya-3150/fam-diary-log/internal/user-context/infrastructure/repository"
+	"github.com/furuya-3150/fam-diary-log/pkg/broker/publisher"
 	"github.com/furuya-3150/fam-diary-log/pkg/db"
 	pkgerrors "github.com/furuya-3150/fam-diary-log/pkg/errors"
 	"github.com/google/uuid"
@@ -16,6 +19,10 @@ type EditUserInput struct {
 	ID    string
 	Name  string
 	Email string
+	// Nicknames replaces the nicknames in the family when not nil
+	Nicknames []string
+	// Timezone replaces the IANA time zone of the user when not empty
+	Timezone string
 }
 
 // FamilyMemberInfo represents a family member with user info and role (DTO)
@@ -38,13 +45,17 @@ type UserUsecase interface {
 
 type userUsecase struct {
 	repo repository.UserRepository
+	fmr  repository.FamilyMemberRepository
 	tm   db.TransactionManager
+	ep   publisher.Publisher
 }
 
-func NewUserUsecase(repo repository.UserRepository, tm db.TransactionManager) UserUsecase {
+func NewUserUsecase(repo repository.UserRepository, fmr repository.FamilyMemberRepository, tm db.TransactionManager, ep publisher.Publisher) UserUsecase {
 	return &userUsecase{
 		repo: repo,
+		fmr:  fmr,
 		tm:   tm,
+		ep:   ep,
 	}
 }
 
@@ -74,13 +85,66 @@ func (u *userUsecase) EditUser(ctx context.Context, input *EditUserInput) (*doma
 
 	user.Name = input.Name
 	user.Email = input.Email
+	if input.Timezone != "" {
+		if _, err := time.LoadLocation(input.Timezone); err != nil {
+			return nil, &pkgerrors.ValidationError{Message: "invalid timezone"}
+		}
+		user.Timezone = input.Timezone
+	}
+	// プロフィールと家族イベントは同じトランザクションで保存する
+	ctx, err = u.tm.BeginTx(ctx)
+	if err != nil {
+		return nil, &pkgerrors.InternalError{Message: "failed to update user"}
+	}
 	updated, err := u.repo.UpdateUser(ctx, user)
 	if err != nil {
+		u.tm.RollbackTx(ctx)
+		return nil, &pkgerrors.InternalError{Message: "failed to update user"}
+	}
+
+	// 家族に所属している場合は呼び名を更新し、他サービスのメンバー情報（名前・呼び名・タイムゾーン）を更新させる
+	member, err := u.fmr.GetFamilyMemberByUserID(ctx, userID)
+	if err != nil {
+		u.tm.RollbackTx(ctx)
+		return nil, &pkgerrors.InternalError{Message: "failed to get family member"}
+	}
+	if member != nil {
+		if input.Nicknames != nil {
+			member.Nicknames = normalizeNicknames(input.Nicknames)
+			if err := u.fmr.UpdateNicknames(ctx, member); err != nil {
+				u.tm.RollbackTx(ctx)
+				return nil, &pkgerrors.InternalError{Message: "failed to update nicknames"}
+			}
+		}
+		event := domain.NewFamilyMemberUpsertedEvent(member, updated, time.Now())
+		if err := u.ep.Publish(ctx, event); err != nil {
+			slog.Error("failed to publish family member upserted event", "user_id", userID, "error", err.Error())
+			u.tm.RollbackTx(ctx)
+			return nil, &pkgerrors.InternalError{Message: "failed to update user"}
+		}
+	}
+
+	if err := u.tm.CommitTx(ctx); err != nil {
 		return nil, &pkgerrors.InternalError{Message: "failed to update user"}
 	}
 	return updated, nil
 }
 
+// normalizeNicknames trims the nicknames and drops blanks and duplicates, keeping the order
+func normalizeNicknames(nicknames []string) []string {
+	result := make([]string, 0, len(nicknames))
+	seen := make(map[string]bool, len(nicknames))
+	for _, n := range nicknames {
+		n = strings.TrimSpace(n)
+		if n == "" || seen[n] {
+			continue
+		}
+		seen[n] = true
+		result = append(result, n)
+	}
+	return result
+}
+
 // GetFamilyMembers gets all users in a family with optional field selection
 func (u *userUsecase) GetFamilyMembers(ctx context.Context, familyID uuid.UUID, fields []string) ([]*FamilyMemberInfo, error) {
 	// 許可されたフィールドのホワイトリスト（テーブル別）
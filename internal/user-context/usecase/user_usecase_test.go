@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 
@@ -34,8 +35,16 @@ func (m *MockTransactionManager) RollbackTx(ctx context.Context) error {
 	return args.Error(0)
 }
 
+// allowTx lets the usecase begin, commit and roll back transactions on tx
+func allowTx(tx *MockTransactionManager) *MockTransactionManager {
+	tx.On("BeginTx", mock.Anything).Return(nil, nil).Maybe()
+	tx.On("CommitTx", mock.Anything).Return(nil).Maybe()
+	tx.On("RollbackTx", mock.Anything).Return(nil).Maybe()
+	return tx
+}
+
 func setupUserUsecase(repo *MockUserRepository, tx *MockTransactionManager) *userUsecase {
-	return &userUsecase{repo: repo, tm: tx}
+	return &userUsecase{repo: repo, fmr: new(MockFamilyMemberRepo), tm: allowTx(tx), ep: new(MockMailPublisher)}
 }
 
 func TestEditUser_Success(t *testing.T) {
@@ -49,6 +58,7 @@ func TestEditUser_Success(t *testing.T) {
 		return u.ID == id && u.Name == "New" && u.Email == "new@example.com"
 	})).Return(&domain.User{ID: id, Name: "New", Email: "new@example.com", CreatedAt: now, UpdatedAt: now}, nil)
 	uc := setupUserUsecase(repo, tx)
+	uc.fmr.(*MockFamilyMemberRepo).On("GetFamilyMemberByUserID", mock.Anything, id).Return(nil, nil)
 	in := &EditUserInput{ID: id.String(), Name: "New", Email: "new@example.com"}
 	got, err := uc.EditUser(context.Background(), in)
 	require.NoError(t, err)
@@ -57,6 +67,114 @@ func TestEditUser_Success(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestEditUser_PublishesFamilyMemberEvent(t *testing.T) {
+	id := uuid.New()
+	familyID := uuid.New()
+	repo := new(MockUserRepository)
+	fmr := new(MockFamilyMemberRepo)
+	ep := new(MockMailPublisher)
+	repo.On("GetUserByID", mock.Anything, id).Return(&domain.User{ID: id, Name: "Old"}, nil)
+	repo.On("UpdateUser", mock.Anything, mock.Anything).Return(&domain.User{ID: id, Name: "New"}, nil)
+	fmr.On("GetFamilyMemberByUserID", mock.Anything, id).Return(&domain.FamilyMember{FamilyID: familyID, UserID: id, Role: domain.RoleMember}, nil)
+	ep.On("Publish", mock.Anything, mock.MatchedBy(func(e *domain.FamilyMemberUpsertedEvent) bool {
+		return e.FamilyID == familyID && e.UserID == id && e.Name == "New"
+	})).Return(nil)
+
+	uc := &userUsecase{repo: repo, fmr: fmr, tm: allowTx(new(MockTransactionManager)), ep: ep}
+	_, err := uc.EditUser(context.Background(), &EditUserInput{ID: id.String(), Name: "New", Email: "new@example.com"})
+
+	require.NoError(t, err)
+	ep.AssertExpectations(t)
+}
+
+// TestEditUser_PublishErrorRollsBack tests that the profile is not saved without its family event
+func TestEditUser_PublishErrorRollsBack(t *testing.T) {
+	id := uuid.New()
+	repo := new(MockUserRepository)
+	fmr := new(MockFamilyMemberRepo)
+	ep := new(MockMailPublisher)
+	tx := new(MockTransactionManager)
+	repo.On("GetUserByID", mock.Anything, id).Return(&domain.User{ID: id, Name: "Old"}, nil)
+	repo.On("UpdateUser", mock.Anything, mock.Anything).Return(&domain.User{ID: id, Name: "New"}, nil)
+	fmr.On("GetFamilyMemberByUserID", mock.Anything, id).Return(&domain.FamilyMember{FamilyID: uuid.New(), UserID: id, Role: domain.RoleMember}, nil)
+	ep.On("Publish", mock.Anything, mock.Anything).Return(errors.New("outbox fail"))
+	tx.On("BeginTx", mock.Anything).Return(nil, nil)
+	tx.On("RollbackTx", mock.Anything).Return(nil)
+
+	uc := &userUsecase{repo: repo, fmr: fmr, tm: tx, ep: ep}
+	_, err := uc.EditUser(context.Background(), &EditUserInput{ID: id.String(), Name: "New", Email: "new@example.com"})
+
+	var ierr *pkgerrors.InternalError
+	require.ErrorAs(t, err, &ierr)
+	tx.AssertExpectations(t)
+	tx.AssertNotCalled(t, "CommitTx", mock.Anything)
+}
+
+func TestEditUser_UpdatesNicknames(t *testing.T) {
+	id := uuid.New()
+	familyID := uuid.New()
+	repo := new(MockUserRepository)
+	fmr := new(MockFamilyMemberRepo)
+	ep := new(MockMailPublisher)
+	repo.On("GetUserByID", mock.Anything, id).Return(&domain.User{ID: id, Name: "Old"}, nil)
+	repo.On("UpdateUser", mock.Anything, mock.Anything).Return(&domain.User{ID: id, Name: "New"}, nil)
+	fmr.On("GetFamilyMemberByUserID", mock.Anything, id).Return(&domain.FamilyMember{FamilyID: familyID, UserID: id, Role: domain.RoleMember}, nil)
+	fmr.On("UpdateNicknames", mock.Anything, mock.MatchedBy(func(m *domain.FamilyMember) bool {
+		return reflect.DeepEqual(m.Nicknames, []string{"おじいちゃん", "じいじ"})
+	})).Return(nil)
+	ep.On("Publish", mock.Anything, mock.MatchedBy(func(e *domain.FamilyMemberUpsertedEvent) bool {
+		return reflect.DeepEqual(e.Nicknames, []string{"おじいちゃん", "じいじ"})
+	})).Return(nil)
+
+	uc := &userUsecase{repo: repo, fmr: fmr, tm: allowTx(new(MockTransactionManager)), ep: ep}
+	_, err := uc.EditUser(context.Background(), &EditUserInput{
+		ID: id.String(), Name: "New", Email: "new@example.com",
+		Nicknames: []string{" おじいちゃん ", "", "じいじ", "おじいちゃん"},
+	})
+
+	require.NoError(t, err)
+	fmr.AssertExpectations(t)
+	ep.AssertExpectations(t)
+}
+
+func TestEditUser_UpdatesTimezone(t *testing.T) {
+	id := uuid.New()
+	familyID := uuid.New()
+	repo := new(MockUserRepository)
+	fmr := new(MockFamilyMemberRepo)
+	ep := new(MockMailPublisher)
+	repo.On("GetUserByID", mock.Anything, id).Return(&domain.User{ID: id, Name: "Old", Timezone: domain.DefaultTimezone}, nil)
+	repo.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.Timezone == "America/New_York"
+	})).Return(&domain.User{ID: id, Name: "New", Timezone: "America/New_York"}, nil)
+	fmr.On("GetFamilyMemberByUserID", mock.Anything, id).Return(&domain.FamilyMember{FamilyID: familyID, UserID: id, Role: domain.RoleMember}, nil)
+	ep.On("Publish", mock.Anything, mock.MatchedBy(func(e *domain.FamilyMemberUpsertedEvent) bool {
+		return e.Timezone == "America/New_York"
+	})).Return(nil)
+
+	uc := &userUsecase{repo: repo, fmr: fmr, tm: allowTx(new(MockTransactionManager)), ep: ep}
+	_, err := uc.EditUser(context.Background(), &EditUserInput{
+		ID: id.String(), Name: "New", Email: "new@example.com", Timezone: "America/New_York",
+	})
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+	ep.AssertExpectations(t)
+}
+
+func TestEditUser_InvalidTimezone(t *testing.T) {
+	id := uuid.New()
+	repo := new(MockUserRepository)
+	repo.On("GetUserByID", mock.Anything, id).Return(&domain.User{ID: id, Name: "Old"}, nil)
+	uc := setupUserUsecase(repo, new(MockTransactionManager))
+
+	_, err := uc.EditUser(context.Background(), &EditUserInput{ID: id.String(), Name: "X", Email: "x@example.com", Timezone: "Mars/Olympus"})
+
+	var verr *pkgerrors.ValidationError
+	require.ErrorAs(t, err, &verr)
+	repo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
+
 func TestEditUser_InvalidUUID(t *testing.T) {
 	repo := new(MockUserRepository)
 	tx := new(MockTransactionManager)
@@ -6,16 +6,20 @@ import (
 	"fmt"
 	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
 	amqp "github.com/rabbitmq/amqp091-go"
 
 	"github.com/furuya-3150/fam-diary-log/pkg/broker/rabbit"
 )
 
 const (
-	DefaultExchangeKind = "topic"
-	DefaultMaxRetries   = 3
+	DefaultExchangeKind    = "topic"
+	DefaultMaxRetries      = 3
+	DefaultConcurrency     = 1
+	DefaultShutdownTimeout = 30 * time.Second
 )
 
 // EventHandler defines the interface for handling events
@@ -38,6 +42,13 @@ type Config struct {
 	RoutingKeys     []string      // multiple routing keys to bind
 	ConsumerTimeout time.Duration // RabbitMQ broker-side consumer timeout via x-consumer-timeout (0 = broker default)
 	MaxRetries      int           // max retry count on handler error (0 = DefaultMaxRetries)
+	// Concurrency is the number of workers handling messages in parallel (0 = DefaultConcurrency).
+	// Prefetch is the number of unacknowledged messages the broker delivers ahead (0 = Concurrency);
+	// it should be at least Concurrency or workers sit idle.
+	Concurrency int
+	Prefetch    int
+	// ShutdownTimeout bounds how long Stop waits for in-flight messages (0 = DefaultShutdownTimeout)
+	ShutdownTimeout time.Duration
 }
 
 // RabbitMQConsumer implements Consumer for RabbitMQ
@@ -49,6 +60,12 @@ type RabbitMQConsumer struct {
 	l           *slog.Logger
 	retryCounts map[string]int
 	retryMu     sync.Mutex
+	// consumerTag identifies the subscription to cancel on Stop
+	consumerTag string
+	// workers tracks in-flight handlers; cancelHandlers aborts them when the drain times out
+	workers        sync.WaitGroup
+	cancelHandlers context.CancelFunc
+	stopping       atomic.Bool
 }
 
 // NewRabbitMQConsumer creates a new RabbitMQConsumer
@@ -68,6 +85,15 @@ func NewRabbitMQConsumer(conn *amqp.Connection, config Config, handler EventHand
 	if config.MaxRetries == 0 {
 		config.MaxRetries = DefaultMaxRetries
 	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = DefaultConcurrency
+	}
+	if config.Prefetch <= 0 {
+		config.Prefetch = config.Concurrency
+	}
+	if config.ShutdownTimeout <= 0 {
+		config.ShutdownTimeout = DefaultShutdownTimeout
+	}
 
 	ch, err := conn.Channel()
 	if err != nil {
@@ -81,6 +107,7 @@ func NewRabbitMQConsumer(conn *amqp.Connection, config Config, handler EventHand
 		handler:     handler,
 		l:           l,
 		retryCounts: make(map[string]int),
+		consumerTag: fmt.Sprintf("%s-%s", config.QueueName, uuid.NewString()),
 	}
 
 	return consumer, nil
@@ -113,25 +140,30 @@ func (c *RabbitMQConsumer) Start(ctx context.Context) error {
 	}
 
 	// Set QoS (prefetch count)
-	if err := c.ch.Qos(1, 0, false); err != nil {
+	if err := c.ch.Qos(c.config.Prefetch, 0, false); err != nil {
 		return fmt.Errorf("failed to set QoS: %w", err)
 	}
 
 	// Consume messages
 	msgs, err := c.ch.Consume(
-		q.Name, // queue
-		"",     // consumer
-		false,  // auto-ack (manual ack)
-		false,  // exclusive
-		false,  // no-local
-		false,  // no-wait
-		nil,    // arguments
+		q.Name,        // queue
+		c.consumerTag, // consumer
+		false,         // auto-ack (manual ack)
+		false,         // exclusive
+		false,         // no-local
+		false,         // no-wait
+		nil,           // arguments
 	)
 	if err != nil {
 		return fmt.Errorf("failed to consume: %w", err)
 	}
 
-	c.l.Info("consumer started", "queue", q.Name, "routing_keys", c.config.RoutingKeys)
+	c.l.Info("consumer started",
+		"queue", q.Name,
+		"routing_keys", c.config.RoutingKeys,
+		"concurrency", c.config.Concurrency,
+		"prefetch", c.config.Prefetch,
+	)
 
 	// Connection/Channel の切断を監視
 	connCloseCh := c.conn.NotifyClose(make(chan *amqp.Error, 1))
@@ -151,14 +183,52 @@ func (c *RabbitMQConsumer) Start(ctx context.Context) error {
 	}()
 
 	// Handle messages
+	handlerCtx, cancel := context.WithCancel(ctx)
+	c.cancelHandlers = cancel
+	c.startWorkers(handlerCtx, msgs)
+
+	return nil
+}
+
+// startWorkers handles the messages with Concurrency workers until msgs is closed
+func (c *RabbitMQConsumer) startWorkers(ctx context.Context, msgs <-chan amqp.Delivery) {
+	var done sync.WaitGroup
+	for i := 0; i < c.config.Concurrency; i++ {
+		c.workers.Add(1)
+		done.Add(1)
+		go func() {
+			defer c.workers.Done()
+			defer done.Done()
+			for msg := range msgs {
+				c.handleMessage(ctx, msg)
+			}
+		}()
+	}
+
 	go func() {
-		for msg := range msgs {
-			c.handleMessage(ctx, msg)
+		done.Wait()
+		if c.stopping.Load() {
+			c.l.Info("consumer drained", "queue", c.config.QueueName)
+			return
 		}
 		c.l.Error("consumer msgs channel closed", "queue", c.config.QueueName)
 	}()
+}
 
-	return nil
+// drain waits for the workers to finish up to the timeout and reports whether they did
+func (c *RabbitMQConsumer) drain(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		c.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
 }
 
 // msgKey はメッセージの内容からカウンター用のキーを生成する
@@ -214,8 +284,27 @@ func (c *RabbitMQConsumer) handleMessage(ctx context.Context, msg amqp.Delivery)
 	c.l.Info("event processed", "routing_key", routingKey, "elapsed", time.Since(start).String())
 }
 
-// Stop stops the consumer
+// Stop stops consuming, waits for in-flight messages up to ShutdownTimeout,
+// and then closes the channel and the connection.
+// Messages still unacknowledged when the channel closes are requeued by the broker.
 func (c *RabbitMQConsumer) Stop() error {
+	c.stopping.Store(true)
+
+	// Cancel closes msgs once the deliveries already received are handed to the workers
+	if err := c.ch.Cancel(c.consumerTag, false); err != nil {
+		c.l.Error("failed to cancel consumer", "queue", c.config.QueueName, "error", err.Error())
+	}
+
+	if !c.drain(c.config.ShutdownTimeout) {
+		c.l.Warn("shutdown timeout exceeded, aborting in-flight messages",
+			"queue", c.config.QueueName,
+			"timeout", c.config.ShutdownTimeout.String(),
+		)
+		if c.cancelHandlers != nil {
+			c.cancelHandlers()
+		}
+	}
+
 	if err := c.ch.Close(); err != nil {
 		return err
 	}
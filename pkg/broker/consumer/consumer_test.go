@@ -5,7 +5,9 @@ import (
 	"errors"
 	"log/slog"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
@@ -49,6 +51,33 @@ func (h *mockHandler) Handle(_ context.Context, _ string, _ []byte) error {
 	return h.err
 }
 
+// blockingHandler は release が閉じられるまでブロックし、同時実行数の最大値を記録する
+type blockingHandler struct {
+	release  chan struct{}
+	inFlight atomic.Int32
+	maxSeen  atomic.Int32
+	handled  atomic.Int32
+}
+
+func (h *blockingHandler) Handle(ctx context.Context, _ string, _ []byte) error {
+	n := h.inFlight.Add(1)
+	defer h.inFlight.Add(-1)
+	for {
+		max := h.maxSeen.Load()
+		if n <= max || h.maxSeen.CompareAndSwap(max, n) {
+			break
+		}
+	}
+
+	select {
+	case <-h.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	h.handled.Add(1)
+	return nil
+}
+
 // --- ヘルパー ---
 
 func newTestConsumer(maxRetries int, handler EventHandler) *RabbitMQConsumer {
@@ -186,3 +215,84 @@ func TestHandleMessage_RaceCondition(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+// TestStartWorkers_BoundedConcurrency は同時に処理されるメッセージ数が Concurrency を超えないことを確認する
+func TestStartWorkers_BoundedConcurrency(t *testing.T) {
+	const concurrency = 3
+	handler := &blockingHandler{release: make(chan struct{})}
+	c := newTestConsumer(3, handler)
+	c.config.Concurrency = concurrency
+
+	msgs := make(chan amqp.Delivery, 10)
+	for i := 0; i < 10; i++ {
+		msgs <- newDelivery([]byte{byte(i)}, &mockAcknowledger{})
+	}
+	close(msgs)
+	c.startWorkers(context.Background(), msgs)
+
+	deadline := time.Now().Add(time.Second)
+	for handler.inFlight.Load() < concurrency && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := handler.inFlight.Load(); got != concurrency {
+		t.Errorf("expected %d messages in flight, got %d", concurrency, got)
+	}
+
+	close(handler.release)
+	if !c.drain(time.Second) {
+		t.Fatal("expected workers to drain")
+	}
+	if got := handler.maxSeen.Load(); got > concurrency {
+		t.Errorf("expected at most %d messages in flight, got %d", concurrency, got)
+	}
+	if got := handler.handled.Load(); got != 10 {
+		t.Errorf("expected 10 messages handled, got %d", got)
+	}
+}
+
+// TestDrain_WaitsForInFlight は処理中のメッセージが完了して Ack されるまで drain が待つことを確認する
+func TestDrain_WaitsForInFlight(t *testing.T) {
+	handler := &blockingHandler{release: make(chan struct{})}
+	c := newTestConsumer(3, handler)
+	c.config.Concurrency = 1
+
+	ack := &mockAcknowledger{}
+	msgs := make(chan amqp.Delivery, 1)
+	msgs <- newDelivery([]byte("hello"), ack)
+	close(msgs)
+	c.startWorkers(context.Background(), msgs)
+
+	time.AfterFunc(20*time.Millisecond, func() { close(handler.release) })
+	if !c.drain(time.Second) {
+		t.Fatal("expected workers to drain")
+	}
+
+	ack.mu.Lock()
+	defer ack.mu.Unlock()
+	if ack.ackCount != 1 {
+		t.Errorf("expected in-flight message to be acked, got ackCount=%d", ack.ackCount)
+	}
+}
+
+// TestDrain_Timeout は処理中のメッセージが終わらない場合に drain がタイムアウトすることを確認する
+func TestDrain_Timeout(t *testing.T) {
+	handler := &blockingHandler{release: make(chan struct{})}
+	c := newTestConsumer(3, handler)
+	c.config.Concurrency = 1
+
+	msgs := make(chan amqp.Delivery, 1)
+	msgs <- newDelivery([]byte("hello"), &mockAcknowledger{})
+	close(msgs)
+	ctx, cancel := context.WithCancel(context.Background())
+	c.startWorkers(ctx, msgs)
+
+	if c.drain(20 * time.Millisecond) {
+		t.Fatal("expected drain to time out")
+	}
+
+	// ハンドラーを中断すると requeue の Nack でワーカーが終了する
+	cancel()
+	if !c.drain(time.Second) {
+		t.Fatal("expected workers to finish after cancel")
+	}
+}
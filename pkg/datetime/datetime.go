@@ -24,3 +24,12 @@ func GetWeekRange(date time.Time) (time.Time, time.Time) {
 
 	return monday, sunday
 }
+
+// GetMonthRange は指定された日付を含む月の初日から末日までの範囲を返す
+func GetMonthRange(date time.Time) (time.Time, time.Time) {
+	first := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	lastDay := first.AddDate(0, 1, -1)
+	last := time.Date(lastDay.Year(), lastDay.Month(), lastDay.Day(), 23, 59, 59, 999999999, lastDay.Location())
+
+	return first, last
+}
@@ -137,3 +137,34 @@ func TestGetWeekRange_DifferentWeeks(t *testing.T) {
 		t.Errorf("week 1 monday should be 12, week 2 monday should be 19, got %d and %d", monday1.Day(), monday2.Day())
 	}
 }
+
+// TestGetMonthRange_LeapYear tests GetMonthRange with February of a leap year
+func TestGetMonthRange_LeapYear(t *testing.T) {
+	date := time.Date(2028, 2, 10, 12, 0, 0, 0, time.UTC)
+
+	first, last := GetMonthRange(date)
+
+	expectedFirst := time.Date(2028, 2, 1, 0, 0, 0, 0, time.UTC)
+	if !first.Equal(expectedFirst) {
+		t.Errorf("expected first %v, got %v", expectedFirst, first)
+	}
+
+	expectedLast := time.Date(2028, 2, 29, 23, 59, 59, 999999999, time.UTC)
+	if !last.Equal(expectedLast) {
+		t.Errorf("expected last %v, got %v", expectedLast, last)
+	}
+}
+
+// TestGetMonthRange_December tests GetMonthRange across the year boundary
+func TestGetMonthRange_December(t *testing.T) {
+	date := time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC)
+
+	first, last := GetMonthRange(date)
+
+	if !first.Equal(time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected first %v", first)
+	}
+	if !last.Equal(time.Date(2026, 12, 31, 23, 59, 59, 999999999, time.UTC)) {
+		t.Errorf("unexpected last %v", last)
+	}
+}
@@ -89,3 +89,8 @@ func (e *ExternalAPIError) Error() string {
 	}
 	return fmt.Sprintf("external api error: %s", e.Message)
 }
+
+// Unwrap returns the cause of the error
+func (e *ExternalAPIError) Unwrap() error {
+	return e.Cause
+}
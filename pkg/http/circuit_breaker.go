@@ -0,0 +1,115 @@
+package http
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+)
+
+// ErrCircuitOpen is returned without sending the request while the circuit of the host is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type circuitState int
+
+const (
+	// circuitClosed lets requests through and counts consecutive failures
+	circuitClosed circuitState = iota
+	// circuitOpen rejects requests until the open timeout elapses
+	circuitOpen
+	// circuitHalfOpen lets a single probe request through to decide whether the host recovered
+	circuitHalfOpen
+)
+
+// circuitBreaker stops calling a host after consecutive failures, so that a failing host is not hammered
+// and callers fail fast instead of waiting for timeouts
+type circuitBreaker struct {
+	mu        sync.Mutex
+	state     circuitState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	threshold int
+	timeout   time.Duration
+	clk       clock.Clock
+}
+
+func newCircuitBreaker(threshold int, timeout time.Duration, clk clock.Clock) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, timeout: timeout, clk: clk}
+}
+
+// allow reports whether a request may be sent. In half-open state only one probe is allowed at a time.
+// A nil breaker allows every request.
+func (b *circuitBreaker) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if b.clk.Now().Sub(b.openedAt) < b.timeout {
+			return false
+		}
+		b.state = circuitHalfOpen
+		b.probing = true
+		return true
+	case circuitHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// success closes the circuit
+func (b *circuitBreaker) success() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = circuitClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// failure opens the circuit when the threshold is reached or the probe failed
+func (b *circuitBreaker) failure() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.state == circuitHalfOpen || b.failures >= b.threshold {
+		b.state = circuitOpen
+		b.openedAt = b.clk.Now()
+	}
+}
+
+// release gives up a probe whose outcome says nothing about the host, e.g. a canceled request
+func (b *circuitBreaker) release() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func (b *circuitBreaker) current() circuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
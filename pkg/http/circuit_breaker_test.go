@@ -0,0 +1,67 @@
+package http
+
+import (
+	"testing"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+)
+
+// TestCircuitBreaker_HalfOpenProbeFails は半開状態の試行が失敗すると回路が再び開くことを確認する
+func TestCircuitBreaker_HalfOpenProbeFails(t *testing.T) {
+	clk := &clock.Fixed{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b := newCircuitBreaker(1, time.Minute, clk)
+
+	b.failure()
+	if b.allow() {
+		t.Fatal("expected open circuit to reject")
+	}
+
+	clk.Time = clk.Time.Add(time.Minute)
+	if !b.allow() {
+		t.Fatal("expected a probe after the open timeout")
+	}
+	if b.current() != circuitHalfOpen {
+		t.Errorf("expected half-open, got %v", b.current())
+	}
+	// 試行中は他のリクエストを通さない
+	if b.allow() {
+		t.Error("expected a single probe at a time")
+	}
+
+	b.failure()
+	if b.current() != circuitOpen {
+		t.Errorf("expected open after failed probe, got %v", b.current())
+	}
+	if b.allow() {
+		t.Error("expected reopened circuit to reject")
+	}
+}
+
+// TestCircuitBreaker_ReleasedProbe は結果の出なかった試行を解放すると次の試行が通ることを確認する
+func TestCircuitBreaker_ReleasedProbe(t *testing.T) {
+	clk := &clock.Fixed{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b := newCircuitBreaker(1, time.Minute, clk)
+
+	b.failure()
+	clk.Time = clk.Time.Add(time.Minute)
+	b.allow()
+	b.release()
+
+	if !b.allow() {
+		t.Error("expected another probe after release")
+	}
+}
+
+// TestCircuitBreaker_SuccessResetsFailures は成功で連続失敗数がリセットされることを確認する
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	b := newCircuitBreaker(2, time.Minute, &clock.Real{})
+
+	b.failure()
+	b.success()
+	b.failure()
+
+	if b.current() != circuitClosed {
+		t.Errorf("expected closed, got %v", b.current())
+	}
+}
@@ -1,34 +1,67 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
 	"github.com/furuya-3150/fam-diary-log/pkg/errors"
 )
 
+// maxErrorBodyBytes is the size of the response body kept in a StatusError
+const maxErrorBodyBytes = 1024
+
 // ClientConfig represents HTTP client configuration
 type ClientConfig struct {
 	Timeout      time.Duration
 	MaxRetries   int
 	RetryBackoff time.Duration
+	// MaxBackoff caps the exponential backoff between retries
+	MaxBackoff time.Duration
+	// MaxRetryAfter is the longest Retry-After honored; when the server asks to wait longer the request fails instead
+	MaxRetryAfter time.Duration
+	// FailureThreshold is the number of consecutive failures that opens the circuit of a host (0 disables the breaker).
+	// OpenTimeout is how long the circuit stays open before a probe request is let through.
+	FailureThreshold int
+	OpenTimeout      time.Duration
 }
 
 // DefaultConfig returns default HTTP client configuration
 func DefaultConfig() ClientConfig {
 	return ClientConfig{
-		Timeout:      10 * time.Second,
-		MaxRetries:   3,
-		RetryBackoff: 1 * time.Second,
+		Timeout:          10 * time.Second,
+		MaxRetries:       3,
+		RetryBackoff:     1 * time.Second,
+		MaxBackoff:       8 * time.Second,
+		MaxRetryAfter:    30 * time.Second,
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
 	}
 }
 
+// StatusError is the cause of the error returned for a 4xx or 5xx response
+type StatusError struct {
+	StatusCode int
+	// Body is the beginning of the response body
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status=%d body=%s", e.StatusCode, e.Body)
+}
+
 // Client provides common HTTP operations with retry and error handling
 type Client struct {
 	httpClient *http.Client
 	config     ClientConfig
+	clk        clock.Clock
+	breakersMu sync.Mutex
+	breakers   map[string]*circuitBreaker
 }
 
 // NewClient creates a new HTTP client with default configuration
@@ -36,60 +69,148 @@ func NewClient() *Client {
 	return NewClientWithConfig(DefaultConfig())
 }
 
-// NewClientWithConfig creates a new HTTP client with custom configuration
+// NewClientWithConfig creates a new HTTP client with custom configuration.
+// A zero MaxBackoff or MaxRetryAfter is taken from DefaultConfig, so that configurations written before these fields
+// existed neither retry without waiting nor fail on every Retry-After.
 func NewClientWithConfig(config ClientConfig) *Client {
+	defaults := DefaultConfig()
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaults.MaxBackoff
+	}
+	if config.MaxRetryAfter <= 0 {
+		config.MaxRetryAfter = defaults.MaxRetryAfter
+	}
 	return &Client{
 		httpClient: &http.Client{
 			Timeout: config.Timeout,
 		},
-		config: config,
+		config:   config,
+		clk:      &clock.Real{},
+		breakers: make(map[string]*circuitBreaker),
 	}
 }
 
-// Do executes an HTTP request with retry logic
+// Do executes an HTTP request with retry logic.
+// Transport errors, 5xx and 429 are retried with exponential backoff, waiting at least the Retry-After of 429 and 503.
+// 4xx responses are returned as errors with a StatusError cause. The response of a nil error is 2xx or 3xx.
+// While the circuit of the host is open, the request fails with ErrCircuitOpen without being sent.
 func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
-	var lastErr error
-	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
-		// Add context to request
-		req = req.WithContext(ctx)
+	if err := rewindable(req); err != nil {
+		return nil, &errors.ExternalAPIError{Message: "failed to read request body", Cause: err}
+	}
+	breaker := c.breaker(req.URL.Host)
 
-		// Execute request
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
+	for attempt := 0; ; attempt++ {
+		if !breaker.allow() {
+			return nil, &errors.ExternalAPIError{
+				Message: fmt.Sprintf("host unavailable: %s", req.URL.Host),
+				Cause:   ErrCircuitOpen,
+			}
+		}
+
+		var lastErr error
+		var wait time.Duration
+		resp, err := c.send(ctx, req)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				breaker.release()
+				return nil, &errors.ExternalAPIError{Message: "http request canceled", Cause: ctx.Err()}
+			}
+			breaker.failure()
 			lastErr = err
-			if attempt < c.config.MaxRetries {
-				time.Sleep(c.config.RetryBackoff * time.Duration(attempt+1))
-				continue
+		case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+			// a rate-limited host is up, only server errors count against the circuit
+			if resp.StatusCode >= 500 {
+				breaker.failure()
+			} else {
+				breaker.success()
+			}
+			if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+				wait = retryAfter(resp, c.clk.Now())
 			}
+			lastErr = readStatusError(resp)
+		case resp.StatusCode >= 400:
+			// Don't retry on client errors
+			breaker.success()
+			statusErr := readStatusError(resp)
 			return nil, &errors.ExternalAPIError{
-				Message: "http request failed",
-				Cause: lastErr,
+				Message: fmt.Sprintf("client error: status=%d", statusErr.StatusCode),
+				Cause:   statusErr,
 			}
+		default:
+			// Success
+			breaker.success()
+			return resp, nil
 		}
 
-		// Check status code
-		if resp.StatusCode >= 500 {
-			// Retry on server errors
-			resp.Body.Close()
-			lastErr = fmt.Errorf("server error: status=%d", resp.StatusCode)
-			if attempt < c.config.MaxRetries {
-				time.Sleep(c.config.RetryBackoff * time.Duration(attempt+1))
-				continue
-			}
+		if attempt >= c.config.MaxRetries {
+			return nil, &errors.ExternalAPIError{Message: "http request failed", Cause: lastErr}
+		}
+		if wait > c.config.MaxRetryAfter {
 			return nil, &errors.ExternalAPIError{
-				Message: fmt.Sprintf("server error: status=%d", resp.StatusCode),
-				Cause: lastErr,
+				Message: fmt.Sprintf("retry after %s exceeds %s", wait, c.config.MaxRetryAfter),
+				Cause:   lastErr,
 			}
 		}
+		if err := sleep(ctx, max(wait, c.backoff(attempt))); err != nil {
+			return nil, &errors.ExternalAPIError{Message: "http request canceled", Cause: err}
+		}
+	}
+}
 
-		if resp.StatusCode >= 400 {
-			// Don't retry on client errors
-			return resp, nil
+// send sends a copy of the request with a fresh body
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	r := req.Clone(ctx)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
 		}
+		r.Body = body
+	}
+	return c.httpClient.Do(r)
+}
 
-		// Success
-		return resp, nil
+// breaker returns the circuit breaker of the host, or nil when the breaker is disabled
+func (c *Client) breaker(host string) *circuitBreaker {
+	if c.config.FailureThreshold <= 0 {
+		return nil
 	}
 
-	return nil, lastErr
-}
\ No newline at end of file
+	c.breakersMu.Lock()
+	defer c.breakersMu.Unlock()
+
+	b, ok := c.breakers[host]
+	if !ok {
+		b = newCircuitBreaker(c.config.FailureThreshold, c.config.OpenTimeout, c.clk)
+		c.breakers[host] = b
+	}
+	return b
+}
+
+// rewindable buffers the request body when it cannot be re-read, so that every attempt sends the whole body.
+// Bodies of http.NewRequest from bytes and strings readers are re-readable already.
+func rewindable(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return nil
+	}
+
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return err
+	}
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	req.Body, _ = req.GetBody()
+	return nil
+}
+
+// readStatusError reads the beginning of the body and closes it
+func readStatusError(resp *http.Response) *StatusError {
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	return &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+}
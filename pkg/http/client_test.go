@@ -0,0 +1,314 @@
+package http
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/furuya-3150/fam-diary-log/pkg/clock"
+	"github.com/furuya-3150/fam-diary-log/pkg/errors"
+)
+
+// --- ヘルパー ---
+
+func testConfig() ClientConfig {
+	return ClientConfig{
+		Timeout:          time.Second,
+		MaxRetries:       3,
+		RetryBackoff:     time.Millisecond,
+		MaxBackoff:       5 * time.Millisecond,
+		MaxRetryAfter:    time.Second,
+		FailureThreshold: 0,
+		OpenTimeout:      time.Minute,
+	}
+}
+
+// newTestServer は呼び出し回数を数え、n 回目（1 始まり）の応答を handle に任せるサーバーを返す
+func newTestServer(t *testing.T, handle func(w http.ResponseWriter, r *http.Request, n int32)) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handle(w, r, calls.Add(1))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+// nonRewindableBody は GetBody が設定されないリクエストボディ
+type nonRewindableBody struct {
+	io.Reader
+}
+
+func (nonRewindableBody) Close() error { return nil }
+
+// --- テスト ---
+
+// TestDo_RetryServerErrorResendsBody は 5xx をリトライし、毎回同じボディが送られることを確認する
+func TestDo_RetryServerErrorResendsBody(t *testing.T) {
+	var bodies []string
+	server, calls := newTestServer(t, func(w http.ResponseWriter, r *http.Request, n int32) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if n < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL, nonRewindableBody{strings.NewReader("payload")})
+	resp, err := NewClientWithConfig(testConfig()).Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", calls.Load())
+	}
+	for i, body := range bodies {
+		if body != "payload" {
+			t.Errorf("call %d: expected body %q, got %q", i+1, "payload", body)
+		}
+	}
+}
+
+// TestDo_ClientErrorNotRetried は 4xx がリトライされず StatusError を原因とするエラーになることを確認する
+func TestDo_ClientErrorNotRetried(t *testing.T) {
+	server, calls := newTestServer(t, func(w http.ResponseWriter, r *http.Request, n int32) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("invalid appid"))
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := NewClientWithConfig(testConfig()).Do(context.Background(), req)
+
+	if resp != nil {
+		t.Error("expected no response")
+	}
+	var apiErr *errors.ExternalAPIError
+	if !stderrors.As(err, &apiErr) {
+		t.Fatalf("expected ExternalAPIError, got %v", err)
+	}
+	var statusErr *StatusError
+	if !stderrors.As(err, &statusErr) {
+		t.Fatalf("expected StatusError cause, got %v", err)
+	}
+	if statusErr.StatusCode != http.StatusBadRequest || statusErr.Body != "invalid appid" {
+		t.Errorf("unexpected status error: %+v", statusErr)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 call, got %d", calls.Load())
+	}
+}
+
+// TestDo_MaxRetriesExceeded はリトライ上限まで 5xx が続くとエラーになることを確認する
+func TestDo_MaxRetriesExceeded(t *testing.T) {
+	server, calls := newTestServer(t, func(w http.ResponseWriter, r *http.Request, n int32) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err := NewClientWithConfig(testConfig()).Do(context.Background(), req)
+
+	var statusErr *StatusError
+	if !stderrors.As(err, &statusErr) || statusErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected StatusError 500, got %v", err)
+	}
+	if calls.Load() != 4 {
+		t.Errorf("expected 4 calls (1 + 3 retries), got %d", calls.Load())
+	}
+}
+
+// TestDo_TooManyRequestsRetried は 429 が Retry-After に従ってリトライされることを確認する
+func TestDo_TooManyRequestsRetried(t *testing.T) {
+	server, calls := newTestServer(t, func(w http.ResponseWriter, r *http.Request, n int32) {
+		if n == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := NewClientWithConfig(testConfig()).Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 calls, got %d", calls.Load())
+	}
+}
+
+// TestDo_RetryAfterTooLong は MaxRetryAfter を超える Retry-After で待たずに失敗することを確認する
+func TestDo_RetryAfterTooLong(t *testing.T) {
+	server, calls := newTestServer(t, func(w http.ResponseWriter, r *http.Request, n int32) {
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	start := time.Now()
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err := NewClientWithConfig(testConfig()).Do(context.Background(), req)
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 call, got %d", calls.Load())
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected to fail without waiting, took %s", elapsed)
+	}
+}
+
+// TestDo_ContextCanceledDuringBackoff はバックオフ中のキャンセルで即座に戻ることを確認する
+func TestDo_ContextCanceledDuringBackoff(t *testing.T) {
+	server, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request, n int32) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	config := testConfig()
+	config.RetryBackoff = 10 * time.Second
+	config.MaxBackoff = 10 * time.Second
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err := NewClientWithConfig(config).Do(ctx, req)
+
+	if !stderrors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected to return promptly, took %s", elapsed)
+	}
+}
+
+// TestDo_CircuitBreaker は連続失敗で回路が開き、タイムアウト後の試行が成功すると閉じることを確認する
+func TestDo_CircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	server, calls := newTestServer(t, func(w http.ResponseWriter, r *http.Request, n int32) {
+		if healthy.Load() {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	config := testConfig()
+	config.MaxRetries = 0
+	config.FailureThreshold = 2
+	client := NewClientWithConfig(config)
+	clk := &clock.Fixed{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	client.clk = clk
+
+	do := func() error {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		resp, err := client.Do(context.Background(), req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	// 2 回失敗すると回路が開く
+	do()
+	do()
+	if err := do(); !stderrors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected the request to be rejected without being sent, got %d calls", calls.Load())
+	}
+
+	// タイムアウト後は試行が送られ、成功すると回路が閉じる
+	healthy.Store(true)
+	clk.Time = clk.Time.Add(config.OpenTimeout)
+	if err := do(); err != nil {
+		t.Fatalf("expected probe to succeed, got %v", err)
+	}
+	if err := do(); err != nil {
+		t.Fatalf("expected closed circuit, got %v", err)
+	}
+	if calls.Load() != 4 {
+		t.Errorf("expected 4 calls, got %d", calls.Load())
+	}
+}
+
+// TestBackoff は指数バックオフが MaxBackoff で頭打ちになり、ジッターが上半分に収まることを確認する
+func TestBackoff(t *testing.T) {
+	client := NewClientWithConfig(ClientConfig{RetryBackoff: 100 * time.Millisecond, MaxBackoff: 400 * time.Millisecond})
+
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{0, 50 * time.Millisecond, 100 * time.Millisecond},
+		{1, 100 * time.Millisecond, 200 * time.Millisecond},
+		{2, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 200 * time.Millisecond, 400 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			if d := client.backoff(tt.attempt); d < tt.min || d > tt.max {
+				t.Errorf("attempt %d: expected backoff in [%s, %s], got %s", tt.attempt, tt.min, tt.max, d)
+			}
+		}
+	}
+}
+
+// TestNewClientWithConfig_Defaults は MaxBackoff と MaxRetryAfter の未設定（0）が既定値で補われることを確認する
+func TestNewClientWithConfig_Defaults(t *testing.T) {
+	client := NewClientWithConfig(ClientConfig{Timeout: time.Second, MaxRetries: 3, RetryBackoff: time.Second})
+
+	defaults := DefaultConfig()
+	if client.config.MaxBackoff != defaults.MaxBackoff {
+		t.Errorf("expected MaxBackoff %s, got %s", defaults.MaxBackoff, client.config.MaxBackoff)
+	}
+	if client.config.MaxRetryAfter != defaults.MaxRetryAfter {
+		t.Errorf("expected MaxRetryAfter %s, got %s", defaults.MaxRetryAfter, client.config.MaxRetryAfter)
+	}
+	if d := client.backoff(0); d < 500*time.Millisecond {
+		t.Errorf("expected backoff of at least 500ms, got %s", d)
+	}
+}
+
+// TestRetryAfter は Retry-After の秒数と HTTP 日付を解釈することを確認する
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{"未指定", "", 0},
+		{"秒数", "5", 5 * time.Second},
+		{"HTTP 日付", now.Add(time.Minute).Format(http.TimeFormat), time.Minute},
+		{"過去の日付", now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"不正な値", "soon", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				resp.Header.Set("Retry-After", tt.header)
+			}
+			if got := retryAfter(resp, now); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
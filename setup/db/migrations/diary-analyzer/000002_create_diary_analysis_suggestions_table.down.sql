@@ -0,0 +1,2 @@
+-- Drop diary_analysis_suggestions table
+DROP TABLE IF EXISTS diary_analysis_suggestions;
@@ -0,0 +1,15 @@
+CREATE TABLE
+  IF NOT EXISTS diary_analysis_suggestions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    analysis_id UUID NOT NULL REFERENCES diary_analyses (id) ON DELETE CASCADE,
+    diary_id UUID NOT NULL,
+    rule VARCHAR(50) NOT NULL DEFAULT '',
+    -- offset and length in characters (Unicode code points)
+    offset_chars INTEGER NOT NULL,
+    length_chars INTEGER NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    suggestion TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+  );
+
+CREATE INDEX IF NOT EXISTS idx_diary_analysis_suggestions_analysis_id ON diary_analysis_suggestions (analysis_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_diary_analyses_family_id_user_id_created_at;
+
+ALTER TABLE diary_analyses
+DROP COLUMN IF EXISTS sentiment_negative_count,
+DROP COLUMN IF EXISTS sentiment_positive_count,
+DROP COLUMN IF EXISTS sentiment_score;
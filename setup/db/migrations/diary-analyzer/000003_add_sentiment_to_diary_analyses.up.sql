@@ -0,0 +1,7 @@
+-- sentiment_score is NULL for analyses made before sentiment scoring
+ALTER TABLE diary_analyses
+ADD COLUMN IF NOT EXISTS sentiment_score DOUBLE PRECISION NULL,
+ADD COLUMN IF NOT EXISTS sentiment_positive_count INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS sentiment_negative_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_diary_analyses_family_id_user_id_created_at ON diary_analyses (family_id, user_id, created_at);
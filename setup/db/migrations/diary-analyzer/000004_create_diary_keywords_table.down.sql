@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS diary_keywords;
+
+ALTER TABLE diary_analyses
+DROP COLUMN IF EXISTS unlock_at;
@@ -0,0 +1,20 @@
+-- unlock_at hides the analysis of a time capsule from other family members until that time
+ALTER TABLE diary_analyses
+ADD COLUMN IF NOT EXISTS unlock_at TIMESTAMPTZ NULL;
+
+CREATE TABLE
+  IF NOT EXISTS diary_keywords (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    analysis_id UUID NOT NULL REFERENCES diary_analyses (id) ON DELETE CASCADE,
+    diary_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    family_id UUID NOT NULL,
+    word VARCHAR(100) NOT NULL,
+    proper_noun BOOLEAN NOT NULL DEFAULT FALSE,
+    count INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+  );
+
+CREATE INDEX IF NOT EXISTS idx_diary_keywords_analysis_id ON diary_keywords (analysis_id);
+
+CREATE INDEX IF NOT EXISTS idx_diary_keywords_family_id_created_at ON diary_keywords (family_id, created_at);
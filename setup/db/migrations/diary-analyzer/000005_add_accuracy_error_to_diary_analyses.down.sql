@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_diary_analyses_diary_id;
+
+ALTER TABLE diary_analyses
+DROP COLUMN IF EXISTS accuracy_error;
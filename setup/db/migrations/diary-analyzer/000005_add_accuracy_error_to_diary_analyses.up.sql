@@ -0,0 +1,6 @@
+-- accuracy_error is the reason the accuracy check failed; NULL when it succeeded.
+-- The backfill re-runs the analyses where it is set.
+ALTER TABLE diary_analyses
+ADD COLUMN IF NOT EXISTS accuracy_error TEXT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_diary_analyses_diary_id ON diary_analyses (diary_id);
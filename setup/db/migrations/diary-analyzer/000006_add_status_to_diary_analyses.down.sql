@@ -0,0 +1,18 @@
+DROP INDEX IF EXISTS idx_diary_analyses_next_retry_at;
+
+UPDATE diary_analyses
+SET
+  accuracy_score = 100
+WHERE
+  accuracy_score IS NULL;
+
+ALTER TABLE diary_analyses
+ALTER COLUMN accuracy_score SET DEFAULT 0,
+ALTER COLUMN accuracy_score SET NOT NULL;
+
+ALTER TABLE diary_analyses
+DROP COLUMN IF EXISTS next_retry_at,
+DROP COLUMN IF EXISTS retry_count,
+DROP COLUMN IF EXISTS keywords_error,
+DROP COLUMN IF EXISTS keywords_status,
+DROP COLUMN IF EXISTS accuracy_status;
@@ -0,0 +1,39 @@
+-- status of each enrichment: pending | complete | failed (reason in *_error)
+ALTER TABLE diary_analyses
+ADD COLUMN IF NOT EXISTS accuracy_status VARCHAR(20) NOT NULL DEFAULT 'complete',
+ADD COLUMN IF NOT EXISTS keywords_status VARCHAR(20) NOT NULL DEFAULT 'complete',
+ADD COLUMN IF NOT EXISTS keywords_error TEXT NULL,
+ADD COLUMN IF NOT EXISTS retry_count INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS next_retry_at TIMESTAMPTZ NULL;
+
+-- accuracy_score is NULL unless the accuracy check completed
+ALTER TABLE diary_analyses
+ALTER COLUMN accuracy_score DROP NOT NULL,
+ALTER COLUMN accuracy_score DROP DEFAULT;
+
+-- a failed accuracy check used to be stored as a perfect score of 100
+UPDATE diary_analyses
+SET
+  accuracy_status = 'failed',
+  accuracy_score = NULL,
+  next_retry_at = CURRENT_TIMESTAMP
+WHERE
+  accuracy_error IS NOT NULL;
+
+-- analyses made before keyword extraction
+UPDATE diary_analyses AS a
+SET
+  keywords_status = 'pending'
+WHERE
+  NOT EXISTS (
+    SELECT
+      1
+    FROM
+      diary_keywords AS k
+    WHERE
+      k.analysis_id = a.id
+  );
+
+CREATE INDEX IF NOT EXISTS idx_diary_analyses_next_retry_at ON diary_analyses (next_retry_at)
+WHERE
+  next_retry_at IS NOT NULL;
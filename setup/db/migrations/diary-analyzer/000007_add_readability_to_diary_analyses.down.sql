@@ -0,0 +1,7 @@
+ALTER TABLE diary_analyses
+DROP COLUMN IF EXISTS type_token_ratio,
+DROP COLUMN IF EXISTS longest_sentence_length,
+DROP COLUMN IF EXISTS average_sentence_length,
+DROP COLUMN IF EXISTS katakana_ratio,
+DROP COLUMN IF EXISTS hiragana_ratio,
+DROP COLUMN IF EXISTS kanji_ratio;
@@ -0,0 +1,8 @@
+-- readability metrics are NULL for analyses made before they were introduced
+ALTER TABLE diary_analyses
+ADD COLUMN IF NOT EXISTS kanji_ratio DOUBLE PRECISION NULL,
+ADD COLUMN IF NOT EXISTS hiragana_ratio DOUBLE PRECISION NULL,
+ADD COLUMN IF NOT EXISTS katakana_ratio DOUBLE PRECISION NULL,
+ADD COLUMN IF NOT EXISTS average_sentence_length DOUBLE PRECISION NULL,
+ADD COLUMN IF NOT EXISTS longest_sentence_length INTEGER NULL,
+ADD COLUMN IF NOT EXISTS type_token_ratio DOUBLE PRECISION NULL;
@@ -0,0 +1,9 @@
+-- the accuracy check of English diaries is skipped, which the previous version does not know
+UPDATE diary_analyses
+SET
+  accuracy_status = 'complete'
+WHERE
+  accuracy_status = 'skipped';
+
+ALTER TABLE diary_analyses
+DROP COLUMN IF EXISTS language;
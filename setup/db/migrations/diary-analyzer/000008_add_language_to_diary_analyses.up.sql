@@ -0,0 +1,3 @@
+-- detected language of the diary: ja | en | mixed | und (undetermined, also for analyses made before detection)
+ALTER TABLE diary_analyses
+ADD COLUMN IF NOT EXISTS language VARCHAR(10) NOT NULL DEFAULT 'und';
@@ -0,0 +1,2 @@
+ALTER TABLE diary_analyses
+DROP COLUMN IF EXISTS accuracy_strategy;
@@ -0,0 +1,10 @@
+-- ID of the scoring strategy of accuracy_score (e.g. flat_v1), NULL when the accuracy is not scored
+ALTER TABLE diary_analyses
+ADD COLUMN IF NOT EXISTS accuracy_strategy VARCHAR(30) NULL;
+
+-- scores made before the strategies were introduced used the flat penalty
+UPDATE diary_analyses
+SET
+  accuracy_strategy = 'flat_v1'
+WHERE
+  accuracy_score IS NOT NULL;
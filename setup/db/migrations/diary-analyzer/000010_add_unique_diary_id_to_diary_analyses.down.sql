@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_diary_analyses_diary_id;
+
+CREATE INDEX IF NOT EXISTS idx_diary_analyses_diary_id ON diary_analyses (diary_id);
@@ -0,0 +1,30 @@
+-- keep only the newest analysis of each diary; redelivered events used to insert duplicates.
+-- suggestions and keywords of the removed analyses are deleted by ON DELETE CASCADE
+DELETE FROM diary_analyses
+WHERE
+  id IN (
+    SELECT
+      id
+    FROM
+      (
+        SELECT
+          id,
+          ROW_NUMBER() OVER (
+            PARTITION BY
+              diary_id
+            ORDER BY
+              updated_at DESC,
+              created_at DESC,
+              id DESC
+          ) AS rn
+        FROM
+          diary_analyses
+      ) AS ranked
+    WHERE
+      rn > 1
+  );
+
+-- one analysis per diary, the conflict target of the upsert
+DROP INDEX IF EXISTS idx_diary_analyses_diary_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_diary_analyses_diary_id ON diary_analyses (diary_id);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS nlp_accuracy_cache;
+
+-- deferred accuracy checks are retried as failures
+UPDATE diary_analyses
+SET
+  accuracy_status = 'failed',
+  accuracy_error = 'nlp quota exceeded'
+WHERE
+  accuracy_status = 'deferred';
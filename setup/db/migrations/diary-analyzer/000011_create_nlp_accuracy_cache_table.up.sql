@@ -0,0 +1,10 @@
+-- suggestions of the NLP providers by the SHA-256 (hex) of the checked text,
+-- so that identical content is never sent to the provider twice
+CREATE TABLE
+  IF NOT EXISTS nlp_accuracy_cache (
+    provider VARCHAR(20) NOT NULL,
+    content_hash CHAR(64) NOT NULL,
+    suggestions JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, content_hash)
+  );
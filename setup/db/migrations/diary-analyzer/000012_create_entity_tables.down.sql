@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS diary_entity_references;
+
+DROP TABLE IF EXISTS family_entities;
+
+DROP TABLE IF EXISTS family_members;
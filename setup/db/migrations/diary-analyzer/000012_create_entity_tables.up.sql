@@ -0,0 +1,46 @@
+-- projection of family members published by user-context (family.member_upserted)
+CREATE TABLE
+  IF NOT EXISTS family_members (
+    family_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    nicknames JSONB NOT NULL DEFAULT '[]',
+    synced_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (family_id, user_id)
+  );
+
+-- places and pets maintained by the family
+CREATE TABLE
+  IF NOT EXISTS family_entities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    family_id UUID NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    aliases JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+  );
+
+CREATE INDEX IF NOT EXISTS idx_family_entities_family_id ON family_entities (family_id);
+
+-- members, places and pets mentioned in diaries.
+-- entity_id is the user_id of a member and the family_entities id of a place or a pet
+CREATE TABLE
+  IF NOT EXISTS diary_entity_references (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    analysis_id UUID NOT NULL REFERENCES diary_analyses (id) ON DELETE CASCADE,
+    diary_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    family_id UUID NOT NULL,
+    entity_kind VARCHAR(20) NOT NULL,
+    entity_id UUID NOT NULL,
+    surface VARCHAR(100) NOT NULL,
+    -- offset and length in characters (Unicode code points)
+    offset_chars INTEGER NOT NULL,
+    length_chars INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+  );
+
+CREATE INDEX IF NOT EXISTS idx_diary_entity_references_analysis_id ON diary_entity_references (analysis_id);
+
+CREATE INDEX IF NOT EXISTS idx_diary_entity_references_family_id_entity ON diary_entity_references (family_id, entity_kind, entity_id);
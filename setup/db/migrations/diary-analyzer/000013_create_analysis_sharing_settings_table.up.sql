@@ -0,0 +1,11 @@
+-- members who opt out of sharing their analyses are excluded from the family comparison.
+-- a member without a row shares their analyses
+CREATE TABLE
+  IF NOT EXISTS analysis_sharing_settings (
+    user_id UUID NOT NULL,
+    family_id UUID NOT NULL,
+    share_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, family_id)
+  );
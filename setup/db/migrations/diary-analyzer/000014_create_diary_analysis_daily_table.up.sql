@@ -0,0 +1,84 @@
+-- rollup of the analyses of a member per local date, maintained by diary-analyzer on every analysis write.
+-- Time capsules are rolled up per unlock_at so that reads can still hide the locked ones from other members.
+-- Each metric is aggregated over the analyses that have it: <metric>_count analyses, their sum, min and max.
+CREATE TABLE
+  IF NOT EXISTS diary_analysis_daily (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    family_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    local_date DATE NOT NULL,
+    unlock_at TIMESTAMPTZ,
+    diary_count INTEGER NOT NULL DEFAULT 0,
+    char_count_count INTEGER NOT NULL DEFAULT 0,
+    char_count_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    char_count_min DOUBLE PRECISION,
+    char_count_max DOUBLE PRECISION,
+    sentence_count_count INTEGER NOT NULL DEFAULT 0,
+    sentence_count_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    sentence_count_min DOUBLE PRECISION,
+    sentence_count_max DOUBLE PRECISION,
+    writing_time_seconds_count INTEGER NOT NULL DEFAULT 0,
+    writing_time_seconds_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    writing_time_seconds_min DOUBLE PRECISION,
+    writing_time_seconds_max DOUBLE PRECISION,
+    accuracy_score_count INTEGER NOT NULL DEFAULT 0,
+    accuracy_score_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    accuracy_score_min DOUBLE PRECISION,
+    accuracy_score_max DOUBLE PRECISION,
+    sentiment_score_count INTEGER NOT NULL DEFAULT 0,
+    sentiment_score_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    sentiment_score_min DOUBLE PRECISION,
+    sentiment_score_max DOUBLE PRECISION,
+    kanji_ratio_count INTEGER NOT NULL DEFAULT 0,
+    kanji_ratio_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    kanji_ratio_min DOUBLE PRECISION,
+    kanji_ratio_max DOUBLE PRECISION,
+    hiragana_ratio_count INTEGER NOT NULL DEFAULT 0,
+    hiragana_ratio_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    hiragana_ratio_min DOUBLE PRECISION,
+    hiragana_ratio_max DOUBLE PRECISION,
+    katakana_ratio_count INTEGER NOT NULL DEFAULT 0,
+    katakana_ratio_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    katakana_ratio_min DOUBLE PRECISION,
+    katakana_ratio_max DOUBLE PRECISION,
+    average_sentence_length_count INTEGER NOT NULL DEFAULT 0,
+    average_sentence_length_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    average_sentence_length_min DOUBLE PRECISION,
+    average_sentence_length_max DOUBLE PRECISION,
+    longest_sentence_length_count INTEGER NOT NULL DEFAULT 0,
+    longest_sentence_length_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    longest_sentence_length_min DOUBLE PRECISION,
+    longest_sentence_length_max DOUBLE PRECISION,
+    type_token_ratio_count INTEGER NOT NULL DEFAULT 0,
+    type_token_ratio_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    type_token_ratio_min DOUBLE PRECISION,
+    type_token_ratio_max DOUBLE PRECISION,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+  );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_diary_analysis_daily_key ON diary_analysis_daily (family_id, user_id, local_date, unlock_at) NULLS NOT DISTINCT;
+
+CREATE INDEX IF NOT EXISTS idx_diary_analysis_daily_user_id_local_date ON diary_analysis_daily (user_id, local_date);
+
+CREATE INDEX IF NOT EXISTS idx_diary_analysis_daily_family_id_local_date ON diary_analysis_daily (family_id, local_date);
+
+INSERT INTO
+  diary_analysis_daily (family_id, user_id, local_date, unlock_at, diary_count,
+  char_count_count, char_count_sum, char_count_min, char_count_max, sentence_count_count, sentence_count_sum, sentence_count_min, sentence_count_max, writing_time_seconds_count, writing_time_seconds_sum, writing_time_seconds_min, writing_time_seconds_max, accuracy_score_count, accuracy_score_sum, accuracy_score_min, accuracy_score_max, sentiment_score_count, sentiment_score_sum, sentiment_score_min, sentiment_score_max, kanji_ratio_count, kanji_ratio_sum, kanji_ratio_min, kanji_ratio_max, hiragana_ratio_count, hiragana_ratio_sum, hiragana_ratio_min, hiragana_ratio_max, katakana_ratio_count, katakana_ratio_sum, katakana_ratio_min, katakana_ratio_max, average_sentence_length_count, average_sentence_length_sum, average_sentence_length_min, average_sentence_length_max, longest_sentence_length_count, longest_sentence_length_sum, longest_sentence_length_min, longest_sentence_length_max, type_token_ratio_count, type_token_ratio_sum, type_token_ratio_min, type_token_ratio_max)
+SELECT
+  family_id, user_id, DATE(created_at), unlock_at, COUNT(*),
+  COUNT(char_count), COALESCE(SUM(char_count), 0), MIN(char_count), MAX(char_count),
+  COUNT(sentence_count), COALESCE(SUM(sentence_count), 0), MIN(sentence_count), MAX(sentence_count),
+  COUNT(writing_time_seconds), COALESCE(SUM(writing_time_seconds), 0), MIN(writing_time_seconds), MAX(writing_time_seconds),
+  COUNT(accuracy_score), COALESCE(SUM(accuracy_score), 0), MIN(accuracy_score), MAX(accuracy_score),
+  COUNT(sentiment_score), COALESCE(SUM(sentiment_score), 0), MIN(sentiment_score), MAX(sentiment_score),
+  COUNT(kanji_ratio), COALESCE(SUM(kanji_ratio), 0), MIN(kanji_ratio), MAX(kanji_ratio),
+  COUNT(hiragana_ratio), COALESCE(SUM(hiragana_ratio), 0), MIN(hiragana_ratio), MAX(hiragana_ratio),
+  COUNT(katakana_ratio), COALESCE(SUM(katakana_ratio), 0), MIN(katakana_ratio), MAX(katakana_ratio),
+  COUNT(average_sentence_length), COALESCE(SUM(average_sentence_length), 0), MIN(average_sentence_length), MAX(average_sentence_length),
+  COUNT(longest_sentence_length), COALESCE(SUM(longest_sentence_length), 0), MIN(longest_sentence_length), MAX(longest_sentence_length),
+  COUNT(type_token_ratio), COALESCE(SUM(type_token_ratio), 0), MIN(type_token_ratio), MAX(type_token_ratio)
+FROM
+  diary_analyses
+GROUP BY
+  family_id, user_id, DATE(created_at), unlock_at;
@@ -0,0 +1,43 @@
+-- time zone of the member synced from user-context (family.member_upserted)
+ALTER TABLE family_members
+ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Tokyo';
+
+-- local date of the analysis in the time zone of its author, which the daily rollup buckets on.
+-- Analyses made before time zones were introduced are dated in Asia/Tokyo.
+ALTER TABLE diary_analyses
+ADD COLUMN IF NOT EXISTS local_date DATE;
+
+UPDATE diary_analyses
+SET
+  local_date = DATE(created_at AT TIME ZONE 'Asia/Tokyo')
+WHERE
+  local_date IS NULL;
+
+ALTER TABLE diary_analyses
+ALTER COLUMN local_date SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_diary_analyses_local_date ON diary_analyses (local_date);
+
+-- the rollups were bucketed on the date in the session time zone
+DELETE FROM diary_analysis_daily;
+
+INSERT INTO
+  diary_analysis_daily (family_id, user_id, local_date, unlock_at, diary_count,
+  char_count_count, char_count_sum, char_count_min, char_count_max, sentence_count_count, sentence_count_sum, sentence_count_min, sentence_count_max, writing_time_seconds_count, writing_time_seconds_sum, writing_time_seconds_min, writing_time_seconds_max, accuracy_score_count, accuracy_score_sum, accuracy_score_min, accuracy_score_max, sentiment_score_count, sentiment_score_sum, sentiment_score_min, sentiment_score_max, kanji_ratio_count, kanji_ratio_sum, kanji_ratio_min, kanji_ratio_max, hiragana_ratio_count, hiragana_ratio_sum, hiragana_ratio_min, hiragana_ratio_max, katakana_ratio_count, katakana_ratio_sum, katakana_ratio_min, katakana_ratio_max, average_sentence_length_count, average_sentence_length_sum, average_sentence_length_min, average_sentence_length_max, longest_sentence_length_count, longest_sentence_length_sum, longest_sentence_length_min, longest_sentence_length_max, type_token_ratio_count, type_token_ratio_sum, type_token_ratio_min, type_token_ratio_max)
+SELECT
+  family_id, user_id, local_date, unlock_at, COUNT(*),
+  COUNT(char_count), COALESCE(SUM(char_count), 0), MIN(char_count), MAX(char_count),
+  COUNT(sentence_count), COALESCE(SUM(sentence_count), 0), MIN(sentence_count), MAX(sentence_count),
+  COUNT(writing_time_seconds), COALESCE(SUM(writing_time_seconds), 0), MIN(writing_time_seconds), MAX(writing_time_seconds),
+  COUNT(accuracy_score), COALESCE(SUM(accuracy_score), 0), MIN(accuracy_score), MAX(accuracy_score),
+  COUNT(sentiment_score), COALESCE(SUM(sentiment_score), 0), MIN(sentiment_score), MAX(sentiment_score),
+  COUNT(kanji_ratio), COALESCE(SUM(kanji_ratio), 0), MIN(kanji_ratio), MAX(kanji_ratio),
+  COUNT(hiragana_ratio), COALESCE(SUM(hiragana_ratio), 0), MIN(hiragana_ratio), MAX(hiragana_ratio),
+  COUNT(katakana_ratio), COALESCE(SUM(katakana_ratio), 0), MIN(katakana_ratio), MAX(katakana_ratio),
+  COUNT(average_sentence_length), COALESCE(SUM(average_sentence_length), 0), MIN(average_sentence_length), MAX(average_sentence_length),
+  COUNT(longest_sentence_length), COALESCE(SUM(longest_sentence_length), 0), MIN(longest_sentence_length), MAX(longest_sentence_length),
+  COUNT(type_token_ratio), COALESCE(SUM(type_token_ratio), 0), MIN(type_token_ratio), MAX(type_token_ratio)
+FROM
+  diary_analyses
+GROUP BY
+  family_id, user_id, local_date, unlock_at;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS writing_goal_achievements;
+
+DROP TABLE IF EXISTS writing_goals;
@@ -0,0 +1,25 @@
+-- personal writing goals maintained by diary-analysis: a target of a metric (posts, characters, writing_minutes)
+-- per week or month. A member has at most one goal per metric and period.
+CREATE TABLE
+  IF NOT EXISTS writing_goals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    user_id UUID NOT NULL,
+    family_id UUID NOT NULL,
+    metric VARCHAR(20) NOT NULL,
+    period VARCHAR(10) NOT NULL,
+    target INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, family_id, metric, period)
+  );
+
+-- goals reached, recorded by diary-analyzer so that goal.achieved is published once per goal, period and target.
+-- period_start is the first local date of the week or month
+CREATE TABLE
+  IF NOT EXISTS writing_goal_achievements (
+    goal_id UUID NOT NULL REFERENCES writing_goals (id) ON DELETE CASCADE,
+    period_start DATE NOT NULL,
+    target INTEGER NOT NULL,
+    achieved_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (goal_id, period_start, target)
+  );
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS nlp_quota_usage;
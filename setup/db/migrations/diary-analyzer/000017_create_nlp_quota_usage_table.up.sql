@@ -0,0 +1,10 @@
+-- requests sent to the NLP providers per day (JST), shared by the consumer and the backfills
+-- so that together they stay within the daily quota of the provider
+CREATE TABLE
+  IF NOT EXISTS nlp_quota_usage (
+    provider VARCHAR(20) NOT NULL,
+    day DATE NOT NULL,
+    used INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, day)
+  );
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS writing_goal_checks;
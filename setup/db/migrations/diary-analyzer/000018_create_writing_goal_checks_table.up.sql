@@ -0,0 +1,13 @@
+-- goal checks that failed after the diary was analyzed, e.g. because goal.achieved could not be published.
+-- They are retried by the scheduler so that the diary event is not redelivered and analyzed again.
+CREATE TABLE
+  IF NOT EXISTS writing_goal_checks (
+    family_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    local_date DATE NOT NULL,
+    retry_count INTEGER NOT NULL DEFAULT 0,
+    next_retry_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (family_id, user_id, local_date)
+  );
+
+CREATE INDEX IF NOT EXISTS idx_writing_goal_checks_next_retry_at ON writing_goal_checks (next_retry_at);
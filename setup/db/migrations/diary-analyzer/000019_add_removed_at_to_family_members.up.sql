@@ -0,0 +1,3 @@
+-- set by family.member_removed; removed members are kept so an older family.member_upserted does not add them back
+ALTER TABLE family_members
+ADD COLUMN IF NOT EXISTS removed_at TIMESTAMPTZ;
@@ -0,0 +1,13 @@
+-- keep one entry per text: the unversioned entries cached before the versions were introduced
+DELETE FROM nlp_accuracy_cache
+WHERE
+  rules_version <> '';
+
+ALTER TABLE nlp_accuracy_cache
+DROP CONSTRAINT IF EXISTS nlp_accuracy_cache_pkey;
+
+ALTER TABLE nlp_accuracy_cache
+DROP COLUMN IF EXISTS rules_version;
+
+ALTER TABLE nlp_accuracy_cache
+ADD PRIMARY KEY (provider, content_hash);
@@ -0,0 +1,10 @@
+-- the version of the rules of the provider, so that a change of the rules does not serve suggestions cached before it.
+-- entries cached before versions were introduced keep '' and are no longer read
+ALTER TABLE nlp_accuracy_cache
+ADD COLUMN IF NOT EXISTS rules_version VARCHAR(20) NOT NULL DEFAULT '';
+
+ALTER TABLE nlp_accuracy_cache
+DROP CONSTRAINT IF EXISTS nlp_accuracy_cache_pkey;
+
+ALTER TABLE nlp_accuracy_cache
+ADD PRIMARY KEY (provider, rules_version, content_hash);
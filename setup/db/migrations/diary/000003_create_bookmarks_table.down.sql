@@ -0,0 +1 @@
+DROP TABLE IF EXISTS bookmarks;
\ No newline at end of file
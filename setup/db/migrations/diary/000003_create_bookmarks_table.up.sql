@@ -0,0 +1,10 @@
+CREATE TABLE
+  bookmarks (
+    user_id UUID NOT NULL,
+    family_id UUID NOT NULL,
+    diary_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, diary_id)
+  );
+
+CREATE INDEX idx_bookmarks_user_id_family_id_created_at ON bookmarks (user_id, family_id, created_at);
@@ -0,0 +1,25 @@
+-- enable pgcrypto for gen_random_uuid()
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+CREATE TABLE
+  collections (
+    id UUID NOT NULL PRIMARY KEY DEFAULT gen_random_uuid (),
+    family_id UUID NOT NULL,
+    created_by UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    cover_diary_id UUID NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT uq_collections_family_id_name UNIQUE (family_id, name)
+  );
+
+CREATE TABLE
+  collection_items (
+    collection_id UUID NOT NULL REFERENCES collections (id) ON DELETE CASCADE,
+    diary_id UUID NOT NULL,
+    position INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (collection_id, diary_id)
+  );
+
+CREATE INDEX idx_collection_items_collection_id_position ON collection_items (collection_id, position);
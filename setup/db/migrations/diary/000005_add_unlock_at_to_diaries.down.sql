@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_diaries_unlock_pending;
+
+ALTER TABLE diaries
+  DROP COLUMN IF EXISTS unlock_published_at,
+  DROP COLUMN IF EXISTS unlock_at;
@@ -0,0 +1,9 @@
+ALTER TABLE diaries
+  ADD COLUMN unlock_at TIMESTAMPTZ NULL,
+  ADD COLUMN unlock_published_at TIMESTAMPTZ NULL;
+
+-- time capsules waiting for the diary.unlocked event
+CREATE INDEX idx_diaries_unlock_pending ON diaries (unlock_at)
+WHERE
+  unlock_at IS NOT NULL
+  AND unlock_published_at IS NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS diary_edit_audits;
+
+DROP TABLE IF EXISTS family_diary_policies;
@@ -0,0 +1,23 @@
+CREATE TABLE
+  family_diary_policies (
+    family_id UUID NOT NULL PRIMARY KEY,
+    -- NULL means entries are editable forever
+    editable_days INTEGER NULL,
+    updated_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+  );
+
+CREATE TABLE
+  diary_edit_audits (
+    id UUID NOT NULL PRIMARY KEY DEFAULT gen_random_uuid (),
+    diary_id UUID NOT NULL,
+    family_id UUID NOT NULL,
+    edited_by UUID NOT NULL,
+    reason TEXT NOT NULL,
+    previous_title VARCHAR(255) NULL,
+    previous_content TEXT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+  );
+
+CREATE INDEX idx_diary_edit_audits_diary_id_created_at ON diary_edit_audits (diary_id, created_at);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS diary_mention_notifications;
+
+DROP TABLE IF EXISTS diary_mentions;
+
+DROP TABLE IF EXISTS family_members;
@@ -0,0 +1,31 @@
+-- projection of family members published by user-context (family.member_upserted)
+CREATE TABLE
+  family_members (
+    family_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    synced_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (family_id, user_id)
+  );
+
+CREATE TABLE
+  diary_mentions (
+    diary_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    start_offset INTEGER NOT NULL,
+    end_offset INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (diary_id, start_offset)
+  );
+
+CREATE INDEX idx_diary_mentions_user_id ON diary_mentions (user_id);
+
+-- one diary.mentioned event per diary and user
+CREATE TABLE
+  diary_mention_notifications (
+    diary_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    notified_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (diary_id, user_id)
+  );
@@ -0,0 +1,2 @@
+ALTER TABLE family_members
+DROP COLUMN IF EXISTS removed_at;
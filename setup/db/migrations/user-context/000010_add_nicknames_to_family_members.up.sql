@@ -0,0 +1,3 @@
+-- nicknames the family calls the member by, matched in diaries by diary-analyzer
+ALTER TABLE family_members
+ADD COLUMN IF NOT EXISTS nicknames jsonb NOT NULL DEFAULT '[]';
@@ -0,0 +1,3 @@
+-- IANA time zone of the user, used to bucket their diaries by local date
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Tokyo';
@@ -0,0 +1,15 @@
+-- family events written in the transaction of the change they describe,
+-- published to family.events by the outbox relay of user-context and retried until the broker accepts them
+CREATE TABLE
+  IF NOT EXISTS family_event_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    routing_key VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMPTZ
+  );
+
+CREATE INDEX IF NOT EXISTS idx_family_event_outbox_pending ON family_event_outbox (id)
+WHERE
+  published_at IS NULL;
@@ -0,0 +1,22 @@
+-- queue a family.member_upserted event for every existing member,
+-- so the member projections of diary and diary-analyzer include members who joined before the events were published.
+-- the projections ignore events older than what they already have, so replaying them is safe
+INSERT INTO
+  family_event_outbox (routing_key, payload)
+SELECT
+  'family.member_upserted',
+  jsonb_build_object(
+    'id', gen_random_uuid()::text,
+    'family_id', fm.family_id,
+    'user_id', fm.user_id,
+    'name', u.name,
+    'nicknames', COALESCE(fm.nicknames, '[]'::jsonb),
+    'role', CASE fm.role WHEN 1 THEN 'admin' WHEN 2 THEN 'member' ELSE 'unknown' END,
+    'timezone', COALESCE(u.timezone, 'Asia/Tokyo'),
+    'timestamp', NOW()
+  )
+FROM
+  family_members fm
+  JOIN users u ON u.id = fm.user_id
+ORDER BY
+  fm.created_at;